@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// sanitizeProtection trims and deduplicates the entries of the protection and validates the branch patterns.
+func sanitizeProtection(p *types.EnvironmentProtection) error {
+	p.Approvers = sanitizeList(p.Approvers)
+	p.ApproverGroups = sanitizeList(p.ApproverGroups)
+	p.Branches = sanitizeList(p.Branches)
+
+	for _, pattern := range p.Branches {
+		if !doublestar.ValidatePattern(pattern) {
+			return usererror.BadRequest(fmt.Sprintf("Invalid branch pattern %q.", pattern))
+		}
+	}
+
+	return nil
+}
+
+func sanitizeList(in []string) []string {
+	out := make([]string, 0, len(in))
+	seen := make(map[string]struct{}, len(in))
+	for _, s := range in {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeProtection(t *testing.T) {
+	p := &types.EnvironmentProtection{
+		Approvers:      []string{" alice ", "alice", ""},
+		ApproverGroups: []string{"ops"},
+		Branches:       []string{"main", " release/* ", "main"},
+	}
+
+	require.NoError(t, sanitizeProtection(p))
+	assert.Equal(t, []string{"alice"}, p.Approvers)
+	assert.Equal(t, []string{"ops"}, p.ApproverGroups)
+	assert.Equal(t, []string{"main", "release/*"}, p.Branches)
+
+	err := sanitizeProtection(&types.EnvironmentProtection{Branches: []string{"release/["}})
+	assert.Error(t, err)
+}
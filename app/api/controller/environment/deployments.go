@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxEnvironments is the maximum number of environments included in the current deployments.
+const maxEnvironments = 100
+
+// ListDeployments lists the executions that deployed to an environment, most recent first.
+func (c *Controller) ListDeployments(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	envIdentifier string,
+	pagination types.Pagination,
+) ([]*types.Execution, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	env, err := c.environmentStore.FindByIdentifier(ctx, repo.ID, envIdentifier)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find environment: %w", err)
+	}
+
+	count, err := c.executionStore.CountDeployments(ctx, env.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count deployments: %w", err)
+	}
+
+	executions, err := c.executionStore.ListDeployments(ctx, env.ID, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	return executions, count, nil
+}
+
+// ListCurrentDeployments returns for every environment of the repository
+// the latest successful execution that deployed to it.
+func (c *Controller) ListCurrentDeployments(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.Deployment, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	envs, err := c.environmentStore.List(ctx, repo.ID, types.ListQueryFilter{
+		Pagination: types.Pagination{Size: maxEnvironments},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	deployments := make([]types.Deployment, len(envs))
+	for i, env := range envs {
+		execution, err := c.executionStore.FindLatestDeployment(ctx, env.ID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find latest deployment to %s: %w", env.Identifier, err)
+		}
+
+		deployments[i] = types.Deployment{
+			Environment: env,
+			Execution:   execution,
+		}
+	}
+
+	return deployments, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInput is used for updating an environment.
+type UpdateInput struct {
+	Identifier  *string                      `json:"identifier"`
+	Description *string                      `json:"description"`
+	Protection  *types.EnvironmentProtection `json:"protection"`
+}
+
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	envIdentifier string,
+	in *UpdateInput,
+) (*types.Environment, error) {
+	if err := c.sanitizeUpdateInput(in); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := c.environmentStore.FindByIdentifier(ctx, repo.ID, envIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find environment: %w", err)
+	}
+
+	return c.environmentStore.UpdateOptLock(ctx,
+		env, func(original *types.Environment) error {
+			if in.Identifier != nil {
+				original.Identifier = *in.Identifier
+			}
+			if in.Description != nil {
+				original.Description = *in.Description
+			}
+			if in.Protection != nil {
+				original.Protection = *in.Protection
+			}
+
+			return nil
+		})
+}
+
+func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Protection != nil {
+		if err := sanitizeProtection(in.Protection); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
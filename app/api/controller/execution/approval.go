@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/approval"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type DecideApprovalInput struct {
+	Decision enum.ApprovalDecision `json:"decision"`
+	Comment  string                `json:"comment"`
+}
+
+func (in *DecideApprovalInput) sanitize() error {
+	decision, ok := in.Decision.Sanitize()
+	if !ok || decision == "" {
+		return usererror.BadRequest("Decision must be either approved or rejected.")
+	}
+	in.Decision = decision
+	in.Comment = strings.TrimSpace(in.Comment)
+
+	return nil
+}
+
+// FindApproval returns the approval of an approval stage of an execution.
+func (c *Controller) FindApproval(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+) (*types.Approval, error) {
+	repo, err := c.getRepoCheckPipelineAccess(
+		ctx,
+		session,
+		repoRef,
+		pipelineIdentifier,
+		enum.PermissionPipelineView,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, approval, err := c.findApproval(ctx, repo, pipelineIdentifier, executionNum, stageNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// DecideApproval approves or rejects an approval stage waiting on approval.
+// Only the users listed in the approval, directly or through a user group, can decide.
+func (c *Controller) DecideApproval(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *DecideApprovalInput,
+) (*types.Approval, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckPipelineAccess(
+		ctx,
+		session,
+		repoRef,
+		pipelineIdentifier,
+		enum.PermissionPipelineExecute,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, approvalGate, err := c.findApproval(ctx, repo, pipelineIdentifier, executionNum, stageNum)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkApprover(ctx, session, repo, approvalGate); err != nil {
+		return nil, err
+	}
+
+	err = c.approvalService.Decide(ctx, stage, approvalGate, in.Decision, &session.Principal.ID, in.Comment)
+	if errors.Is(err, approval.ErrNotWaiting) {
+		return nil, usererror.BadRequest("The stage is not waiting on approval.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide on approval: %w", err)
+	}
+
+	return approvalGate, nil
+}
+
+func (c *Controller) findApproval(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+) (*types.Stage, *types.Approval, error) {
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	if stage.Type != types.StageTypeApproval {
+		return nil, nil, usererror.NotFound("The stage is not an approval stage.")
+	}
+
+	approval, err := c.approvalStore.FindByStageID(ctx, stage.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find approval of stage %d: %w", stageNum, err)
+	}
+
+	return stage, approval, nil
+}
+
+// checkApprover checks if the principal is one of the approvers, anyone allowed
+// to execute the pipeline is an approver if the approval doesn't restrict them.
+func (c *Controller) checkApprover(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	approval *types.Approval,
+) error {
+	ok, err := c.isApprover(ctx, session, repo, approval.Users, approval.UserGroups)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return usererror.Forbidden("You are not an approver of this stage.")
+	}
+
+	return nil
+}
+
+// isApprover returns true if the principal is one of the users (by UID or email) or
+// a member of one of the user groups. Anyone is an approver if both lists are empty.
+func (c *Controller) isApprover(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	users []string,
+	userGroupIdentifiers []string,
+) (bool, error) {
+	if len(users) == 0 && len(userGroupIdentifiers) == 0 {
+		return true, nil
+	}
+
+	for _, user := range users {
+		if strings.EqualFold(user, session.Principal.UID) || strings.EqualFold(user, session.Principal.Email) {
+			return true, nil
+		}
+	}
+
+	if len(userGroupIdentifiers) == 0 {
+		return false, nil
+	}
+
+	userGroups, err := c.userGroupStore.FindManyByIdentifiersAndSpaceID(ctx, userGroupIdentifiers, repo.ParentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find approver user groups: %w", err)
+	}
+
+	userGroupIDs := make([]int64, len(userGroups))
+	for i, userGroup := range userGroups {
+		userGroupIDs[i] = userGroup.ID
+	}
+
+	userIDs, err := c.userGroupService.ListUserIDsByGroupIDs(ctx, userGroupIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to list users of approver user groups: %w", err)
+	}
+
+	return slices.Contains(userIDs, session.Principal.ID), nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/artifact"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListArtifacts lists the artifacts uploaded by an execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	filter types.ListQueryFilter,
+) ([]*types.PipelineArtifact, int64, error) {
+	_, execution, err := c.findExecutionForArtifacts(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.artifactService.List(ctx, execution.PipelineID, execution.ID, filter)
+}
+
+// UploadArtifact stores a file as an artifact of a running execution.
+func (c *Controller) UploadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	key string,
+	expiresIn time.Duration,
+	file io.Reader,
+) (*types.PipelineArtifact, error) {
+	repo, execution, err := c.findExecutionForArtifacts(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Artifacts can only be uploaded while the execution is running.")
+	}
+
+	return c.artifactService.Upload(ctx, artifact.UploadParams{
+		RepoID:      repo.ID,
+		PipelineID:  execution.PipelineID,
+		ExecutionID: execution.ID,
+		Key:         key,
+		CreatedBy:   session.Principal.ID,
+		ExpiresIn:   expiresIn,
+	}, file)
+}
+
+// DownloadArtifact returns either a signed URL or a reader of an artifact of an execution.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	key string,
+) (string, io.ReadCloser, error) {
+	_, execution, err := c.findExecutionForArtifacts(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return c.artifactService.Download(ctx, execution.PipelineID, execution.ID, key)
+}
+
+func (c *Controller) findExecutionForArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	reqPermission enum.Permission,
+) (*types.RepositoryCore, *types.Execution, error) {
+	repo, err := c.getRepoCheckPipelineAccess(ctx, session, repoRef, pipelineIdentifier, reqPermission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return repo, execution, nil
+}
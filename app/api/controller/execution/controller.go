@@ -21,10 +21,13 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/approval"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -42,6 +45,14 @@ type Controller struct {
 	stageStore     store.StageStore
 	pipelineStore  store.PipelineStore
 	repoFinder     refcache.RepoFinder
+
+	approvalStore    store.ApprovalStore
+	approvalService  *approval.Service
+	userGroupStore   store.UserGroupStore
+	userGroupService usergroup.SearchService
+
+	artifactService  *artifact.Service
+	environmentStore store.EnvironmentStore
 }
 
 func NewController(
@@ -55,6 +66,12 @@ func NewController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	repoFinder refcache.RepoFinder,
+	approvalStore store.ApprovalStore,
+	approvalService *approval.Service,
+	userGroupStore store.UserGroupStore,
+	userGroupService usergroup.SearchService,
+	artifactService *artifact.Service,
+	environmentStore store.EnvironmentStore,
 ) *Controller {
 	return &Controller{
 		tx:             tx,
@@ -67,6 +84,14 @@ func NewController(
 		stageStore:     stageStore,
 		pipelineStore:  pipelineStore,
 		repoFinder:     repoFinder,
+
+		approvalStore:    approvalStore,
+		approvalService:  approvalService,
+		userGroupStore:   userGroupStore,
+		userGroupService: userGroupService,
+
+		artifactService:  artifactService,
+		environmentStore: environmentStore,
 	}
 }
 
@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const refPrefixBranch = "refs/heads/"
+
+type PromoteInput struct {
+	// Environment is the identifier of the environment the build is promoted to.
+	Environment string `json:"environment"`
+
+	// Params are added to the parameters of the promoted build, overriding existing ones.
+	Params map[string]string `json:"params"`
+}
+
+// Promote triggers a new execution with the promote event that deploys the commit
+// built by a successful execution to an environment of the repository.
+func (c *Controller) Promote(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	in *PromoteInput,
+) (*types.Execution, error) {
+	if in.Environment == "" {
+		return nil, usererror.BadRequest("Environment is required.")
+	}
+
+	repo, err := c.getRepoCheckPipelineAccess(
+		ctx,
+		session,
+		repoRef,
+		pipelineIdentifier,
+		enum.PermissionPipelineExecute,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if execution.Status != enum.CIStatusSuccess {
+		return nil, usererror.BadRequest("Only successful executions can be promoted.")
+	}
+
+	env, err := c.environmentStore.FindByIdentifier(ctx, repo.ID, in.Environment)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("Environment %q not found.", in.Environment)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find environment: %w", err)
+	}
+
+	if err = c.checkPromotion(ctx, session, repo, env, execution); err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string, len(execution.Params)+len(in.Params))
+	maps.Copy(params, execution.Params)
+	maps.Copy(params, in.Params)
+
+	hook := &triggerer.Hook{
+		Parent:       execution.ID,
+		Trigger:      session.Principal.UID,
+		TriggeredBy:  session.Principal.ID,
+		Action:       enum.TriggerActionPromote,
+		Link:         execution.Link,
+		Timestamp:    execution.Timestamp,
+		Title:        execution.Title,
+		Message:      execution.Message,
+		Before:       execution.Before,
+		After:        execution.After,
+		Ref:          execution.Ref,
+		Fork:         execution.Fork,
+		Source:       execution.Source,
+		Target:       execution.Target,
+		AuthorLogin:  execution.Author,
+		AuthorName:   execution.AuthorName,
+		AuthorEmail:  execution.AuthorEmail,
+		AuthorAvatar: execution.AuthorAvatar,
+		Sender:       session.Principal.UID,
+		Params:       params,
+		Deploy:       env.Identifier,
+		DeployID:     env.ID,
+	}
+
+	promoted, err := c.triggerer.Trigger(ctx, pipeline, hook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger promotion: %w", err)
+	}
+	if promoted == nil {
+		return nil, usererror.BadRequestf("The pipeline is not configured to run on promotion to %q.", env.Identifier)
+	}
+
+	return promoted, nil
+}
+
+// checkPromotion verifies the protection of the environment allows the principal to promote the execution.
+func (c *Controller) checkPromotion(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	env *types.Environment,
+	execution *types.Execution,
+) error {
+	if !matchesBranch(env.Protection.Branches, execution.Ref) {
+		return usererror.Forbidden(fmt.Sprintf(
+			"Only builds of the branches %s can be promoted to %q.",
+			strings.Join(env.Protection.Branches, ", "), env.Identifier))
+	}
+
+	ok, err := c.isApprover(ctx, session, repo, env.Protection.Approvers, env.Protection.ApproverGroups)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return usererror.Forbidden(fmt.Sprintf("You are not allowed to promote to %q.", env.Identifier))
+	}
+
+	return nil
+}
+
+// matchesBranch returns true if the ref is a branch matching one of the glob patterns,
+// or if there are no patterns. Builds of pull requests and tags never match a pattern.
+func matchesBranch(patterns []string, ref string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	branch, ok := strings.CutPrefix(ref, refPrefixBranch)
+	if !ok {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, branch); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		ref      string
+		want     bool
+	}{
+		{
+			name: "no patterns",
+			ref:  "refs/pullreq/1/head",
+			want: true,
+		},
+		{
+			name:     "exact branch",
+			patterns: []string{"main"},
+			ref:      "refs/heads/main",
+			want:     true,
+		},
+		{
+			name:     "glob branch",
+			patterns: []string{"main", "release/*"},
+			ref:      "refs/heads/release/1.0",
+			want:     true,
+		},
+		{
+			name:     "other branch",
+			patterns: []string{"main", "release/*"},
+			ref:      "refs/heads/feature/x",
+			want:     false,
+		},
+		{
+			name:     "pull request build",
+			patterns: []string{"*"},
+			ref:      "refs/pullreq/1/head",
+			want:     false,
+		},
+		{
+			name:     "tag build",
+			patterns: []string{"**"},
+			ref:      "refs/tags/v1.0",
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, matchesBranch(test.patterns, test.ref))
+		})
+	}
+}
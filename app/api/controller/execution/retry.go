@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RetryInput struct {
+	// Stages are the numbers of the stages to run again, the failed stages are run again if empty.
+	Stages []int64 `json:"stages"`
+}
+
+// Retry creates a new execution from a finished execution that runs the failed or the selected stages,
+// and the stages depending on them, again. The results of the other stages are copied.
+func (c *Controller) Retry(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	in *RetryInput,
+) (*types.Execution, error) {
+	repo, err := c.getRepoCheckPipelineAccess(
+		ctx,
+		session,
+		repoRef,
+		pipelineIdentifier,
+		enum.PermissionPipelineExecute,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if !execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Only finished executions can be retried.")
+	}
+
+	retry, err := c.triggerer.Retry(ctx, pipeline, &triggerer.RetryInput{
+		Parent:      execution,
+		Stages:      in.Stages,
+		Trigger:     session.Principal.UID,
+		TriggeredBy: session.Principal.ID,
+	})
+	if errors.Is(err, triggerer.ErrNoStagesToRetry) {
+		return nil, usererror.BadRequest("The execution has no failed stages, select the stages to retry.")
+	}
+	if errors.Is(err, triggerer.ErrRetryStageNotFound) {
+		return nil, usererror.BadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry execution %d: %w", executionNum, err)
+	}
+
+	return retry, nil
+}
@@ -16,10 +16,13 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/approval"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -42,7 +45,15 @@ func ProvideController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	repoFinder refcache.RepoFinder,
+	approvalStore store.ApprovalStore,
+	approvalService *approval.Service,
+	userGroupStore store.UserGroupStore,
+	userGroupService usergroup.SearchService,
+	artifactService *artifact.Service,
+	environmentStore store.EnvironmentStore,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, stageStore, pipelineStore, repoFinder)
+		canceler, commitService, triggerer, stageStore, pipelineStore, repoFinder,
+		approvalStore, approvalService, userGroupStore, userGroupService, artifactService,
+		environmentStore)
 }
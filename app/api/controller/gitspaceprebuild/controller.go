@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/gitspaceprebuild"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer    authz.Authorizer
+	prebuildStore store.GitspacePrebuildStore
+	repoFinder    refcache.RepoFinder
+	git           git.Interface
+	prebuildSvc   *gitspaceprebuild.Service
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	prebuildStore store.GitspacePrebuildStore,
+	repoFinder refcache.RepoFinder,
+	git git.Interface,
+	prebuildSvc *gitspaceprebuild.Service,
+) *Controller {
+	return &Controller{
+		authorizer:    authorizer,
+		prebuildStore: prebuildStore,
+		repoFinder:    repoFinder,
+		git:           git,
+		prebuildSvc:   prebuildSvc,
+	}
+}
+
+// getRepoCheckAccess fetches a repo, checks if the permission is allowed based on the repo state,
+// and checks if the current user has the permission on the repo.
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+	allowedRepoStates ...enum.RepoState,
+) (*types.RepositoryCore, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoFinder.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err := apiauth.CheckRepoState(ctx, session, repo, reqPermission, allowedRepoStates...); err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+// triggerBuild requests a build of the prebuild for the current head commit of its branch.
+func (c *Controller) triggerBuild(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	prebuild *types.GitspacePrebuild,
+) error {
+	branch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: prebuild.Branch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get branch of gitspace prebuild: %w", err)
+	}
+
+	err = c.prebuildSvc.Trigger(ctx, prebuild, branch.Branch.SHA.String())
+	if err != nil {
+		return fmt.Errorf("failed to trigger gitspace prebuild: %w", err)
+	}
+
+	return nil
+}
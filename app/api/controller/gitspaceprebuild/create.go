@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/gitspaceprebuild"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier string `json:"identifier"`
+	Branch     string `json:"branch"`
+	Disabled   bool   `json:"disabled"`
+}
+
+// Create creates a prebuild for a repository branch and starts the first build of it.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.GitspacePrebuild, error) {
+	if err := c.sanitizeCreateInput(in); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: in.Branch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	prebuild := &types.GitspacePrebuild{
+		RepoID:     repo.ID,
+		Identifier: in.Identifier,
+		Branch:     in.Branch,
+		Disabled:   in.Disabled,
+		State:      enum.GitspacePrebuildStatePending,
+		CreatedBy:  session.Principal.ID,
+		Created:    now,
+		Updated:    now,
+		Version:    0,
+	}
+
+	err = c.prebuildStore.Create(ctx, prebuild)
+	if err != nil {
+		return nil, fmt.Errorf("gitspace prebuild creation failed: %w", err)
+	}
+
+	if prebuild.Disabled {
+		return prebuild, nil
+	}
+
+	// the prebuild is created even if the prebuilds are turned off, it's built once they're turned on.
+	err = c.triggerBuild(ctx, repo, prebuild)
+	if err != nil && !errors.Is(err, gitspaceprebuild.ErrPrebuildsDisabled) {
+		return nil, err
+	}
+
+	return c.prebuildStore.Find(ctx, prebuild.ID)
+}
+
+func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Branch = strings.TrimSpace(in.Branch)
+	if in.Branch == "" {
+		return usererror.BadRequest("Branch is required.")
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeCreateInput(t *testing.T) {
+	c := &Controller{}
+
+	in := &CreateInput{Identifier: "main-prebuild", Branch: " main "}
+	require.NoError(t, c.sanitizeCreateInput(in))
+	assert.Equal(t, "main", in.Branch)
+
+	assert.Error(t, c.sanitizeCreateInput(&CreateInput{Identifier: "main-prebuild", Branch: " "}))
+	assert.Error(t, c.sanitizeCreateInput(&CreateInput{Identifier: "", Branch: "main"}))
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Delete deletes a gitspace prebuild and removes its image.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prebuildIdentifier string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	prebuild, err := c.prebuildStore.FindByIdentifier(ctx, repo.ID, prebuildIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to find gitspace prebuild: %w", err)
+	}
+
+	err = c.prebuildStore.Delete(ctx, prebuild.ID)
+	if err != nil {
+		return fmt.Errorf("could not delete gitspace prebuild: %w", err)
+	}
+
+	if err = c.prebuildSvc.RemoveImage(ctx, prebuild); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("image", prebuild.Image).
+			Msg("failed to remove image of deleted gitspace prebuild")
+	}
+
+	return nil
+}
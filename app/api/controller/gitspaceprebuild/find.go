@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prebuildIdentifier string,
+) (*types.GitspacePrebuild, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	prebuild, err := c.prebuildStore.FindByIdentifier(ctx, repo.ID, prebuildIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gitspace prebuild %s: %w", prebuildIdentifier, err)
+	}
+
+	return prebuild, nil
+}
+
+// Logs returns the output of the latest build of a gitspace prebuild.
+func (c *Controller) Logs(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prebuildIdentifier string,
+) (*types.GitspacePrebuildLogs, error) {
+	prebuild, err := c.Find(ctx, session, repoRef, prebuildIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := c.prebuildStore.FindLogs(ctx, prebuild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gitspace prebuild logs: %w", err)
+	}
+
+	return logs, nil
+}
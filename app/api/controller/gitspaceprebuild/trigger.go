@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/gitspaceprebuild"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Trigger starts a build of the prebuild for the current head commit of its branch.
+func (c *Controller) Trigger(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prebuildIdentifier string,
+) (*types.GitspacePrebuild, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	prebuild, err := c.prebuildStore.FindByIdentifier(ctx, repo.ID, prebuildIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gitspace prebuild: %w", err)
+	}
+
+	if prebuild.Disabled {
+		return nil, usererror.BadRequest("Gitspace prebuild is disabled.")
+	}
+
+	err = c.triggerBuild(ctx, repo, prebuild)
+	if errors.Is(err, gitspaceprebuild.ErrPrebuildsDisabled) {
+		return nil, usererror.BadRequest("Gitspace prebuilds are not enabled.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.prebuildStore.Find(ctx, prebuild.ID)
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInput is used for updating a gitspace prebuild.
+type UpdateInput struct {
+	Identifier *string `json:"identifier"`
+	Disabled   *bool   `json:"disabled"`
+}
+
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prebuildIdentifier string,
+	in *UpdateInput,
+) (*types.GitspacePrebuild, error) {
+	if err := c.sanitizeUpdateInput(in); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	prebuild, err := c.prebuildStore.FindByIdentifier(ctx, repo.ID, prebuildIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gitspace prebuild: %w", err)
+	}
+
+	return c.prebuildStore.UpdateOptLock(ctx,
+		prebuild, func(original *types.GitspacePrebuild) error {
+			if in.Identifier != nil {
+				original.Identifier = *in.Identifier
+			}
+			if in.Disabled != nil {
+				original.Disabled = *in.Disabled
+			}
+
+			return nil
+		})
+}
+
+func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
+	if in.Identifier != nil {
+		*in.Identifier = strings.TrimSpace(*in.Identifier)
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
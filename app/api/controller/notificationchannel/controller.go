@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Config struct {
+	AllowLoopback       bool
+	AllowPrivateNetwork bool
+}
+
+type Controller struct {
+	config       Config
+	authorizer   authz.Authorizer
+	spaceFinder  refcache.SpaceFinder
+	repoFinder   refcache.RepoFinder
+	channelStore store.NotificationChannelStore
+	encrypter    encrypt.Encrypter
+}
+
+func NewController(
+	config Config,
+	authorizer authz.Authorizer,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+	channelStore store.NotificationChannelStore,
+	encrypter encrypt.Encrypter,
+) *Controller {
+	return &Controller{
+		config:       config,
+		authorizer:   authorizer,
+		spaceFinder:  spaceFinder,
+		repoFinder:   repoFinder,
+		channelStore: channelStore,
+		encrypter:    encrypter,
+	}
+}
+
+// parent identifies the space or the repository the notification channels belong to.
+type parent struct {
+	Type enum.ParentResourceType
+	ID   int64
+}
+
+// getRepoParentCheckAccess checks the required repo permission and returns the repo as the channel parent.
+// The view permission is required to read the channels and the edit permission to modify them.
+func (c *Controller) getRepoParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (parent, error) {
+	if repoRef == "" {
+		return parent{}, errors.InvalidArgument("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoFinder.FindByRef(ctx, repoRef)
+	if err != nil {
+		return parent{}, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepoState(ctx, session, repo, reqPermission); err != nil {
+		return parent{}, err
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return parent{}, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return parent{Type: enum.ParentResourceTypeRepo, ID: repo.ID}, nil
+}
+
+// getSpaceParentCheckAccess checks the required space permission and returns the space as the channel parent.
+func (c *Controller) getSpaceParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	reqPermission enum.Permission,
+) (parent, error) {
+	s, err := space.GetSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef, reqPermission)
+	if err != nil {
+		return parent{}, err
+	}
+
+	return parent{Type: enum.ParentResourceTypeSpace, ID: s.ID}, nil
+}
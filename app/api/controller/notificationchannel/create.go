@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+type CreateInput struct {
+	Identifier  string                       `json:"identifier"`
+	Description string                       `json:"description"`
+	Type        enum.NotificationChannelType `json:"type"`
+	URL         string                       `json:"url"`
+	Enabled     bool                         `json:"enabled"`
+	Triggers    []enum.NotificationTrigger   `json:"triggers"`
+}
+
+func (in *CreateInput) sanitize(config Config) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	typ, ok := in.Type.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid notification channel type '%s'", in.Type)
+	}
+	in.Type = typ
+
+	if err := webhook.CheckURL(in.URL, config.AllowLoopback, config.AllowPrivateNetwork, false); err != nil {
+		return err
+	}
+
+	triggers, err := sanitizeTriggers(in.Triggers)
+	if err != nil {
+		return err
+	}
+	in.Triggers = triggers
+
+	return nil
+}
+
+// CreateRepo creates a new notification channel of the repository.
+func (c *Controller) CreateRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.NotificationChannel, error) {
+	p, err := c.getRepoParentCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	return c.create(ctx, session, p, in)
+}
+
+// CreateSpace creates a new notification channel of the space.
+func (c *Controller) CreateSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CreateInput,
+) (*types.NotificationChannel, error) {
+	p, err := c.getSpaceParentCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.create(ctx, session, p, in)
+}
+
+func (c *Controller) create(
+	ctx context.Context,
+	session *auth.Session,
+	p parent,
+	in *CreateInput,
+) (*types.NotificationChannel, error) {
+	if err := in.sanitize(c.config); err != nil {
+		return nil, err
+	}
+
+	encryptedURL, err := c.encrypter.Encrypt(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	channel := &types.NotificationChannel{
+		ParentID:    p.ID,
+		ParentType:  p.Type,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		URL:         string(encryptedURL),
+		Enabled:     in.Enabled,
+		Triggers:    in.Triggers,
+	}
+
+	err = c.channelStore.Create(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// sanitizeTriggers validates the notification triggers and returns them sorted and without duplicates.
+func sanitizeTriggers(triggers []enum.NotificationTrigger) ([]enum.NotificationTrigger, error) {
+	if len(triggers) == 0 {
+		return nil, usererror.BadRequest("At least one notification trigger must be provided")
+	}
+
+	result := make([]enum.NotificationTrigger, len(triggers))
+	for i, trigger := range triggers {
+		t, ok := trigger.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Invalid notification trigger '%s'", trigger)
+		}
+		result[i] = t
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result), nil
+}
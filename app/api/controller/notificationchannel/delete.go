@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeleteRepo deletes a notification channel of the repository.
+func (c *Controller) DeleteRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) error {
+	p, err := c.getRepoParentCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	return c.delete(ctx, p, identifier)
+}
+
+// DeleteSpace deletes a notification channel of the space.
+func (c *Controller) DeleteSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) error {
+	p, err := c.getSpaceParentCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.delete(ctx, p, identifier)
+}
+
+func (c *Controller) delete(ctx context.Context, p parent, identifier string) error {
+	channel, err := c.channelStore.FindByIdentifier(ctx, p.Type, p.ID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	return c.channelStore.Delete(ctx, channel.ID)
+}
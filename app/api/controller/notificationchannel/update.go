@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Identifier  *string                       `json:"identifier"`
+	Description *string                       `json:"description"`
+	Type        *enum.NotificationChannelType `json:"type"`
+	URL         *string                       `json:"url"`
+	Enabled     *bool                         `json:"enabled"`
+	Triggers    *[]enum.NotificationTrigger   `json:"triggers"`
+}
+
+func (in *UpdateInput) sanitize(config Config) error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Type != nil {
+		typ, ok := in.Type.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid notification channel type '%s'", *in.Type)
+		}
+		in.Type = &typ
+	}
+
+	if in.URL != nil {
+		if err := webhook.CheckURL(*in.URL, config.AllowLoopback, config.AllowPrivateNetwork, false); err != nil {
+			return err
+		}
+	}
+
+	if in.Triggers != nil {
+		triggers, err := sanitizeTriggers(*in.Triggers)
+		if err != nil {
+			return err
+		}
+		in.Triggers = &triggers
+	}
+
+	return nil
+}
+
+// UpdateRepo updates an existing notification channel of the repository.
+func (c *Controller) UpdateRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.NotificationChannel, error) {
+	p, err := c.getRepoParentCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	return c.update(ctx, p, identifier, in)
+}
+
+// UpdateSpace updates an existing notification channel of the space.
+func (c *Controller) UpdateSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.NotificationChannel, error) {
+	p, err := c.getSpaceParentCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.update(ctx, p, identifier, in)
+}
+
+func (c *Controller) update(
+	ctx context.Context,
+	p parent,
+	identifier string,
+	in *UpdateInput,
+) (*types.NotificationChannel, error) {
+	if err := in.sanitize(c.config); err != nil {
+		return nil, err
+	}
+
+	var encryptedURL string
+	if in.URL != nil {
+		encrypted, err := c.encrypter.Encrypt(*in.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+		}
+		encryptedURL = string(encrypted)
+	}
+
+	channel, err := c.channelStore.FindByIdentifier(ctx, p.Type, p.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	return c.channelStore.UpdateOptLock(ctx, channel, func(channel *types.NotificationChannel) error {
+		if in.Identifier != nil {
+			channel.Identifier = *in.Identifier
+		}
+		if in.Description != nil {
+			channel.Description = *in.Description
+		}
+		if in.Type != nil {
+			channel.Type = *in.Type
+		}
+		if in.URL != nil {
+			channel.URL = encryptedURL
+		}
+		if in.Enabled != nil {
+			channel.Enabled = *in.Enabled
+		}
+		if in.Triggers != nil {
+			channel.Triggers = *in.Triggers
+		}
+
+		return nil
+	})
+}
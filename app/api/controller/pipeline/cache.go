@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/artifact"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListCaches lists the caches shared by the executions of a pipeline.
+func (c *Controller) ListCaches(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	filter types.ListQueryFilter,
+) ([]*types.PipelineArtifact, int64, error) {
+	_, pipeline, err := c.findPipelineForCaches(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.artifactService.List(ctx, pipeline.ID, 0, filter)
+}
+
+// UploadCache stores a file as a cache of a pipeline, replacing the previous cache with the same key.
+func (c *Controller) UploadCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+	expiresIn time.Duration,
+	file io.Reader,
+) (*types.PipelineArtifact, error) {
+	repo, pipeline, err := c.findPipelineForCaches(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.artifactService.Upload(ctx, artifact.UploadParams{
+		RepoID:     repo.ID,
+		PipelineID: pipeline.ID,
+		Key:        key,
+		CreatedBy:  session.Principal.ID,
+		ExpiresIn:  expiresIn,
+	}, file)
+}
+
+// DownloadCache returns either a signed URL or a reader of a cache of a pipeline.
+func (c *Controller) DownloadCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+) (string, io.ReadCloser, error) {
+	_, pipeline, err := c.findPipelineForCaches(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return c.artifactService.Download(ctx, pipeline.ID, 0, key)
+}
+
+// DeleteCache removes a cache of a pipeline.
+func (c *Controller) DeleteCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+) error {
+	_, pipeline, err := c.findPipelineForCaches(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return err
+	}
+
+	return c.artifactService.Delete(ctx, pipeline.ID, 0, key)
+}
+
+func (c *Controller) findPipelineForCaches(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	reqPermission enum.Permission,
+) (*types.RepositoryCore, *types.Pipeline, error) {
+	repo, err := c.getRepoCheckPipelineAccess(ctx, session, repoRef, pipelineIdentifier, reqPermission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	return repo, pipeline, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
@@ -35,6 +36,8 @@ type Controller struct {
 	pipelineStore store.PipelineStore
 	reporter      events.Reporter
 	repoFinder    refcache.RepoFinder
+
+	artifactService *artifact.Service
 }
 
 func NewController(
@@ -43,6 +46,7 @@ func NewController(
 	pipelineStore store.PipelineStore,
 	reporter events.Reporter,
 	repoFinder refcache.RepoFinder,
+	artifactService *artifact.Service,
 ) *Controller {
 	return &Controller{
 		repoFinder:    repoFinder,
@@ -50,6 +54,8 @@ func NewController(
 		authorizer:    authorizer,
 		pipelineStore: pipelineStore,
 		reporter:      reporter,
+
+		artifactService: artifactService,
 	}
 }
 
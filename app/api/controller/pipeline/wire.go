@@ -17,6 +17,7 @@ package pipeline
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 
@@ -34,6 +35,7 @@ func ProvideController(
 	pipelineStore store.PipelineStore,
 	reporter *events.Reporter,
 	repoFinder refcache.RepoFinder,
+	artifactService *artifact.Service,
 ) *Controller {
 	return NewController(
 		authorizer,
@@ -41,5 +43,6 @@ func ProvideController(
 		pipelineStore,
 		*reporter,
 		repoFinder,
+		artifactService,
 	)
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -79,6 +80,7 @@ type Controller struct {
 	labelSvc               *label.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
+	pullreqTemplates       *pullreqtemplate.Service
 }
 
 func NewController(
@@ -113,6 +115,7 @@ func NewController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	pullreqTemplates *pullreqtemplate.Service,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -146,6 +149,7 @@ func NewController(
 		labelSvc:               labelSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
+		pullreqTemplates:       pullreqTemplates,
 	}
 }
 
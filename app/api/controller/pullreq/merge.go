@@ -403,16 +403,39 @@ func (c *Controller) Merge(
 		committer = nil // Not important for fast-forward merge
 	}
 
+	defaultTitle := defaultMergeCommitTitle(in.Method, sourceRepo, pr)
+
+	// apply the commit message template of the protection rules to the parts of the commit message not customized
+	if ruleOut.CommitMessageTemplate != "" &&
+		(in.Method == enum.MergeMethodMerge || in.Method == enum.MergeMethodSquash) {
+		prefilledMessage := ""
+		if in.Message != "" {
+			prefilledMessage, err = c.prefilledCommitMessage(ctx, in.Method, sourceRepo, pr, in.SourceSHA)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		customTitle, customMessage := isCustomCommitMessage(in.Title, in.Message, defaultTitle, prefilledMessage)
+		if !customTitle || !customMessage {
+			title, message, err := c.renderCommitMessageTemplate(
+				ctx, ruleOut.CommitMessageTemplate, sourceRepo, pr, in.SourceSHA, reviewers)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to render commit message template: %w", err)
+			}
+
+			if !customTitle {
+				in.Title = title
+			}
+			if !customMessage {
+				in.Message = message
+			}
+		}
+	}
+
 	// backfill commit title if none provided
 	if in.Title == "" {
-		switch in.Method {
-		case enum.MergeMethodMerge:
-			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-		case enum.MergeMethodSquash:
-			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-			// Not used.
-		}
+		in.Title = defaultTitle
 	}
 
 	// create merge commit(s)
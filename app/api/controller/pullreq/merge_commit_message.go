@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxCoAuthorCommits is the maximum number of pull request commits inspected for co-authors.
+	maxCoAuthorCommits = 1000
+
+	// prefilledMessageCommits and prefilledMessageMaxLength match the squash commit message
+	// prefilled by the web UI: a list of the first pull request commits, truncated to the max length.
+	prefilledMessageCommits   = 500
+	prefilledMessageMaxLength = 1000
+)
+
+// renderCommitMessageTemplate builds the merge commit title and message from the commit message template
+// defined by the protection rules of the target branch.
+func (c *Controller) renderCommitMessageTemplate(
+	ctx context.Context,
+	template string,
+	sourceRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	sourceSHA string,
+	reviewers []*types.PullReqReviewer,
+) (string, string, error) {
+	author := identityFromPrincipalInfo(pr.Author)
+
+	values := protection.CommitMessageValues{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Description:  pr.Description,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+		Author:       author,
+	}
+
+	if protection.CommitMessageTemplateUses(template, protection.CommitMessagePlaceholderCoAuthors) {
+		output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: git.CreateReadParams(sourceRepo),
+			GitREF:     sourceSHA,
+			After:      pr.MergeBaseSHA,
+			Limit:      maxCoAuthorCommits,
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		seen := map[string]struct{}{strings.ToLower(author.Email): {}}
+		for _, commit := range output.Commits {
+			identity := commit.Author.Identity
+			email := strings.ToLower(identity.Email)
+			if _, ok := seen[email]; ok {
+				continue
+			}
+
+			seen[email] = struct{}{}
+			values.CoAuthors = append(values.CoAuthors, protection.CommitMessageIdentity{
+				Name:  identity.Name,
+				Email: identity.Email,
+			})
+		}
+	}
+
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision != enum.PullReqReviewDecisionApproved {
+			continue
+		}
+
+		values.Reviewers = append(values.Reviewers, identityFromPrincipalInfo(reviewer.Reviewer))
+	}
+
+	title, message := protection.RenderCommitMessageTemplate(template, values)
+
+	return title, message, nil
+}
+
+// defaultMergeCommitTitle returns the title of the merge commit used if none is provided.
+func defaultMergeCommitTitle(
+	method enum.MergeMethod,
+	sourceRepo *types.RepositoryCore,
+	pr *types.PullReq,
+) string {
+	switch method {
+	case enum.MergeMethodMerge:
+		return fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		// Not used.
+	}
+
+	return ""
+}
+
+// prefilledCommitMessage returns the commit message the web UI prefills for the merge method.
+func (c *Controller) prefilledCommitMessage(
+	ctx context.Context,
+	method enum.MergeMethod,
+	sourceRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	sourceSHA string,
+) (string, error) {
+	if method != enum.MergeMethodSquash {
+		return "", nil
+	}
+
+	output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(sourceRepo),
+		GitREF:     sourceSHA,
+		After:      pr.MergeBaseSHA,
+		Limit:      prefilledMessageCommits,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	messages := make([]string, len(output.Commits))
+	for i, commit := range output.Commits {
+		messages[i] = commit.Message
+	}
+
+	return squashCommitMessage(messages), nil
+}
+
+// squashCommitMessage lists the commit messages the way the web UI does for a squash merge.
+func squashCommitMessage(messages []string) string {
+	sb := strings.Builder{}
+	for _, message := range messages {
+		sb.WriteString("* ")
+		sb.WriteString(message)
+		sb.WriteString("\n")
+	}
+
+	message := []rune(sb.String())
+	if len(message) > prefilledMessageMaxLength {
+		message = message[:prefilledMessageMaxLength]
+	}
+
+	return string(message)
+}
+
+// isCustomCommitMessage reports which parts of the provided commit message were customized by the user.
+// The title and the message are judged independently: each is custom unless it's empty
+// or equal to the one prefilled by the clients.
+func isCustomCommitMessage(
+	title, message string,
+	defaultTitle, prefilledMessage string,
+) (customTitle bool, customMessage bool) {
+	customTitle = title != "" && title != defaultTitle
+	customMessage = strings.TrimSpace(message) != "" &&
+		strings.TrimSpace(message) != strings.TrimSpace(prefilledMessage)
+
+	return customTitle, customMessage
+}
+
+func identityFromPrincipalInfo(p types.PrincipalInfo) protection.CommitMessageIdentity {
+	return protection.CommitMessageIdentity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMergeCommitTitle(t *testing.T) {
+	sourceRepo := &types.RepositoryCore{Path: "space/repo"}
+	pr := &types.PullReq{Number: 7, Title: "Add feature", SourceBranch: "feature"}
+
+	assert.Equal(t, "Merge branch 'feature' of space/repo (#7)",
+		defaultMergeCommitTitle(enum.MergeMethodMerge, sourceRepo, pr))
+	assert.Equal(t, "Add feature (#7)", defaultMergeCommitTitle(enum.MergeMethodSquash, sourceRepo, pr))
+	assert.Empty(t, defaultMergeCommitTitle(enum.MergeMethodRebase, sourceRepo, pr))
+}
+
+func TestSquashCommitMessage(t *testing.T) {
+	assert.Equal(t, "* First\n* Second\nwith body\n", squashCommitMessage([]string{"First", "Second\nwith body"}))
+	assert.Empty(t, squashCommitMessage(nil))
+
+	long := squashCommitMessage([]string{strings.Repeat("a", prefilledMessageMaxLength)})
+	assert.Len(t, long, prefilledMessageMaxLength)
+}
+
+func TestIsCustomCommitMessage(t *testing.T) {
+	const defaultTitle = "Add feature (#7)"
+	const prefilledMessage = "* commit\n"
+
+	tests := []struct {
+		name          string
+		title         string
+		message       string
+		customTitle   bool
+		customMessage bool
+	}{
+		{name: "nothing provided"},
+		{name: "default title", title: defaultTitle},
+		{name: "default title with prefilled message", title: defaultTitle, message: prefilledMessage},
+		{name: "default title with custom message", title: defaultTitle, message: "Custom", customMessage: true},
+		{name: "custom title with prefilled message", title: "Custom", message: prefilledMessage, customTitle: true},
+		{name: "custom title", title: "Custom", customTitle: true},
+		{name: "custom message", message: "Custom", customMessage: true},
+		{name: "custom title and message", title: "Custom", message: "Custom", customTitle: true, customMessage: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			customTitle, customMessage := isCustomCommitMessage(test.title, test.message, defaultTitle, prefilledMessage)
+			assert.Equal(t, test.customTitle, customTitle)
+			assert.Equal(t, test.customMessage, customMessage)
+		})
+	}
+}
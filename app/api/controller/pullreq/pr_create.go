@@ -44,6 +44,10 @@ type CreateInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 
+	// Template is the name of the pull request template applied when the description is empty.
+	// If not provided, the repository's default template is used (if present).
+	Template string `json:"template"`
+
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
@@ -56,6 +60,7 @@ type CreateInput struct {
 func (in *CreateInput) Sanitize() error {
 	in.Title = strings.TrimSpace(in.Title)
 	in.Description = strings.TrimSpace(in.Description)
+	in.Template = strings.TrimSpace(in.Template)
 
 	if err := validateTitle(in.Title); err != nil {
 		return err
@@ -106,6 +111,10 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.applyDescriptionTemplate(ctx, targetRepo, in); err != nil {
+		return nil, err
+	}
+
 	if err = c.checkIfAlreadyExists(ctx, targetRepo.ID, sourceRepo.ID, in.TargetBranch, in.SourceBranch); err != nil {
 		return nil, err
 	}
@@ -496,3 +505,46 @@ func newPullReq(
 		Merger:            nil,
 	}
 }
+
+// applyDescriptionTemplate sets the description of the pull request to the content of the requested
+// (or default) pull request template from the target branch, in case no description was provided.
+// Only a template requested by name fails the pull request creation, an unusable default template is skipped.
+func (c *Controller) applyDescriptionTemplate(
+	ctx context.Context,
+	targetRepo *types.RepositoryCore,
+	in *CreateInput,
+) error {
+	if in.Description != "" {
+		return nil
+	}
+
+	template, err := c.pullreqTemplates.Find(ctx, targetRepo, in.TargetBranch, in.Template)
+	if errors.IsNotFound(err) {
+		return usererror.BadRequestf("Pull request template %q not found in the target branch", in.Template)
+	}
+	if err != nil && in.Template == "" {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find default pull request template, skipping it")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request template: %w", err)
+	}
+	if template == nil {
+		return nil
+	}
+
+	description := strings.TrimSpace(template.Content)
+	if err := validateDescription(description); err != nil {
+		if in.Template == "" {
+			log.Ctx(ctx).Warn().Err(err).Str("path", template.Path).
+				Msg("default pull request template isn't a valid description, skipping it")
+			return nil
+		}
+
+		return err
+	}
+
+	in.Description = description
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// templateGitStub holds a repository with only the default pull request template, or fails with err.
+type templateGitStub struct {
+	git.Interface
+
+	content string
+	err     error
+}
+
+func (g *templateGitStub) GetTreeNode(
+	_ context.Context,
+	params *git.GetTreeNodeParams,
+) (*git.GetTreeNodeOutput, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	return &git.GetTreeNodeOutput{
+		Node: git.TreeNode{Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, Path: params.Path},
+	}, nil
+}
+
+func (g *templateGitStub) ListTreeNodes(
+	_ context.Context,
+	params *git.ListTreeNodeParams,
+) (*git.ListTreeNodeOutput, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	return nil, gitness_errors.NotFound("path %q not found", params.Path)
+}
+
+func (g *templateGitStub) GetBlob(context.Context, *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	return &git.GetBlobOutput{
+		Size:    int64(len(g.content)),
+		Content: io.NopCloser(strings.NewReader(g.content)),
+	}, nil
+}
+
+func TestApplyDescriptionTemplate(t *testing.T) {
+	errGit := errors.New("git unavailable")
+
+	tests := []struct {
+		name        string
+		git         *templateGitStub
+		in          CreateInput
+		description string
+		err         error
+		status      int
+	}{
+		{
+			name:        "default template",
+			git:         &templateGitStub{content: "\n## Summary\n"},
+			description: "## Summary",
+		},
+		{
+			name:        "description provided",
+			git:         &templateGitStub{content: "## Summary"},
+			in:          CreateInput{Description: "Custom"},
+			description: "Custom",
+		},
+		{
+			name: "unavailable default template is skipped",
+			git:  &templateGitStub{err: errGit},
+		},
+		{
+			name: "unavailable named template",
+			git:  &templateGitStub{err: errGit},
+			in:   CreateInput{Template: "feature"},
+			err:  errGit,
+		},
+		{
+			name:   "missing named template",
+			git:    &templateGitStub{},
+			in:     CreateInput{Template: "feature"},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{pullreqTemplates: pullreqtemplate.New(test.git)}
+			in := test.in
+
+			err := c.applyDescriptionTemplate(context.Background(), &types.RepositoryCore{}, &in)
+			switch {
+			case test.err != nil:
+				require.ErrorIs(t, err, test.err)
+			case test.status != 0:
+				var uerr *usererror.Error
+				require.ErrorAs(t, err, &uerr)
+				assert.Equal(t, test.status, uerr.Status)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, test.description, in.Description)
+			}
+		})
+	}
+}
@@ -55,6 +55,13 @@ func (c *Controller) Find(
 		return nil, fmt.Errorf("failed to backfill pull request metadata: %w", err)
 	}
 
+	if options.IncludeStack {
+		pr.Stack, err = c.getStack(ctx, pr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request stack: %w", err)
+		}
+	}
+
 	return pr, nil
 }
 
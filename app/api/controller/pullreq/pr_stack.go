@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxStackDepth limits the number of base pull requests resolved for a stacked pull request.
+const maxStackDepth = 20
+
+// getStack returns the dependency chain of the pull request: the chain of open pull requests
+// it's stacked on and the open pull requests that are directly stacked on it.
+// Returns nil if the pull request is neither stacked nor has any stacked pull requests.
+func (c *Controller) getStack(ctx context.Context, pr *types.PullReq) (*types.PullReqStack, error) {
+	if pr.State != enum.PullReqStateOpen || pr.SourceRepoID != pr.TargetRepoID {
+		return nil, nil //nolint:nilnil // no stack
+	}
+
+	base, err := stackBase(pr, func(branch string) (*types.PullReq, error) {
+		list, err := c.listOpenPullReqs(ctx, &types.PullReqFilter{
+			SourceRepoID: pr.TargetRepoID,
+			SourceBranch: branch,
+			TargetRepoID: pr.TargetRepoID,
+		})
+		if err != nil || len(list) == 0 {
+			return nil, err
+		}
+		return list[0], nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base pull requests: %w", err)
+	}
+
+	dependents, err := c.listOpenPullReqs(ctx, &types.PullReqFilter{
+		SourceRepoID: pr.TargetRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		TargetBranch: pr.SourceBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependent pull requests: %w", err)
+	}
+
+	if len(base) == 0 && len(dependents) == 0 {
+		return nil, nil //nolint:nilnil // no stack
+	}
+
+	stack := &types.PullReqStack{
+		Base:       base,
+		Dependents: make([]types.PullReqStackEntry, len(dependents)),
+	}
+	for i, dependent := range dependents {
+		stack.Dependents[i] = newPullReqStackEntry(dependent)
+	}
+
+	return stack, nil
+}
+
+func (c *Controller) listOpenPullReqs(ctx context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	filter.Size = maxStackDepth
+	filter.States = []enum.PullReqState{enum.PullReqStateOpen}
+	filter.Sort = enum.PullReqSortNumber
+	filter.Order = enum.OrderAsc
+
+	return c.pullreqStore.List(ctx, filter)
+}
+
+// stackBase follows the target branches of the pull request to find the chain of pull requests it depends on.
+// The findBySource function should return the pull request with the provided source branch, or nil if there's none.
+func stackBase(
+	pr *types.PullReq,
+	findBySource func(branch string) (*types.PullReq, error),
+) ([]types.PullReqStackEntry, error) {
+	var base []types.PullReqStackEntry
+
+	visited := map[int64]struct{}{pr.ID: {}}
+	current := pr
+	for len(base) < maxStackDepth {
+		next, err := findBySource(current.TargetBranch)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		if _, ok := visited[next.ID]; ok {
+			break // a cycle
+		}
+
+		visited[next.ID] = struct{}{}
+		base = append(base, newPullReqStackEntry(next))
+		current = next
+	}
+
+	return base, nil
+}
+
+func newPullReqStackEntry(pr *types.PullReq) types.PullReqStackEntry {
+	return types.PullReqStackEntry{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		State:        pr.State,
+		IsDraft:      pr.IsDraft,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStackBase(t *testing.T) {
+	newPR := func(id int64, source, target string) *types.PullReq {
+		return &types.PullReq{ID: id, Number: id, SourceBranch: source, TargetBranch: target}
+	}
+
+	tests := []struct {
+		name   string
+		pr     *types.PullReq
+		open   []*types.PullReq
+		expect []int64
+	}{
+		{
+			name:   "not stacked",
+			pr:     newPR(1, "feature", "main"),
+			open:   []*types.PullReq{newPR(2, "other", "main")},
+			expect: nil,
+		},
+		{
+			name: "chain",
+			pr:   newPR(3, "c", "b"),
+			open: []*types.PullReq{
+				newPR(1, "a", "main"),
+				newPR(2, "b", "a"),
+			},
+			expect: []int64{2, 1},
+		},
+		{
+			name: "cycle",
+			pr:   newPR(1, "a", "b"),
+			open: []*types.PullReq{
+				newPR(2, "b", "c"),
+				newPR(3, "c", "a"),
+				newPR(1, "a", "b"),
+			},
+			expect: []int64{2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base, err := stackBase(test.pr, func(branch string) (*types.PullReq, error) {
+				for _, pr := range test.open {
+					if pr.SourceBranch == branch {
+						return pr, nil
+					}
+				}
+				return nil, nil //nolint:nilnil
+			})
+			require.NoError(t, err)
+
+			var numbers []int64
+			for _, entry := range base {
+				numbers = append(numbers, entry.Number)
+			}
+			assert.Equal(t, test.expect, numbers)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const maxDismissReasonLength = 1024
+
+type ReviewDismissInput struct {
+	Reason string `json:"reason"`
+}
+
+func (in *ReviewDismissInput) Sanitize() error {
+	in.Reason = strings.TrimSpace(in.Reason)
+
+	if in.Reason == "" {
+		return usererror.BadRequest("A reason for dismissing the review must be provided.")
+	}
+
+	if len(in.Reason) > maxDismissReasonLength {
+		return usererror.BadRequestf("The reason can't be longer than %d characters.", maxDismissReasonLength)
+	}
+
+	return nil
+}
+
+// ReviewDismiss dismisses the change request of a reviewer. The reviewer is asked to review the pull request again.
+func (c *Controller) ReviewDismiss(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	reviewerID int64,
+	in *ReviewDismissInput,
+) (*types.PullReqReviewer, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	// RepoEdit permission is required to dismiss a submitted review, same as for removing it.
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Reviews can be dismissed only for open pull requests")
+	}
+
+	reviewer, err := c.reviewerStore.Find(ctx, pr.ID, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviewer: %w", err)
+	}
+
+	if reviewer.ReviewDecision != enum.PullReqReviewDecisionChangeReq {
+		return nil, usererror.BadRequest("Only reviews that requested changes can be dismissed")
+	}
+
+	changeReqSHA := reviewer.SHA
+
+	reviewer.ReviewDecision = enum.PullReqReviewDecisionPending
+	if err = c.reviewerStore.Update(ctx, reviewer); err != nil {
+		return nil, fmt.Errorf("failed to update reviewer: %w", err)
+	}
+
+	err = func() error {
+		payload := &types.PullRequestActivityPayloadReviewDismiss{
+			CommitSHA:   changeReqSHA,
+			Decision:    enum.PullReqReviewDecisionChangeReq,
+			PrincipalID: reviewer.PrincipalID,
+			Reason:      in.Reason,
+		}
+
+		metadata := &types.PullReqActivityMetadata{
+			Mentions: &types.PullReqActivityMentionsMetadata{IDs: []int64{reviewer.PrincipalID}},
+		}
+
+		if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request activity: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after review dismissal")
+	}
+
+	c.eventReporter.ReviewDismissed(ctx, &events.ReviewDismissedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ReviewerID: reviewer.PrincipalID,
+		Decision:   enum.PullReqReviewDecisionChangeReq,
+		Reason:     in.Reason,
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return reviewer, nil
+}
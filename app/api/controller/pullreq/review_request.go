@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ReviewRequestInput struct {
+	ReviewerIDs []int64 `json:"reviewer_ids"`
+}
+
+func (in *ReviewRequestInput) Sanitize() error {
+	if len(in.ReviewerIDs) == 0 {
+		return usererror.BadRequest("At least one reviewer must be provided.")
+	}
+
+	return nil
+}
+
+// ReviewRequest asks existing reviewers of the pull request to review it again.
+// Only the author of the pull request can re-request reviews.
+func (c *Controller) ReviewRequest(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *ReviewRequestInput,
+) ([]*types.PullReqReviewer, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.CreatedBy != session.Principal.ID {
+		return nil, usererror.Forbidden("Only the author of the pull request can request a review again")
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Review can be requested only for open pull requests")
+	}
+
+	reviewers := make([]*types.PullReqReviewer, 0, len(in.ReviewerIDs))
+	for _, reviewerID := range in.ReviewerIDs {
+		reviewer, err := c.reviewerStore.Find(ctx, pr.ID, reviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find reviewer %d: %w", reviewerID, err)
+		}
+
+		reviewers = append(reviewers, reviewer)
+	}
+
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision != enum.PullReqReviewDecisionPending {
+			reviewer.ReviewDecision = enum.PullReqReviewDecisionPending
+			if err = c.reviewerStore.Update(ctx, reviewer); err != nil {
+				return nil, fmt.Errorf("failed to update reviewer: %w", err)
+			}
+		}
+
+		err = func() error {
+			payload := &types.PullRequestActivityPayloadReviewRequest{
+				PrincipalID: reviewer.PrincipalID,
+			}
+
+			metadata := &types.PullReqActivityMetadata{
+				Mentions: &types.PullReqActivityMentionsMetadata{IDs: []int64{reviewer.PrincipalID}},
+			}
+
+			if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+				return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+			}
+
+			_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, metadata)
+			if err != nil {
+				return fmt.Errorf("failed to create pull request activity: %w", err)
+			}
+
+			return nil
+		}()
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after review request")
+		}
+
+		c.eventReporter.ReviewRequested(ctx, &events.ReviewRequestedPayload{
+			Base:       eventBase(pr, &session.Principal),
+			ReviewerID: reviewer.PrincipalID,
+		})
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return reviewers, nil
+}
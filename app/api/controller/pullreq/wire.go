@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -67,6 +68,7 @@ func ProvideController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	pullreqTemplates *pullreqtemplate.Service,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -99,5 +101,6 @@ func ProvideController(
 		labelSvc,
 		instrumentation,
 		userGroupService,
+		pullreqTemplates,
 	)
 }
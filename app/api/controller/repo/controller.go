@@ -35,6 +35,7 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
@@ -110,6 +111,9 @@ type Controller struct {
 	instrumentation    instrument.Service
 	rulesSvc           *rules.Service
 	sseStreamer        sse.Streamer
+
+	repoMembershipStore store.RepoMembershipStore
+	pullreqTemplates    *pullreqtemplate.Service
 }
 
 func NewController(
@@ -148,6 +152,8 @@ func NewController(
 	userGroupService usergroup.SearchService,
 	rulesSvc *rules.Service,
 	sseStreamer sse.Streamer,
+	repoMembershipStore store.RepoMembershipStore,
+	pullreqTemplates *pullreqtemplate.Service,
 ) *Controller {
 	return &Controller{
 		defaultBranch:      config.Git.DefaultBranch,
@@ -185,6 +191,9 @@ func NewController(
 		userGroupService:   userGroupService,
 		rulesSvc:           rulesSvc,
 		sseStreamer:        sseStreamer,
+
+		repoMembershipStore: repoMembershipStore,
+		pullreqTemplates:    pullreqTemplates,
 	}
 }
 
@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MembershipAddInput holds the data for adding a member to a repository.
+// Either a user or service account UID, or a user group ID must be provided.
+type MembershipAddInput struct {
+	PrincipalUID string              `json:"principal_uid"`
+	UserGroupID  int64               `json:"usergroup_id"`
+	Role         enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipAddInput) Validate() error {
+	if (in.PrincipalUID == "") == (in.UserGroupID == 0) {
+		return usererror.BadRequest("Either principal UID or user group ID must be provided")
+	}
+
+	return validateRepoMembershipRole(&in.Role)
+}
+
+// validateRepoMembershipRole sanitizes the role of a repository membership.
+// Custom roles are defined in spaces, so they can't be assigned on a repository.
+func validateRepoMembershipRole(role *enum.MembershipRole) error {
+	sanitized, ok := role.Sanitize()
+	if !ok || sanitized == enum.MembershipRoleCustom {
+		return usererror.BadRequestf("Provided role '%s' is not supported for repository memberships", *role)
+	}
+
+	*role = sanitized
+
+	return nil
+}
+
+// checkRepoMembershipRoleGrantable ensures the caller holds all repository and pipeline permissions
+// of the role on the repository, as those are the permissions granted by a repository membership.
+// It prevents callers from granting (or revoking) more access than they have themselves.
+func (c *Controller) checkRepoMembershipRoleGrantable(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	role enum.MembershipRole,
+) error {
+	for _, permission := range role.Permissions() {
+		var err error
+		switch {
+		case strings.HasPrefix(string(permission), "repo_"):
+			err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission)
+		case strings.HasPrefix(string(permission), "pipeline_"):
+			err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, "", permission)
+		default:
+			continue
+		}
+
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			return usererror.Forbidden(fmt.Sprintf(
+				"Role '%s' grants permission '%s' which you don't have on the repository", role, permission))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check permission '%s': %w", permission, err)
+		}
+	}
+
+	return nil
+}
+
+// findRepoUserGroup finds a user group that can be a member of the repository.
+// Only user groups of the space of the repository or of any of its ancestors are visible to the repository.
+func (c *Controller) findRepoUserGroup(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	userGroupID int64,
+) (*types.UserGroup, error) {
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User group %d not found", userGroupID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user group: %w", err)
+	}
+
+	spaceIDs, err := c.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces of the repository: %w", err)
+	}
+
+	if !slices.Contains(spaceIDs, userGroup.SpaceID) {
+		return nil, usererror.BadRequestf("User group %d not found", userGroupID)
+	}
+
+	return userGroup, nil
+}
+
+// MembershipAdd adds a new member, a user, service account or a user group, to a repository.
+func (c *Controller) MembershipAdd(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MembershipAddInput,
+) (*types.RepoMembershipInfo, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkRepoMembershipRoleGrantable(ctx, session, repo, in.Role); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership := &types.RepoMembershipInfo{
+		RepoMembership: types.RepoMembership{
+			RepoID:    repo.ID,
+			Role:      in.Role,
+			CreatedBy: session.Principal.ID,
+			Created:   now,
+			Updated:   now,
+		},
+		AddedBy: *session.Principal.ToPrincipalInfo(),
+	}
+
+	if in.PrincipalUID != "" {
+		principal, err := c.principalStore.FindByUID(ctx, in.PrincipalUID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, usererror.BadRequestf("Principal '%s' not found", in.PrincipalUID)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to find the principal: %w", err)
+		}
+
+		if principal.Type != enum.PrincipalTypeUser && principal.Type != enum.PrincipalTypeServiceAccount {
+			return nil, usererror.BadRequestf("Principal '%s' can't be a repository member", in.PrincipalUID)
+		}
+
+		membership.PrincipalID = &principal.ID
+		membership.Principal = principal.ToPrincipalInfo()
+	} else {
+		userGroup, err := c.findRepoUserGroup(ctx, repo, in.UserGroupID)
+		if err != nil {
+			return nil, err
+		}
+
+		membership.UserGroupID = &userGroup.ID
+		membership.UserGroup = userGroup.ToUserGroupInfo()
+	}
+
+	err = c.repoMembershipStore.Create(ctx, &membership.RepoMembership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repo membership: %w", err)
+	}
+
+	return membership, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MembershipDelete removes a member from a repository.
+func (c *Controller) MembershipDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	membershipID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	membership, err := c.findRepoMembership(ctx, repo, membershipID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.checkRepoMembershipRoleGrantable(ctx, session, repo, membership.Role); err != nil {
+		return err
+	}
+
+	err = c.repoMembershipStore.Delete(ctx, membership.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete repo membership: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MembershipList lists the members, users, service accounts and user groups, of a repository.
+func (c *Controller) MembershipList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.RepoMembershipInfo, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := c.repoMembershipStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repo memberships: %w", err)
+	}
+
+	return c.mapRepoMembershipInfos(ctx, memberships)
+}
+
+// findRepoMembership returns the membership with the provided ID if it belongs to the repository.
+func (c *Controller) findRepoMembership(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	membershipID int64,
+) (*types.RepoMembership, error) {
+	membership, err := c.repoMembershipStore.Find(ctx, membershipID)
+	if errors.Is(err, store.ErrResourceNotFound) || (err == nil && membership.RepoID != repo.ID) {
+		return nil, usererror.NotFound("Repository membership not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+// mapRepoMembershipInfos adds the principal and user group info to the repository memberships.
+func (c *Controller) mapRepoMembershipInfos(
+	ctx context.Context,
+	memberships []*types.RepoMembership,
+) ([]types.RepoMembershipInfo, error) {
+	principalIDs := make([]int64, 0, 2*len(memberships))
+	userGroupIDs := make([]int64, 0, len(memberships))
+	for _, membership := range memberships {
+		principalIDs = append(principalIDs, membership.CreatedBy)
+		if membership.PrincipalID != nil {
+			principalIDs = append(principalIDs, *membership.PrincipalID)
+		}
+		if membership.UserGroupID != nil {
+			userGroupIDs = append(userGroupIDs, *membership.UserGroupID)
+		}
+	}
+
+	principalInfos, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load principal infos: %w", err)
+	}
+
+	userGroups, err := c.userGroupStore.Map(ctx, userGroupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user groups: %w", err)
+	}
+
+	infos := make([]types.RepoMembershipInfo, len(memberships))
+	for i, membership := range memberships {
+		infos[i].RepoMembership = *membership
+
+		if addedBy, ok := principalInfos[membership.CreatedBy]; ok {
+			infos[i].AddedBy = *addedBy
+		}
+
+		if membership.PrincipalID != nil {
+			infos[i].Principal = principalInfos[*membership.PrincipalID]
+		}
+
+		if membership.UserGroupID != nil {
+			if userGroup, ok := userGroups[*membership.UserGroupID]; ok {
+				infos[i].UserGroup = userGroup.ToUserGroupInfo()
+			}
+		}
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipAddInputValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      MembershipAddInput
+		wantErr bool
+	}{
+		{
+			name: "principal",
+			in:   MembershipAddInput{PrincipalUID: "contractor", Role: enum.MembershipRoleContributor},
+		},
+		{
+			name: "user group",
+			in:   MembershipAddInput{UserGroupID: 3, Role: enum.MembershipRoleReader},
+		},
+		{
+			name:    "no member",
+			in:      MembershipAddInput{Role: enum.MembershipRoleReader},
+			wantErr: true,
+		},
+		{
+			name:    "principal and user group",
+			in:      MembershipAddInput{PrincipalUID: "contractor", UserGroupID: 3, Role: enum.MembershipRoleReader},
+			wantErr: true,
+		},
+		{
+			name:    "custom role",
+			in:      MembershipAddInput{PrincipalUID: "contractor", Role: enum.MembershipRoleCustom},
+			wantErr: true,
+		},
+		{
+			name:    "unknown role",
+			in:      MembershipAddInput{PrincipalUID: "contractor", Role: "admin"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.in.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// permissionAuthorizer grants a fixed set of permissions on any resource.
+type permissionAuthorizer struct {
+	authz.Authorizer
+	permissions []enum.Permission
+}
+
+func (a permissionAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	for _, p := range a.permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestCheckRepoMembershipRoleGrantable(t *testing.T) {
+	// a repository admin without the permission to delete the repository
+	c := &Controller{authorizer: permissionAuthorizer{permissions: []enum.Permission{
+		enum.PermissionRepoView,
+		enum.PermissionRepoEdit,
+		enum.PermissionRepoPush,
+		enum.PermissionRepoReview,
+		enum.PermissionPipelineView,
+	}}}
+	repo := &types.RepositoryCore{ID: 1, Path: "space/repo", Identifier: "repo"}
+	ctx := context.Background()
+	session := &auth.Session{}
+
+	err := c.checkRepoMembershipRoleGrantable(ctx, session, repo, enum.MembershipRoleContributor)
+	require.NoError(t, err)
+
+	err = c.checkRepoMembershipRoleGrantable(ctx, session, repo, enum.MembershipRoleSpaceOwner)
+	var uerr *usererror.Error
+	require.ErrorAs(t, err, &uerr)
+	assert.Equal(t, http.StatusForbidden, uerr.Status)
+
+	// executors run pipelines, which the caller can't do.
+	err = c.checkRepoMembershipRoleGrantable(ctx, session, repo, enum.MembershipRoleExecutor)
+	require.ErrorAs(t, err, &uerr)
+}
+
+type fakeUserGroupStore struct {
+	store.UserGroupStore
+	userGroups map[int64]*types.UserGroup
+}
+
+func (s fakeUserGroupStore) Find(_ context.Context, id int64) (*types.UserGroup, error) {
+	userGroup, ok := s.userGroups[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return userGroup, nil
+}
+
+// fakeSpaceStore holds the space tree 1 -> 2 -> 3.
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) GetAncestorIDs(_ context.Context, spaceID int64) ([]int64, error) {
+	var ids []int64
+	for id := spaceID; id > 0; id-- {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func TestFindRepoUserGroup(t *testing.T) {
+	c := &Controller{
+		spaceStore: fakeSpaceStore{},
+		userGroupStore: fakeUserGroupStore{userGroups: map[int64]*types.UserGroup{
+			10: {ID: 10, SpaceID: 1},
+			11: {ID: 11, SpaceID: 2},
+			12: {ID: 12, SpaceID: 3},
+		}},
+	}
+	repo := &types.RepositoryCore{ID: 1, ParentID: 2}
+
+	for _, id := range []int64{10, 11} {
+		userGroup, err := c.findRepoUserGroup(context.Background(), repo, id)
+		require.NoError(t, err)
+		assert.Equal(t, id, userGroup.ID)
+	}
+
+	// user groups of a sub space or unknown user groups aren't visible to the repository.
+	for _, id := range []int64{12, 13} {
+		_, err := c.findRepoUserGroup(context.Background(), repo, id)
+		var uerr *usererror.Error
+		require.ErrorAs(t, err, &uerr)
+		assert.Equal(t, http.StatusBadRequest, uerr.Status)
+	}
+}
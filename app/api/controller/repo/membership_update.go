@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MembershipUpdateInput holds the data for updating a repository membership.
+type MembershipUpdateInput struct {
+	Role enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipUpdateInput) Validate() error {
+	return validateRepoMembershipRole(&in.Role)
+}
+
+// MembershipUpdate changes the role of a repository member.
+func (c *Controller) MembershipUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	membershipID int64,
+	in *MembershipUpdateInput,
+) (*types.RepoMembershipInfo, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	membership, err := c.findRepoMembership(ctx, repo, membershipID)
+	if err != nil {
+		return nil, err
+	}
+
+	if membership.Role != in.Role {
+		if err = c.checkRepoMembershipRoleGrantable(ctx, session, repo, membership.Role); err != nil {
+			return nil, err
+		}
+		if err = c.checkRepoMembershipRoleGrantable(ctx, session, repo, in.Role); err != nil {
+			return nil, err
+		}
+
+		membership.Role = in.Role
+		membership.Updated = time.Now().UnixMilli()
+
+		err = c.repoMembershipStore.Update(ctx, membership)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update repo membership: %w", err)
+		}
+	}
+
+	infos, err := c.mapRepoMembershipInfos(ctx, []*types.RepoMembership{membership})
+	if err != nil {
+		return nil, err
+	}
+
+	return &infos[0], nil
+}
@@ -15,6 +15,7 @@
 package reposettings
 
 import (
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/services/settings"
 
 	"github.com/gotidy/ptr"
@@ -22,23 +23,47 @@ import (
 
 // GeneralSettings represent the general repository settings as exposed externally.
 type GeneralSettings struct {
-	FileSizeLimit *int64 `json:"file_size_limit" yaml:"file_size_limit"`
+	FileSizeLimit               *int64 `json:"file_size_limit" yaml:"file_size_limit"`
+	ReviewReminderSLAInHours    *int64 `json:"review_reminder_sla_in_hours" yaml:"review_reminder_sla_in_hours"`
+	ReviewEscalationSLAInHours  *int64 `json:"review_escalation_sla_in_hours" yaml:"review_escalation_sla_in_hours"`
+	ReviewEscalationUserGroupID *int64 `json:"review_escalation_usergroup_id" yaml:"review_escalation_usergroup_id"`
+}
+
+func (s *GeneralSettings) Validate() error {
+	for _, v := range []*int64{
+		s.FileSizeLimit,
+		s.ReviewReminderSLAInHours,
+		s.ReviewEscalationSLAInHours,
+		s.ReviewEscalationUserGroupID,
+	} {
+		if v != nil && *v < 0 {
+			return usererror.BadRequest("Settings values can't be negative.")
+		}
+	}
+
+	return nil
 }
 
 func GetDefaultGeneralSettings() *GeneralSettings {
 	return &GeneralSettings{
-		FileSizeLimit: ptr.Int64(settings.DefaultFileSizeLimit),
+		FileSizeLimit:               ptr.Int64(settings.DefaultFileSizeLimit),
+		ReviewReminderSLAInHours:    ptr.Int64(settings.DefaultReviewReminderSLAInHours),
+		ReviewEscalationSLAInHours:  ptr.Int64(settings.DefaultReviewEscalationSLAInHours),
+		ReviewEscalationUserGroupID: ptr.Int64(settings.DefaultReviewEscalationUserGroupID),
 	}
 }
 
 func GetGeneralSettingsMappings(s *GeneralSettings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeyFileSizeLimit, s.FileSizeLimit),
+		settings.Mapping(settings.KeyReviewReminderSLAInHours, s.ReviewReminderSLAInHours),
+		settings.Mapping(settings.KeyReviewEscalationSLAInHours, s.ReviewEscalationSLAInHours),
+		settings.Mapping(settings.KeyReviewEscalationUserGroupID, s.ReviewEscalationUserGroupID),
 	}
 }
 
 func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
-	kvs := make([]settings.KeyValue, 0, 1)
+	kvs := make([]settings.KeyValue, 0, 4)
 
 	if s.FileSizeLimit != nil {
 		kvs = append(kvs, settings.KeyValue{
@@ -46,5 +71,23 @@ func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
 			Value: s.FileSizeLimit,
 		})
 	}
+	if s.ReviewReminderSLAInHours != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyReviewReminderSLAInHours,
+			Value: s.ReviewReminderSLAInHours,
+		})
+	}
+	if s.ReviewEscalationSLAInHours != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyReviewEscalationSLAInHours,
+			Value: s.ReviewEscalationSLAInHours,
+		})
+	}
+	if s.ReviewEscalationUserGroupID != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyReviewEscalationUserGroupID,
+			Value: s.ReviewEscalationUserGroupID,
+		})
+	}
 	return kvs
 }
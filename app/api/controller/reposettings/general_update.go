@@ -33,6 +33,10 @@ func (c *Controller) GeneralUpdate(
 	repoRef string,
 	in *GeneralSettings,
 ) (*GeneralSettings, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
 	// migrating repos need to adjust repo settings (like file-size-limit) during the migration.
 	var additionalAllowedRepoStates = []enum.RepoState{enum.RepoStateMigrateGitPush}
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, additionalAllowedRepoStates...)
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/runner-go/client"
+)
+
+// heartbeatInterval is the minimum time between two updates of the
+// last time a runner was seen, to avoid a write on every RPC call.
+const heartbeatInterval = 15 * time.Second
+
+type Controller struct {
+	runnerStore store.RunnerStore
+	stageStore  store.StageStore
+	stepStore   store.StepStore
+	// client exposes the execution manager using the drone runner protocol types.
+	client client.Client
+}
+
+func NewController(
+	config *types.Config,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	executionManager manager.ExecutionManager,
+	urlProvider url.Provider,
+) *Controller {
+	return &Controller{
+		runnerStore: runnerStore,
+		stageStore:  stageStore,
+		stepStore:   stepStore,
+		client:      manager.NewEmbeddedClient(executionManager, urlProvider, config),
+	}
+}
+
+// checkAdmin ensures only admins manage runners, as runners get access to
+// the secrets of all the pipelines they execute.
+func checkAdmin(session *auth.Session) error {
+	if session == nil || !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Controller) findRunner(ctx context.Context, identifier string) (*types.Runner, error) {
+	runner, err := c.runnerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+	return runner, nil
+}
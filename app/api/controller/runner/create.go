@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+)
+
+const (
+	tokenPrefix = "runner_"
+	tokenLength = 40
+)
+
+type CreateInput struct {
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	// Labels restrict the runner to the stages with the same labels,
+	// they take precedence over the labels the runner sends when polling.
+	Labels map[string]string `json:"labels"`
+}
+
+// Create registers a new runner. The returned registration token is only
+// available in the response and has to be configured as the runner secret.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInput,
+) (*types.RunnerWithToken, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, err
+	}
+
+	if err := sanitizeCreateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	token := tokenPrefix + uniuri.NewLen(tokenLength)
+	now := time.Now().UnixMilli()
+	runner := &types.Runner{
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Labels:      in.Labels,
+		TokenHash:   hashToken(token),
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+	if err := c.runnerStore.Create(ctx, runner); err != nil {
+		return nil, fmt.Errorf("failed to create runner: %w", err)
+	}
+
+	return &types.RunnerWithToken{
+		Runner: *runner,
+		Token:  token,
+	}, nil
+}
+
+func sanitizeCreateInput(in *CreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	for k := range in.Labels {
+		if strings.TrimSpace(k) == "" {
+			return usererror.BadRequest("Runner label keys can't be empty")
+		}
+	}
+	if in.Labels == nil {
+		in.Labels = map[string]string{}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// List lists the registered runners.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter types.ListQueryFilter,
+) ([]*types.Runner, int64, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.runnerStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count runners: %w", err)
+	}
+
+	runners, err := c.runnerStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list runners: %w", err)
+	}
+
+	return runners, count, nil
+}
+
+// Find finds a runner.
+func (c *Controller) Find(ctx context.Context, session *auth.Session, identifier string) (*types.Runner, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, err
+	}
+	return c.findRunner(ctx, identifier)
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+	"github.com/rs/zerolog/log"
+)
+
+// Authenticate returns the runner owning the registration token and
+// records its heartbeat.
+func (c *Controller) Authenticate(ctx context.Context, token string) (*types.Runner, error) {
+	if token == "" {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	runner, err := c.runnerStore.FindByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	now := time.Now()
+	if now.Sub(time.UnixMilli(runner.LastSeen)) >= heartbeatInterval {
+		if err := c.runnerStore.UpdateLastSeen(ctx, runner.ID, now.UnixMilli()); err != nil {
+			// a missed heartbeat only delays the reclaim of the runner stages, don't fail the call.
+			log.Ctx(ctx).Warn().Err(err).Str("runner", runner.Identifier).Msg("failed to record runner heartbeat")
+		} else {
+			runner.LastSeen = now.UnixMilli()
+		}
+	}
+
+	return runner, nil
+}
+
+// Request requests the next stage the runner can execute. The labels of the
+// runner, if registered with any, take precedence over the polled ones.
+func (c *Controller) Request(
+	ctx context.Context,
+	runner *types.Runner,
+	filter *client.Filter,
+) (*drone.Stage, error) {
+	if len(runner.Labels) > 0 {
+		filter.Labels = runner.Labels
+	}
+
+	platform := types.RunnerPlatform{
+		Kind:    filter.Kind,
+		Type:    filter.Type,
+		OS:      filter.OS,
+		Arch:    filter.Arch,
+		Kernel:  filter.Kernel,
+		Variant: filter.Variant,
+		Labels:  filter.Labels,
+	}
+	if !reflect.DeepEqual(platform, runner.Platform) {
+		// the platform is checked when the runner accepts a stage, which can be served by another replica.
+		if err := c.runnerStore.UpdatePlatform(ctx, runner.ID, platform); err != nil {
+			return nil, fmt.Errorf("failed to update runner platform: %w", err)
+		}
+		runner.Platform = platform
+	}
+
+	return c.client.Request(ctx, filter)
+}
+
+// Accept assigns the stage to the runner. The stage has to match the platform and the labels
+// the runner requested stages for, so runners can't claim stages (and their secrets) routed elsewhere.
+func (c *Controller) Accept(ctx context.Context, runner *types.Runner, stageID int64) (*drone.Stage, error) {
+	s, err := c.stageStore.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	filter := scheduler.Filter{
+		Kind:    runner.Platform.Kind,
+		Type:    runner.Platform.Type,
+		OS:      runner.Platform.OS,
+		Arch:    runner.Platform.Arch,
+		Kernel:  runner.Platform.Kernel,
+		Variant: runner.Platform.Variant,
+		Labels:  runner.Platform.Labels,
+	}
+	if len(runner.Labels) > 0 {
+		filter.Labels = runner.Labels
+	}
+	if !scheduler.Match(filter, s) {
+		return nil, usererror.Conflict("The stage doesn't match the platform or the labels of the runner")
+	}
+
+	stage := &drone.Stage{
+		ID:      stageID,
+		Machine: runner.Machine(),
+	}
+	if err := c.client.Accept(ctx, stage); err != nil {
+		return nil, err
+	}
+	return stage, nil
+}
+
+// Details returns everything needed by the runner to execute the stage.
+func (c *Controller) Details(ctx context.Context, runner *types.Runner, stageID int64) (*client.Context, error) {
+	if err := c.checkStage(ctx, runner, stageID); err != nil {
+		return nil, err
+	}
+	return c.client.Detail(ctx, &drone.Stage{ID: stageID})
+}
+
+// UpdateStage updates the stage executed by the runner.
+func (c *Controller) UpdateStage(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+	stage *drone.Stage,
+) error {
+	if err := c.checkStage(ctx, runner, stageID); err != nil {
+		return err
+	}
+	stage.ID = stageID
+	stage.Machine = runner.Machine()
+	for _, step := range stage.Steps {
+		step.StageID = stageID
+	}
+	return c.client.Update(ctx, stage)
+}
+
+// UpdateStep updates a step of a stage executed by the runner.
+func (c *Controller) UpdateStep(
+	ctx context.Context,
+	runner *types.Runner,
+	stepID int64,
+	step *drone.Step,
+) error {
+	stageID, err := c.checkStep(ctx, runner, stepID)
+	if err != nil {
+		return err
+	}
+	step.ID = stepID
+	step.StageID = stageID
+	return c.client.UpdateStep(ctx, step)
+}
+
+// Watch blocks until the execution is canceled or the context is done.
+func (c *Controller) Watch(ctx context.Context, runner *types.Runner, executionID int64) (bool, error) {
+	stages, err := c.stageStore.List(ctx, executionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list stages: %w", err)
+	}
+	machine := runner.Machine()
+	for _, stage := range stages {
+		if stage.Machine == machine {
+			return c.client.Watch(ctx, executionID)
+		}
+	}
+	return false, usererror.ErrForbidden
+}
+
+// Batch writes lines to the live logs of a step.
+func (c *Controller) Batch(ctx context.Context, runner *types.Runner, stepID int64, lines []*drone.Line) error {
+	if _, err := c.checkStep(ctx, runner, stepID); err != nil {
+		return err
+	}
+	return c.client.Batch(ctx, stepID, lines)
+}
+
+// Upload uploads the complete logs of a step.
+func (c *Controller) Upload(ctx context.Context, runner *types.Runner, stepID int64, lines []*drone.Line) error {
+	if _, err := c.checkStep(ctx, runner, stepID); err != nil {
+		return err
+	}
+	return c.client.Upload(ctx, stepID, lines)
+}
+
+// checkStage ensures the stage was accepted by the runner.
+func (c *Controller) checkStage(ctx context.Context, runner *types.Runner, stageID int64) error {
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if err != nil {
+		return fmt.Errorf("failed to find stage: %w", err)
+	}
+	if stage.Machine != runner.Machine() {
+		return usererror.ErrForbidden
+	}
+	return nil
+}
+
+// checkStep ensures the step belongs to a stage accepted by the runner and returns the stage ID.
+func (c *Controller) checkStep(ctx context.Context, runner *types.Runner, stepID int64) (int64, error) {
+	step, err := c.stepStore.Find(ctx, stepID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find step: %w", err)
+	}
+	if err := c.checkStage(ctx, runner, step.StageID); err != nil {
+		return 0, err
+	}
+	return step.StageID, nil
+}
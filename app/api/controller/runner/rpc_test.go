@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunnerStore struct {
+	store.RunnerStore
+	platforms map[int64]types.RunnerPlatform
+}
+
+func (s *fakeRunnerStore) UpdatePlatform(_ context.Context, id int64, platform types.RunnerPlatform) error {
+	s.platforms[id] = platform
+	return nil
+}
+
+type fakeStageStore struct {
+	store.StageStore
+	stages map[int64]*types.Stage
+}
+
+func (s *fakeStageStore) Find(_ context.Context, id int64) (*types.Stage, error) {
+	stage, ok := s.stages[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return stage, nil
+}
+
+type fakeStepStore struct {
+	store.StepStore
+	steps map[int64]*types.Step
+}
+
+func (s *fakeStepStore) Find(_ context.Context, id int64) (*types.Step, error) {
+	step, ok := s.steps[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return step, nil
+}
+
+type fakeClient struct {
+	client.Client
+	accepted []int64
+	filters  []*client.Filter
+}
+
+func (c *fakeClient) Request(_ context.Context, filter *client.Filter) (*drone.Stage, error) {
+	c.filters = append(c.filters, filter)
+	return &drone.Stage{ID: 1}, nil
+}
+
+func (c *fakeClient) Accept(_ context.Context, stage *drone.Stage) error {
+	c.accepted = append(c.accepted, stage.ID)
+	return nil
+}
+
+func newTestController() (*Controller, *fakeRunnerStore, *fakeClient) {
+	runnerStore := &fakeRunnerStore{platforms: map[int64]types.RunnerPlatform{}}
+	fc := &fakeClient{}
+	c := &Controller{
+		runnerStore: runnerStore,
+		stageStore: &fakeStageStore{stages: map[int64]*types.Stage{
+			1: {ID: 1, OS: "linux", Arch: "amd64"},
+			2: {ID: 2, OS: "windows", Arch: "amd64"},
+			3: {ID: 3, OS: "linux", Arch: "amd64", Labels: map[string]string{"gpu": "true"}},
+			4: {ID: 4, OS: "linux", Arch: "amd64", Machine: "runner:build-01"},
+			5: {ID: 5, OS: "linux", Arch: "amd64", Machine: "runner:build-02"},
+			6: {ID: 6, OS: "linux", Arch: "amd64", Machine: "gitness"},
+		}},
+		stepStore: &fakeStepStore{steps: map[int64]*types.Step{
+			40: {ID: 40, StageID: 4},
+			50: {ID: 50, StageID: 5},
+		}},
+		client: fc,
+	}
+	return c, runnerStore, fc
+}
+
+func assertStatus(t *testing.T, status int, err error) {
+	t.Helper()
+	var uerr *usererror.Error
+	require.ErrorAs(t, err, &uerr)
+	assert.Equal(t, status, uerr.Status)
+}
+
+func TestRequest_RecordsPlatform(t *testing.T) {
+	c, runnerStore, fc := newTestController()
+	runner := &types.Runner{ID: 7, Identifier: "build-01", Labels: map[string]string{"gpu": "true"}}
+
+	_, err := c.Request(context.Background(), runner, &client.Filter{
+		OS:     "linux",
+		Arch:   "arm64",
+		Labels: map[string]string{"gpu": "false"},
+	})
+	require.NoError(t, err)
+
+	// the registered labels take precedence over the polled ones.
+	expected := types.RunnerPlatform{OS: "linux", Arch: "arm64", Labels: map[string]string{"gpu": "true"}}
+	assert.Equal(t, expected, runnerStore.platforms[7])
+	assert.Equal(t, expected, runner.Platform)
+	require.Len(t, fc.filters, 1)
+	assert.Equal(t, map[string]string{"gpu": "true"}, fc.filters[0].Labels)
+
+	// an unchanged platform isn't written again.
+	delete(runnerStore.platforms, 7)
+	_, err = c.Request(context.Background(), runner, &client.Filter{OS: "linux", Arch: "arm64"})
+	require.NoError(t, err)
+	assert.Empty(t, runnerStore.platforms)
+}
+
+func TestAccept(t *testing.T) {
+	tests := []struct {
+		name     string
+		runner   *types.Runner
+		stageID  int64
+		accepted bool
+	}{
+		{
+			name:     "matching platform",
+			runner:   &types.Runner{Identifier: "build-01", Platform: types.RunnerPlatform{OS: "linux", Arch: "amd64"}},
+			stageID:  1,
+			accepted: true,
+		},
+		{
+			name:     "other os",
+			runner:   &types.Runner{Identifier: "build-01", Platform: types.RunnerPlatform{OS: "linux", Arch: "amd64"}},
+			stageID:  2,
+			accepted: false,
+		},
+		{
+			name:     "missing labels",
+			runner:   &types.Runner{Identifier: "build-01", Platform: types.RunnerPlatform{OS: "linux", Arch: "amd64"}},
+			stageID:  3,
+			accepted: false,
+		},
+		{
+			name: "registered labels",
+			runner: &types.Runner{
+				Identifier: "build-01",
+				Labels:     map[string]string{"gpu": "true"},
+				Platform:   types.RunnerPlatform{OS: "linux", Arch: "amd64"},
+			},
+			stageID:  3,
+			accepted: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _, fc := newTestController()
+
+			stage, err := c.Accept(context.Background(), test.runner, test.stageID)
+			if !test.accepted {
+				assertStatus(t, http.StatusConflict, err)
+				assert.Empty(t, fc.accepted)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.runner.Machine(), stage.Machine)
+			assert.Equal(t, []int64{test.stageID}, fc.accepted)
+		})
+	}
+}
+
+func TestCheckStage(t *testing.T) {
+	c, _, _ := newTestController()
+	runner := &types.Runner{Identifier: "build-01"}
+
+	tests := []struct {
+		name    string
+		stageID int64
+		status  int
+	}{
+		{name: "accepted by the runner", stageID: 4},
+		{name: "accepted by another runner", stageID: 5, status: http.StatusForbidden},
+		{name: "executed by an embedded runner", stageID: 6, status: http.StatusForbidden},
+		{name: "not accepted yet", stageID: 1, status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := c.checkStage(context.Background(), runner, test.stageID)
+			if test.status == 0 {
+				require.NoError(t, err)
+				return
+			}
+			assertStatus(t, test.status, err)
+		})
+	}
+
+	err := c.checkStage(context.Background(), runner, 99)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
+
+func TestCheckStep(t *testing.T) {
+	c, _, _ := newTestController()
+	runner := &types.Runner{Identifier: "build-01"}
+
+	stageID, err := c.checkStep(context.Background(), runner, 40)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stageID)
+
+	_, err = c.checkStep(context.Background(), runner, 50)
+	assertStatus(t, http.StatusForbidden, err)
+
+	_, err = c.checkStep(context.Background(), runner, 99)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
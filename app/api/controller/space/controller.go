@@ -102,6 +102,7 @@ type Controller struct {
 	executionStore   store.ExecutionStore
 	rulesSvc         *rules.Service
 	usageMetricStore store.UsageMetricStore
+	customRoleStore  store.CustomRoleStore
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -116,6 +117,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	gitspaceSvc *gitspace.Service, labelSvc *label.Service,
 	instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore,
+	customRoleStore store.CustomRoleStore,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -147,6 +149,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		executionStore:      executionStore,
 		rulesSvc:            rulesSvc,
 		usageMetricStore:    usageMetricStore,
+		customRoleStore:     customRoleStore,
 	}
 }
 
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+type CustomRoleCreateInput struct {
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+func (in *CustomRoleCreateInput) Sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	permissions, err := sanitizeCustomRolePermissions(in.Permissions)
+	if err != nil {
+		return err
+	}
+
+	in.Permissions = permissions
+
+	return nil
+}
+
+// CreateCustomRole defines a new custom membership role in the space.
+func (c *Controller) CreateCustomRole(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CustomRoleCreateInput,
+) (*types.CustomRole, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	role := &types.CustomRole{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Permissions: in.Permissions,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		Version:     0,
+	}
+
+	err = c.customRoleStore.Create(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custom role: %w", err)
+	}
+
+	return role, nil
+}
+
+// sanitizeCustomRolePermissions validates the permissions of a custom role
+// and returns them sorted and without duplicates.
+func sanitizeCustomRolePermissions(permissions []enum.Permission) ([]enum.Permission, error) {
+	if len(permissions) == 0 {
+		return nil, usererror.BadRequest("At least one permission must be provided")
+	}
+
+	for _, permission := range permissions {
+		if !permission.IsAssignable() {
+			return nil, usererror.BadRequestf("Permission '%s' can't be assigned to a custom role", permission)
+		}
+	}
+
+	permissions = slices.Clone(permissions)
+	slices.Sort(permissions)
+
+	return slices.Compact(permissions), nil
+}
+
+// findCustomRole returns the custom role with the identifier defined in the space
+// or in the closest of its ancestors, as the custom roles are inherited by the subspaces.
+func (c *Controller) findCustomRole(
+	ctx context.Context,
+	space *types.SpaceCore,
+	identifier string,
+) (*types.CustomRole, error) {
+	for {
+		role, err := c.customRoleStore.FindByIdentifier(ctx, space.ID, identifier)
+		if err == nil {
+			return role, nil
+		}
+		if !errors.Is(err, store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find custom role: %w", err)
+		}
+
+		if space.ParentID == 0 {
+			return nil, usererror.BadRequestf("Custom role '%s' not found", identifier)
+		}
+
+		space, err = c.spaceFinder.FindByID(ctx, space.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent space: %w", err)
+		}
+	}
+}
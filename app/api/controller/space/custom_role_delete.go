@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeleteCustomRole deletes a custom membership role of the space, if it isn't assigned to any membership.
+func (c *Controller) DeleteCustomRole(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	roleIdentifier string,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	role, err := c.customRoleStore.FindByIdentifier(ctx, space.ID, roleIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	count, err := c.customRoleStore.CountMemberships(ctx, role.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count memberships of custom role: %w", err)
+	}
+
+	if count > 0 {
+		return usererror.BadRequestf("Custom role '%s' is assigned to %d membership(s)", role.Identifier, count)
+	}
+
+	if err = c.customRoleStore.Delete(ctx, role.ID); err != nil {
+		return fmt.Errorf("failed to delete custom role: %w", err)
+	}
+
+	return nil
+}
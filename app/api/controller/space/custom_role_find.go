@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindCustomRole returns a custom membership role defined in the space.
+func (c *Controller) FindCustomRole(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	roleIdentifier string,
+) (*types.CustomRole, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	role, err := c.customRoleStore.FindByIdentifier(ctx, space.ID, roleIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	return role, nil
+}
+
+// ListCustomRoles lists the custom membership roles defined in the space,
+// and if inherited is set also the roles inherited from the ancestor spaces.
+func (c *Controller) ListCustomRoles(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	inherited bool,
+) ([]*types.CustomRole, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	spaceIDs := []int64{space.ID}
+	if inherited {
+		spaceIDs, err = c.spaceStore.GetAncestorIDs(ctx, space.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ancestor spaces: %w", err)
+		}
+	}
+
+	roles, err := c.customRoleStore.List(ctx, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeCustomRolePermissions(t *testing.T) {
+	permissions, err := sanitizeCustomRolePermissions([]enum.Permission{
+		enum.PermissionSpaceEdit,
+		enum.PermissionRepoView,
+		enum.PermissionSpaceEdit,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []enum.Permission{enum.PermissionRepoView, enum.PermissionSpaceEdit}, permissions)
+
+	_, err = sanitizeCustomRolePermissions(nil)
+	assert.Error(t, err)
+
+	_, err = sanitizeCustomRolePermissions([]enum.Permission{"not_a_permission"})
+	assert.Error(t, err)
+}
+
+func TestValidateMembershipRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       enum.MembershipRole
+		customRole string
+		expected   enum.MembershipRole
+		wantErr    bool
+	}{
+		{name: "builtin role", role: enum.MembershipRoleReader, expected: enum.MembershipRoleReader},
+		{name: "custom role implies role", customRole: "auditor", expected: enum.MembershipRoleCustom},
+		{name: "custom role explicit", role: enum.MembershipRoleCustom, customRole: "auditor",
+			expected: enum.MembershipRoleCustom},
+		{name: "custom role with builtin role", role: enum.MembershipRoleReader, customRole: "auditor",
+			wantErr: true},
+		{name: "custom without custom role", role: enum.MembershipRoleCustom, wantErr: true},
+		{name: "missing role", wantErr: true},
+		{name: "unknown role", role: "unknown", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			role := test.role
+			err := validateMembershipRole(&role, test.customRole)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, role)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CustomRoleUpdateInput struct {
+	Identifier  *string            `json:"identifier"`
+	Description *string            `json:"description"`
+	Permissions *[]enum.Permission `json:"permissions"`
+}
+
+func (in *CustomRoleUpdateInput) Sanitize() error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Permissions != nil {
+		permissions, err := sanitizeCustomRolePermissions(*in.Permissions)
+		if err != nil {
+			return err
+		}
+		in.Permissions = &permissions
+	}
+
+	return nil
+}
+
+// UpdateCustomRole updates a custom membership role of the space.
+// The changed permissions apply to all memberships the role is assigned to.
+func (c *Controller) UpdateCustomRole(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	roleIdentifier string,
+	in *CustomRoleUpdateInput,
+) (*types.CustomRole, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	role, err := c.customRoleStore.FindByIdentifier(ctx, space.ID, roleIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find custom role: %w", err)
+	}
+
+	return c.customRoleStore.UpdateOptLock(ctx, role, func(role *types.CustomRole) error {
+		if in.Identifier != nil {
+			role.Identifier = *in.Identifier
+		}
+		if in.Description != nil {
+			role.Description = *in.Description
+		}
+		if in.Permissions != nil {
+			role.Permissions = *in.Permissions
+		}
+
+		return nil
+	})
+}
@@ -31,6 +31,8 @@ import (
 type MembershipAddInput struct {
 	UserUID string              `json:"user_uid"`
 	Role    enum.MembershipRole `json:"role"`
+	// CustomRole is the identifier of a custom role of the space or of one of its ancestors.
+	CustomRole string `json:"custom_role"`
 }
 
 func (in *MembershipAddInput) Validate() error {
@@ -38,18 +40,66 @@ func (in *MembershipAddInput) Validate() error {
 		return usererror.BadRequest("UserUID must be provided")
 	}
 
-	if in.Role == "" {
+	return validateMembershipRole(&in.Role, in.CustomRole)
+}
+
+// validateMembershipRole validates the role of a membership. If a custom role is provided,
+// the role can be omitted, otherwise a predefined role has to be provided.
+func validateMembershipRole(role *enum.MembershipRole, customRole string) error {
+	if customRole != "" {
+		if *role != "" && *role != enum.MembershipRoleCustom {
+			return usererror.BadRequestf("Role must be '%s' or omitted if a custom role is provided",
+				enum.MembershipRoleCustom)
+		}
+
+		*role = enum.MembershipRoleCustom
+
+		return nil
+	}
+
+	if *role == "" {
 		return usererror.BadRequest("Role must be provided")
 	}
 
-	role, ok := in.Role.Sanitize()
+	sanitized, ok := role.Sanitize()
 	if !ok {
 		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
+			*role, enum.MembershipRoles)
 		return usererror.BadRequest(msg)
 	}
 
-	in.Role = role
+	if sanitized == enum.MembershipRoleCustom {
+		return usererror.BadRequestf("Custom role must be provided for role '%s'", enum.MembershipRoleCustom)
+	}
+
+	*role = sanitized
+
+	return nil
+}
+
+// setMembershipRole sets the role of the membership, resolving the custom role if one is provided.
+func (c *Controller) setMembershipRole(
+	ctx context.Context,
+	space *types.SpaceCore,
+	membership *types.Membership,
+	role enum.MembershipRole,
+	customRoleIdentifier string,
+) error {
+	membership.Role = role
+	membership.CustomRoleID = nil
+	membership.CustomRole = ""
+
+	if role != enum.MembershipRoleCustom {
+		return nil
+	}
+
+	customRole, err := c.findCustomRole(ctx, space, customRoleIdentifier)
+	if err != nil {
+		return err
+	}
+
+	membership.CustomRoleID = &customRole.ID
+	membership.CustomRole = customRole.Identifier
 
 	return nil
 }
@@ -87,7 +137,11 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		CreatedBy: session.Principal.ID,
 		Created:   now,
 		Updated:   now,
-		Role:      in.Role,
+	}
+
+	err = c.setMembershipRole(ctx, space, &membership, in.Role, in.CustomRole)
+	if err != nil {
+		return nil, err
 	}
 
 	err = c.membershipStore.Create(ctx, &membership)
@@ -18,7 +18,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -26,23 +25,12 @@ import (
 
 type MembershipUpdateInput struct {
 	Role enum.MembershipRole `json:"role"`
+	// CustomRole is the identifier of a custom role of the space or of one of its ancestors.
+	CustomRole string `json:"custom_role"`
 }
 
 func (in *MembershipUpdateInput) Validate() error {
-	if in.Role == "" {
-		return usererror.BadRequest("Role must be provided")
-	}
-
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
-	}
-
-	in.Role = role
-
-	return nil
+	return validateMembershipRole(&in.Role, in.CustomRole)
 }
 
 // MembershipUpdate changes the role of an existing membership.
@@ -75,11 +63,14 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to find membership for update: %w", err)
 	}
 
-	if membership.Role == in.Role {
+	if membership.Role == in.Role && membership.CustomRole == in.CustomRole {
 		return membership, nil
 	}
 
-	membership.Role = in.Role
+	err = c.setMembershipRole(ctx, space, &membership.Membership, in.Role, in.CustomRole)
+	if err != nil {
+		return nil, err
+	}
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
 	if err != nil {
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacesettings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	config       *types.Config
+	authorizer   authz.Authorizer
+	spaceFinder  refcache.SpaceFinder
+	settings     *settings.Service
+	auditService audit.Service
+}
+
+func NewController(
+	config *types.Config,
+	authorizer authz.Authorizer,
+	spaceFinder refcache.SpaceFinder,
+	settings *settings.Service,
+	auditService audit.Service,
+) *Controller {
+	return &Controller{
+		config:       config,
+		authorizer:   authorizer,
+		spaceFinder:  spaceFinder,
+		settings:     settings,
+		auditService: auditService,
+	}
+}
+
+// getSpaceCheckAccess fetches a space and checks if the current user has permission to access it.
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	reqPermission enum.Permission,
+) (*types.SpaceCore, error) {
+	space, err := space.GetSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef, reqPermission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return space, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacesettings
+
+import (
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+// GitspaceSettings represents the gitspace related part of space settings as exposed externally.
+type GitspaceSettings struct {
+	// IdleTimeoutInMins is the number of minutes after which idle gitspaces are stopped, 0 disables it.
+	IdleTimeoutInMins *int64 `json:"idle_timeout_in_mins" yaml:"idle_timeout_in_mins"`
+}
+
+func (s *GitspaceSettings) sanitize() error {
+	if s.IdleTimeoutInMins != nil && *s.IdleTimeoutInMins < 0 {
+		return usererror.BadRequest("Idle timeout can't be negative.")
+	}
+
+	return nil
+}
+
+func GetDefaultGitspaceSettings(config *types.Config) *GitspaceSettings {
+	return &GitspaceSettings{
+		IdleTimeoutInMins: ptr.Int64(int64(config.Gitspace.IdleTimeoutInMins)),
+	}
+}
+
+func GetGitspaceSettingsMappings(s *GitspaceSettings) []settings.SettingHandler {
+	return []settings.SettingHandler{
+		settings.Mapping(settings.KeyGitspaceIdleTimeoutInMins, s.IdleTimeoutInMins),
+	}
+}
+
+func GetGitspaceSettingsAsKeyValues(s *GitspaceSettings) []settings.KeyValue {
+	kvs := make([]settings.KeyValue, 0, 1)
+	if s.IdleTimeoutInMins != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyGitspaceIdleTimeoutInMins,
+			Value: *s.IdleTimeoutInMins,
+		})
+	}
+	return kvs
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacesettings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GitspaceUpdate updates the gitspace settings of the space.
+func (c *Controller) GitspaceUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *GitspaceSettings,
+) (*GitspaceSettings, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	// read old settings values
+	old := GetDefaultGitspaceSettings(c.config)
+	oldMappings := GetGitspaceSettingsMappings(old)
+	err = c.settings.SpaceMap(ctx, space.ID, oldMappings...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map settings (old): %w", err)
+	}
+
+	err = c.settings.SpaceSetMany(ctx, space.ID, GetGitspaceSettingsAsKeyValues(in)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set settings: %w", err)
+	}
+
+	// read all settings and return complete config
+	out := GetDefaultGitspaceSettings(c.config)
+	mappings := GetGitspaceSettingsMappings(out)
+	err = c.settings.SpaceMap(ctx, space.ID, mappings...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map settings: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceSettings, space.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(space.Path),
+		audit.WithOldObject(old),
+		audit.WithNewObject(out),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space settings operation: %s", err)
+	}
+
+	return out, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -35,6 +36,10 @@ type Controller struct {
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
 	publicKeyStore    store.PublicKeyStore
+	spaceFinder       refcache.SpaceFinder
+	repoFinder        refcache.RepoFinder
+
+	notificationPreferenceStore store.NotificationPreferenceStore
 }
 
 func NewController(
@@ -45,6 +50,9 @@ func NewController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+	notificationPreferenceStore store.NotificationPreferenceStore,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -54,6 +62,10 @@ func NewController(
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
 		publicKeyStore:    publicKeyStore,
+		spaceFinder:       spaceFinder,
+		repoFinder:        repoFinder,
+
+		notificationPreferenceStore: notificationPreferenceStore,
 	}
 }
 
@@ -16,15 +16,20 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
 )
 
 type CreateTokenInput struct {
@@ -32,6 +37,12 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+
+	// Permissions optionally restricts the token to a subset of the permissions of the user.
+	Permissions []enum.Permission `json:"permissions"`
+	// Spaces and Repos optionally restrict the token to the provided spaces and repositories.
+	Spaces []string `json:"spaces"`
+	Repos  []string `json:"repos"`
 }
 
 /*
@@ -57,6 +68,11 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	scope, err := c.getTokenScope(ctx, session, in)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
 		c.tokenStore,
@@ -64,11 +80,16 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.Identifier,
 		in.Lifetime,
+		scope,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = c.setTokenScopePaths(ctx, token); err != nil {
+		return nil, err
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -87,5 +108,102 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		return err
 	}
 
+	for _, permission := range in.Permissions {
+		if !permission.IsAssignable() {
+			return usererror.BadRequestf("Permission '%s' can't be granted to a token", permission)
+		}
+	}
+
+	slices.Sort(in.Permissions)
+	in.Permissions = slices.Compact(in.Permissions)
+
+	return nil
+}
+
+// getTokenScope resolves the scope of a new token from the input. It returns nil if the token isn't restricted.
+// Only spaces and repositories the caller can view are accepted to not reveal the existence of the others.
+func (c *Controller) getTokenScope(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateTokenInput,
+) (*types.TokenScope, error) {
+	if len(in.Permissions) == 0 && len(in.Spaces) == 0 && len(in.Repos) == 0 {
+		return nil, nil //nolint:nilnil // nil scope means the token isn't restricted
+	}
+
+	scope := &types.TokenScope{
+		Permissions: in.Permissions,
+	}
+
+	for _, spaceRef := range in.Spaces {
+		space, err := c.spaceFinder.FindByRef(ctx, spaceRef)
+		if err == nil {
+			err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView)
+		}
+		if errors.Is(err, store.ErrResourceNotFound) || errors.Is(err, apiauth.ErrNotAuthorized) {
+			return nil, usererror.BadRequestf("Space '%s' not found", spaceRef)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space of token scope: %w", err)
+		}
+
+		if !slices.Contains(scope.SpaceIDs, space.ID) {
+			scope.SpaceIDs = append(scope.SpaceIDs, space.ID)
+		}
+	}
+
+	for _, repoRef := range in.Repos {
+		repo, err := c.repoFinder.FindByRef(ctx, repoRef)
+		if err == nil {
+			err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+		}
+		if errors.Is(err, store.ErrResourceNotFound) || errors.Is(err, apiauth.ErrNotAuthorized) {
+			return nil, usererror.BadRequestf("Repository '%s' not found", repoRef)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo of token scope: %w", err)
+		}
+
+		if !slices.Contains(scope.RepoIDs, repo.ID) {
+			scope.RepoIDs = append(scope.RepoIDs, repo.ID)
+		}
+	}
+
+	return scope, nil
+}
+
+// setTokenScopePaths sets the current paths of the spaces and repositories of the token scope.
+// Deleted spaces and repositories are omitted.
+func (c *Controller) setTokenScopePaths(ctx context.Context, token *types.Token) error {
+	if token.Scope == nil {
+		return nil
+	}
+
+	token.Scope.Spaces = make([]string, 0, len(token.Scope.SpaceIDs))
+	for _, spaceID := range token.Scope.SpaceIDs {
+		space, err := c.spaceFinder.FindByID(ctx, spaceID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find space of token scope: %w", err)
+		}
+
+		token.Scope.Spaces = append(token.Scope.Spaces, space.Path)
+	}
+
+	token.Scope.Repos = make([]string, 0, len(token.Scope.RepoIDs))
+	for _, repoID := range token.Scope.RepoIDs {
+		repo, err := c.repoFinder.FindByID(ctx, repoID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find repo of token scope: %w", err)
+		}
+
+		token.Scope.Repos = append(token.Scope.Repos, repo.Path)
+	}
+
 	return nil
 }
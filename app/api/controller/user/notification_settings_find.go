@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FindNotificationSettings returns the notification settings of the user.
+// The delivery of every notification trigger is returned, including the ones the user hasn't changed.
+func (c *Controller) FindNotificationSettings(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.NotificationSettings, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.getNotificationSettings(ctx, user.ID)
+}
+
+func (c *Controller) getNotificationSettings(
+	ctx context.Context,
+	principalID int64,
+) (*types.NotificationSettings, error) {
+	preferences, err := c.notificationPreferenceStore.List(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	triggers, _ := enum.GetAllNotificationTriggers()
+	_, defaultDelivery := enum.GetAllNotificationDeliveries()
+
+	settings := &types.NotificationSettings{
+		Events: make(map[enum.NotificationTrigger]enum.NotificationDelivery, len(triggers)),
+	}
+	for _, trigger := range triggers {
+		settings.Events[trigger] = defaultDelivery
+	}
+	for _, preference := range preferences {
+		settings.Events[preference.Trigger] = preference.Delivery
+	}
+
+	settings.Repos, err = c.notificationPreferenceStore.ListRepos(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository notification preferences: %w", err)
+	}
+
+	for _, repoPreference := range settings.Repos {
+		repo, err := c.repoFinder.FindByID(ctx, repoPreference.RepoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoPreference.RepoID).
+				Msg("failed to find repository of notification preference")
+			continue
+		}
+
+		repoPreference.RepoPath = repo.Path
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type NotificationSettingsUpdateInput struct {
+	// Events holds the new delivery of notification triggers. Triggers that aren't listed are left unchanged.
+	Events map[enum.NotificationTrigger]enum.NotificationDelivery `json:"events"`
+	// Repos holds the repositories to watch or ignore.
+	Repos []RepoNotificationSettingsInput `json:"repos"`
+}
+
+type RepoNotificationSettingsInput struct {
+	RepoRef string                    `json:"repo_ref"`
+	Mode    enum.RepoNotificationMode `json:"mode"`
+}
+
+func (in *NotificationSettingsUpdateInput) sanitize() error {
+	events := make(map[enum.NotificationTrigger]enum.NotificationDelivery, len(in.Events))
+	for trigger, delivery := range in.Events {
+		t, ok := trigger.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid notification trigger '%s'", trigger)
+		}
+
+		d, ok := delivery.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid notification delivery '%s'", delivery)
+		}
+
+		// review reminders are digests already and are sent on their own schedule.
+		if d == enum.NotificationDeliveryDigest &&
+			(t == enum.NotificationTriggerReviewReminder || t == enum.NotificationTriggerReviewEscalation) {
+			return usererror.BadRequestf("Notification '%s' can't be delivered in the digest", t)
+		}
+
+		events[t] = d
+	}
+	in.Events = events
+
+	for i := range in.Repos {
+		if in.Repos[i].RepoRef == "" {
+			return usererror.BadRequest("A valid repository reference must be provided")
+		}
+
+		mode, ok := in.Repos[i].Mode.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid repository notification mode '%s'", in.Repos[i].Mode)
+		}
+		in.Repos[i].Mode = mode
+	}
+
+	return nil
+}
+
+// UpdateNotificationSettings updates the notification settings of the user.
+func (c *Controller) UpdateNotificationSettings(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *NotificationSettingsUpdateInput,
+) (*types.NotificationSettings, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]int64, len(in.Repos))
+	for i, repoIn := range in.Repos {
+		repo, err := c.repoFinder.FindByRef(ctx, repoIn.RepoRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return nil, err
+		}
+
+		repoIDs[i] = repo.ID
+	}
+
+	now := time.Now().UnixMilli()
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		for trigger, delivery := range in.Events {
+			err := c.notificationPreferenceStore.Upsert(ctx, &types.NotificationPreference{
+				PrincipalID: user.ID,
+				Trigger:     trigger,
+				Delivery:    delivery,
+				Updated:     now,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update notification preference: %w", err)
+			}
+		}
+
+		for i, repoIn := range in.Repos {
+			err := c.notificationPreferenceStore.UpsertRepo(ctx, &types.RepoNotificationPreference{
+				PrincipalID: user.ID,
+				RepoID:      repoIDs[i],
+				Mode:        repoIn.Mode,
+				Updated:     now,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update repository notification preference: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getNotificationSettings(ctx, user.ID)
+}
@@ -48,6 +48,12 @@ func HandleFind(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 
 		options.IncludeGitStats = true // always backfill PR git stats when fetching one PR.
 
+		options.IncludeStack, err = request.GetIncludeStackFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		pr, err := pullreqCtrl.Find(ctx, session, repoRef, pullreqNumber, options)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+)
+
+// pollTimeout is the duration after which long polling calls are answered with
+// no content, the runners reconnect right away, which doubles as a heartbeat.
+const pollTimeout = 30 * time.Second
+
+// Authenticate returns a middleware authenticating the runners with their registration token.
+func Authenticate(runnerCtrl *runner.Controller) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			runner, err := runnerCtrl.Authenticate(ctx, r.Header.Get(request.HeaderRunnerToken))
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(request.WithRunner(ctx, runner)))
+		})
+	}
+}
+
+// HandlePing returns a http.HandlerFunc answering the connectivity checks of the runners.
+func HandlePing() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleRequest returns a http.HandlerFunc that long polls the next stage the runner can execute.
+func HandleRequest(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rn, _ := request.RunnerFrom(ctx)
+
+		filter := new(client.Filter)
+		if err := json.NewDecoder(r.Body).Decode(filter); err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		pollCtx, cancel := context.WithTimeout(ctx, pollTimeout)
+		defer cancel()
+
+		stage, err := runnerCtrl.Request(pollCtx, rn, filter)
+		if err != nil && pollCtx.Err() != nil && ctx.Err() == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if err != nil {
+			renderRPCError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stage)
+	}
+}
+
+// HandleAccept returns a http.HandlerFunc that assigns a stage to the runner.
+func HandleAccept(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rn, _ := request.RunnerFrom(ctx)
+		stageID, err := request.GetRunnerStageIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		stage, err := runnerCtrl.Accept(ctx, rn, stageID)
+		if err != nil {
+			renderRPCError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stage)
+	}
+}
+
+// HandleDetails returns a http.HandlerFunc that returns what the runner needs to execute a stage.
+func HandleDetails(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rn, _ := request.RunnerFrom(ctx)
+		stageID, err := request.GetRunnerStageIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		details, err := runnerCtrl.Details(ctx, rn, stageID)
+		if err != nil {
+			renderRPCError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, details)
+	}
+}
+
+// HandleUpdateStage returns a http.HandlerFunc that updates a stage executed by the runner.
+func HandleUpdateStage(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rn, _ := request.RunnerFrom(ctx)
+		stageID, err := request.GetRunnerStageIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		stage := new(drone.Stage)
+		if err = json.NewDecoder(r.Body).Decode(stage); err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		if err = runnerCtrl.UpdateStage(ctx, rn, stageID, stage); err != nil {
+			renderRPCError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stage)
+	}
+}
+
+// HandleUpdateStep returns a http.HandlerFunc that updates a step executed by the runner.
+func HandleUpdateStep(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rn, _ := request.RunnerFrom(ctx)
+		stepID, err := request.GetRunnerStepIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		step := new(drone.Step)
+		if err = json.NewDecoder(r.Body).Decode(step); err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		if err = runnerCtrl.UpdateStep(ctx, rn, stepID, step); err != nil {
+			renderRPCError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, step)
+	}
+}
+
+// HandleWatch returns a http.HandlerFunc that long polls the cancellation of an execution.
+// The runner is told about a cancellation with an OK status and polls again on no content.
+func HandleWatch(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rn, _ := request.RunnerFrom(ctx)
+		buildID, err := request.GetRunnerBuildIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pollCtx, cancel := context.WithTimeout(ctx, pollTimeout)
+		defer cancel()
+
+		canceled, err := runnerCtrl.Watch(pollCtx, rn, buildID)
+		if err != nil && pollCtx.Err() != nil && ctx.Err() == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if err != nil {
+			renderRPCError(ctx, w, err)
+			return
+		}
+
+		if !canceled {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// HandleBatch returns a http.HandlerFunc that writes lines to the live logs of a step.
+func HandleBatch(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return handleLines(runnerCtrl.Batch)
+}
+
+// HandleUpload returns a http.HandlerFunc that uploads the complete logs of a step.
+func HandleUpload(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return handleLines(runnerCtrl.Upload)
+}
+
+// HandleCard returns a http.HandlerFunc accepting the cards of a step, which aren't supported.
+// Runners retry the calls answered with no content, so an OK status is used.
+func HandleCard() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func handleLines(
+	f func(ctx context.Context, rn *types.Runner, stepID int64, lines []*drone.Line) error,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rn, _ := request.RunnerFrom(ctx)
+		stepID, err := request.GetRunnerStepIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		var lines []*drone.Line
+		if err = json.NewDecoder(r.Body).Decode(&lines); err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		if err = f(ctx, rn, stepID, lines); err != nil {
+			renderRPCError(ctx, w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// renderRPCError renders version conflicts with the status the runners expect for optimistic lock errors.
+func renderRPCError(ctx context.Context, w http.ResponseWriter, err error) {
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		render.UserError(ctx, w, usererror.New(http.StatusConflict, "Optimistic Lock Error"))
+		return
+	}
+	render.TranslatedUserError(ctx, w, err)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/environment"
+	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type environmentRequest struct {
+	repoRequest
+	Identifier string `path:"environment_identifier"`
+}
+
+type createEnvironmentRequest struct {
+	repoRequest
+	environment.CreateInput
+}
+
+type updateEnvironmentRequest struct {
+	environmentRequest
+	environment.UpdateInput
+}
+
+type promoteExecutionRequest struct {
+	executionRequest
+	execution.PromoteInput
+}
+
+var queryParameterQueryEnvironment = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the environments by their identifier."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func environmentOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("environment")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createEnvironment"})
+	_ = reflector.SetRequest(&opCreate, new(createEnvironmentRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Environment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/environments", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("environment")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listEnvironments"})
+	opList.WithParameters(queryParameterQueryEnvironment, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.Environment{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/environments", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("environment")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findEnvironment"})
+	_ = reflector.SetRequest(&opFind, new(environmentRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Environment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/environments/{environment_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("environment")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateEnvironment"})
+	_ = reflector.SetRequest(&opUpdate, new(updateEnvironmentRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Environment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/environments/{environment_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("environment")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteEnvironment"})
+	_ = reflector.SetRequest(&opDelete, new(environmentRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/environments/{environment_identifier}", opDelete)
+
+	opDeployments := openapi3.Operation{}
+	opDeployments.WithTags("environment")
+	opDeployments.WithMapOfAnything(map[string]interface{}{"operationId": "listEnvironmentDeployments"})
+	opDeployments.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opDeployments, new(environmentRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDeployments, []types.Execution{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDeployments, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeployments, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeployments, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeployments, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/environments/{environment_identifier}/deployments", opDeployments)
+
+	opCurrentDeployments := openapi3.Operation{}
+	opCurrentDeployments.WithTags("environment")
+	opCurrentDeployments.WithMapOfAnything(map[string]interface{}{"operationId": "listDeployments"})
+	_ = reflector.SetRequest(&opCurrentDeployments, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCurrentDeployments, []types.Deployment{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCurrentDeployments, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCurrentDeployments, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCurrentDeployments, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCurrentDeployments, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/deployments", opCurrentDeployments)
+
+	opPromote := openapi3.Operation{}
+	opPromote.WithTags("pipeline")
+	opPromote.WithMapOfAnything(map[string]interface{}{"operationId": "promoteExecution"})
+	_ = reflector.SetRequest(&opPromote, new(promoteExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPromote, new(types.Execution), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opPromote, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPromote, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPromote, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPromote, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPromote, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/promote", opPromote)
+}
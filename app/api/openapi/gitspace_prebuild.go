@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/gitspaceprebuild"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type gitspacePrebuildRequest struct {
+	repoRequest
+	Identifier string `path:"gitspace_prebuild_identifier"`
+}
+
+type createGitspacePrebuildRequest struct {
+	repoRequest
+	gitspaceprebuild.CreateInput
+}
+
+type updateGitspacePrebuildRequest struct {
+	gitspacePrebuildRequest
+	gitspaceprebuild.UpdateInput
+}
+
+//nolint:funlen
+func gitspacePrebuildOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("gitspace_prebuild")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createGitspacePrebuild"})
+	_ = reflector.SetRequest(&opCreate, new(createGitspacePrebuildRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.GitspacePrebuild), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/gitspace-prebuilds", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("gitspace_prebuild")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listGitspacePrebuilds"})
+	_ = reflector.SetRequest(&opList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.GitspacePrebuild{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/gitspace-prebuilds", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("gitspace_prebuild")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findGitspacePrebuild"})
+	_ = reflector.SetRequest(&opFind, new(gitspacePrebuildRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.GitspacePrebuild), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/gitspace-prebuilds/{gitspace_prebuild_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("gitspace_prebuild")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateGitspacePrebuild"})
+	_ = reflector.SetRequest(&opUpdate, new(updateGitspacePrebuildRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.GitspacePrebuild), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/gitspace-prebuilds/{gitspace_prebuild_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("gitspace_prebuild")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteGitspacePrebuild"})
+	_ = reflector.SetRequest(&opDelete, new(gitspacePrebuildRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/gitspace-prebuilds/{gitspace_prebuild_identifier}", opDelete)
+
+	opTrigger := openapi3.Operation{}
+	opTrigger.WithTags("gitspace_prebuild")
+	opTrigger.WithMapOfAnything(map[string]interface{}{"operationId": "triggerGitspacePrebuild"})
+	_ = reflector.SetRequest(&opTrigger, new(gitspacePrebuildRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTrigger, new(types.GitspacePrebuild), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTrigger, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTrigger, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTrigger, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTrigger, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTrigger, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/gitspace-prebuilds/{gitspace_prebuild_identifier}/trigger", opTrigger)
+
+	opLogs := openapi3.Operation{}
+	opLogs.WithTags("gitspace_prebuild")
+	opLogs.WithMapOfAnything(map[string]interface{}{"operationId": "getGitspacePrebuildLogs"})
+	_ = reflector.SetRequest(&opLogs, new(gitspacePrebuildRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLogs, new(types.GitspacePrebuildLogs), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLogs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLogs, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLogs, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLogs, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/gitspace-prebuilds/{gitspace_prebuild_identifier}/logs", opLogs)
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// notificationChannelType is used to add has_url field.
+type notificationChannelType struct {
+	types.NotificationChannel
+	HasURL bool `json:"has_url"`
+}
+
+type spaceNotificationChannelRequest struct {
+	spaceRequest
+	Identifier string `path:"notification_channel_identifier"`
+}
+
+type repoNotificationChannelRequest struct {
+	repoRequest
+	Identifier string `path:"notification_channel_identifier"`
+}
+
+type createSpaceNotificationChannelRequest struct {
+	spaceRequest
+	notificationchannel.CreateInput
+}
+
+type createRepoNotificationChannelRequest struct {
+	repoRequest
+	notificationchannel.CreateInput
+}
+
+type updateSpaceNotificationChannelRequest struct {
+	spaceNotificationChannelRequest
+	notificationchannel.UpdateInput
+}
+
+type updateRepoNotificationChannelRequest struct {
+	repoNotificationChannelRequest
+	notificationchannel.UpdateInput
+}
+
+//nolint:funlen
+func notificationChannelOperations(reflector *openapi3.Reflector) {
+	// space
+
+	createSpaceNotificationChannel := openapi3.Operation{}
+	createSpaceNotificationChannel.WithTags("notification_channel")
+	createSpaceNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "createSpaceNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&createSpaceNotificationChannel, new(createSpaceNotificationChannelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createSpaceNotificationChannel, new(notificationChannelType), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createSpaceNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createSpaceNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createSpaceNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createSpaceNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/notification-channels", createSpaceNotificationChannel)
+
+	listSpaceNotificationChannels := openapi3.Operation{}
+	listSpaceNotificationChannels.WithTags("notification_channel")
+	listSpaceNotificationChannels.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listSpaceNotificationChannels"},
+	)
+	_ = reflector.SetRequest(&listSpaceNotificationChannels, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSpaceNotificationChannels, new([]notificationChannelType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSpaceNotificationChannels, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listSpaceNotificationChannels, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSpaceNotificationChannels, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSpaceNotificationChannels, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/notification-channels", listSpaceNotificationChannels)
+
+	getSpaceNotificationChannel := openapi3.Operation{}
+	getSpaceNotificationChannel.WithTags("notification_channel")
+	getSpaceNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "getSpaceNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&getSpaceNotificationChannel, new(spaceNotificationChannelRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getSpaceNotificationChannel, new(notificationChannelType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getSpaceNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getSpaceNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getSpaceNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getSpaceNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", getSpaceNotificationChannel)
+
+	updateSpaceNotificationChannel := openapi3.Operation{}
+	updateSpaceNotificationChannel.WithTags("notification_channel")
+	updateSpaceNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateSpaceNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&updateSpaceNotificationChannel, new(updateSpaceNotificationChannelRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateSpaceNotificationChannel, new(notificationChannelType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateSpaceNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateSpaceNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateSpaceNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateSpaceNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", updateSpaceNotificationChannel)
+
+	deleteSpaceNotificationChannel := openapi3.Operation{}
+	deleteSpaceNotificationChannel.WithTags("notification_channel")
+	deleteSpaceNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "deleteSpaceNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&deleteSpaceNotificationChannel, new(spaceNotificationChannelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteSpaceNotificationChannel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteSpaceNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&deleteSpaceNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteSpaceNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteSpaceNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", deleteSpaceNotificationChannel)
+	// repo
+
+	createRepoNotificationChannel := openapi3.Operation{}
+	createRepoNotificationChannel.WithTags("notification_channel")
+	createRepoNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "createRepoNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&createRepoNotificationChannel, new(createRepoNotificationChannelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createRepoNotificationChannel, new(notificationChannelType), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createRepoNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createRepoNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createRepoNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createRepoNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/notification-channels", createRepoNotificationChannel)
+
+	listRepoNotificationChannels := openapi3.Operation{}
+	listRepoNotificationChannels.WithTags("notification_channel")
+	listRepoNotificationChannels.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listRepoNotificationChannels"},
+	)
+	_ = reflector.SetRequest(&listRepoNotificationChannels, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listRepoNotificationChannels, new([]notificationChannelType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listRepoNotificationChannels, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listRepoNotificationChannels, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listRepoNotificationChannels, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listRepoNotificationChannels, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/notification-channels", listRepoNotificationChannels)
+
+	getRepoNotificationChannel := openapi3.Operation{}
+	getRepoNotificationChannel.WithTags("notification_channel")
+	getRepoNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "getRepoNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&getRepoNotificationChannel, new(repoNotificationChannelRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getRepoNotificationChannel, new(notificationChannelType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getRepoNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getRepoNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getRepoNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getRepoNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/notification-channels/{notification_channel_identifier}", getRepoNotificationChannel)
+
+	updateRepoNotificationChannel := openapi3.Operation{}
+	updateRepoNotificationChannel.WithTags("notification_channel")
+	updateRepoNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateRepoNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&updateRepoNotificationChannel, new(updateRepoNotificationChannelRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateRepoNotificationChannel, new(notificationChannelType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateRepoNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateRepoNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateRepoNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateRepoNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/notification-channels/{notification_channel_identifier}", updateRepoNotificationChannel)
+
+	deleteRepoNotificationChannel := openapi3.Operation{}
+	deleteRepoNotificationChannel.WithTags("notification_channel")
+	deleteRepoNotificationChannel.WithMapOfAnything(
+		map[string]interface{}{"operationId": "deleteRepoNotificationChannel"},
+	)
+	_ = reflector.SetRequest(&deleteRepoNotificationChannel, new(repoNotificationChannelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteRepoNotificationChannel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteRepoNotificationChannel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&deleteRepoNotificationChannel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteRepoNotificationChannel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteRepoNotificationChannel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/notification-channels/{notification_channel_identifier}", deleteRepoNotificationChannel)
+}
@@ -59,16 +59,22 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	buildAdmin(&reflector)
 	buildPrincipals(&reflector)
 	spaceOperations(&reflector)
+	customRoleOperations(&reflector)
 	pluginOperations(&reflector)
 	repoOperations(&reflector)
+	repoMembershipOperations(&reflector)
 	rulesOperations(&reflector)
 	pipelineOperations(&reflector)
+	pipelineArtifactOperations(&reflector)
+	environmentOperations(&reflector)
+	gitspacePrebuildOperations(&reflector)
 	connectorOperations(&reflector)
 	templateOperations(&reflector)
 	secretOperations(&reflector)
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
 	webhookOperations(&reflector)
+	notificationChannelOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
 	gitspaceOperations(&reflector)
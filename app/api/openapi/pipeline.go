@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
@@ -49,6 +50,21 @@ type logRequest struct {
 	StepNum  string `path:"step_number"`
 }
 
+type approvalRequest struct {
+	executionRequest
+	StageNum string `path:"stage_number"`
+}
+
+type decideApprovalRequest struct {
+	approvalRequest
+	execution.DecideApprovalInput
+}
+
+type retryExecutionRequest struct {
+	executionRequest
+	execution.RetryInput
+}
+
 type createExecutionRequest struct {
 	pipelineRequest
 }
@@ -241,6 +257,46 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/cancel", executionCancel)
 
+	executionRetry := openapi3.Operation{}
+	executionRetry.WithTags("pipeline")
+	executionRetry.WithMapOfAnything(map[string]interface{}{"operationId": "retryExecution"})
+	_ = reflector.SetRequest(&executionRetry, new(retryExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionRetry, new(types.Execution), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/retry", executionRetry)
+
+	approvalFind := openapi3.Operation{}
+	approvalFind.WithTags("pipeline")
+	approvalFind.WithMapOfAnything(map[string]interface{}{"operationId": "findApproval"})
+	_ = reflector.SetRequest(&approvalFind, new(approvalRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&approvalFind, new(types.Approval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/stages/{stage_number}/approval",
+		approvalFind)
+
+	approvalDecide := openapi3.Operation{}
+	approvalDecide.WithTags("pipeline")
+	approvalDecide.WithMapOfAnything(map[string]interface{}{"operationId": "decideApproval"})
+	_ = reflector.SetRequest(&approvalDecide, new(decideApprovalRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(types.Approval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/stages/{stage_number}/approval",
+		approvalDecide)
+
 	executionDelete := openapi3.Operation{}
 	executionDelete.WithTags("pipeline")
 	executionDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteExecution"})
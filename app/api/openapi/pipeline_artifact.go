@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type artifactRequest struct {
+	executionRequest
+	Key string `path:"artifact_key"`
+}
+
+type cacheRequest struct {
+	pipelineRequest
+	Key string `path:"cache_key"`
+}
+
+var queryParameterQueryArtifact = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the artifacts by their keys."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterExpiresIn = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamExpiresIn,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Optional duration (e.g. 24h) after which the file expires, capped by the retention."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var artifactUploadBody = openapi3.RequestBodyOrRef{
+	RequestBody: &openapi3.RequestBody{
+		Description: ptr.String("Binary file to upload"),
+		Content: map[string]openapi3.MediaType{
+			"application/octet-stream": {Schema: &openapi3.SchemaOrRef{}},
+		},
+		Required: ptr.Bool(true),
+	},
+}
+
+//nolint:funlen
+func pipelineArtifactOperations(reflector *openapi3.Reflector) {
+	artifactList := openapi3.Operation{}
+	artifactList.WithTags("pipeline")
+	artifactList.WithMapOfAnything(map[string]interface{}{"operationId": "listArtifacts"})
+	artifactList.WithParameters(queryParameterQueryArtifact, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&artifactList, new(executionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactList, []types.PipelineArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts", artifactList)
+
+	artifactUpload := openapi3.Operation{}
+	artifactUpload.WithTags("pipeline")
+	artifactUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadArtifact"})
+	artifactUpload.WithParameters(queryParameterExpiresIn)
+	artifactUpload.WithRequestBody(artifactUploadBody)
+	_ = reflector.SetRequest(&artifactUpload, new(artifactRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(types.PipelineArtifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_key}",
+		artifactUpload)
+
+	artifactDownload := openapi3.Operation{}
+	artifactDownload.WithTags("pipeline")
+	artifactDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadArtifact"})
+	_ = reflector.SetRequest(&artifactDownload, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetupResponse(openapi3.OperationContext{
+		Operation:  &artifactDownload,
+		HTTPStatus: http.StatusOK,
+	})
+	_ = reflector.SetJSONResponse(&artifactDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_key}",
+		artifactDownload)
+
+	cacheList := openapi3.Operation{}
+	cacheList.WithTags("pipeline")
+	cacheList.WithMapOfAnything(map[string]interface{}{"operationId": "listCaches"})
+	cacheList.WithParameters(queryParameterQueryArtifact, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&cacheList, new(pipelineRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&cacheList, []types.PipelineArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches", cacheList)
+
+	cacheUpload := openapi3.Operation{}
+	cacheUpload.WithTags("pipeline")
+	cacheUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadCache"})
+	cacheUpload.WithParameters(queryParameterExpiresIn)
+	cacheUpload.WithRequestBody(artifactUploadBody)
+	_ = reflector.SetRequest(&cacheUpload, new(cacheRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(types.PipelineArtifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheUpload)
+
+	cacheDownload := openapi3.Operation{}
+	cacheDownload.WithTags("pipeline")
+	cacheDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadCache"})
+	_ = reflector.SetRequest(&cacheDownload, new(cacheRequest), http.MethodGet)
+	_ = reflector.SetupResponse(openapi3.OperationContext{
+		Operation:  &cacheDownload,
+		HTTPStatus: http.StatusOK,
+	})
+	_ = reflector.SetJSONResponse(&cacheDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheDownload)
+
+	cacheDelete := openapi3.Operation{}
+	cacheDelete.WithTags("pipeline")
+	cacheDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteCache"})
+	_ = reflector.SetRequest(&cacheDelete, new(cacheRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&cacheDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheDelete)
+}
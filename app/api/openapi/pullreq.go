@@ -120,6 +120,17 @@ type reviewSubmitPullReqRequest struct {
 	pullReqRequest
 }
 
+type reviewDismissPullReqRequest struct {
+	pullReqRequest
+	PullReqReviewerID int64 `path:"pullreq_reviewer_id"`
+	pullreq.ReviewDismissInput
+}
+
+type reviewRequestPullReqRequest struct {
+	pullReqRequest
+	pullreq.ReviewRequestInput
+}
+
 type fileViewAddPullReqRequest struct {
 	pullReqRequest
 	pullreq.FileViewAddInput
@@ -211,6 +222,22 @@ var queryParameterTargetBranchPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludeStack = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamIncludeStack,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String(
+			"If true, the stack of pull requests this pull request belongs to would be included in the response."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterCreatedByPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamCreatedBy,
@@ -519,7 +546,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	getPullReq := openapi3.Operation{}
 	getPullReq.WithTags("pullreq")
 	getPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReq"})
-	getPullReq.WithParameters(queryParameterIncludeChecks, queryParameterIncludeRules)
+	getPullReq.WithParameters(queryParameterIncludeChecks, queryParameterIncludeRules, queryParameterIncludeStack)
 	_ = reflector.SetRequest(&getPullReq, new(getPullReqRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&getPullReq, new(types.PullReq), http.StatusOK)
 	_ = reflector.SetJSONResponse(&getPullReq, new(usererror.Error), http.StatusBadRequest)
@@ -689,6 +716,30 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews", reviewSubmit)
 
+	reviewDismiss := openapi3.Operation{}
+	reviewDismiss.WithTags("pullreq")
+	reviewDismiss.WithMapOfAnything(map[string]interface{}{"operationId": "reviewDismissPullReq"})
+	_ = reflector.SetRequest(&reviewDismiss, new(reviewDismissPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&reviewDismiss, new(types.PullReqReviewer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reviewDismiss, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewDismiss, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewDismiss, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewDismiss, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/{pullreq_reviewer_id}/dismiss", reviewDismiss)
+
+	reviewRequest := openapi3.Operation{}
+	reviewRequest.WithTags("pullreq")
+	reviewRequest.WithMapOfAnything(map[string]interface{}{"operationId": "reviewRequestPullReq"})
+	_ = reflector.SetRequest(&reviewRequest, new(reviewRequestPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&reviewRequest, new([]*types.PullReqReviewer), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reviewRequest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewRequest, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews/request", reviewRequest)
+
 	mergePullReqOp := openapi3.Operation{}
 	mergePullReqOp.WithTags("pullreq")
 	mergePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergePullReqOp"})
@@ -1167,6 +1167,18 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/codeowners/validate", opCodeOwnerValidate)
 
+	opListPullReqTemplates := openapi3.Operation{}
+	opListPullReqTemplates.WithTags("repository")
+	opListPullReqTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqTemplates"})
+	opListPullReqTemplates.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opListPullReqTemplates, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPullReqTemplates, []types.PullReqTemplate{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPullReqTemplates, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPullReqTemplates, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPullReqTemplates, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListPullReqTemplates, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq-templates", opListPullReqTemplates)
+
 	opSettingsSecurityUpdate := openapi3.Operation{}
 	opSettingsSecurityUpdate.WithTags("repository")
 	opSettingsSecurityUpdate.WithMapOfAnything(
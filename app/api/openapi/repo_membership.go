@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type repoMembershipRequest struct {
+	repoRequest
+	ID int64 `path:"repo_membership_id"`
+}
+
+type repoMembershipAddRequest struct {
+	repoRequest
+	repo.MembershipAddInput
+}
+
+type repoMembershipUpdateRequest struct {
+	repoMembershipRequest
+	repo.MembershipUpdateInput
+}
+
+func repoMembershipOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("repository")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipList"})
+	_ = reflector.SetRequest(&opList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.RepoMembershipInfo{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/members", opList)
+
+	opAdd := openapi3.Operation{}
+	opAdd.WithTags("repository")
+	opAdd.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipAdd"})
+	_ = reflector.SetRequest(&opAdd, new(repoMembershipAddRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAdd, new(types.RepoMembershipInfo), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/members", opAdd)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("repository")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipUpdate"})
+	_ = reflector.SetRequest(&opUpdate, new(repoMembershipUpdateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.RepoMembershipInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/members/{repo_membership_id}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("repository")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipDelete"})
+	_ = reflector.SetRequest(&opDelete, new(repoMembershipRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/members/{repo_membership_id}", opDelete)
+}
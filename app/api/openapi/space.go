@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/controller/spacesettings"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
@@ -36,6 +37,11 @@ type spaceRequest struct {
 	Ref string `path:"space_ref"`
 }
 
+type gitspaceSettingsRequest struct {
+	spaceRequest
+	spacesettings.GitspaceSettings
+}
+
 type updateSpaceRequest struct {
 	spaceRequest
 	space.UpdateInput
@@ -710,4 +716,32 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetUsageMetrics, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opGetUsageMetrics, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usage/metric", opGetUsageMetrics)
+
+	opSettingsGitspaceUpdate := openapi3.Operation{}
+	opSettingsGitspaceUpdate.WithTags("space")
+	opSettingsGitspaceUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateSpaceGitspaceSettings"})
+	_ = reflector.SetRequest(
+		&opSettingsGitspaceUpdate, new(gitspaceSettingsRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceUpdate, new(spacesettings.GitspaceSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/spaces/{space_ref}/settings/gitspace", opSettingsGitspaceUpdate)
+
+	opSettingsGitspaceFind := openapi3.Operation{}
+	opSettingsGitspaceFind.WithTags("space")
+	opSettingsGitspaceFind.WithMapOfAnything(
+		map[string]interface{}{"operationId": "findSpaceGitspaceSettings"})
+	_ = reflector.SetRequest(&opSettingsGitspaceFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceFind, new(spacesettings.GitspaceSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsGitspaceFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/spaces/{space_ref}/settings/gitspace", opSettingsGitspaceFind)
 }
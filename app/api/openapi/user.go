@@ -122,6 +122,26 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
 
+	opNotificationSettingsFind := openapi3.Operation{}
+	opNotificationSettingsFind.WithTags("user")
+	opNotificationSettingsFind.WithMapOfAnything(map[string]interface{}{"operationId": "getNotificationSettings"})
+	_ = reflector.SetRequest(&opNotificationSettingsFind, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opNotificationSettingsFind, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opNotificationSettingsFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-settings", opNotificationSettingsFind)
+
+	opNotificationSettingsUpdate := openapi3.Operation{}
+	opNotificationSettingsUpdate.WithTags("user")
+	opNotificationSettingsUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateNotificationSettings"})
+	_ = reflector.SetRequest(&opNotificationSettingsUpdate, new(user.NotificationSettingsUpdateInput),
+		http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opNotificationSettingsUpdate, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opNotificationSettingsUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opNotificationSettingsUpdate, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notification-settings", opNotificationSettingsUpdate)
+
 	opKeyCreate := openapi3.Operation{}
 	opKeyCreate.WithTags("user")
 	opKeyCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
@@ -35,6 +35,7 @@ const (
 	spaceKey
 	repoKey
 	requestIDKey
+	runnerKey
 )
 
 // WithAuthSession returns a copy of parent in which the principal
@@ -108,6 +109,18 @@ func RepoFrom(ctx context.Context) (*types.Repository, bool) {
 	return v, ok && v != nil
 }
 
+// WithRunner returns a copy of parent in which the runner value is set.
+func WithRunner(parent context.Context, v *types.Runner) context.Context {
+	return context.WithValue(parent, runnerKey, v)
+}
+
+// RunnerFrom returns the value of the runner key on the
+// context - ok is true iff a non-nile value existed.
+func RunnerFrom(ctx context.Context) (*types.Runner, bool) {
+	v, ok := ctx.Value(runnerKey).(*types.Runner)
+	return v, ok && v != nil
+}
+
 // WithRequestID returns a copy of parent in which the request id value is set.
 func WithRequestID(parent context.Context, v string) context.Context {
 	return context.WithValue(parent, requestIDKey, v)
@@ -21,6 +21,15 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+const (
+	PathParamRepoMembershipID = "repo_membership_id"
+)
+
+// GetRepoMembershipIDFromPath extracts the repository membership ID from the url.
+func GetRepoMembershipIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamRepoMembershipID)
+}
+
 // ParseMembershipUserSort extracts the membership sort parameter from the url.
 func ParseMembershipUserSort(r *http.Request) enum.MembershipUserSort {
 	return enum.ParseMembershipUserSort(
@@ -16,7 +16,9 @@ package request
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 )
 
@@ -29,6 +31,7 @@ const (
 	QueryParamLatest            = "latest"
 	QueryParamLastExecutions    = "last_executions"
 	QueryParamBranch            = "branch"
+	QueryParamExpiresIn         = "expires_in"
 )
 
 func GetPipelineIdentifierFromPath(r *http.Request) (string, error) {
@@ -60,6 +63,21 @@ func GetTriggerIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTriggerIdentifier)
 }
 
+// GetExpiresInFromQuery extracts the optional retention of an uploaded artifact, e.g. "24h".
+func GetExpiresInFromQuery(r *http.Request) (time.Duration, error) {
+	value, ok := QueryParam(r, QueryParamExpiresIn)
+	if !ok {
+		return 0, nil
+	}
+
+	expiresIn, err := time.ParseDuration(value)
+	if err != nil || expiresIn <= 0 {
+		return 0, usererror.BadRequestf("Parameter '%s' must be a positive duration.", QueryParamExpiresIn)
+	}
+
+	return expiresIn, nil
+}
+
 func ParseListPipelinesFilterFromRequest(r *http.Request) (types.ListPipelinesFilter, error) {
 	lastExecs, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLastExecutions, 10)
 	if err != nil {
@@ -39,6 +39,7 @@ const (
 	QueryParamSourceRepoRef      = "source_repo_ref"
 	QueryParamSourceBranch       = "source_branch"
 	QueryParamTargetBranch       = "target_branch"
+	QueryParamIncludeStack       = "include_stack"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -64,6 +65,10 @@ func GetPullReqTargetBranchFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTargetBranch)
 }
 
+func GetIncludeStackFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamIncludeStack, deflt)
+}
+
 func GetSourceRepoRefFromQueryOrDefault(r *http.Request, deflt string) string {
 	return QueryParamOrDefault(r, QueryParamSourceRepoRef, deflt)
 }
@@ -126,6 +126,7 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
+		Scope:     tkn.Scope,
 	}, nil
 }
 
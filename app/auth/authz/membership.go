@@ -16,12 +16,15 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/refcache"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -34,17 +37,20 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceFinder     refcache.SpaceFinder
+	repoFinder      refcache.RepoFinder
 	publicAccess    publicaccess.Service
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
 	publicAccess publicaccess.Service,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceFinder:     spaceFinder,
+		repoFinder:      repoFinder,
 		publicAccess:    publicAccess,
 	}
 }
@@ -76,11 +82,27 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
-	if session.Principal.Admin {
+	// a scoped token grants a subset of the permissions of its principal
+	tokenMetadata, isToken := session.Metadata.(*auth.TokenMetadata)
+	if isToken && tokenMetadata.Scope != nil && len(tokenMetadata.Scope.Permissions) > 0 &&
+		!slices.Contains(tokenMetadata.Scope.Permissions, permission) {
+		return false, nil
+	}
+
+	// a scoped token can't change any account, not even the one of its principal,
+	// as it could e.g. create an access token without the restrictions of its scope.
+	if isToken && tokenMetadata.Scope != nil && resource.Type == enum.ResourceTypeUser &&
+		permission != enum.PermissionUserView {
+		return false, nil
+	}
+
+	if session.Principal.Admin && (!isToken || tokenMetadata.Scope == nil || !tokenMetadata.Scope.HasResources()) {
 		return true, nil // system admin can call any API
 	}
 
 	var spacePath string
+	// repoPath is set for resources of a single repository, as the repository memberships apply to them.
+	var repoPath string
 
 	//nolint:exhaustive // we want to fail on anything else
 	switch resource.Type {
@@ -89,12 +111,18 @@ func (a *MembershipAuthorizer) Check(
 
 	case enum.ResourceTypeRepo:
 		spacePath = scope.SpacePath
+		if resource.Identifier != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+		}
 
 	case enum.ResourceTypeServiceAccount:
 		spacePath = scope.SpacePath
 
 	case enum.ResourceTypePipeline:
 		spacePath = scope.SpacePath
+		if scope.Repo != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, scope.Repo)
+		}
 
 	case enum.ResourceTypeSecret:
 		spacePath = scope.SpacePath
@@ -147,8 +175,24 @@ func (a *MembershipAuthorizer) Check(
 		return a.checkWithAccessPermissionMetadata(ctx, accessPermissionMetadata, spacePath, permission)
 	}
 
+	// a scoped token is restricted to its spaces and repositories on top of the memberships of its principal
+	if isToken && tokenMetadata.Scope != nil {
+		inScope, err := a.isInTokenScope(ctx, tokenMetadata.Scope, spacePath, repoPath)
+		if err != nil {
+			return false, err
+		}
+
+		if !inScope {
+			return false, nil
+		}
+
+		if session.Principal.Admin {
+			return true, nil
+		}
+	}
+
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	if !isToken && session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
@@ -156,6 +200,7 @@ func (a *MembershipAuthorizer) Check(
 		ctx, PermissionCacheKey{
 			PrincipalID: session.Principal.ID,
 			SpaceRef:    spacePath,
+			RepoRef:     repoPath,
 			Permission:  permission,
 		},
 	)
@@ -231,3 +276,58 @@ func (a *MembershipAuthorizer) checkWithAccessPermissionMetadata(
 
 	return false, fmt.Errorf("no %s permission provided", requestedPermission)
 }
+
+// isInTokenScope checks whether the requested space or repository is within the resources of the token scope.
+// A space of the scope covers its subspaces and all repositories in them.
+func (a *MembershipAuthorizer) isInTokenScope(
+	ctx context.Context,
+	scope *types.TokenScope,
+	requestedSpacePath string,
+	requestedRepoPath string,
+) (bool, error) {
+	if !scope.HasResources() {
+		return true, nil
+	}
+
+	for _, spaceID := range scope.SpaceIDs {
+		space, err := a.spaceFinder.FindByID(ctx, spaceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find space of token scope: %w", err)
+		}
+
+		if isPathWithin(requestedSpacePath, space.Path) {
+			return true, nil
+		}
+	}
+
+	if requestedRepoPath == "" {
+		return false, nil
+	}
+
+	for _, repoID := range scope.RepoIDs {
+		repo, err := a.repoFinder.FindByID(ctx, repoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find repo of token scope: %w", err)
+		}
+
+		if strings.EqualFold(requestedRepoPath, repo.Path) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isPathWithin returns true if the path is equal to the root path or is one of its descendants.
+func isPathWithin(path string, root string) bool {
+	path = strings.ToLower(strings.Trim(path, types.PathSeparatorAsString))
+	root = strings.ToLower(strings.Trim(root, types.PathSeparatorAsString))
+
+	return path == root || strings.HasPrefix(path, root+types.PathSeparatorAsString)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/cache"
 	gitness_store "github.com/harness/gitness/store"
@@ -34,27 +35,59 @@ import (
 type PermissionCacheKey struct {
 	PrincipalID int64
 	SpaceRef    string
-	Permission  enum.Permission
+	// RepoRef is the path of the repository the permission is requested for, if any.
+	// Memberships of the repository are evaluated in addition to the space memberships.
+	RepoRef    string
+	Permission enum.Permission
 }
 type PermissionCache cache.Cache[PermissionCacheKey, bool]
 
 func NewPermissionCache(
 	spaceFinder refcache.SpaceFinder,
 	membershipStore store.MembershipStore,
+	customRoleStore store.CustomRoleStore,
+	repoFinder refcache.RepoFinder,
+	repoMembershipStore store.RepoMembershipStore,
+	userGroupService usergroup.SearchService,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
-		spaceFinder:     spaceFinder,
-		membershipStore: membershipStore,
+		spaceFinder:         spaceFinder,
+		membershipStore:     membershipStore,
+		customRoleStore:     customRoleStore,
+		repoFinder:          repoFinder,
+		repoMembershipStore: repoMembershipStore,
+		userGroupService:    userGroupService,
 	}, cacheDuration)
 }
 
+// permissionCacheGetter resolves the permissions of the principals from their space memberships
+// and from the memberships of the repository, the highest granted permission wins.
+// Changes of the permissions of a custom role are picked up once the cached entries expire.
 type permissionCacheGetter struct {
-	spaceFinder     refcache.SpaceFinder
-	membershipStore store.MembershipStore
+	spaceFinder         refcache.SpaceFinder
+	membershipStore     store.MembershipStore
+	customRoleStore     store.CustomRoleStore
+	repoFinder          refcache.RepoFinder
+	repoMembershipStore store.RepoMembershipStore
+	userGroupService    usergroup.SearchService
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
+	hasPermission, err := g.findSpacePermission(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	if hasPermission || key.RepoRef == "" {
+		return hasPermission, nil
+	}
+
+	return g.findRepoPermission(ctx, key)
+}
+
+// findSpacePermission checks the permission against the memberships of the space and its ancestors.
+func (g permissionCacheGetter) findSpacePermission(ctx context.Context, key PermissionCacheKey) (bool, error) {
 	spaceRef := key.SpaceRef
 	principalID := key.PrincipalID
 
@@ -82,9 +115,14 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 		}
 
 		// If the membership is defined in the current space, check if the user has the required permission.
-		if membership != nil &&
-			roleHasPermission(membership.Role, key.Permission) {
-			return true, nil
+		if membership != nil {
+			hasPermission, err := g.membershipHasPermission(ctx, membership, key.Permission)
+			if err != nil {
+				return false, err
+			}
+			if hasPermission {
+				return true, nil
+			}
 		}
 
 		// If membership with the requested permission has not been found in the current space,
@@ -103,6 +141,82 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 	return false, nil
 }
 
+// membershipHasPermission checks the permission against the custom role of the membership,
+// or against the predefined role if the membership has no custom role.
+func (g permissionCacheGetter) membershipHasPermission(
+	ctx context.Context,
+	membership *types.Membership,
+	permission enum.Permission,
+) (bool, error) {
+	if membership.Role != enum.MembershipRoleCustom || membership.CustomRoleID == nil {
+		return roleHasPermission(membership.Role, permission), nil
+	}
+
+	customRole, err := g.customRoleStore.Find(ctx, *membership.CustomRoleID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find custom role of membership: %w", err)
+	}
+
+	return slices.Contains(customRole.Permissions, permission), nil
+}
+
+// findRepoPermission checks the permission against the memberships of the repository,
+// either of the principal directly or of any user group the principal belongs to.
+func (g permissionCacheGetter) findRepoPermission(ctx context.Context, key PermissionCacheKey) (bool, error) {
+	repo, err := g.repoFinder.FindByRef(ctx, key.RepoRef)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo '%s': %w", key.RepoRef, err)
+	}
+
+	memberships, err := g.repoMembershipStore.List(ctx, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list repo memberships: %w", err)
+	}
+
+	return g.repoMembershipsHavePermission(ctx, memberships, key.PrincipalID, key.Permission)
+}
+
+// repoMembershipsHavePermission checks if any of the repository memberships grants the permission to the principal.
+// The user groups of the memberships are expanded only if no direct membership grants the permission.
+func (g permissionCacheGetter) repoMembershipsHavePermission(
+	ctx context.Context,
+	memberships []*types.RepoMembership,
+	principalID int64,
+	permission enum.Permission,
+) (bool, error) {
+	var userGroupIDs []int64
+	for _, membership := range memberships {
+		if !roleHasPermission(membership.Role, permission) {
+			continue
+		}
+
+		if membership.PrincipalID != nil && *membership.PrincipalID == principalID {
+			return true, nil
+		}
+
+		if membership.UserGroupID != nil {
+			userGroupIDs = append(userGroupIDs, *membership.UserGroupID)
+		}
+	}
+
+	if len(userGroupIDs) == 0 {
+		return false, nil
+	}
+
+	userIDs, err := g.userGroupService.ListUserIDsByGroupIDs(ctx, userGroupIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to list users of user groups: %w", err)
+	}
+
+	return slices.Contains(userIDs, principalID), nil
+}
+
 func roleHasPermission(role enum.MembershipRole, permission enum.Permission) bool {
 	_, hasRole := slices.BinarySearch(role.Permissions(), permission)
 	return hasRole
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userGroupMembers resolves user groups from a fixed map of group IDs to user IDs.
+type userGroupMembers struct {
+	usergroup.SearchService
+	members map[int64][]int64
+	calls   int
+}
+
+func (s *userGroupMembers) ListUserIDsByGroupIDs(_ context.Context, groupIDs []int64) ([]int64, error) {
+	s.calls++
+	var userIDs []int64
+	for _, groupID := range groupIDs {
+		userIDs = append(userIDs, s.members[groupID]...)
+	}
+	return userIDs, nil
+}
+
+func TestRepoMembershipsHavePermission(t *testing.T) {
+	ptr := func(id int64) *int64 { return &id }
+
+	memberships := []*types.RepoMembership{
+		{PrincipalID: ptr(1), Role: enum.MembershipRoleReader},
+		{PrincipalID: ptr(2), Role: enum.MembershipRoleContributor},
+		{UserGroupID: ptr(10), Role: enum.MembershipRoleReader},
+		{UserGroupID: ptr(11), Role: enum.MembershipRoleContributor},
+	}
+
+	tests := []struct {
+		name        string
+		principalID int64
+		permission  enum.Permission
+		want        bool
+		groupCalls  int
+	}{
+		{name: "direct", principalID: 2, permission: enum.PermissionRepoPush, want: true},
+		{name: "direct role lacks permission", principalID: 1, permission: enum.PermissionRepoPush, groupCalls: 1},
+		{name: "user group", principalID: 3, permission: enum.PermissionRepoView, want: true, groupCalls: 1},
+		{name: "user group role lacks permission", principalID: 3, permission: enum.PermissionRepoPush, groupCalls: 1},
+		{name: "other user group", principalID: 4, permission: enum.PermissionRepoPush, want: true, groupCalls: 1},
+		{name: "no membership", principalID: 5, permission: enum.PermissionRepoView, groupCalls: 1},
+		{name: "no role has permission", principalID: 4, permission: enum.PermissionRepoDelete},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			groups := &userGroupMembers{members: map[int64][]int64{10: {3}, 11: {4}}}
+			g := permissionCacheGetter{userGroupService: groups}
+
+			got, err := g.repoMembershipsHavePermission(context.Background(), memberships,
+				test.principalID, test.permission)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.groupCalls, groups.calls)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type privateAccess struct {
+	publicaccess.Service
+}
+
+func (privateAccess) Get(context.Context, enum.PublicResourceType, string) (bool, error) {
+	return false, nil
+}
+
+func TestIsPathWithin(t *testing.T) {
+	assert.True(t, isPathWithin("space", "space"))
+	assert.True(t, isPathWithin("Space/Sub", "space"))
+	assert.True(t, isPathWithin("/space/sub/", "space"))
+	assert.False(t, isPathWithin("spaces", "space"))
+	assert.False(t, isPathWithin("other/space", "space"))
+	assert.False(t, isPathWithin("space", "space/sub"))
+}
+
+func TestMembershipAuthorizer_CheckScopedTokenPermissions(t *testing.T) {
+	authorizer := NewMembershipAuthorizer(nil, refcache.SpaceFinder{}, refcache.RepoFinder{}, privateAccess{})
+
+	scope := &types.Scope{SpacePath: "space"}
+	resource := &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"}
+
+	session := &auth.Session{
+		Principal: types.Principal{ID: 1, Admin: true},
+		Metadata: &auth.TokenMetadata{
+			TokenType: enum.TokenTypePAT,
+			Scope:     &types.TokenScope{Permissions: []enum.Permission{enum.PermissionRepoView}},
+		},
+	}
+
+	ok, err := authorizer.Check(context.Background(), session, scope, resource, enum.PermissionRepoView)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = authorizer.Check(context.Background(), session, scope, resource, enum.PermissionRepoPush)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	session.Metadata = &auth.TokenMetadata{TokenType: enum.TokenTypePAT}
+
+	ok, err = authorizer.Check(context.Background(), session, scope, resource, enum.PermissionRepoPush)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestMembershipAuthorizer_CheckScopedTokenOwnAccount(t *testing.T) {
+	authorizer := NewMembershipAuthorizer(nil, refcache.SpaceFinder{}, refcache.RepoFinder{}, privateAccess{})
+
+	resource := &types.Resource{Type: enum.ResourceTypeUser, Identifier: "jane"}
+
+	tests := []struct {
+		name  string
+		admin bool
+		scope *types.TokenScope
+	}{
+		{
+			name:  "scoped to a space",
+			scope: &types.TokenScope{SpaceIDs: []int64{1}},
+		},
+		{
+			name:  "scoped to permissions",
+			scope: &types.TokenScope{Permissions: []enum.Permission{enum.PermissionUserEdit}},
+		},
+		{
+			name:  "admin scoped to a repo",
+			admin: true,
+			scope: &types.TokenScope{RepoIDs: []int64{1}},
+		},
+		{
+			name:  "admin scoped to permissions",
+			admin: true,
+			scope: &types.TokenScope{Permissions: []enum.Permission{enum.PermissionUserEdit}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &auth.Session{
+				Principal: types.Principal{ID: 1, UID: "jane", Admin: test.admin},
+				Metadata:  &auth.TokenMetadata{TokenType: enum.TokenTypePAT, Scope: test.scope},
+			}
+
+			// creating an access token requires to edit the user.
+			ok, err := authorizer.Check(context.Background(), session, &types.Scope{}, resource, enum.PermissionUserEdit)
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			if len(test.scope.Permissions) == 0 {
+				ok, err = authorizer.Check(context.Background(), session, &types.Scope{}, resource, enum.PermissionUserView)
+				require.NoError(t, err)
+				assert.True(t, ok)
+			}
+
+			// without a scope the user edits their own account.
+			session.Metadata = &auth.TokenMetadata{TokenType: enum.TokenTypePAT}
+			ok, err = authorizer.Check(context.Background(), session, &types.Scope{}, resource, enum.PermissionUserEdit)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
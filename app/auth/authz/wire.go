@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -33,15 +34,28 @@ var WireSet = wire.NewSet(
 func ProvideAuthorizer(
 	pCache PermissionCache,
 	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
 	publicAccess publicaccess.Service,
 ) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceFinder, publicAccess)
+	return NewMembershipAuthorizer(pCache, spaceFinder, repoFinder, publicAccess)
 }
 
 func ProvidePermissionCache(
 	spaceFinder refcache.SpaceFinder,
 	membershipStore store.MembershipStore,
+	customRoleStore store.CustomRoleStore,
+	repoFinder refcache.RepoFinder,
+	repoMembershipStore store.RepoMembershipStore,
+	userGroupService usergroup.SearchService,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceFinder, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(
+		spaceFinder,
+		membershipStore,
+		customRoleStore,
+		repoFinder,
+		repoMembershipStore,
+		userGroupService,
+		permissionCacheTimeout,
+	)
 }
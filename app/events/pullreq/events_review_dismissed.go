@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ReviewDismissedEvent events.EventType = "review-dismissed"
+	ReviewRequestedEvent events.EventType = "review-requested"
+)
+
+type ReviewDismissedPayload struct {
+	Base
+	ReviewerID int64                      `json:"reviewer_id"`
+	Decision   enum.PullReqReviewDecision `json:"decision"`
+	Reason     string                     `json:"reason,omitempty"`
+	Automatic  bool                       `json:"automatic,omitempty"`
+}
+
+type ReviewRequestedPayload struct {
+	Base
+	ReviewerID int64 `json:"reviewer_id"`
+}
+
+func (r *Reporter) ReviewDismissed(
+	ctx context.Context,
+	payload *ReviewDismissedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewDismissedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request review dismissed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request review dismissed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewDismissed(
+	fn events.HandlerFunc[*ReviewDismissedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewDismissedEvent, fn, opts...)
+}
+
+func (r *Reporter) ReviewRequested(
+	ctx context.Context,
+	payload *ReviewRequestedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewRequestedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request review requested event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request review requested event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewRequested(
+	fn events.HandlerFunc[*ReviewRequestedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewRequestedEvent, fn, opts...)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/gitspace/orchestrator/ide"
 	"github.com/harness/gitness/app/gitspace/scm"
+	gitspaceTypes "github.com/harness/gitness/app/gitspace/types"
 	"github.com/harness/gitness/types"
 )
 
@@ -46,4 +47,23 @@ type Orchestrator interface {
 
 	// StreamLogs is used to fetch gitspace's start/stop logs from the container orchestrator.
 	StreamLogs(ctx context.Context, gitspaceConfig types.GitspaceConfig, infra types.Infrastructure) (string, error)
+
+	// LastActivity returns the time (in unix milliseconds) of the most recent user activity in the gitspace,
+	// e.g. IDE connections, ssh sessions and executed commands. It returns 0 if there was no activity yet.
+	LastActivity(ctx context.Context, gitspaceConfig types.GitspaceConfig, infra types.Infrastructure) (int64, error)
+
+	// CreatePrebuildImage sets up a temporary gitspace container with the code cloned, the features installed
+	// and the onCreateCommand and updateContentCommand executed, and commits it as the image imageName.
+	CreatePrebuildImage(
+		ctx context.Context,
+		gitspaceConfig types.GitspaceConfig,
+		infra types.Infrastructure,
+		resolvedDetails scm.ResolvedDetails,
+		defaultBaseImage string,
+		imageName string,
+		gitspaceLogger gitspaceTypes.GitspaceLogger,
+	) error
+
+	// RemoveImage removes an image, e.g. an outdated prebuild image, from the infra.
+	RemoveImage(ctx context.Context, infra types.Infrastructure, imageName string) error
 }
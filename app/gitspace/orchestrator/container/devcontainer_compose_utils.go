@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	gitspaceTypes "github.com/harness/gitness/app/gitspace/types"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	composeProjectLabel  = "com.docker.compose.project"
+	composeOverrideFile  = ".gitspace-compose-override.json"
+	composeStorageVolume = "gitspace-storage"
+)
+
+// composeProject holds everything needed to run docker compose commands for a gitspace.
+// Files are empty for the commands which operate on an already created project (stop, start, down).
+type composeProject struct {
+	Name  string
+	Files []string
+	Env   []string
+}
+
+// composeService contains the parts of a service of the resolved compose config used by gitspaces.
+type composeService struct {
+	Image string          `json:"image"`
+	User  string          `json:"user"`
+	Build json.RawMessage `json:"build"`
+}
+
+type composeConfig struct {
+	Services map[string]*composeService `json:"services"`
+}
+
+// composeOverride is written as JSON, which docker compose accepts as it is a subset of YAML.
+//
+//nolint:tagliatelle
+type composeOverride struct {
+	Services map[string]*composeOverrideService `json:"services"`
+	Volumes  map[string]*composeOverrideVolume  `json:"volumes"`
+}
+
+//nolint:tagliatelle
+type composeOverrideService struct {
+	ContainerName string                  `json:"container_name"`
+	Ports         []string                `json:"ports,omitempty"`
+	Volumes       []*composeOverrideMount `json:"volumes"`
+	Environment   map[string]string       `json:"environment,omitempty"`
+	Labels        map[string]string       `json:"labels"`
+}
+
+type composeOverrideMount struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type composeOverrideVolume struct {
+	Name     string `json:"name"`
+	External bool   `json:"external"`
+}
+
+// getComposeProjectName returns the compose project name of the gitspace. Compose only allows
+// lowercase alphanumeric characters, dashes and underscores in project names.
+func getComposeProjectName(containerName string) string {
+	return strings.ToLower(containerName)
+}
+
+// newComposeProject resolves the compose files of the devcontainer.json, which are relative to the
+// .devcontainer folder of the repository checked out in sourceDir.
+func newComposeProject(
+	containerName string,
+	sourceDir string,
+	devcontainerConfig types.DevcontainerConfig,
+	env []string,
+) (*composeProject, error) {
+	configDir := filepath.Join(sourceDir, ".devcontainer")
+	files := make([]string, 0, len(devcontainerConfig.DockerComposeFile)+1)
+	for _, file := range devcontainerConfig.DockerComposeFile {
+		path := filepath.Join(configDir, file)
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("docker compose file %q is outside of the repository", file)
+		}
+		files = append(files, path)
+	}
+
+	return &composeProject{
+		Name:  getComposeProjectName(containerName),
+		Files: files,
+		Env:   env,
+	}, nil
+}
+
+// run executes a docker compose command for the project and returns its stdout.
+func (p *composeProject) run(
+	ctx context.Context,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+	args ...string,
+) ([]byte, error) {
+	cmdArgs := []string{"compose", "--project-name", p.Name}
+	for _, file := range p.Files {
+		cmdArgs = append(cmdArgs, "--file", file)
+	}
+	cmdArgs = append(cmdArgs, args...)
+
+	cmd := exec.CommandContext(ctx, "docker", cmdArgs...)
+	cmd.Env = p.Env
+	if len(p.Files) == 0 {
+		// Make sure compose doesn't pick up a compose file from the working directory of the server.
+		cmd.Dir = os.TempDir()
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+
+	gitspaceLogger.Info("Executing: docker " + strings.Join(cmdArgs, " "))
+	err := cmd.Run()
+	if errOutput := strings.TrimSpace(errBuf.String()); errOutput != "" {
+		// compose writes its progress to stderr.
+		gitspaceLogger.Info(errOutput)
+	}
+	if err != nil {
+		return nil, logStreamWrapError(gitspaceLogger,
+			fmt.Sprintf("Error while running docker compose %s", args[0]), err)
+	}
+
+	return outBuf.Bytes(), nil
+}
+
+// service returns the resolved compose configuration of the service.
+func (p *composeProject) service(
+	ctx context.Context,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+	serviceName string,
+) (*composeService, error) {
+	output, err := p.run(ctx, gitspaceLogger, "config", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	config := composeConfig{}
+	if err = json.Unmarshal(output, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse docker compose config: %w", err)
+	}
+
+	service, ok := config.Services[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("service %q is not defined in the docker compose files", serviceName)
+	}
+	return service, nil
+}
+
+// imageName returns the image of the service, using the default compose image name for built services.
+func (p *composeProject) imageName(serviceName string, service *composeService) string {
+	if service.Image != "" {
+		return service.Image
+	}
+	return p.Name + "-" + serviceName
+}
+
+// upServices returns the services to start. All services are started if runServices isn't set,
+// otherwise the listed services and the service the IDE attaches to.
+func upServices(devcontainerConfig types.DevcontainerConfig) []string {
+	if len(devcontainerConfig.RunServices) == 0 {
+		return nil
+	}
+	services := []string{devcontainerConfig.Service}
+	for _, service := range devcontainerConfig.RunServices {
+		if service != devcontainerConfig.Service {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// generateComposeOverride creates the compose file merged on top of the repository's compose files,
+// which attaches the gitspace storage, ports, environment and labels to the service the IDE attaches to.
+func generateComposeOverride(
+	serviceName string,
+	containerName string,
+	storage string,
+	homeDir string,
+	portMappings map[int]*types.PortMapping,
+	env []string,
+	labels map[string]string,
+) ([]byte, error) {
+	ports := make([]string, 0, len(portMappings))
+	for port, mapping := range portMappings {
+		ports = append(ports, fmt.Sprintf("%s:%d:%s/tcp", catchAllIP, mapping.PublishedPort, strconv.Itoa(port)))
+	}
+
+	environment := make(map[string]string, len(env))
+	for _, value := range env {
+		key, val, _ := strings.Cut(value, "=")
+		environment[key] = val
+	}
+
+	override := composeOverride{
+		Services: map[string]*composeOverrideService{
+			serviceName: {
+				ContainerName: containerName,
+				Ports:         ports,
+				Volumes: []*composeOverrideMount{
+					{Type: "volume", Source: composeStorageVolume, Target: homeDir},
+				},
+				Environment: environment,
+				Labels:      labels,
+			},
+		},
+		Volumes: map[string]*composeOverrideVolume{
+			composeStorageVolume: {Name: storage, External: true},
+		},
+	}
+
+	return json.MarshalIndent(override, "", "  ")
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComposeProject(t *testing.T) {
+	project, err := newComposeProject("gitspace-User-test", "/src", types.DevcontainerConfig{
+		DockerComposeFile: types.StringOrArray{"docker-compose.yml", "../compose.dev.yml"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "gitspace-user-test", project.Name)
+	assert.Equal(t, []string{"/src/.devcontainer/docker-compose.yml", "/src/compose.dev.yml"}, project.Files)
+
+	_, err = newComposeProject("gitspace-user-test", "/src", types.DevcontainerConfig{
+		DockerComposeFile: types.StringOrArray{"../../docker-compose.yml"},
+	}, nil)
+	assert.Error(t, err)
+}
+
+func TestUpServices(t *testing.T) {
+	assert.Nil(t, upServices(types.DevcontainerConfig{Service: "app"}))
+	assert.Equal(t, []string{"app", "db"}, upServices(types.DevcontainerConfig{
+		Service:     "app",
+		RunServices: []string{"db", "app"},
+	}))
+}
+
+func TestGenerateComposeOverride(t *testing.T) {
+	raw, err := generateComposeOverride("app", "gitspace-user-test", "gitspace-storage-1", "/home/vscode",
+		map[int]*types.PortMapping{8089: {PublishedPort: 32768, ForwardedPort: 8089}},
+		[]string{"FOO=bar=baz"},
+		map[string]string{gitspaceRemoteUserLabel: "vscode"})
+	require.NoError(t, err)
+
+	override := composeOverride{}
+	require.NoError(t, json.Unmarshal(raw, &override))
+
+	service := override.Services["app"]
+	require.NotNil(t, service)
+	assert.Equal(t, "gitspace-user-test", service.ContainerName)
+	assert.Equal(t, []string{"0.0.0.0:32768:8089/tcp"}, service.Ports)
+	assert.Equal(t, map[string]string{"FOO": "bar=baz"}, service.Environment)
+	assert.Equal(t, "vscode", service.Labels[gitspaceRemoteUserLabel])
+	require.Len(t, service.Volumes, 1)
+	assert.Equal(t, "/home/vscode", service.Volumes[0].Target)
+	assert.Equal(t, &composeOverrideVolume{Name: "gitspace-storage-1", External: true},
+		override.Volumes[service.Volumes[0].Source])
+}
+
+func TestMergeLifeCycleHooks(t *testing.T) {
+	config := types.DevcontainerConfig{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"onCreateCommand": "make deps",
+		"updateContentCommand": ["make", "generate"],
+		"postAttachCommand": {"server": "make run"}
+	}`), &config))
+
+	hooks := mergeLifeCycleHooks(config, nil)
+	require.Len(t, hooks[OnCreateAction], 1)
+	assert.Equal(t, []string{"make deps"}, hooks[OnCreateAction][0].Command.ToCommandArray())
+	require.Len(t, hooks[UpdateContentAction], 1)
+	assert.Equal(t, []string{"make generate"}, hooks[UpdateContentAction][0].Command.ToCommandArray())
+	require.Len(t, hooks[PostAttachAction], 1)
+	assert.Equal(t, PostAttachAction, hooks[PostAttachAction][0].ActionType)
+	assert.Empty(t, hooks[PostCreateAction])
+	assert.Empty(t, hooks[PostStartAction])
+}
@@ -185,13 +185,38 @@ func ExtractForwardPorts(devcontainerConfig types.DevcontainerConfig) []int {
 }
 
 func ExtractLifecycleCommands(actionType PostAction, devcontainerConfig types.DevcontainerConfig) []string {
+	command := getLifecycleCommand(actionType, devcontainerConfig.OnCreateCommand,
+		devcontainerConfig.UpdateContentCommand, devcontainerConfig.PostCreateCommand,
+		devcontainerConfig.PostStartCommand, devcontainerConfig.PostAttachCommand)
+	if command == nil {
+		return []string{} // Return empty string if actionType is not recognized
+	}
+	return command.ToCommandArray()
+}
+
+// getLifecycleCommand picks the command matching the action type from the commands of a devcontainer.json
+// or a feature config.
+func getLifecycleCommand(
+	actionType PostAction,
+	onCreate types.LifecycleCommand,
+	updateContent types.LifecycleCommand,
+	postCreate types.LifecycleCommand,
+	postStart types.LifecycleCommand,
+	postAttach types.LifecycleCommand,
+) *types.LifecycleCommand {
 	switch actionType {
+	case OnCreateAction:
+		return &onCreate
+	case UpdateContentAction:
+		return &updateContent
 	case PostCreateAction:
-		return devcontainerConfig.PostCreateCommand.ToCommandArray()
+		return &postCreate
 	case PostStartAction:
-		return devcontainerConfig.PostStartCommand.ToCommandArray()
+		return &postStart
+	case PostAttachAction:
+		return &postAttach
 	default:
-		return []string{} // Return empty string if actionType is not recognized
+		return nil
 	}
 }
 
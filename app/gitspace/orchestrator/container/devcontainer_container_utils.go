@@ -216,49 +216,40 @@ func mergeLifeCycleHooks(
 	devcontainerConfig types.DevcontainerConfig,
 	features []*types.ResolvedFeature,
 ) map[PostAction][]*LifecycleHookStep {
-	var postCreateHooks []*LifecycleHookStep
-	var postStartHooks []*LifecycleHookStep
-	for _, feature := range features {
-		if len(feature.DownloadedFeature.DevcontainerFeatureConfig.PostCreateCommand.ToCommandArray()) > 0 {
-			postCreateHooks = append(postCreateHooks, &LifecycleHookStep{
-				Source:        feature.DownloadedFeature.Source,
-				Command:       feature.DownloadedFeature.DevcontainerFeatureConfig.PostCreateCommand,
-				ActionType:    PostCreateAction,
-				StopOnFailure: true,
-			})
+	lifecycleHooks := make(map[PostAction][]*LifecycleHookStep, len(createLifecycleActions))
+	for _, actionType := range createLifecycleActions {
+		var hooks []*LifecycleHookStep
+		for _, feature := range features {
+			featureConfig := feature.DownloadedFeature.DevcontainerFeatureConfig
+			command := getLifecycleCommand(actionType, featureConfig.OnCreateCommand,
+				featureConfig.UpdateContentCommand, featureConfig.PostCreateCommand,
+				featureConfig.PostStartCommand, featureConfig.PostAttachCommand)
+			if len(command.ToCommandArray()) > 0 {
+				hooks = append(hooks, &LifecycleHookStep{
+					Source:        feature.DownloadedFeature.Source,
+					Command:       *command,
+					ActionType:    actionType,
+					StopOnFailure: true,
+				})
+			}
 		}
-		if len(feature.DownloadedFeature.DevcontainerFeatureConfig.PostStartCommand.ToCommandArray()) > 0 {
-			postStartHooks = append(postStartHooks, &LifecycleHookStep{
-				Source:        feature.DownloadedFeature.Source,
-				Command:       feature.DownloadedFeature.DevcontainerFeatureConfig.PostStartCommand,
-				ActionType:    PostStartAction,
-				StopOnFailure: true,
+
+		command := getLifecycleCommand(actionType, devcontainerConfig.OnCreateCommand,
+			devcontainerConfig.UpdateContentCommand, devcontainerConfig.PostCreateCommand,
+			devcontainerConfig.PostStartCommand, devcontainerConfig.PostAttachCommand)
+		if len(command.ToCommandArray()) > 0 {
+			hooks = append(hooks, &LifecycleHookStep{
+				Source:        "devcontainer.json",
+				Command:       *command,
+				ActionType:    actionType,
+				StopOnFailure: false,
 			})
 		}
-	}
-
-	if len(devcontainerConfig.PostCreateCommand.ToCommandArray()) > 0 {
-		postCreateHooks = append(postCreateHooks, &LifecycleHookStep{
-			Source:        "devcontainer.json",
-			Command:       devcontainerConfig.PostCreateCommand,
-			ActionType:    PostCreateAction,
-			StopOnFailure: false,
-		})
-	}
 
-	if len(devcontainerConfig.PostStartCommand.ToCommandArray()) > 0 {
-		postStartHooks = append(postStartHooks, &LifecycleHookStep{
-			Source:        "devcontainer.json",
-			Command:       devcontainerConfig.PostStartCommand,
-			ActionType:    PostStartAction,
-			StopOnFailure: false,
-		})
+		lifecycleHooks[actionType] = hooks
 	}
 
-	return map[PostAction][]*LifecycleHookStep{
-		PostCreateAction: postCreateHooks,
-		PostStartAction:  postStartHooks,
-	}
+	return lifecycleHooks
 }
 
 func mergeEntrypoints(
@@ -422,7 +413,10 @@ func mergeMounts(
 		allMounts = append(allMounts, parsedMount)
 	}
 
-	allMounts = append(allMounts, defaultMount)
+	// Prebuild containers don't mount the storage, so that the home directory ends up in the committed image.
+	if defaultMount.Target != "" {
+		allMounts = append(allMounts, defaultMount)
+	}
 
 	return allMounts, nil
 }
@@ -16,7 +16,9 @@ package container
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -142,6 +144,7 @@ func (e *EmbeddedDockerOrchestrator) CreateAndStartGitspace(
 			ctx,
 			gitspaceConfig,
 			dockerClient,
+			infra,
 			resolvedRepoDetails,
 			accessKey,
 			ideService,
@@ -177,6 +180,7 @@ func (e *EmbeddedDockerOrchestrator) startStoppedGitspace(
 	ctx context.Context,
 	gitspaceConfig types.GitspaceConfig,
 	dockerClient *client.Client,
+	infra types.Infrastructure,
 	resolvedRepoDetails scm.ResolvedDetails,
 	accessKey string,
 	ideService ide.IDE,
@@ -198,7 +202,7 @@ func (e *EmbeddedDockerOrchestrator) startStoppedGitspace(
 
 	homeDir := GetUserHomeDir(remoteUser)
 
-	startErr := ManageContainer(ctx, ContainerActionStart, containerName, dockerClient, logStreamInstance)
+	startErr := e.manageGitspace(ctx, ContainerActionStart, containerName, dockerClient, infra, logStreamInstance)
 	if startErr != nil {
 		return startErr
 	}
@@ -229,21 +233,24 @@ func (e *EmbeddedDockerOrchestrator) startStoppedGitspace(
 		return err
 	}
 
-	if len(lifecycleHooks) > 0 && len(lifecycleHooks[PostStartAction]) > 0 {
-		for _, lifecycleHook := range lifecycleHooks[PostStartAction] {
-			startErr = ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, logStreamInstance,
-				lifecycleHook.Command.ToCommandArray(), PostStartAction)
-			if startErr != nil {
-				log.Warn().Msgf("Error in post-start command, continuing : %s", startErr.Error())
+	for _, actionType := range restartLifecycleActions {
+		if len(lifecycleHooks) > 0 {
+			for _, lifecycleHook := range lifecycleHooks[actionType] {
+				startErr = ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, logStreamInstance,
+					lifecycleHook.Command.ToCommandArray(), actionType)
+				if startErr != nil {
+					log.Warn().Msgf("Error in %s command, continuing : %s", actionType, startErr.Error())
+				}
 			}
+			continue
 		}
-	} else {
-		// Execute post-start command for the containers before this label was introduced
+
+		// Execute the commands for the containers before the lifecycle hooks label was introduced
 		devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
-		command := ExtractLifecycleCommands(PostStartAction, devcontainerConfig)
-		startErr = ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, logStreamInstance, command, PostStartAction)
+		command := ExtractLifecycleCommands(actionType, devcontainerConfig)
+		startErr = ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, logStreamInstance, command, actionType)
 		if startErr != nil {
-			log.Warn().Msgf("Error in post-start command, continuing : %s", startErr.Error())
+			log.Warn().Msgf("Error in %s command, continuing : %s", actionType, startErr.Error())
 		}
 	}
 
@@ -283,7 +290,7 @@ func (e *EmbeddedDockerOrchestrator) StopGitspace(
 
 	case ContainerStateRunning:
 		logger.Debug().Msg("stopping gitspace")
-		if err = e.stopRunningGitspace(ctx, gitspaceConfig, containerName, dockerClient); err != nil {
+		if err = e.stopRunningGitspace(ctx, gitspaceConfig, infra, containerName, dockerClient); err != nil {
 			return err
 		}
 	case ContainerStatePaused, ContainerStateCreated, ContainerStateUnknown, ContainerStateDead:
@@ -301,6 +308,7 @@ func (e *EmbeddedDockerOrchestrator) StopGitspace(
 func (e *EmbeddedDockerOrchestrator) stopRunningGitspace(
 	ctx context.Context,
 	gitspaceConfig types.GitspaceConfig,
+	infra types.Infrastructure,
 	containerName string,
 	dockerClient *client.Client,
 ) error {
@@ -312,7 +320,7 @@ func (e *EmbeddedDockerOrchestrator) stopRunningGitspace(
 	defer e.flushLogStream(logStreamInstance, gitspaceConfig.ID)
 
 	// Step 5: Stop the container
-	return ManageContainer(ctx, ContainerActionStop, containerName, dockerClient, logStreamInstance)
+	return e.manageGitspace(ctx, ContainerActionStop, containerName, dockerClient, infra, logStreamInstance)
 }
 
 // Status is NOOP for EmbeddedDockerOrchestrator as the docker host is verified by the infra provisioner.
@@ -359,8 +367,8 @@ func (e *EmbeddedDockerOrchestrator) StopAndRemoveGitspace(
 	// Step 5: Stop the container if it's not already stopped
 	if state != ContainerStateStopped {
 		logger.Debug().Msg("stopping gitspace")
-		if err = ManageContainer(
-			ctx, ContainerActionStop, containerName, dockerClient, logStreamInstance); err != nil {
+		if err = e.manageGitspace(
+			ctx, ContainerActionStop, containerName, dockerClient, infra, logStreamInstance); err != nil {
 			return fmt.Errorf("failed to stop gitspace %s: %w", containerName, err)
 		}
 		logger.Debug().Msg("stopped gitspace")
@@ -368,8 +376,8 @@ func (e *EmbeddedDockerOrchestrator) StopAndRemoveGitspace(
 
 	// Step 6: Remove the container
 	logger.Debug().Msg("removing gitspace")
-	if err = ManageContainer(
-		ctx, ContainerActionRemove, containerName, dockerClient, logStreamInstance); err != nil {
+	if err = e.manageGitspace(
+		ctx, ContainerActionRemove, containerName, dockerClient, infra, logStreamInstance); err != nil {
 		return fmt.Errorf("failed to remove gitspace %s: %w", containerName, err)
 	}
 
@@ -384,6 +392,41 @@ func (e *EmbeddedDockerOrchestrator) StreamLogs(
 	return "", fmt.Errorf("not implemented")
 }
 
+// LastActivity runs the activity probe in the running gitspace container.
+func (e *EmbeddedDockerOrchestrator) LastActivity(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	infra types.Infrastructure,
+) (int64, error) {
+	containerName := GetGitspaceContainerName(gitspaceConfig)
+
+	dockerClient, err := e.getDockerClient(ctx, infra)
+	if err != nil {
+		return 0, err
+	}
+	defer e.closeDockerClient(dockerClient)
+
+	state, err := e.checkContainerState(ctx, dockerClient, containerName)
+	if err != nil {
+		return 0, err
+	}
+	if state != ContainerStateRunning {
+		return 0, fmt.Errorf("gitspace %s is not running, current state: %s", containerName, state)
+	}
+
+	ports := make([]int, 0, len(infra.GitspacePortMappings))
+	for _, mapping := range infra.GitspacePortMappings {
+		ports = append(ports, mapping.PublishedPort)
+	}
+
+	exec := &devcontainer.Exec{
+		ContainerName: containerName,
+		DockerClient:  dockerClient,
+	}
+
+	return utils.GetLastActivity(ctx, exec, ports)
+}
+
 // getAccessKey retrieves the access key from the Gitspace config, returns an error if not found.
 func (e *EmbeddedDockerOrchestrator) getAccessKey(gitspaceConfig types.GitspaceConfig) (string, error) {
 	if gitspaceConfig.GitspaceInstance != nil && gitspaceConfig.GitspaceInstance.AccessKey != nil {
@@ -406,6 +449,11 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 	containerName := GetGitspaceContainerName(gitspaceConfig)
 
 	devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
+	if devcontainerConfig.IsComposeBased() {
+		return e.runComposeSetupSteps(ctx, gitspaceConfig, dockerClient, ideService, infrastructure,
+			resolvedRepoDetails, defaultBaseImage, gitspaceLogger, imageAuthMap)
+	}
+
 	imageName := getImage(devcontainerConfig, defaultBaseImage)
 
 	runArgsMap, err := ExtractRunArgsWithLogging(ctx, gitspaceConfig.SpaceID, e.runArgProvider,
@@ -414,9 +462,23 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 		return err
 	}
 
-	// Pull the required image
-	if err = PullImage(ctx, imageName, dockerClient, runArgsMap, gitspaceLogger, imageAuthMap); err != nil {
-		return err
+	isPrebuilt := gitspaceConfig.PrebuiltImage != ""
+	switch {
+	case isPrebuilt:
+		// The prebuilt image already contains the code, the features and the results of the prebuild hooks
+		imageName = gitspaceConfig.PrebuiltImage
+		gitspaceLogger.Info("Using prebuilt image " + imageName)
+	case devcontainerConfig.IsDockerfileBased():
+		// Build the image from the Dockerfile in the repository
+		imageName, err = buildImageFromSource(ctx, containerName, dockerClient, resolvedRepoDetails, gitspaceLogger)
+		if err != nil {
+			return err
+		}
+	default:
+		// Pull the required image
+		if err = PullImage(ctx, imageName, dockerClient, runArgsMap, gitspaceLogger, imageAuthMap); err != nil {
+			return err
+		}
 	}
 
 	metadataFromImage, imageUser, err := ExtractMetadataAndUserFromImage(ctx, imageName, dockerClient)
@@ -424,17 +486,7 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 		return err
 	}
 
-	portMappings := infrastructure.GitspacePortMappings
-	forwardPorts := ExtractForwardPorts(devcontainerConfig)
-	if len(forwardPorts) > 0 {
-		for _, port := range forwardPorts {
-			portMappings[port] = &types.PortMapping{
-				PublishedPort: port,
-				ForwardedPort: port,
-			}
-		}
-		gitspaceLogger.Info(fmt.Sprintf("Forwarding ports : %v", forwardPorts))
-	}
+	portMappings := addForwardPorts(infrastructure.GitspacePortMappings, devcontainerConfig, gitspaceLogger)
 
 	storage := infrastructure.Storage
 	environment := ExtractEnv(devcontainerConfig, runArgsMap)
@@ -451,7 +503,12 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 	gitspaceLogger.Info(fmt.Sprintf("Container user: %s", containerUser))
 	gitspaceLogger.Info(fmt.Sprintf("Remote user: %s", remoteUser))
 	var features []*types.ResolvedFeature
-	if devcontainerConfig.Features != nil && len(*devcontainerConfig.Features) > 0 {
+	if isPrebuilt {
+		features, err = getPrebuiltFeatures(ctx, imageName, dockerClient)
+		if err != nil {
+			return err
+		}
+	} else if devcontainerConfig.Features != nil && len(*devcontainerConfig.Features) > 0 {
 		sortedFeatures, newImageName, err := InstallFeatures(ctx, gitspaceConfig.GitspaceInstance.Identifier,
 			dockerClient, *devcontainerConfig.Features, devcontainerConfig.OverrideFeatureInstallOrder, imageName,
 			containerUser, remoteUser, containerUserHomeDir, remoteUserHomeDir, gitspaceLogger)
@@ -492,6 +549,11 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 		return err
 	}
 
+	setupHookSteps := lifecycleHookSteps
+	if isPrebuilt {
+		setupHookSteps = withoutPrebuildHooks(lifecycleHookSteps)
+	}
+
 	// Setup and run commands
 	exec := &devcontainer.Exec{
 		ContainerName:     containerName,
@@ -502,6 +564,129 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 		AccessType:        gitspaceConfig.GitspaceInstance.AccessType,
 	}
 
+	if err = e.setupGitspaceAndIDE(
+		ctx,
+		exec,
+		gitspaceLogger,
+		ideService,
+		gitspaceConfig,
+		resolvedRepoDetails,
+		defaultBaseImage,
+		environment,
+		setupHookSteps,
+	); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while setting up gitspace", err)
+	}
+
+	return nil
+}
+
+// runComposeSetupSteps brings up the docker compose project of the devcontainer and sets up the gitspace
+// in the service the IDE attaches to.
+func (e *EmbeddedDockerOrchestrator) runComposeSetupSteps(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	dockerClient *client.Client,
+	ideService ide.IDE,
+	infrastructure types.Infrastructure,
+	resolvedRepoDetails scm.ResolvedDetails,
+	defaultBaseImage string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+	imageAuthMap map[string]gitspaceTypes.DockerRegistryAuth,
+) error {
+	containerName := GetGitspaceContainerName(gitspaceConfig)
+	devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
+
+	if devcontainerConfig.Service == "" {
+		return logStreamWrapError(gitspaceLogger, "Error while reading devcontainer.json",
+			fmt.Errorf("service is required when dockerComposeFile is set"))
+	}
+
+	// The checkout is kept while the project exists as the compose files can bind mount files from it.
+	sourceDir := utils.GetGitspaceSourceDirectory(containerName)
+	if err := utils.CloneSource(ctx, resolvedRepoDetails, sourceDir, gitspaceLogger); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while cloning repository", err)
+	}
+
+	project, err := e.getComposeProject(infrastructure, containerName, sourceDir, devcontainerConfig)
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while resolving docker compose project", err)
+	}
+
+	service, err := project.service(ctx, gitspaceLogger, devcontainerConfig.Service)
+	if err != nil {
+		return err
+	}
+
+	imageName := project.imageName(devcontainerConfig.Service, service)
+	if len(service.Build) > 0 {
+		if _, err = project.run(ctx, gitspaceLogger, "build", devcontainerConfig.Service); err != nil {
+			return err
+		}
+	} else if err = PullImage(ctx, imageName, dockerClient, nil, gitspaceLogger, imageAuthMap); err != nil {
+		return err
+	}
+
+	metadataFromImage, imageUser, err := ExtractMetadataAndUserFromImage(ctx, imageName, dockerClient)
+	if err != nil {
+		return err
+	}
+	if service.User != "" {
+		imageUser = service.User
+	}
+
+	portMappings := addForwardPorts(infrastructure.GitspacePortMappings, devcontainerConfig, gitspaceLogger)
+
+	environment := ExtractEnv(devcontainerConfig, nil)
+	if len(environment) > 0 {
+		gitspaceLogger.Info(fmt.Sprintf("Setting Environment : %v", environment))
+	}
+
+	containerUser := GetContainerUser(nil, devcontainerConfig, metadataFromImage, imageUser)
+	remoteUser := GetRemoteUser(devcontainerConfig, metadataFromImage, containerUser)
+	remoteUserHomeDir := GetUserHomeDir(remoteUser)
+
+	gitspaceLogger.Info(fmt.Sprintf("Container user: %s", containerUser))
+	gitspaceLogger.Info(fmt.Sprintf("Remote user: %s", remoteUser))
+
+	if devcontainerConfig.Features != nil && len(*devcontainerConfig.Features) > 0 {
+		gitspaceLogger.Warn("Features are not supported for docker compose based devcontainers, skipping")
+	}
+
+	lifecycleHookSteps := mergeLifeCycleHooks(devcontainerConfig, nil)
+	lifecycleHookStepsStr, err := json.Marshal(lifecycleHookSteps)
+	if err != nil {
+		return err
+	}
+
+	override, err := generateComposeOverride(devcontainerConfig.Service, containerName, infrastructure.Storage,
+		remoteUserHomeDir, portMappings, environment, map[string]string{
+			gitspaceRemoteUserLabel:     remoteUser,
+			gitspaceLifeCycleHooksLabel: string(lifecycleHookStepsStr),
+		})
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while generating docker compose override", err)
+	}
+	overridePath := filepath.Join(sourceDir, composeOverrideFile)
+	if err = os.WriteFile(overridePath, override, 0600); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while writing docker compose override", err)
+	}
+	project.Files = append(project.Files, overridePath)
+
+	upArgs := append([]string{"up", "--detach"}, upServices(devcontainerConfig)...)
+	if _, err = project.run(ctx, gitspaceLogger, upArgs...); err != nil {
+		return err
+	}
+
+	exec := &devcontainer.Exec{
+		ContainerName:     containerName,
+		DockerClient:      dockerClient,
+		DefaultWorkingDir: remoteUserHomeDir,
+		RemoteUser:        remoteUser,
+		AccessKey:         *gitspaceConfig.GitspaceInstance.AccessKey,
+		AccessType:        gitspaceConfig.GitspaceInstance.AccessType,
+	}
+
 	if err = e.setupGitspaceAndIDE(
 		ctx,
 		exec,
@@ -519,6 +704,131 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 	return nil
 }
 
+// getComposeProject returns the compose project of the gitspace with the docker CLI configured for the infra.
+// If sourceDir is empty, the project can only be used for commands operating on existing containers.
+func (e *EmbeddedDockerOrchestrator) getComposeProject(
+	infra types.Infrastructure,
+	containerName string,
+	sourceDir string,
+	devcontainerConfig types.DevcontainerConfig,
+) (*composeProject, error) {
+	env, err := e.dockerClientFactory.CLIEnv(infra)
+	if err != nil {
+		return nil, err
+	}
+	if sourceDir == "" {
+		return &composeProject{Name: getComposeProjectName(containerName), Env: env}, nil
+	}
+	return newComposeProject(containerName, sourceDir, devcontainerConfig, env)
+}
+
+// isComposeContainer checks whether the gitspace container was created by docker compose.
+func (e *EmbeddedDockerOrchestrator) isComposeContainer(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+) (bool, error) {
+	inspectResp, err := dockerClient.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return false, fmt.Errorf("could not inspect container %s: %w", containerName, err)
+	}
+	_, ok := inspectResp.Config.Labels[composeProjectLabel]
+	return ok, nil
+}
+
+// manageGitspace runs the action on the gitspace container, or on the whole compose project
+// if the gitspace is docker compose based.
+func (e *EmbeddedDockerOrchestrator) manageGitspace(
+	ctx context.Context,
+	action Action,
+	containerName string,
+	dockerClient *client.Client,
+	infra types.Infrastructure,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	isCompose, err := e.isComposeContainer(ctx, dockerClient, containerName)
+	if err != nil {
+		return err
+	}
+	if !isCompose {
+		return ManageContainer(ctx, action, containerName, dockerClient, gitspaceLogger)
+	}
+
+	project, err := e.getComposeProject(infra, containerName, "", types.DevcontainerConfig{})
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case ContainerActionStop:
+		_, err = project.run(ctx, gitspaceLogger, "stop")
+	case ContainerActionStart:
+		_, err = project.run(ctx, gitspaceLogger, "start")
+	case ContainerActionRemove:
+		_, err = project.run(ctx, gitspaceLogger, "down", "--remove-orphans")
+		if err == nil {
+			sourceDir := utils.GetGitspaceSourceDirectory(containerName)
+			if removeErr := os.RemoveAll(sourceDir); removeErr != nil {
+				log.Ctx(ctx).Warn().Err(removeErr).Msgf("failed to remove source directory %s", sourceDir)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown action: %s", action)
+	}
+	if err != nil {
+		return err
+	}
+
+	gitspaceLogger.Info(fmt.Sprintf("Successfully ran %s on docker compose project %s", action, project.Name))
+	return nil
+}
+
+// buildImageFromSource checks out the repository and builds the devcontainer image from its Dockerfile.
+func buildImageFromSource(
+	ctx context.Context,
+	containerName string,
+	dockerClient *client.Client,
+	resolvedRepoDetails scm.ResolvedDetails,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) (string, error) {
+	sourceDir := utils.GetGitspaceSourceDirectory(containerName)
+	defer func() {
+		if err := os.RemoveAll(sourceDir); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove source directory %s", sourceDir)
+		}
+	}()
+
+	if err := utils.CloneSource(ctx, resolvedRepoDetails, sourceDir, gitspaceLogger); err != nil {
+		return "", logStreamWrapError(gitspaceLogger, "Error while cloning repository", err)
+	}
+
+	imageName, err := utils.BuildFromDockerfile(ctx, dockerClient, sourceDir,
+		resolvedRepoDetails.DevcontainerConfig.Build, gitspaceLogger)
+	if err != nil {
+		return "", logStreamWrapError(gitspaceLogger, "Error while building image from Dockerfile", err)
+	}
+	return imageName, nil
+}
+
+// addForwardPorts adds the forwardPorts of the devcontainer.json to the port mappings of the infra.
+func addForwardPorts(
+	portMappings map[int]*types.PortMapping,
+	devcontainerConfig types.DevcontainerConfig,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) map[int]*types.PortMapping {
+	forwardPorts := ExtractForwardPorts(devcontainerConfig)
+	if len(forwardPorts) > 0 {
+		for _, port := range forwardPorts {
+			portMappings[port] = &types.PortMapping{
+				PublishedPort: port,
+				ForwardedPort: port,
+			}
+		}
+		gitspaceLogger.Info(fmt.Sprintf("Forwarding ports : %v", forwardPorts))
+	}
+	return portMappings
+}
+
 func InstallFeatures(
 	ctx context.Context,
 	gitspaceInstanceIdentifier string,
@@ -674,36 +984,23 @@ func (e *EmbeddedDockerOrchestrator) buildSetupSteps(
 			StopOnFailure: true,
 		}}
 
-	// Add the postCreateCommand lifecycle hooks to the steps
-	for _, lifecycleHook := range lifecycleHookSteps[PostCreateAction] {
-		steps = append(steps, step{
-			Name: fmt.Sprintf("Execute postCreateCommand from %s", lifecycleHook.Source),
-			Execute: func(
-				ctx context.Context,
-				exec *devcontainer.Exec,
-				gitspaceLogger gitspaceTypes.GitspaceLogger,
-			) error {
-				return ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, gitspaceLogger,
-					lifecycleHook.Command.ToCommandArray(), PostCreateAction)
-			},
-			StopOnFailure: lifecycleHook.StopOnFailure,
-		})
-	}
-
-	// Add the postStartCommand lifecycle hooks to the steps
-	for _, lifecycleHook := range lifecycleHookSteps[PostStartAction] {
-		steps = append(steps, step{
-			Name: fmt.Sprintf("Execute postStartCommand from %s", lifecycleHook.Source),
-			Execute: func(
-				ctx context.Context,
-				exec *devcontainer.Exec,
-				gitspaceLogger gitspaceTypes.GitspaceLogger,
-			) error {
-				return ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, gitspaceLogger,
-					lifecycleHook.Command.ToCommandArray(), PostStartAction)
-			},
-			StopOnFailure: lifecycleHook.StopOnFailure,
-		})
+	// Add the lifecycle hooks to the steps in the order defined by the spec
+	for _, actionType := range createLifecycleActions {
+		for _, lifecycleHook := range lifecycleHookSteps[actionType] {
+			steps = append(steps, step{
+				Name: fmt.Sprintf("Execute %s from %s",
+					lifecycleCommandProperties[actionType], lifecycleHook.Source),
+				Execute: func(
+					ctx context.Context,
+					exec *devcontainer.Exec,
+					gitspaceLogger gitspaceTypes.GitspaceLogger,
+				) error {
+					return ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, gitspaceLogger,
+						lifecycleHook.Command.ToCommandArray(), actionType)
+				},
+				StopOnFailure: lifecycleHook.StopOnFailure,
+			})
+		}
 	}
 
 	return steps
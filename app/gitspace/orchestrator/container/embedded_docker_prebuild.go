@@ -0,0 +1,312 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/harness/gitness/app/gitspace/orchestrator/devcontainer"
+	"github.com/harness/gitness/app/gitspace/orchestrator/utils"
+	"github.com/harness/gitness/app/gitspace/scm"
+	gitspaceTypes "github.com/harness/gitness/app/gitspace/types"
+	"github.com/harness/gitness/types"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/rs/zerolog/log"
+)
+
+// prebuildLifecycleActions are the lifecycle hooks executed while prebuilding, as per the spec
+// the remaining hooks are executed when a gitspace is started from the prebuilt image.
+var prebuildLifecycleActions = []PostAction{
+	OnCreateAction,
+	UpdateContentAction,
+}
+
+// CreatePrebuildImage sets up a temporary container without the gitspace storage, so that the cloned code and
+// everything installed into the home directory is part of the committed image. When a gitspace is started from
+// the image, docker copies the home directory into its empty storage volume.
+func (e *EmbeddedDockerOrchestrator) CreatePrebuildImage(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	infra types.Infrastructure,
+	resolvedRepoDetails scm.ResolvedDetails,
+	defaultBaseImage string,
+	prebuildImageName string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	containerName := GetGitspaceContainerName(gitspaceConfig)
+	devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
+	if devcontainerConfig.IsComposeBased() {
+		return fmt.Errorf("prebuilds are not supported for docker compose based devcontainers")
+	}
+
+	dockerClient, err := e.getDockerClient(ctx, infra)
+	if err != nil {
+		return err
+	}
+	defer e.closeDockerClient(dockerClient)
+
+	// Remove a container left behind by an interrupted prebuild.
+	if err = e.removePrebuildContainer(ctx, dockerClient, containerName); err != nil {
+		return err
+	}
+	defer func() {
+		if removeErr := e.removePrebuildContainer(ctx, dockerClient, containerName); removeErr != nil {
+			log.Ctx(ctx).Warn().Err(removeErr).Msgf("failed to remove prebuild container %s", containerName)
+		}
+	}()
+
+	imageName := getImage(devcontainerConfig, defaultBaseImage)
+
+	runArgsMap, err := ExtractRunArgsWithLogging(ctx, gitspaceConfig.SpaceID, e.runArgProvider,
+		devcontainerConfig.RunArgs, gitspaceLogger)
+	if err != nil {
+		return err
+	}
+
+	if devcontainerConfig.IsDockerfileBased() {
+		imageName, err = buildImageFromSource(ctx, containerName, dockerClient, resolvedRepoDetails, gitspaceLogger)
+		if err != nil {
+			return err
+		}
+	} else if err = PullImage(ctx, imageName, dockerClient, runArgsMap, gitspaceLogger, nil); err != nil {
+		return err
+	}
+
+	metadataFromImage, imageUser, err := ExtractMetadataAndUserFromImage(ctx, imageName, dockerClient)
+	if err != nil {
+		return err
+	}
+
+	environment := ExtractEnv(devcontainerConfig, runArgsMap)
+	containerUser := GetContainerUser(runArgsMap, devcontainerConfig, metadataFromImage, imageUser)
+	remoteUser := GetRemoteUser(devcontainerConfig, metadataFromImage, containerUser)
+	containerUserHomeDir := GetUserHomeDir(containerUser)
+	remoteUserHomeDir := GetUserHomeDir(remoteUser)
+
+	var features []*types.ResolvedFeature
+	if devcontainerConfig.Features != nil && len(*devcontainerConfig.Features) > 0 {
+		features, imageName, err = InstallFeatures(ctx, gitspaceConfig.Identifier, dockerClient,
+			*devcontainerConfig.Features, devcontainerConfig.OverrideFeatureInstallOrder, imageName,
+			containerUser, remoteUser, containerUserHomeDir, remoteUserHomeDir, gitspaceLogger)
+		if err != nil {
+			return err
+		}
+	}
+
+	lifecycleHookSteps, err := CreateContainer(ctx, dockerClient, imageName, containerName, gitspaceLogger,
+		"", "", mount.TypeVolume, nil, environment, runArgsMap, containerUser, remoteUser, features,
+		devcontainerConfig, metadataFromImage)
+	if err != nil {
+		return err
+	}
+
+	if err = ManageContainer(ctx, ContainerActionStart, containerName, dockerClient, gitspaceLogger); err != nil {
+		return err
+	}
+
+	exec := &devcontainer.Exec{
+		ContainerName:     containerName,
+		DockerClient:      dockerClient,
+		DefaultWorkingDir: remoteUserHomeDir,
+		RemoteUser:        remoteUser,
+	}
+	steps := buildPrebuildSteps(resolvedRepoDetails, defaultBaseImage, environment,
+		remoteUserHomeDir, lifecycleHookSteps)
+	if err = e.ExecuteSteps(ctx, exec, gitspaceLogger, steps); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while prebuilding gitspace", err)
+	}
+
+	if err = ManageContainer(ctx, ContainerActionStop, containerName, dockerClient, gitspaceLogger); err != nil {
+		return err
+	}
+
+	featuresStr, err := json.Marshal(features)
+	if err != nil {
+		return fmt.Errorf("failed to marshal features: %w", err)
+	}
+
+	gitspaceLogger.Info("Committing prebuild image " + prebuildImageName)
+	_, err = dockerClient.ContainerCommit(ctx, containerName, container.CommitOptions{
+		Reference: prebuildImageName,
+		Comment:   "gitspace prebuild of " + resolvedRepoDetails.RepoName + "@" + resolvedRepoDetails.Branch,
+		Config: &container.Config{
+			Labels: map[string]string{gitspacePrebuildFeaturesLabel: string(featuresStr)},
+		},
+	})
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while committing prebuild image", err)
+	}
+
+	gitspaceLogger.Info("Successfully created prebuild image " + prebuildImageName)
+	return nil
+}
+
+// RemoveImage removes the image from the docker host, a missing image isn't an error.
+func (e *EmbeddedDockerOrchestrator) RemoveImage(
+	ctx context.Context,
+	infra types.Infrastructure,
+	imageName string,
+) error {
+	dockerClient, err := e.getDockerClient(ctx, infra)
+	if err != nil {
+		return err
+	}
+	defer e.closeDockerClient(dockerClient)
+
+	_, err = dockerClient.ImageRemove(ctx, imageName, image.RemoveOptions{PruneChildren: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("failed to remove image %s: %w", imageName, err)
+	}
+	return nil
+}
+
+func (e *EmbeddedDockerOrchestrator) removePrebuildContainer(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+) error {
+	err := dockerClient.ContainerRemove(ctx, containerName, container.RemoveOptions{Force: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("failed to remove prebuild container %s: %w", containerName, err)
+	}
+	return nil
+}
+
+// buildPrebuildSteps constructs the steps of a prebuild. Unlike a gitspace setup, the IDE isn't set up and
+// the git user isn't configured, as the image is shared by the gitspaces of all users. The git credentials
+// are only cached in memory, so they aren't part of the committed image.
+func buildPrebuildSteps(
+	resolvedRepoDetails scm.ResolvedDetails,
+	defaultBaseImage string,
+	environment []string,
+	homeDir string,
+	lifecycleHookSteps map[PostAction][]*LifecycleHookStep,
+) []step {
+	codeRepoDir := filepath.Join(homeDir, resolvedRepoDetails.RepoName)
+
+	cloneDetails := resolvedRepoDetails
+	cloneDetails.Credentials = nil
+
+	steps := []step{
+		{
+			Name:          "Validate Supported OS",
+			Execute:       utils.ValidateSupportedOS,
+			StopOnFailure: true,
+		},
+		{
+			Name:          "Manage User",
+			Execute:       utils.ManageUser,
+			StopOnFailure: true,
+		},
+		{
+			Name: "Set environment",
+			Execute: func(
+				ctx context.Context,
+				exec *devcontainer.Exec,
+				gitspaceLogger gitspaceTypes.GitspaceLogger,
+			) error {
+				return utils.SetEnv(ctx, exec, gitspaceLogger, environment)
+			},
+			StopOnFailure: true,
+		},
+		{
+			Name:          "Install Git",
+			Execute:       utils.InstallGit,
+			StopOnFailure: true,
+		},
+		{
+			Name: "Setup Git Credentials",
+			Execute: func(
+				ctx context.Context,
+				exec *devcontainer.Exec,
+				gitspaceLogger gitspaceTypes.GitspaceLogger,
+			) error {
+				return utils.SetupGitCredentials(ctx, exec, resolvedRepoDetails, gitspaceLogger)
+			},
+			StopOnFailure: true,
+		},
+		{
+			Name: "Clone Code",
+			Execute: func(
+				ctx context.Context,
+				exec *devcontainer.Exec,
+				gitspaceLogger gitspaceTypes.GitspaceLogger,
+			) error {
+				return utils.CloneCode(ctx, exec, cloneDetails, defaultBaseImage, gitspaceLogger)
+			},
+			StopOnFailure: true,
+		},
+	}
+
+	for _, actionType := range prebuildLifecycleActions {
+		for _, lifecycleHook := range lifecycleHookSteps[actionType] {
+			steps = append(steps, step{
+				Name: fmt.Sprintf("Execute %s from %s",
+					lifecycleCommandProperties[actionType], lifecycleHook.Source),
+				Execute: func(
+					ctx context.Context,
+					exec *devcontainer.Exec,
+					gitspaceLogger gitspaceTypes.GitspaceLogger,
+				) error {
+					return ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, gitspaceLogger,
+						lifecycleHook.Command.ToCommandArray(), actionType)
+				},
+				// Failing commands would make every gitspace started from the image broken.
+				StopOnFailure: true,
+			})
+		}
+	}
+
+	return steps
+}
+
+// withoutPrebuildHooks returns the lifecycle hooks without the ones already executed while prebuilding.
+func withoutPrebuildHooks(lifecycleHookSteps map[PostAction][]*LifecycleHookStep) map[PostAction][]*LifecycleHookStep {
+	hooks := make(map[PostAction][]*LifecycleHookStep, len(lifecycleHookSteps))
+	for actionType, steps := range lifecycleHookSteps {
+		if !slices.Contains(prebuildLifecycleActions, actionType) {
+			hooks[actionType] = steps
+		}
+	}
+	return hooks
+}
+
+// getPrebuiltFeatures returns the features installed in a prebuilt image.
+func getPrebuiltFeatures(
+	ctx context.Context,
+	imageName string,
+	dockerClient *client.Client,
+) ([]*types.ResolvedFeature, error) {
+	imageInspect, _, err := dockerClient.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return nil, fmt.Errorf("error while inspecting image: %w", err)
+	}
+
+	var features []*types.ResolvedFeature
+	if featuresStr, ok := imageInspect.Config.Labels[gitspacePrebuildFeaturesLabel]; ok {
+		if err = json.Unmarshal([]byte(featuresStr), &features); err != nil {
+			return nil, fmt.Errorf("error while unmarshalling prebuild features: %w", err)
+		}
+	}
+	return features, nil
+}
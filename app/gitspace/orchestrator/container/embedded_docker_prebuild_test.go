@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithoutPrebuildHooks(t *testing.T) {
+	step := &LifecycleHookStep{}
+	hooks := map[PostAction][]*LifecycleHookStep{
+		OnCreateAction:      {step},
+		UpdateContentAction: {step},
+		PostCreateAction:    {step},
+		PostStartAction:     {step},
+	}
+
+	got := withoutPrebuildHooks(hooks)
+
+	assert.Equal(t, map[PostAction][]*LifecycleHookStep{
+		PostCreateAction: {step},
+		PostStartAction:  {step},
+	}, got)
+	assert.Len(t, hooks, 4)
+}
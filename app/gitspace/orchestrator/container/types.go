@@ -25,10 +25,38 @@ type StartResponse struct {
 type PostAction string
 
 const (
-	PostCreateAction PostAction = "post-create"
-	PostStartAction  PostAction = "post-start"
+	OnCreateAction      PostAction = "on-create"
+	UpdateContentAction PostAction = "update-content"
+	PostCreateAction    PostAction = "post-create"
+	PostStartAction     PostAction = "post-start"
+	PostAttachAction    PostAction = "post-attach"
 )
 
+// createLifecycleActions lists the lifecycle hooks run on a newly created container, in the order
+// defined by the devcontainer spec.
+var createLifecycleActions = []PostAction{
+	OnCreateAction,
+	UpdateContentAction,
+	PostCreateAction,
+	PostStartAction,
+	PostAttachAction,
+}
+
+// lifecycleCommandProperties maps the lifecycle hooks to their devcontainer.json property names.
+var lifecycleCommandProperties = map[PostAction]string{
+	OnCreateAction:      "onCreateCommand",
+	UpdateContentAction: "updateContentCommand",
+	PostCreateAction:    "postCreateCommand",
+	PostStartAction:     "postStartCommand",
+	PostAttachAction:    "postAttachCommand",
+}
+
+// restartLifecycleActions lists the lifecycle hooks run every time a stopped container is started again.
+var restartLifecycleActions = []PostAction{
+	PostStartAction,
+	PostAttachAction,
+}
+
 type State string
 
 const (
@@ -33,6 +33,8 @@ const (
 	deprecatedRemoteUser        = "harness"
 	gitspaceRemoteUserLabel     = "gitspace.remote.user"
 	gitspaceLifeCycleHooksLabel = "gitspace.lifecycle.hooks"
+
+	gitspacePrebuildFeaturesLabel = "gitspace.prebuild.features"
 )
 
 func GetGitspaceContainerName(config types.GitspaceConfig) string {
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gitspaceTypes "github.com/harness/gitness/app/gitspace/types"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// prebuildInfra is the infra prebuilds are created on, only the embedded docker is supported.
+var prebuildInfra = types.Infrastructure{ProviderType: enum.InfraProviderTypeDocker}
+
+// CreatePrebuildImage resolves the devcontainer config of the branch of the gitspace config
+// and creates the prebuild image from it.
+func (o Orchestrator) CreatePrebuildImage(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	imageName string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	scmResolvedDetails, err := o.scm.GetSCMRepoDetails(ctx, gitspaceConfig)
+	if err != nil {
+		return fmt.Errorf("failed to fetch code repo details: %w", err)
+	}
+
+	// NOTE: Prebuilds use the same static gitspace user as the gitspaces started from them.
+	gitspaceConfig.GitspaceUser.Identifier = harnessUser
+
+	return o.containerOrchestrator.CreatePrebuildImage(ctx, gitspaceConfig, prebuildInfra, *scmResolvedDetails,
+		o.config.DefaultBaseImage, imageName, gitspaceLogger)
+}
+
+// RemovePrebuildImage removes a prebuild image which is no longer used.
+func (o Orchestrator) RemovePrebuildImage(ctx context.Context, imageName string) error {
+	return o.containerOrchestrator.RemoveImage(ctx, prebuildInfra, imageName)
+}
+
+// findPrebuiltImage returns the image of the ready prebuild of the gitspace's branch,
+// or an empty string if the gitspace has to be set up from scratch.
+func (o Orchestrator) findPrebuiltImage(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	infra types.Infrastructure,
+) string {
+	if infra.ProviderType != prebuildInfra.ProviderType ||
+		gitspaceConfig.CodeRepo.Type != enum.CodeRepoTypeGitness ||
+		gitspaceConfig.CodeRepo.Ref == nil {
+		return ""
+	}
+
+	repo, err := o.repoFinder.FindByRef(ctx, *gitspaceConfig.CodeRepo.Ref)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find repository of gitspace to look up prebuild")
+		return ""
+	}
+
+	prebuild, err := o.prebuildStore.FindByBranch(ctx, repo.ID, gitspaceConfig.CodeRepo.Branch)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return ""
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find prebuild of gitspace branch")
+		return ""
+	}
+
+	if prebuild.Disabled || prebuild.State != enum.GitspacePrebuildStateReady || prebuild.Image == "" {
+		return ""
+	}
+
+	return prebuild.Image
+}
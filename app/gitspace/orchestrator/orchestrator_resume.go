@@ -153,6 +153,8 @@ func (o Orchestrator) ResumeStartGitspace(
 	// NOTE: Currently we use a static identifier as the Gitspace user.
 	gitspaceConfig.GitspaceUser.Identifier = harnessUser
 
+	gitspaceConfig.PrebuiltImage = o.findPrebuiltImage(ctx, gitspaceConfig, provisionedInfra)
+
 	startResponse, err := o.containerOrchestrator.CreateAndStartGitspace(
 		ctx, gitspaceConfig, provisionedInfra, *scmResolvedDetails, o.config.DefaultBaseImage, ideSvc)
 	if err != nil {
@@ -26,6 +26,8 @@ import (
 	"github.com/harness/gitness/app/gitspace/platformconnector"
 	"github.com/harness/gitness/app/gitspace/scm"
 	"github.com/harness/gitness/app/gitspace/secret"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -49,6 +51,8 @@ type Orchestrator struct {
 	config                *Config
 	ideFactory            ide.Factory
 	secretResolverFactory *secret.ResolverFactory
+	prebuildStore         store.GitspacePrebuildStore
+	repoFinder            refcache.RepoFinder
 }
 
 func NewOrchestrator(
@@ -60,6 +64,8 @@ func NewOrchestrator(
 	config *Config,
 	ideFactory ide.Factory,
 	secretResolverFactory *secret.ResolverFactory,
+	prebuildStore store.GitspacePrebuildStore,
+	repoFinder refcache.RepoFinder,
 ) Orchestrator {
 	return Orchestrator{
 		scm:                   scm,
@@ -70,6 +76,8 @@ func NewOrchestrator(
 		config:                config,
 		ideFactory:            ideFactory,
 		secretResolverFactory: secretResolverFactory,
+		prebuildStore:         prebuildStore,
+		repoFinder:            repoFinder,
 	}
 }
 
@@ -389,6 +397,25 @@ func (o Orchestrator) GetGitspaceLogs(ctx context.Context, gitspaceConfig types.
 	return logs, nil
 }
 
+// GetLastActivity fetches the time (in unix milliseconds) of the most recent user activity in the gitspace.
+func (o Orchestrator) GetLastActivity(ctx context.Context, gitspaceConfig types.GitspaceConfig) (int64, error) {
+	infra, err := o.getProvisionedInfra(ctx, gitspaceConfig, []enum.InfraStatus{enum.InfraStatusProvisioned})
+	if err != nil {
+		return 0, fmt.Errorf(
+			"unable to find provisioned infra while fetching activity for gitspace instance %s: %w",
+			gitspaceConfig.GitspaceInstance.Identifier, err)
+	}
+
+	// NOTE: Currently we use a static identifier as the Gitspace user.
+	gitspaceConfig.GitspaceUser.Identifier = harnessUser
+	lastActivity, err := o.containerOrchestrator.LastActivity(ctx, gitspaceConfig, *infra)
+	if err != nil {
+		return 0, fmt.Errorf("error while fetching activity from container orchestrator: %w", err)
+	}
+
+	return lastActivity, nil
+}
+
 func (o Orchestrator) getProvisionedInfra(
 	ctx context.Context,
 	gitspaceConfig types.GitspaceConfig,
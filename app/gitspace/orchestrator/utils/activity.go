@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/gitspace/orchestrator/devcontainer"
+	"github.com/harness/gitness/app/gitspace/types"
+)
+
+const templateReportActivity = "report_activity.sh"
+
+// GetLastActivity probes the gitspace container for IDE connections, ssh sessions and recently
+// executed commands, and returns the time (in unix milliseconds) of the most recent user activity.
+// It returns 0 if no activity has been recorded yet.
+func GetLastActivity(
+	ctx context.Context,
+	exec *devcontainer.Exec,
+	ports []int,
+) (int64, error) {
+	script, err := GenerateScriptFromTemplate(
+		templateReportActivity, &types.ReportActivityPayload{
+			Ports: ports,
+		})
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to generate script to report activity from template %s: %w", templateReportActivity, err)
+	}
+
+	output, err := exec.ExecuteCommand(ctx, script, true, "/")
+	if err != nil {
+		return 0, fmt.Errorf("failed to report activity: %w", err)
+	}
+
+	return parseLastActivity(output)
+}
+
+func parseLastActivity(output string) (int64, error) {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output of activity report %q: %w", output, err)
+	}
+
+	if seconds <= 0 {
+		return 0, nil
+	}
+
+	return seconds * 1000, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/app/gitspace/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLastActivity(t *testing.T) {
+	lastActivity, err := parseLastActivity("1700000000\n")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000000), lastActivity)
+
+	lastActivity, err = parseLastActivity("0\n")
+	require.NoError(t, err)
+	assert.Zero(t, lastActivity)
+
+	_, err = parseLastActivity("sh: stat: not found\n")
+	assert.Error(t, err)
+}
+
+func TestReportActivityTemplate(t *testing.T) {
+	script, err := GenerateScriptFromTemplate(templateReportActivity, &types.ReportActivityPayload{Ports: []int{8089, 22}})
+	require.NoError(t, err)
+	assert.Contains(t, script, `PORTS="8089 22 "`)
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/gitspace/scm"
+	gitspaceTypes "github.com/harness/gitness/app/gitspace/types"
+	"github.com/harness/gitness/types"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	devcontainerConfigDir = ".devcontainer"
+	builtImageRepository  = "gitspace-build"
+)
+
+// GetGitspaceSourceDirectory returns the directory on the docker host used to check out the repository
+// when the devcontainer needs files from it (Dockerfile builds and compose projects).
+func GetGitspaceSourceDirectory(containerName string) string {
+	return filepath.Join("/tmp", containerName+"-source")
+}
+
+// CloneSource does a shallow clone of the configured branch into the provided directory.
+// An existing checkout in the directory is replaced.
+func CloneSource(
+	ctx context.Context,
+	resolvedRepoDetails scm.ResolvedDetails,
+	dir string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clean source directory %s: %w", dir, err)
+	}
+
+	gitspaceLogger.Info("Cloning " + resolvedRepoDetails.RepoName + " to build the devcontainer")
+
+	args := []string{"clone", "--depth", "1", "--single-branch"}
+	if resolvedRepoDetails.Branch != "" {
+		args = append(args, "--branch", resolvedRepoDetails.Branch)
+	}
+	args = append(args, resolvedRepoDetails.CloneURL.Value(), dir)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		// stderr might contain the clone url with credentials, so it's only logged on the server.
+		log.Ctx(ctx).Warn().Err(err).Msgf("git clone failed: %s", errBuf.String())
+		return fmt.Errorf("failed to clone repository %s: %w", resolvedRepoDetails.RepoName, err)
+	}
+
+	gitspaceLogger.Info("Successfully cloned " + resolvedRepoDetails.RepoName)
+	return nil
+}
+
+// BuildFromDockerfile builds the image described by the build property of devcontainer.json using the
+// repository checked out in sourceDir. The image is tagged using a hash of the build inputs, so a rebuild
+// is skipped if an image for the same inputs is already present on the docker host.
+func BuildFromDockerfile(
+	ctx context.Context,
+	dockerClient *client.Client,
+	sourceDir string,
+	buildConfig *types.DevcontainerBuildConfig,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) (string, error) {
+	contextDir, dockerfile, err := resolveBuildPaths(sourceDir, buildConfig)
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := getBuiltImageName(ctx, sourceDir, contextDir, dockerfile, buildConfig)
+	if err != nil {
+		return "", err
+	}
+
+	imagePresentLocally, err := IsImagePresentLocally(ctx, imageName, dockerClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to check for image %s: %w", imageName, err)
+	}
+	if imagePresentLocally {
+		gitspaceLogger.Info(fmt.Sprintf("Image %s was already built from the same sources, skipping build",
+			imageName))
+		return imageName, nil
+	}
+
+	gitspaceLogger.Info(fmt.Sprintf("Building image %s from %s", imageName, buildConfig.Dockerfile))
+
+	buildContext, err := packBuildContextDirectory(contextDir)
+	if err != nil {
+		return "", err
+	}
+
+	buildArgs := make(map[string]*string, len(buildConfig.Args))
+	for key, value := range buildConfig.Args {
+		buildArgs[key] = &value
+	}
+
+	buildRes, err := dockerClient.ImageBuild(ctx, buildContext, dockerTypes.ImageBuildOptions{
+		Tags:       []string{imageName},
+		Dockerfile: dockerfile,
+		BuildArgs:  buildArgs,
+		Target:     buildConfig.Target,
+		CacheFrom:  buildConfig.CacheFrom,
+		Remove:     true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build image %s: %w", imageName, err)
+	}
+	defer func() {
+		if closeErr := buildRes.Body.Close(); closeErr != nil {
+			log.Ctx(ctx).Err(closeErr).Msg("failed to close docker image build response body")
+		}
+	}()
+
+	if err = processImageBuildResponse(buildRes.Body, gitspaceLogger); err != nil {
+		return "", err
+	}
+
+	imagePresentLocally, err = IsImagePresentLocally(ctx, imageName, dockerClient)
+	if err != nil {
+		return "", err
+	}
+	if !imagePresentLocally {
+		return "", fmt.Errorf("error during docker build, image %s not present", imageName)
+	}
+
+	gitspaceLogger.Info("Successfully built image " + imageName)
+	return imageName, nil
+}
+
+// resolveBuildPaths returns the absolute build context directory and the dockerfile path relative to it.
+// As per the spec, both are relative to the folder containing devcontainer.json.
+func resolveBuildPaths(sourceDir string, buildConfig *types.DevcontainerBuildConfig) (string, string, error) {
+	configDir := filepath.Join(sourceDir, devcontainerConfigDir)
+
+	contextDir := configDir
+	if buildConfig.Context != "" {
+		contextDir = filepath.Join(configDir, buildConfig.Context)
+	}
+	if !isWithinDir(sourceDir, contextDir) {
+		return "", "", fmt.Errorf("build context %q is outside of the repository", buildConfig.Context)
+	}
+
+	dockerfilePath := filepath.Join(configDir, buildConfig.Dockerfile)
+	dockerfile, err := filepath.Rel(contextDir, dockerfilePath)
+	if err != nil || !isWithinDir(contextDir, dockerfilePath) {
+		return "", "", fmt.Errorf("dockerfile %q must be inside the build context %q",
+			buildConfig.Dockerfile, buildConfig.Context)
+	}
+
+	return contextDir, filepath.ToSlash(dockerfile), nil
+}
+
+func isWithinDir(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// getBuiltImageName computes the tag of the image from the git tree of the build context,
+// the dockerfile and the build options.
+func getBuiltImageName(
+	ctx context.Context,
+	sourceDir string,
+	contextDir string,
+	dockerfile string,
+	buildConfig *types.DevcontainerBuildConfig,
+) (string, error) {
+	relContext, err := filepath.Rel(sourceDir, contextDir)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "HEAD:"+filepath.ToSlash(relContext))
+	cmd.Dir = sourceDir
+	treeHash, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the git tree of the build context: %w", err)
+	}
+
+	dockerfileContent, err := os.ReadFile(filepath.Join(contextDir, dockerfile))
+	if err != nil {
+		return "", fmt.Errorf("failed to read dockerfile: %w", err)
+	}
+
+	return builtImageRepository + ":" + buildCacheKey(
+		strings.TrimSpace(string(treeHash)), dockerfileContent, buildConfig), nil
+}
+
+func buildCacheKey(treeHash string, dockerfileContent []byte, buildConfig *types.DevcontainerBuildConfig) string {
+	hash := sha256.New()
+	hash.Write([]byte(treeHash))
+	hash.Write([]byte{0})
+	hash.Write(dockerfileContent)
+	hash.Write([]byte{0})
+	hash.Write([]byte(buildConfig.Target))
+
+	keys := make([]string, 0, len(buildConfig.Args))
+	for key := range buildConfig.Args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		hash.Write([]byte{0})
+		hash.Write([]byte(key + "=" + buildConfig.Args[key]))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+type imageBuildMessage struct {
+	Stream string `json:"stream"`
+	Error  string `json:"error"`
+}
+
+func processImageBuildResponse(body io.Reader, gitspaceLogger gitspaceTypes.GitspaceLogger) error {
+	decoder := json.NewDecoder(body)
+	for {
+		var msg imageBuildMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode image build response: %w", err)
+		}
+		if msg.Error != "" {
+			gitspaceLogger.Error("Error while building image", errors.New(msg.Error))
+			return fmt.Errorf("image build failed: %s", msg.Error)
+		}
+		if line := strings.TrimSpace(msg.Stream); line != "" {
+			gitspaceLogger.Info(line)
+		}
+	}
+}
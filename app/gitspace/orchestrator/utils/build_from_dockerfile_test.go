@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveBuildPaths(t *testing.T) {
+	contextDir, dockerfile, err := resolveBuildPaths("/src", &types.DevcontainerBuildConfig{
+		Dockerfile: "Dockerfile",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/src/.devcontainer", contextDir)
+	assert.Equal(t, "Dockerfile", dockerfile)
+
+	contextDir, dockerfile, err = resolveBuildPaths("/src", &types.DevcontainerBuildConfig{
+		Dockerfile: "Dockerfile",
+		Context:    "..",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/src", contextDir)
+	assert.Equal(t, ".devcontainer/Dockerfile", dockerfile)
+
+	_, _, err = resolveBuildPaths("/src", &types.DevcontainerBuildConfig{
+		Dockerfile: "Dockerfile",
+		Context:    "../..",
+	})
+	assert.Error(t, err)
+
+	_, _, err = resolveBuildPaths("/src", &types.DevcontainerBuildConfig{
+		Dockerfile: "../Dockerfile",
+		Context:    "docker",
+	})
+	assert.Error(t, err)
+}
+
+func TestBuildCacheKey(t *testing.T) {
+	config := &types.DevcontainerBuildConfig{
+		Args: map[string]string{"A": "1", "B": "2"},
+	}
+	key := buildCacheKey("tree", []byte("FROM alpine"), config)
+	assert.Len(t, key, 32)
+	assert.Equal(t, key, buildCacheKey("tree", []byte("FROM alpine"), config))
+
+	assert.NotEqual(t, key, buildCacheKey("other-tree", []byte("FROM alpine"), config))
+	assert.NotEqual(t, key, buildCacheKey("tree", []byte("FROM ubuntu"), config))
+	assert.NotEqual(t, key, buildCacheKey("tree", []byte("FROM alpine"), &types.DevcontainerBuildConfig{
+		Args: map[string]string{"A": "1", "B": "3"},
+	}))
+	assert.NotEqual(t, key, buildCacheKey("tree", []byte("FROM alpine"), &types.DevcontainerBuildConfig{
+		Args:   map[string]string{"A": "1", "B": "2"},
+		Target: "dev",
+	}))
+}
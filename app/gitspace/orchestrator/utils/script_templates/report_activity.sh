@@ -0,0 +1,43 @@
+#!/bin/sh
+
+# Prints the unix time (in seconds) of the most recent user activity in the gitspace,
+# or 0 if no activity has been recorded yet.
+
+PORTS="{{ range .Ports }}{{ . }} {{ end }}"
+
+now=$(date +%s)
+
+# An established connection to the IDE or to any forwarded port means the gitspace is in use.
+for port in $PORTS; do
+    hex_port=$(printf '%04X' "$port")
+    for table in /proc/net/tcp /proc/net/tcp6; do
+        if [ -r "$table" ] && awk -v port="$hex_port" '
+            NR > 1 { split($2, addr, ":"); if (addr[2] == port && $4 == "01") found = 1 }
+            END { exit !found }' "$table"; then
+            echo "$now"
+            exit 0
+        fi
+    done
+done
+
+# So does an open ssh session.
+for cmdline in /proc/[0-9]*/cmdline; do
+    if tr '\0' ' ' < "$cmdline" 2>/dev/null | grep -q '^sshd: [^ ]*@'; then
+        echo "$now"
+        exit 0
+    fi
+done
+
+# Otherwise fall back to the last time a command was recorded in any shell history.
+latest=0
+for history in /root/.bash_history /root/.zsh_history /root/.local/share/fish/fish_history \
+    /home/*/.bash_history /home/*/.zsh_history /home/*/.local/share/fish/fish_history; do
+    if [ -f "$history" ]; then
+        modified=$(stat -c %Y "$history" 2>/dev/null || echo 0)
+        if [ "$modified" -gt "$latest" ]; then
+            latest=$modified
+        fi
+    fi
+done
+
+echo "$latest"
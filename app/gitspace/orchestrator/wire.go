@@ -22,6 +22,8 @@ import (
 	"github.com/harness/gitness/app/gitspace/platformconnector"
 	"github.com/harness/gitness/app/gitspace/scm"
 	"github.com/harness/gitness/app/gitspace/secret"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
 )
@@ -40,6 +42,8 @@ func ProvideOrchestrator(
 	config *Config,
 	ideFactory ide.Factory,
 	secretResolverFactory *secret.ResolverFactory,
+	prebuildStore store.GitspacePrebuildStore,
+	repoFinder refcache.RepoFinder,
 ) Orchestrator {
 	return NewOrchestrator(
 		scm,
@@ -50,5 +54,7 @@ func ProvideOrchestrator(
 		config,
 		ideFactory,
 		secretResolverFactory,
+		prebuildStore,
+		repoFinder,
 	)
 }
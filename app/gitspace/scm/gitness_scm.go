@@ -190,7 +190,8 @@ func (s *GitnessSCM) ResolveCredentials(
 			&gitspacePrincipal,
 			user,
 			defaultGitspacePATIdentifier,
-			&gitspaceJWTLifetime)
+			&gitspaceJWTLifetime,
+			nil)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create JWT: %w", err)
@@ -91,3 +91,7 @@ type RunIntellijIDEPayload struct {
 	IdeDownloadURL string
 	IdeDirName     string
 }
+
+type ReportActivityPayload struct {
+	Ports []int
+}
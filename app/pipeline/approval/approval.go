@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stageTypeCustom is the v1 stage type the approval stages are replaced with,
+// to keep the yaml understood by the v1 parser of the server and the runners.
+const stageTypeCustom = "custom"
+
+// Spec is the spec of a v1 approval stage:
+//
+//	stages:
+//	- name: approve
+//	  type: approval
+//	  spec:
+//	    message: Deploy to production?
+//	    approvers:
+//	      users: [jane]
+//	      user_groups: [release-managers]
+//	    timeout: 24h
+//	    default: reject
+type Spec struct {
+	Message   string `yaml:"message"`
+	Approvers struct {
+		Users      []string `yaml:"users"`
+		UserGroups []string `yaml:"user_groups"`
+	} `yaml:"approvers"`
+	Timeout string `yaml:"timeout"`
+	Default string `yaml:"default"`
+}
+
+// ToApproval converts the spec to the (not yet decided) approval of a stage.
+func (s *Spec) ToApproval() (*types.Approval, error) {
+	approval := &types.Approval{
+		Message:    s.Message,
+		Users:      s.Approvers.Users,
+		UserGroups: s.Approvers.UserGroups,
+	}
+	if approval.Users == nil {
+		approval.Users = []string{}
+	}
+	if approval.UserGroups == nil {
+		approval.UserGroups = []string{}
+	}
+
+	if s.Timeout != "" {
+		timeout, err := time.ParseDuration(s.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid approval timeout %q", s.Timeout)
+		}
+		approval.Timeout = timeout.Milliseconds()
+	}
+
+	switch s.Default {
+	case "approve", string(enum.ApprovalDecisionApproved):
+		approval.DefaultDecision = enum.ApprovalDecisionApproved
+	case "", "reject", string(enum.ApprovalDecisionRejected):
+		approval.DefaultDecision = enum.ApprovalDecisionRejected
+	default:
+		return nil, fmt.Errorf("invalid approval default decision %q, must be approve or reject", s.Default)
+	}
+
+	return approval, nil
+}
+
+// Extract replaces the approval stages of a v1 yaml with empty custom stages and
+// returns the updated yaml along with the approval specs, by index of the stage.
+// The yaml is returned unchanged if it doesn't contain approval stages.
+func Extract(data []byte) ([]byte, map[int]*Spec, error) {
+	root := new(yaml.Node)
+	if err := yaml.Unmarshal(data, root); err != nil {
+		return nil, nil, fmt.Errorf("could not parse yaml: %w", err)
+	}
+
+	specs := map[int]*Spec{}
+
+	var stages *yaml.Node
+	if len(root.Content) > 0 {
+		stages = mappingValue(mappingValue(root.Content[0], "spec"), "stages")
+	}
+	if stages == nil || stages.Kind != yaml.SequenceNode {
+		return data, specs, nil
+	}
+
+	for idx, stage := range stages.Content {
+		typ := mappingValue(stage, "type")
+		if typ == nil || typ.Value != types.StageTypeApproval {
+			continue
+		}
+
+		approvalSpec := new(Spec)
+		if node := mappingValue(stage, "spec"); node != nil {
+			if err := node.Decode(approvalSpec); err != nil {
+				return nil, nil, fmt.Errorf("invalid approval stage spec: %w", err)
+			}
+			*node = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+
+		specs[idx] = approvalSpec
+		typ.Value = stageTypeCustom
+	}
+
+	if len(specs) == 0 {
+		return data, specs, nil
+	}
+
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not write yaml: %w", err)
+	}
+
+	return out, specs, nil
+}
+
+// mappingValue returns the value of the key in a yaml mapping node, or nil if not found.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// Strip removes the approval stages from the yaml before it's sent to a runner,
+// if the yaml can't be parsed it's returned unchanged and the runner reports the error.
+func Strip(data []byte) []byte {
+	out, _, err := Extract(data)
+	if err != nil {
+		return data
+	}
+	return out
+}
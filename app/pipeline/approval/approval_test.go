@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/drone/spec/dist/go/parse/normalize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pipelineWithApproval = `kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: golang
+          script: go test ./...
+  - name: approve
+    type: approval
+    spec:
+      message: Deploy to production?
+      approvers:
+        users: [jane]
+        user_groups: [release-managers]
+      timeout: 1h
+      default: approve
+`
+
+func TestExtract(t *testing.T) {
+	data, specs, err := Extract([]byte(pipelineWithApproval))
+	require.NoError(t, err)
+
+	require.Len(t, specs, 1)
+	spec := specs[1]
+	require.NotNil(t, spec)
+	assert.Equal(t, "Deploy to production?", spec.Message)
+	assert.Equal(t, []string{"jane"}, spec.Approvers.Users)
+	assert.Equal(t, []string{"release-managers"}, spec.Approvers.UserGroups)
+
+	// the updated yaml has to stay a v1 yaml understood by the v1 parser.
+	assert.True(t, regexp.MustCompilePOSIX(`^spec:`).Match(data))
+
+	config, err := v1yaml.ParseBytes(data)
+	require.NoError(t, err)
+	require.NoError(t, normalize.Normalize(config))
+
+	pipeline, ok := config.Spec.(*v1yaml.Pipeline)
+	require.True(t, ok)
+	require.Len(t, pipeline.Stages, 2)
+	assert.IsType(t, &v1yaml.StageCI{}, pipeline.Stages[0].Spec)
+	assert.IsType(t, &v1yaml.StageCustom{}, pipeline.Stages[1].Spec)
+}
+
+func TestExtract_NoApproval(t *testing.T) {
+	in := []byte("kind: pipeline\nspec:\n  stages: []\n")
+
+	data, specs, err := Extract(in)
+	require.NoError(t, err)
+	assert.Empty(t, specs)
+	assert.Equal(t, in, data)
+}
+
+func TestSpec_ToApproval(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     Spec
+		timeout  int64
+		decision enum.ApprovalDecision
+		wantErr  bool
+	}{
+		{name: "defaults", decision: enum.ApprovalDecisionRejected},
+		{
+			name:     "timeout and approve",
+			spec:     Spec{Timeout: "30m", Default: "approve"},
+			timeout:  30 * 60 * 1000,
+			decision: enum.ApprovalDecisionApproved,
+		},
+		{name: "invalid timeout", spec: Spec{Timeout: "soon"}, wantErr: true},
+		{name: "invalid default", spec: Spec{Default: "maybe"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			approval, err := test.spec.ToApproval()
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.timeout, approval.Timeout)
+			assert.Equal(t, test.decision, approval.DefaultDecision)
+			assert.NotNil(t, approval.Users)
+			assert.NotNil(t, approval.UserGroups)
+		})
+	}
+}
@@ -0,0 +1,378 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxKeyLength is the maximum length of an artifact key.
+	maxKeyLength = 1024
+
+	// sweepBatchSize is the number of expired artifacts removed per store query.
+	sweepBatchSize = 100
+
+	blobPathPrefix = "pipeline-artifacts"
+	blobCacheScope = "cache"
+)
+
+// Service stores the artifacts of pipeline executions and the caches of pipelines in the blob store.
+// Artifacts belong to a single execution, caches (execution ID is zero) are shared by all executions
+// of a pipeline. Both are removed by Sweep once they expire.
+type Service struct {
+	artifactRetention time.Duration
+	cacheRetention    time.Duration
+	maxSize           int64
+
+	artifactStore store.PipelineArtifactStore
+	blobStore     blob.Store
+}
+
+func New(
+	artifactRetention time.Duration,
+	cacheRetention time.Duration,
+	maxSize int64,
+	artifactStore store.PipelineArtifactStore,
+	blobStore blob.Store,
+) (*Service, error) {
+	if artifactRetention <= 0 {
+		return nil, errors.New("artifact retention has to be positive")
+	}
+	if cacheRetention <= 0 {
+		return nil, errors.New("cache retention has to be positive")
+	}
+	if maxSize <= 0 {
+		return nil, errors.New("artifact max size has to be positive")
+	}
+
+	return &Service{
+		artifactRetention: artifactRetention,
+		cacheRetention:    cacheRetention,
+		maxSize:           maxSize,
+		artifactStore:     artifactStore,
+		blobStore:         blobStore,
+	}, nil
+}
+
+// UploadParams describes where an uploaded file is stored.
+type UploadParams struct {
+	RepoID      int64
+	PipelineID  int64
+	ExecutionID int64
+	Key         string
+	CreatedBy   int64
+
+	// ExpiresIn optionally shortens the retention of the file, it can't extend it.
+	ExpiresIn time.Duration
+}
+
+// Upload stores the file in the blob store and records it, replacing an existing file with the same key.
+func (s *Service) Upload(
+	ctx context.Context,
+	params UploadParams,
+	file io.Reader,
+) (*types.PipelineArtifact, error) {
+	key, err := SanitizeKey(params.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if file == nil {
+		return nil, usererror.BadRequest("No file provided.")
+	}
+
+	reader := &limitedReader{r: file, limit: s.maxSize}
+
+	err = s.blobStore.Upload(ctx, reader, blobPath(params.RepoID, params.PipelineID, params.ExecutionID, key))
+	if reader.exceeded {
+		return nil, usererror.RequestTooLargef("The file exceeds the maximum size of %d bytes.", s.maxSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	now := time.Now()
+	artifact := &types.PipelineArtifact{
+		RepoID:      params.RepoID,
+		PipelineID:  params.PipelineID,
+		ExecutionID: params.ExecutionID,
+		Kind:        kind(params.ExecutionID),
+		Key:         key,
+		Size:        reader.read,
+		CreatedBy:   params.CreatedBy,
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
+		Expires:     now.Add(s.retention(params.ExecutionID, params.ExpiresIn)).UnixMilli(),
+	}
+
+	err = s.artifactStore.Create(ctx, artifact)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return s.replace(ctx, artifact)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipeline artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// replace updates the record of an already existing file that was overwritten in the blob store.
+func (s *Service) replace(ctx context.Context, artifact *types.PipelineArtifact) (*types.PipelineArtifact, error) {
+	existing, err := s.artifactStore.FindByKey(ctx, artifact.PipelineID, artifact.ExecutionID, artifact.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing pipeline artifact: %w", err)
+	}
+
+	existing.Size = artifact.Size
+	existing.Updated = artifact.Updated
+	existing.Expires = artifact.Expires
+
+	if err = s.artifactStore.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update pipeline artifact: %w", err)
+	}
+
+	return existing, nil
+}
+
+// Find returns the record of a stored file.
+func (s *Service) Find(
+	ctx context.Context,
+	pipelineID int64,
+	executionID int64,
+	key string,
+) (*types.PipelineArtifact, error) {
+	key, err := SanitizeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := s.artifactStore.FindByKey(ctx, pipelineID, executionID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// Download returns either a signed URL or a reader of a stored file.
+// Downloading a cache extends its expiry.
+func (s *Service) Download(
+	ctx context.Context,
+	pipelineID int64,
+	executionID int64,
+	key string,
+) (string, io.ReadCloser, error) {
+	artifact, err := s.Find(ctx, pipelineID, executionID, key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if artifact.Kind == enum.PipelineArtifactKindCache {
+		s.touch(ctx, artifact)
+	}
+
+	filePath := blobPath(artifact.RepoID, artifact.PipelineID, artifact.ExecutionID, artifact.Key)
+
+	signedURL, err := s.blobStore.GetSignedURL(ctx, filePath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := s.blobStore.Download(ctx, filePath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return "", nil, usererror.NotFound("The file was not found in the blob store.")
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download file from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
+
+// touch extends the expiry of a cache that is still in use.
+func (s *Service) touch(ctx context.Context, artifact *types.PipelineArtifact) {
+	expires := time.Now().Add(s.cacheRetention).UnixMilli()
+	if expires <= artifact.Expires {
+		return
+	}
+
+	artifact.Expires = expires
+	if err := s.artifactStore.Update(ctx, artifact); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to extend expiry of pipeline cache %d", artifact.ID)
+	}
+}
+
+// List returns the files of an execution (or the caches of a pipeline) and their total count.
+func (s *Service) List(
+	ctx context.Context,
+	pipelineID int64,
+	executionID int64,
+	filter types.ListQueryFilter,
+) ([]*types.PipelineArtifact, int64, error) {
+	count, err := s.artifactStore.Count(ctx, pipelineID, executionID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pipeline artifacts: %w", err)
+	}
+
+	artifacts, err := s.artifactStore.List(ctx, pipelineID, executionID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pipeline artifacts: %w", err)
+	}
+
+	return artifacts, count, nil
+}
+
+// Delete removes a stored file.
+func (s *Service) Delete(
+	ctx context.Context,
+	pipelineID int64,
+	executionID int64,
+	key string,
+) error {
+	artifact, err := s.Find(ctx, pipelineID, executionID, key)
+	if err != nil {
+		return err
+	}
+
+	return s.delete(ctx, artifact)
+}
+
+// Sweep removes all files that expired before the provided time and returns their number.
+func (s *Service) Sweep(ctx context.Context, now time.Time) (int, error) {
+	var n int
+	for {
+		artifacts, err := s.artifactStore.ListExpired(ctx, now.UnixMilli(), sweepBatchSize)
+		if err != nil {
+			return n, fmt.Errorf("failed to list expired pipeline artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			if err := s.delete(ctx, artifact); err != nil {
+				return n, err
+			}
+			n++
+		}
+
+		if len(artifacts) < sweepBatchSize {
+			return n, nil
+		}
+	}
+}
+
+func (s *Service) delete(ctx context.Context, artifact *types.PipelineArtifact) error {
+	filePath := blobPath(artifact.RepoID, artifact.PipelineID, artifact.ExecutionID, artifact.Key)
+
+	err := s.blobStore.Delete(ctx, filePath)
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("failed to delete file %q from blob store: %w", filePath, err)
+	}
+
+	if err = s.artifactStore.Delete(ctx, artifact.ID); err != nil {
+		return fmt.Errorf("failed to delete pipeline artifact: %w", err)
+	}
+
+	return nil
+}
+
+// retention returns how long an uploaded file is kept.
+func (s *Service) retention(executionID int64, expiresIn time.Duration) time.Duration {
+	retention := s.artifactRetention
+	if executionID == 0 {
+		retention = s.cacheRetention
+	}
+
+	if expiresIn > 0 && expiresIn < retention {
+		return expiresIn
+	}
+
+	return retention
+}
+
+// SanitizeKey cleans an artifact key and ensures it can't escape the directory of the execution or pipeline.
+func SanitizeKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", usererror.BadRequest("Artifact key is required.")
+	}
+
+	if len(key) > maxKeyLength {
+		return "", usererror.BadRequestf("Artifact key can't be longer than %d characters.", maxKeyLength)
+	}
+
+	if strings.HasPrefix(key, "/") {
+		return "", usererror.BadRequest("Artifact key can't be an absolute path.")
+	}
+
+	key = path.Clean(key)
+	if key == "." || key == ".." || strings.HasPrefix(key, "../") {
+		return "", usererror.BadRequest("Artifact key can't point outside of the artifact storage.")
+	}
+
+	return key, nil
+}
+
+func kind(executionID int64) enum.PipelineArtifactKind {
+	if executionID == 0 {
+		return enum.PipelineArtifactKindCache
+	}
+	return enum.PipelineArtifactKindArtifact
+}
+
+func blobPath(repoID, pipelineID, executionID int64, key string) string {
+	scope := blobCacheScope
+	if executionID != 0 {
+		scope = fmt.Sprint(executionID)
+	}
+	return fmt.Sprintf("%s/%d/%d/%s/%s", blobPathPrefix, repoID, pipelineID, scope, key)
+}
+
+// limitedReader fails the read once more than limit bytes were read,
+// which aborts the upload to the blob store.
+type limitedReader struct {
+	r        io.Reader
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		l.exceeded = true
+		return n, errors.New("file size limit exceeded")
+	}
+	return n, err
+}
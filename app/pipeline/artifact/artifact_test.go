@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{name: "file", key: "coverage.out", want: "coverage.out"},
+		{name: "nested", key: "dist/linux/app", want: "dist/linux/app"},
+		{name: "cleaned", key: " dist//linux/./app ", want: "dist/linux/app"},
+		{name: "inner parent", key: "dist/../app", want: "app"},
+		{name: "empty", key: "  ", wantErr: true},
+		{name: "dot", key: ".", wantErr: true},
+		{name: "absolute", key: "/etc/passwd", wantErr: true},
+		{name: "parent", key: "..", wantErr: true},
+		{name: "escape", key: "dist/../../other/app", wantErr: true},
+		{name: "too long", key: strings.Repeat("a", maxKeyLength+1), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SanitizeKey(test.key)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	s := &Service{
+		artifactRetention: 7 * 24 * time.Hour,
+		cacheRetention:    24 * time.Hour,
+	}
+
+	assert.Equal(t, 7*24*time.Hour, s.retention(1, 0))
+	assert.Equal(t, time.Hour, s.retention(1, time.Hour))
+	assert.Equal(t, 7*24*time.Hour, s.retention(1, 30*24*time.Hour))
+	assert.Equal(t, 24*time.Hour, s.retention(0, 0))
+	assert.Equal(t, 24*time.Hour, s.retention(0, 48*time.Hour))
+}
+
+func TestBlobPath(t *testing.T) {
+	assert.Equal(t, "pipeline-artifacts/1/2/3/dist/app", blobPath(1, 2, 3, "dist/app"))
+	assert.Equal(t, "pipeline-artifacts/1/2/cache/go-mod", blobPath(1, 2, 0, "go-mod"))
+}
+
+func TestLimitedReader(t *testing.T) {
+	r := &limitedReader{r: strings.NewReader("hello"), limit: 5}
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.False(t, r.exceeded)
+	assert.Equal(t, int64(5), r.read)
+
+	r = &limitedReader{r: strings.NewReader("hello world"), limit: 5}
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+	assert.True(t, r.exceeded)
+}
@@ -20,11 +20,13 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
 	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
@@ -362,12 +364,24 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 		return nil, err
 	}
 
+	// Approval stages are handled by the server, runners don't understand them.
+	file.Data = approval.Strip(file.Data)
+
 	netrc, err := m.createNetrc(repo)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: failed to create netrc")
 		return nil, err
 	}
 
+	// Expose the artifact and cache storage to the steps, the netrc token is allowed to use it.
+	// The params are only sent to the runner and aren't stored with the execution.
+	if execution.Params == nil {
+		execution.Params = map[string]string{}
+	}
+	for k, v := range m.artifactEnvs(ctx, repo, pipeline, execution, netrc) {
+		execution.Params[k] = v
+	}
+
 	return &ExecutionContext{
 		Repo:         repo,
 		RepoIsPublic: repoIsPublic,
@@ -404,6 +418,24 @@ func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
 	}, nil
 }
 
+// artifactEnvs returns the environment variables the steps use to access the artifact and cache storage.
+func (m *Manager) artifactEnvs(
+	ctx context.Context,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	execution *types.Execution,
+	netrc *Netrc,
+) map[string]string {
+	pipelinePath := []string{"repos", repo.Path, "+", "pipelines", pipeline.Identifier}
+
+	return map[string]string{
+		"GITNESS_ARTIFACTS_URL": m.urlProvider.GenerateContainerAPIURL(ctx,
+			append(pipelinePath, "executions", strconv.FormatInt(execution.Number, 10), "artifacts")...),
+		"GITNESS_CACHE_URL":       m.urlProvider.GenerateContainerAPIURL(ctx, append(pipelinePath, "caches")...),
+		"GITNESS_ARTIFACTS_TOKEN": netrc.Password,
+	}
+}
+
 // Before signals the build step is about to start.
 func (m *Manager) BeforeStep(_ context.Context, step *types.Step) error {
 	log := log.With().
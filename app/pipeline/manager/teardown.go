@@ -122,7 +122,7 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
-	err = t.scheduleDownstream(ctx, stages)
+	err = t.scheduleDownstream(ctx, repo, execution, stages)
 	if err != nil {
 		log.Error().Err(err).
 			Msg("manager: cannot schedule downstream builds")
@@ -263,6 +263,7 @@ func isexecutionComplete(stages []*types.Stage) bool {
 		if stage.Status == enum.CIStatusPending ||
 			stage.Status == enum.CIStatusRunning ||
 			stage.Status == enum.CIStatusWaitingOnDeps ||
+			stage.Status == enum.CIStatusWaitingOnApproval ||
 			stage.Status == enum.CIStatusDeclined ||
 			stage.Status == enum.CIStatusBlocked {
 			return false
@@ -290,8 +291,12 @@ func areDepsComplete(stage *types.Stage, stages []*types.Stage) bool {
 // scheduleDownstream is a helper function that tests for
 // downstream stages and schedules stages if all dependencies
 // and execution requirements are met.
+//
+//nolint:gocognit // refactor if needed
 func (t *teardown) scheduleDownstream(
 	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
 	stages []*types.Stage,
 ) error {
 	var errs error
@@ -322,6 +327,12 @@ func (t *teardown) scheduleDownstream(
 			Str("stage.depends_on", strings.Join(sibling.DependsOn, ",")).
 			Logger()
 
+		// approval stages aren't scheduled, they wait for the decision of an approver instead.
+		if sibling.Type == types.StageTypeApproval {
+			t.waitOnApproval(ctx, repo, execution, sibling, stages)
+			continue
+		}
+
 		log.Debug().Msg("manager: schedule next stage")
 
 		sibling.Status = enum.CIStatusPending
@@ -349,6 +360,41 @@ func (t *teardown) scheduleDownstream(
 	return errs
 }
 
+// waitOnApproval moves an approval stage, whose dependencies are complete,
+// to waiting on approval and notifies the UI.
+func (t *teardown) waitOnApproval(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	stage *types.Stage,
+	stages []*types.Stage,
+) {
+	log := log.With().
+		Int64("stage.id", stage.ID).
+		Str("stage.name", stage.Name).
+		Logger()
+
+	log.Debug().Msg("manager: stage is waiting on approval")
+
+	stage.Status = enum.CIStatusWaitingOnApproval
+	stage.Started = time.Now().UnixMilli()
+	err := t.Stages.Update(noContext, stage)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		rErr := t.resync(ctx, stage)
+		if rErr != nil {
+			log.Warn().Err(rErr).Msg("failed to resync after version conflict")
+		}
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("manager: cannot update stage status")
+		return
+	}
+
+	execution.Stages = stages
+	t.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeExecutionWaitingOnApproval, execution)
+}
+
 // resync updates the stage from the database. Note that it does
 // not update the Version field. This is by design. It prevents
 // the current go routine from updating a stage that has been
@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reclaimer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/livelog"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "gitness:pipeline:reclaim-stages"
+	jobCron        = "* * * * *" // every minute
+	jobMaxDuration = 50 * time.Second
+)
+
+// Service reclaims the stages accepted by remote runners that stopped calling
+// the server (or were deleted) and schedules them again for execution.
+type Service struct {
+	heartbeatTimeout time.Duration
+	tx               dbtx.Transactor
+	runnerStore      store.RunnerStore
+	stageStore       store.StageStore
+	stepStore        store.StepStore
+	scheduler        scheduler.Scheduler
+	logStream        livelog.LogStream
+	jobScheduler     *job.Scheduler
+	executor         *job.Executor
+}
+
+func New(
+	heartbeatTimeout time.Duration,
+	tx dbtx.Transactor,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	scheduler scheduler.Scheduler,
+	logStream livelog.LogStream,
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	if heartbeatTimeout <= 0 {
+		return nil, errors.New("runner heartbeat timeout has to be provided")
+	}
+
+	return &Service{
+		heartbeatTimeout: heartbeatTimeout,
+		tx:               tx,
+		runnerStore:      runnerStore,
+		stageStore:       stageStore,
+		stepStore:        stepStore,
+		scheduler:        scheduler,
+		logStream:        logStream,
+		jobScheduler:     jobScheduler,
+		executor:         executor,
+	}, nil
+}
+
+// Register registers the job handler and schedules the recurring reclaim job.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register job handler for stage reclaim: %w", err)
+	}
+
+	err := s.jobScheduler.AddRecurring(ctx, jobType, jobType, jobCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule stage reclaim job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle reclaims the stages of all remote runners that disappeared.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	stages, err := s.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list incomplete stages: %w", err)
+	}
+
+	cutoff := time.Now().Add(-s.heartbeatTimeout).UnixMilli()
+	stale, err := s.runnerStore.ListSeenBefore(ctx, cutoff)
+	if err != nil {
+		return "", fmt.Errorf("failed to list stale runners: %w", err)
+	}
+
+	gone := make(map[string]bool, len(stale))
+	for _, runner := range stale {
+		gone[runner.Identifier] = true
+	}
+
+	reclaimed := 0
+	for _, stage := range stages {
+		identifier, ok := types.RunnerIdentifierFromMachine(stage.Machine)
+		if !ok {
+			continue
+		}
+
+		isGone, checked := gone[identifier]
+		if !checked {
+			isGone, err = s.isDeleted(ctx, identifier)
+			if err != nil {
+				return "", err
+			}
+			gone[identifier] = isGone
+		}
+		if !isGone {
+			continue
+		}
+
+		ok, err = s.reclaim(ctx, stage)
+		if err != nil {
+			return "", fmt.Errorf("failed to reclaim stage %d of runner %q: %w", stage.ID, identifier, err)
+		}
+		if ok {
+			reclaimed++
+		}
+	}
+
+	if reclaimed == 0 {
+		return "", nil
+	}
+
+	result := fmt.Sprintf("reclaimed %d stages", reclaimed)
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) isDeleted(ctx context.Context, identifier string) (bool, error) {
+	_, err := s.runnerStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find runner %q: %w", identifier, err)
+	}
+	return false, nil
+}
+
+// reclaim resets the stage to pending, removes the steps reported by the runner
+// and schedules the stage again. It returns false if the stage was updated concurrently.
+func (s *Service) reclaim(ctx context.Context, stage *types.Stage) (bool, error) {
+	stages, err := s.stageStore.ListWithSteps(ctx, stage.ExecutionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list steps: %w", err)
+	}
+
+	var steps []*types.Step
+	for _, st := range stages {
+		if st.ID == stage.ID {
+			steps = st.Steps
+			break
+		}
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.stepStore.DeleteByStageID(ctx, stage.ID); err != nil {
+			return err
+		}
+
+		stage.Machine = ""
+		stage.Status = enum.CIStatusPending
+		stage.Error = ""
+		stage.ExitCode = 0
+		stage.Started = 0
+		stage.Stopped = 0
+
+		return s.stageStore.Update(ctx, stage)
+	})
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, step := range steps {
+		// the stream might have never been created, the runner will create it again.
+		_ = s.logStream.Delete(ctx, step.ID)
+	}
+
+	if err := s.scheduler.Schedule(ctx, stage); err != nil {
+		return false, fmt.Errorf("failed to schedule stage: %w", err)
+	}
+
+	return true, nil
+}
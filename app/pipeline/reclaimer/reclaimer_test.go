@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reclaimer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/livelog"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeRunnerStore struct {
+	store.RunnerStore
+	runners    map[string]*types.Runner
+	seenBefore int64
+}
+
+func (s *fakeRunnerStore) ListSeenBefore(_ context.Context, seenBefore int64) ([]*types.Runner, error) {
+	s.seenBefore = seenBefore
+	var runners []*types.Runner
+	for _, runner := range s.runners {
+		if runner.LastSeen > 0 && runner.LastSeen < seenBefore {
+			runners = append(runners, runner)
+		}
+	}
+	return runners, nil
+}
+
+func (s *fakeRunnerStore) FindByIdentifier(_ context.Context, identifier string) (*types.Runner, error) {
+	runner, ok := s.runners[identifier]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return runner, nil
+}
+
+type fakeStageStore struct {
+	store.StageStore
+	stages    []*types.Stage
+	steps     map[int64][]*types.Step
+	conflicts map[int64]bool
+	updated   []int64
+}
+
+func (s *fakeStageStore) ListIncomplete(context.Context) ([]*types.Stage, error) {
+	return s.stages, nil
+}
+
+func (s *fakeStageStore) ListWithSteps(_ context.Context, executionID int64) ([]*types.Stage, error) {
+	var stages []*types.Stage
+	for _, stage := range s.stages {
+		if stage.ExecutionID != executionID {
+			continue
+		}
+		withSteps := *stage
+		withSteps.Steps = s.steps[stage.ID]
+		stages = append(stages, &withSteps)
+	}
+	return stages, nil
+}
+
+func (s *fakeStageStore) Update(_ context.Context, stage *types.Stage) error {
+	if s.conflicts[stage.ID] {
+		return gitness_store.ErrVersionConflict
+	}
+	s.updated = append(s.updated, stage.ID)
+	return nil
+}
+
+type fakeStepStore struct {
+	store.StepStore
+	deleted []int64
+}
+
+func (s *fakeStepStore) DeleteByStageID(_ context.Context, stageID int64) error {
+	s.deleted = append(s.deleted, stageID)
+	return nil
+}
+
+type fakeScheduler struct {
+	scheduler.Scheduler
+	scheduled []int64
+}
+
+func (s *fakeScheduler) Schedule(_ context.Context, stage *types.Stage) error {
+	s.scheduled = append(s.scheduled, stage.ID)
+	return nil
+}
+
+func TestHandle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	runnerStore := &fakeRunnerStore{runners: map[string]*types.Runner{
+		"alive": {Identifier: "alive", LastSeen: now.UnixMilli()},
+		"stale": {Identifier: "stale", LastSeen: now.Add(-time.Hour).UnixMilli()},
+		"new":   {Identifier: "new"},
+	}}
+	stageStore := &fakeStageStore{
+		stages: []*types.Stage{
+			{ID: 1, ExecutionID: 1, Machine: "runner:alive", Status: enum.CIStatusRunning},
+			{ID: 2, ExecutionID: 1, Machine: "runner:stale", Status: enum.CIStatusRunning, Started: 10},
+			{ID: 3, ExecutionID: 2, Machine: "runner:deleted", Status: enum.CIStatusRunning},
+			{ID: 4, ExecutionID: 2, Machine: "gitness", Status: enum.CIStatusRunning},
+			{ID: 5, ExecutionID: 3, Status: enum.CIStatusPending},
+			{ID: 6, ExecutionID: 3, Machine: "runner:new", Status: enum.CIStatusRunning},
+			{ID: 7, ExecutionID: 4, Machine: "runner:deleted", Status: enum.CIStatusRunning},
+		},
+		steps: map[int64][]*types.Step{
+			2: {{ID: 20, StageID: 2}, {ID: 21, StageID: 2}},
+		},
+		conflicts: map[int64]bool{7: true},
+	}
+	stepStore := &fakeStepStore{}
+	sched := &fakeScheduler{}
+	logStream := livelog.NewMemory()
+
+	require.NoError(t, logStream.Create(ctx, 20))
+	require.NoError(t, logStream.Create(ctx, 21))
+
+	s, err := New(time.Minute, fakeTx{}, runnerStore, stageStore, stepStore, sched, logStream, nil, nil)
+	require.NoError(t, err)
+
+	result, err := s.Handle(ctx, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "reclaimed 2 stages", result)
+
+	assert.InDelta(t, now.Add(-time.Minute).UnixMilli(), runnerStore.seenBefore, float64(time.Second.Milliseconds()))
+
+	// the stages of the stale and the deleted runners are reset and scheduled again,
+	// the stage updated concurrently is left to whoever updated it.
+	assert.Equal(t, []int64{2, 3}, stageStore.updated)
+	assert.Equal(t, []int64{2, 3, 7}, stepStore.deleted)
+	assert.Equal(t, []int64{2, 3}, sched.scheduled)
+
+	stage := stageStore.stages[1]
+	assert.Empty(t, stage.Machine)
+	assert.Equal(t, enum.CIStatusPending, stage.Status)
+	assert.Zero(t, stage.Started)
+
+	assert.Equal(t, "runner:alive", stageStore.stages[0].Machine)
+	assert.Equal(t, "gitness", stageStore.stages[3].Machine)
+	assert.Equal(t, "runner:new", stageStore.stages[5].Machine)
+
+	// the live logs of the reclaimed steps are removed, the runner creates them again.
+	assert.Empty(t, logStream.Info(ctx).Streams)
+}
+
+func TestHandle_NothingToReclaim(t *testing.T) {
+	stageStore := &fakeStageStore{stages: []*types.Stage{
+		{ID: 1, ExecutionID: 1, Status: enum.CIStatusPending},
+	}}
+
+	s, err := New(time.Minute, fakeTx{}, &fakeRunnerStore{}, stageStore, &fakeStepStore{},
+		&fakeScheduler{}, livelog.NewMemory(), nil, nil)
+	require.NoError(t, err)
+
+	result, err := s.Handle(context.Background(), "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Empty(t, stageStore.updated)
+}
+
+func TestNew_RequiresHeartbeatTimeout(t *testing.T) {
+	_, err := New(0, fakeTx{}, nil, nil, nil, nil, nil, nil, nil)
+	require.Error(t, err)
+}
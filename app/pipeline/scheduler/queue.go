@@ -80,13 +80,7 @@ func (q *queue) Pause(_ context.Context) error {
 
 func (q *queue) Request(ctx context.Context, params Filter) (*types.Stage, error) {
 	w := &worker{
-		kind:    params.Kind,
-		typ:     params.Type,
-		os:      params.OS,
-		arch:    params.Arch,
-		kernel:  params.Kernel,
-		variant: params.Variant,
-		labels:  params.Labels,
+		filter:  params,
 		channel: make(chan *types.Stage),
 		done:    ctx.Done(),
 	}
@@ -159,38 +153,10 @@ func (q *queue) signal(ctx context.Context) error {
 
 	loop:
 		for w := range q.workers {
-			// the worker must match the resource kind and type
-			if !matchResource(w.kind, w.typ, item.Kind, item.Type) {
+			if !Match(w.filter, item) {
 				continue
 			}
 
-			if w.os != "" || w.arch != "" || w.variant != "" || w.kernel != "" {
-				// the worker is platform-specific. check to ensure
-				// the queue item matches the worker platform.
-				if w.os != item.OS {
-					continue
-				}
-				if w.arch != item.Arch {
-					continue
-				}
-				// if the pipeline defines a variant it must match
-				// the worker variant (e.g. arm6, arm7, etc).
-				if item.Variant != "" && item.Variant != w.variant {
-					continue
-				}
-				// if the pipeline defines a kernel version it must match
-				// the worker kernel version (e.g. 1709, 1803).
-				if item.Kernel != "" && item.Kernel != w.kernel {
-					continue
-				}
-			}
-
-			if len(item.Labels) > 0 || len(w.labels) > 0 {
-				if !checkLabels(item.Labels, w.labels) {
-					continue
-				}
-			}
-
 			select {
 			case w.channel <- item:
 			case <-w.done:
@@ -223,17 +189,49 @@ func (q *queue) start() error {
 }
 
 type worker struct {
-	kind    string
-	typ     string
-	os      string
-	arch    string
-	kernel  string
-	variant string
-	labels  map[string]string
+	filter  Filter
 	channel chan *types.Stage
 	done    <-chan struct{}
 }
 
+// Match returns true if the stage can be executed by a worker
+// requesting stages with the provided filter.
+func Match(w Filter, item *types.Stage) bool {
+	// the worker must match the resource kind and type
+	if !matchResource(w.Kind, w.Type, item.Kind, item.Type) {
+		return false
+	}
+
+	if w.OS != "" || w.Arch != "" || w.Variant != "" || w.Kernel != "" {
+		// the worker is platform-specific. check to ensure
+		// the queue item matches the worker platform.
+		if w.OS != item.OS {
+			return false
+		}
+		if w.Arch != item.Arch {
+			return false
+		}
+		// if the pipeline defines a variant it must match
+		// the worker variant (e.g. arm6, arm7, etc).
+		if item.Variant != "" && item.Variant != w.Variant {
+			return false
+		}
+		// if the pipeline defines a kernel version it must match
+		// the worker kernel version (e.g. 1709, 1803).
+		if item.Kernel != "" && item.Kernel != w.Kernel {
+			return false
+		}
+	}
+
+	if len(item.Labels) > 0 || len(w.Labels) > 0 {
+		if !checkLabels(item.Labels, w.Labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func checkLabels(a, b map[string]string) bool {
 	if len(a) != len(b) {
 		return false
@@ -14,6 +14,11 @@
 
 package dag
 
+import (
+	"slices"
+	"sort"
+)
+
 // Dag is a directed acyclic graph.
 type Dag struct {
 	graph map[string]*Vertex
@@ -63,6 +68,28 @@ func (d *Dag) Ancestors(name string) []*Vertex {
 	return d.ancestors(vertex)
 }
 
+// Descendants returns the names of the vertices that depend on the
+// vertex, directly or through other vertices, sorted by name.
+func (d *Dag) Descendants(name string) []string {
+	visited := map[string]bool{name: true}
+	queue := []string{name}
+	var combined []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, vertex := range d.graph {
+			if visited[vertex.Name] || !slices.Contains(vertex.graph, current) {
+				continue
+			}
+			visited[vertex.Name] = true
+			combined = append(combined, vertex.Name)
+			queue = append(queue, vertex.Name)
+		}
+	}
+	sort.Strings(combined)
+	return combined
+}
+
 // DetectCycles returns true if cycles are detected in the graph.
 func (d *Dag) DetectCycles() bool {
 	visited := make(map[string]bool)
@@ -217,3 +217,25 @@ func TestDependencies_Complex(t *testing.T) {
 		t.Errorf("Unexpected dependencies for notify, got %v", got)
 	}
 }
+
+func TestDescendants(t *testing.T) {
+	dag := New()
+	dag.Add("backend")
+	dag.Add("frontend")
+	dag.Add("test", "backend")
+	dag.Add("deploy", "test", "frontend")
+	dag.Add("notify", "deploy")
+
+	if got, want := dag.Descendants("backend"), []string{"deploy", "notify", "test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want descendants %v, got %v", want, got)
+	}
+	if got, want := dag.Descendants("frontend"), []string{"deploy", "notify"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want descendants %v, got %v", want, got)
+	}
+	if v := dag.Descendants("notify"); len(v) != 0 {
+		t.Errorf("Expect vertexes without dependents have zero descendants")
+	}
+	if v := dag.Descendants("does-not-exist"); len(v) != 0 {
+		t.Errorf("Expect vertex not found does not panic")
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/checks"
+	"github.com/harness/gitness/app/pipeline/triggerer/dag"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrNoStagesToRetry is returned if the retried execution has no failed stages
+	// and no stages were selected.
+	ErrNoStagesToRetry = errors.New("no stages to retry")
+
+	// ErrRetryStageNotFound is returned if a selected stage isn't part of the retried execution.
+	ErrRetryStageNotFound = errors.New("stage to retry not found")
+)
+
+// RetryInput describes which stages of a finished execution are run again.
+type RetryInput struct {
+	// Parent is the finished execution that is retried.
+	Parent *types.Execution
+
+	// Stages are the numbers of the stages to run again.
+	// If empty, the failed stages of the parent execution are run again.
+	Stages []int64
+
+	Trigger     string
+	TriggeredBy int64
+}
+
+// Retry creates a new execution linked to the parent execution. The selected (or failed) stages
+// and the stages depending on them are run again, the results of all other stages are copied
+// from the parent execution. The inputs and params of the parent execution are preserved.
+func (t *triggerer) Retry(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	in *RetryInput,
+) (*types.Execution, error) {
+	log := log.With().
+		Int64("pipeline.id", pipeline.ID).
+		Int64("parent.number", in.Parent.Number).
+		Logger()
+
+	parentStages, err := t.stageStore.ListWithSteps(ctx, in.Parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages of execution %d: %w", in.Parent.Number, err)
+	}
+
+	retried, err := stagesToRetry(parentStages, in.Stages)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := map[int64]*types.Approval{}
+	for _, stage := range parentStages {
+		if stage.Type != types.StageTypeApproval {
+			continue
+		}
+		approval, err := t.approvalStore.FindByStageID(ctx, stage.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find approval of stage %d: %w", stage.Number, err)
+		}
+		approvals[stage.Number] = retryApproval(approval, retried[stage.Number])
+	}
+
+	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	pipeline, err = t.pipelineStore.IncrementSeqNum(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment execution sequence number: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	execution := retryExecution(in, now)
+	execution.Number = pipeline.Seq
+	execution.Params = combine(execution.Params, Envs(ctx, repo, pipeline, t.urlProvider))
+
+	stages := make([]*types.Stage, len(parentStages))
+	copiedLogs := map[*types.Step]int64{}
+	for i, parentStage := range parentStages {
+		if retried[parentStage.Number] {
+			stages[i] = retryStage(parentStage, retried, parentStages, now)
+			continue
+		}
+
+		stages[i] = copyStage(parentStage, now)
+		for j, step := range stages[i].Steps {
+			copiedLogs[step] = parentStage.Steps[j].ID
+		}
+	}
+
+	err = t.createExecutionWithStages(ctx, execution, stages, approvals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create execution: %w", err)
+	}
+
+	// the logs of the copied steps are copied on a best effort basis,
+	// they are still available on the parent execution.
+	for step, parentStepID := range copiedLogs {
+		if err := t.copyLogs(ctx, parentStepID, step.ID); err != nil {
+			log.Warn().Err(err).Msgf("trigger: failed to copy logs of step %d", parentStepID)
+		}
+	}
+
+	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msg("trigger: could not write to check store")
+	}
+
+	for _, stage := range stages {
+		if stage.Status != enum.CIStatusPending {
+			continue
+		}
+		err = t.scheduler.Schedule(ctx, stage)
+		if err != nil {
+			log.Error().Err(err).Msg("trigger: cannot enqueue execution")
+			return nil, err
+		}
+	}
+
+	return execution, nil
+}
+
+func (t *triggerer) copyLogs(ctx context.Context, fromStepID, toStepID int64) error {
+	rc, err := t.logStore.Find(ctx, fromStepID)
+	if err != nil {
+		return fmt.Errorf("failed to find logs: %w", err)
+	}
+	defer rc.Close()
+
+	if err := t.logStore.Create(ctx, toStepID, rc); err != nil {
+		return fmt.Errorf("failed to create logs: %w", err)
+	}
+
+	return nil
+}
+
+// stagesToRetry returns the numbers of the stages that need to run again: the selected stages,
+// or the failed stages if none are selected, and all the stages depending on them.
+func stagesToRetry(stages []*types.Stage, selected []int64) (map[int64]bool, error) {
+	byName := make(map[string]*types.Stage, len(stages))
+	graph := dag.New()
+	for _, stage := range stages {
+		byName[stage.Name] = stage
+		graph.Add(stage.Name, stage.DependsOn...)
+	}
+
+	retried := map[int64]bool{}
+	for _, number := range selected {
+		idx := slices.IndexFunc(stages, func(stage *types.Stage) bool { return stage.Number == number })
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrRetryStageNotFound, number)
+		}
+		retried[number] = true
+	}
+
+	if len(selected) == 0 {
+		for _, stage := range stages {
+			if stage.Status.IsFailed() {
+				retried[stage.Number] = true
+			}
+		}
+	}
+
+	if len(retried) == 0 {
+		return nil, ErrNoStagesToRetry
+	}
+
+	for _, stage := range stages {
+		if !retried[stage.Number] {
+			continue
+		}
+		for _, name := range graph.Descendants(stage.Name) {
+			if descendant, ok := byName[name]; ok {
+				retried[descendant.Number] = true
+			}
+		}
+	}
+
+	return retried, nil
+}
+
+// retryExecution returns a new execution with the inputs of the parent execution.
+func retryExecution(in *RetryInput, now int64) *types.Execution {
+	parent := in.Parent
+	return &types.Execution{
+		RepoID:       parent.RepoID,
+		PipelineID:   parent.PipelineID,
+		Trigger:      in.Trigger,
+		CreatedBy:    in.TriggeredBy,
+		Parent:       parent.ID,
+		Status:       enum.CIStatusPending,
+		Event:        parent.Event,
+		Action:       parent.Action,
+		Link:         parent.Link,
+		Timestamp:    parent.Timestamp,
+		Title:        parent.Title,
+		Message:      parent.Message,
+		Before:       parent.Before,
+		After:        parent.After,
+		Ref:          parent.Ref,
+		Fork:         parent.Fork,
+		Source:       parent.Source,
+		Target:       parent.Target,
+		Author:       parent.Author,
+		AuthorName:   parent.AuthorName,
+		AuthorEmail:  parent.AuthorEmail,
+		AuthorAvatar: parent.AuthorAvatar,
+		Sender:       parent.Sender,
+		Params:       maps.Clone(parent.Params),
+		Cron:         parent.Cron,
+		Deploy:       parent.Deploy,
+		DeployID:     parent.DeployID,
+		Debug:        parent.Debug,
+		Created:      now,
+		Updated:      now,
+	}
+}
+
+// retryStage returns a new stage that runs the parent stage again.
+func retryStage(parent *types.Stage, retried map[int64]bool, stages []*types.Stage, now int64) *types.Stage {
+	stage := &types.Stage{
+		RepoID:    parent.RepoID,
+		Number:    parent.Number,
+		Name:      parent.Name,
+		Kind:      parent.Kind,
+		Type:      parent.Type,
+		Status:    enum.CIStatusPending,
+		OS:        parent.OS,
+		Arch:      parent.Arch,
+		Variant:   parent.Variant,
+		Kernel:    parent.Kernel,
+		Limit:     parent.Limit,
+		LimitRepo: parent.LimitRepo,
+		OnSuccess: parent.OnSuccess,
+		OnFailure: parent.OnFailure,
+		DependsOn: parent.DependsOn,
+		Labels:    parent.Labels,
+		Created:   now,
+		Updated:   now,
+	}
+
+	// the stage has to wait for the dependencies that run again,
+	// the results of the other dependencies are already known.
+	for _, dependency := range stages {
+		if retried[dependency.Number] && slices.Contains(parent.DependsOn, dependency.Name) {
+			stage.Status = enum.CIStatusWaitingOnDeps
+			break
+		}
+	}
+
+	if stage.Type == types.StageTypeApproval && stage.Status == enum.CIStatusPending {
+		stage.Status = enum.CIStatusWaitingOnApproval
+		stage.Started = now
+	}
+
+	return stage
+}
+
+// copyStage returns a copy of the parent stage along with the results of its steps.
+func copyStage(parent *types.Stage, now int64) *types.Stage {
+	stage := *parent
+	stage.ID = 0
+	stage.ExecutionID = 0
+	stage.Version = 0
+	stage.Created = now
+	stage.Updated = now
+
+	stage.Steps = make([]*types.Step, len(parent.Steps))
+	for i, parentStep := range parent.Steps {
+		step := *parentStep
+		step.ID = 0
+		step.StageID = 0
+		step.Version = 0
+		stage.Steps[i] = &step
+	}
+
+	return &stage
+}
+
+// retryApproval returns the approval of the stage of the new execution. The decision is kept
+// if the approval stage isn't run again.
+func retryApproval(parent *types.Approval, retried bool) *types.Approval {
+	approval := *parent
+	approval.ID = 0
+	approval.ExecutionID = 0
+	approval.StageID = 0
+	approval.Version = 0
+
+	if retried {
+		approval.Decision = ""
+		approval.DecidedBy = nil
+		approval.Comment = ""
+		approval.Decided = 0
+	}
+
+	return &approval
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func retryTestStages() []*types.Stage {
+	return []*types.Stage{
+		{Number: 1, Name: "backend", Status: enum.CIStatusSuccess},
+		{Number: 2, Name: "frontend", Status: enum.CIStatusFailure},
+		{Number: 3, Name: "test", Status: enum.CIStatusSuccess, DependsOn: []string{"backend"}},
+		{Number: 4, Name: "deploy", Status: enum.CIStatusSkipped, DependsOn: []string{"test", "frontend"}},
+		{Number: 5, Name: "lint", Status: enum.CIStatusSuccess},
+	}
+}
+
+func TestStagesToRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		selected []int64
+		want     map[int64]bool
+		wantErr  error
+	}{
+		{
+			name: "failed stages and dependents",
+			want: map[int64]bool{2: true, 4: true},
+		},
+		{
+			name:     "selected stage and dependents",
+			selected: []int64{1},
+			want:     map[int64]bool{1: true, 3: true, 4: true},
+		},
+		{
+			name:     "selected stage without dependents",
+			selected: []int64{5},
+			want:     map[int64]bool{5: true},
+		},
+		{
+			name:     "unknown stage",
+			selected: []int64{6},
+			wantErr:  ErrRetryStageNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := stagesToRetry(retryTestStages(), test.selected)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestStagesToRetry_NothingFailed(t *testing.T) {
+	stages := []*types.Stage{{Number: 1, Name: "build", Status: enum.CIStatusSuccess}}
+
+	_, err := stagesToRetry(stages, nil)
+	require.ErrorIs(t, err, ErrNoStagesToRetry)
+}
+
+func TestRetryStage(t *testing.T) {
+	stages := retryTestStages()
+	retried := map[int64]bool{2: true, 4: true}
+
+	frontend := retryStage(stages[1], retried, stages, 100)
+	assert.Equal(t, enum.CIStatusPending, frontend.Status)
+	assert.Zero(t, frontend.ID)
+
+	deploy := retryStage(stages[3], retried, stages, 100)
+	assert.Equal(t, enum.CIStatusWaitingOnDeps, deploy.Status)
+	assert.Equal(t, []string{"test", "frontend"}, deploy.DependsOn)
+}
+
+func TestCopyStage(t *testing.T) {
+	parent := &types.Stage{
+		ID:          7,
+		ExecutionID: 3,
+		Number:      1,
+		Name:        "backend",
+		Status:      enum.CIStatusSuccess,
+		Version:     4,
+		Steps:       []*types.Step{{ID: 9, StageID: 7, Number: 1, Status: enum.CIStatusSuccess, Version: 2}},
+	}
+
+	stage := copyStage(parent, 100)
+	assert.Zero(t, stage.ID)
+	assert.Zero(t, stage.ExecutionID)
+	assert.Zero(t, stage.Version)
+	assert.Equal(t, enum.CIStatusSuccess, stage.Status)
+	require.Len(t, stage.Steps, 1)
+	assert.Zero(t, stage.Steps[0].ID)
+	assert.Zero(t, stage.Steps[0].StageID)
+	assert.Equal(t, enum.CIStatusSuccess, stage.Steps[0].Status)
+
+	// the parent must not be modified.
+	assert.Equal(t, int64(9), parent.Steps[0].ID)
+}
@@ -21,6 +21,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -71,6 +72,10 @@ type Hook struct {
 	Cron         string             `json:"cron"`
 	Sender       string             `json:"sender"`
 	Params       map[string]string  `json:"params"`
+
+	// Deploy and DeployID identify the environment an execution promotes a build to.
+	Deploy   string `json:"deploy"`
+	DeployID int64  `json:"deploy_id"`
 }
 
 // Triggerer is responsible for triggering a Execution from an
@@ -78,12 +83,18 @@ type Hook struct {
 // returned.
 type Triggerer interface {
 	Trigger(ctx context.Context, pipeline *types.Pipeline, hook *Hook) (*types.Execution, error)
+
+	// Retry creates a new execution from a finished execution, see RetryInput.
+	Retry(ctx context.Context, pipeline *types.Pipeline, in *RetryInput) (*types.Execution, error)
 }
 
 type triggerer struct {
 	executionStore   store.ExecutionStore
 	checkStore       store.CheckStore
 	stageStore       store.StageStore
+	stepStore        store.StepStore
+	approvalStore    store.ApprovalStore
+	logStore         store.LogStore
 	tx               dbtx.Transactor
 	pipelineStore    store.PipelineStore
 	fileService      file.Service
@@ -100,6 +111,9 @@ func New(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	stepStore store.StepStore,
+	approvalStore store.ApprovalStore,
+	logStore store.LogStore,
 	pipelineStore store.PipelineStore,
 	tx dbtx.Transactor,
 	repoStore store.RepoStore,
@@ -115,6 +129,9 @@ func New(
 		executionStore:   executionStore,
 		checkStore:       checkStore,
 		stageStore:       stageStore,
+		stepStore:        stepStore,
+		approvalStore:    approvalStore,
+		logStore:         logStore,
 		scheduler:        scheduler,
 		urlProvider:      urlProvider,
 		tx:               tx,
@@ -194,6 +211,8 @@ func (t *triggerer) Trigger(
 		AuthorEmail:  base.AuthorEmail,
 		AuthorAvatar: base.AuthorAvatar,
 		Params:       base.Params,
+		Deploy:       base.Deploy,
+		DeployID:     base.DeployID,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
 		Cron:         base.Cron,
@@ -205,6 +224,7 @@ func (t *triggerer) Trigger(
 	// and creating stages accordingly. For V1 YAML - for now we can just parse the stages
 	// and create them sequentially.
 	stages := []*types.Stage{}
+	var approvals map[int64]*types.Approval
 	//nolint:nestif // refactor if needed
 	if !isV1Yaml(file.Data) {
 		// Convert from jsonnet/starlark to drone yaml
@@ -264,6 +284,8 @@ func (t *triggerer) Trigger(
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match repo")
 			case skipCron(pipeline, base.Cron):
 				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match cron job")
+			case skipTarget(pipeline, base.Deploy):
+				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match deploy target")
 			default:
 				matched = append(matched, pipeline)
 				node.Skip = false
@@ -340,7 +362,7 @@ func (t *triggerer) Trigger(
 			}
 		}
 	} else {
-		stages, err = parseV1Stages(
+		stages, approvals, err = parseV1Stages(
 			ctx, file.Data, repo, execution, t.templateStore, t.pluginStore, t.publicAccess)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse v1 YAML into stages: %w", err)
@@ -358,7 +380,7 @@ func (t *triggerer) Trigger(
 	execution.Number = pipeline.Seq
 	execution.Params = combine(execution.Params, Envs(ctx, repo, pipeline, t.urlProvider))
 
-	err = t.createExecutionWithStages(ctx, execution, stages)
+	err = t.createExecutionWithStages(ctx, execution, stages, approvals)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: cannot create execution")
 		return nil, err
@@ -396,8 +418,9 @@ func trunc(s string, i int) string {
 // if we are unable to do so or the yaml contains something unexpected.
 // Currently, all the stages will be executed one after the other on completion.
 // Once we have depends on in v1, this will be changed to use the DAG.
+// The approvals of the approval stages are returned by stage number.
 //
-//nolint:gocognit // refactor if needed.
+//nolint:gocognit,gocyclo,cyclop // refactor if needed.
 func parseV1Stages(
 	ctx context.Context,
 	data []byte,
@@ -406,28 +429,36 @@ func parseV1Stages(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
-) ([]*types.Stage, error) {
+) ([]*types.Stage, map[int64]*types.Approval, error) {
 	stages := []*types.Stage{}
+	approvals := map[int64]*types.Approval{}
+
+	// Approval stages aren't understood by the v1 parser, they are parsed separately.
+	data, approvalSpecs, err := approval.Extract(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse approval stages: %w", err)
+	}
+
 	// For V1 YAML, just go through the YAML and create stages serially for now
 	config, err := v1yaml.ParseBytes(data)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse v1 yaml: %w", err)
+		return nil, nil, fmt.Errorf("could not parse v1 yaml: %w", err)
 	}
 
 	// Normalize the config to make sure stage names and step names are unique
 	err = normalize.Normalize(config)
 	if err != nil {
-		return nil, fmt.Errorf("could not normalize v1 yaml: %w", err)
+		return nil, nil, fmt.Errorf("could not normalize v1 yaml: %w", err)
 	}
 
 	if config.Kind != "pipeline" {
-		return nil, fmt.Errorf("cannot support non-pipeline kinds in v1 at the moment: %w", err)
+		return nil, nil, fmt.Errorf("cannot support non-pipeline kinds in v1 at the moment: %w", err)
 	}
 
 	// get repo public access
 	repoIsPublic, err := publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
 	if err != nil {
-		return nil, fmt.Errorf("could not check repo public access: %w", err)
+		return nil, nil, fmt.Errorf("could not check repo public access: %w", err)
 	}
 
 	inputParams := map[string]interface{}{}
@@ -443,7 +474,7 @@ func parseV1Stages(
 	}
 
 	if err := specresolver.Resolve(config, lookupFunc); err != nil {
-		return nil, fmt.Errorf("could not resolve yaml plugins/templates: %w", err)
+		return nil, nil, fmt.Errorf("could not resolve yaml plugins/templates: %w", err)
 	}
 
 	switch v := config.Spec.(type) {
@@ -452,52 +483,73 @@ func parseV1Stages(
 		script.ExpandConfig(config, inputParams)
 
 		for idx, stage := range v.Stages {
-			// Only parse CI stages for now
+			// Only parse CI and approval stages for now
+			approvalSpec, isApproval := approvalSpecs[idx]
 			switch stage.Spec.(type) {
 			case *v1yaml.StageCI:
-				now := time.Now().UnixMilli()
-				var onSuccess, onFailure bool
-				onSuccess = true
-				if stage.When != nil {
-					if when := stage.When.Eval; when != "" {
-						// TODO: pass in params for resolution
-						onSuccess, onFailure, err = script.EvalWhen(when, inputParams)
-						if err != nil {
-							return nil, fmt.Errorf("could not resolve when condition for stage: %w", err)
-						}
-					}
+			case *v1yaml.StageCustom:
+				if !isApproval {
+					return nil, nil, fmt.Errorf("only CI, approval and template stages are supported in v1 at the moment")
 				}
+			default:
+				return nil, nil, fmt.Errorf("only CI, approval and template stages are supported in v1 at the moment")
+			}
 
-				dependsOn := []string{}
-				if prevStage != "" {
-					dependsOn = append(dependsOn, prevStage)
+			now := time.Now().UnixMilli()
+			var onSuccess, onFailure bool
+			onSuccess = true
+			if stage.When != nil {
+				if when := stage.When.Eval; when != "" {
+					// TODO: pass in params for resolution
+					onSuccess, onFailure, err = script.EvalWhen(when, inputParams)
+					if err != nil {
+						return nil, nil, fmt.Errorf("could not resolve when condition for stage: %w", err)
+					}
 				}
-				status := enum.CIStatusWaitingOnDeps
-				// If the stage has no dependencies, it can be picked up for execution.
-				if len(dependsOn) == 0 {
-					status = enum.CIStatusPending
+			}
+
+			dependsOn := []string{}
+			if prevStage != "" {
+				dependsOn = append(dependsOn, prevStage)
+			}
+			status := enum.CIStatusWaitingOnDeps
+			// If the stage has no dependencies, it can be picked up for execution.
+			if len(dependsOn) == 0 {
+				status = enum.CIStatusPending
+			}
+			temp := &types.Stage{
+				RepoID:    repo.ID,
+				Number:    int64(idx + 1),
+				Name:      stage.Id, // for v1, ID is the unique identifier per stage
+				Created:   now,
+				Updated:   now,
+				Status:    status,
+				OnSuccess: onSuccess,
+				OnFailure: onFailure,
+				DependsOn: dependsOn,
+			}
+
+			if isApproval {
+				approval, err := approvalSpec.ToApproval()
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid approval stage %q: %w", stage.Id, err)
 				}
-				temp := &types.Stage{
-					RepoID:    repo.ID,
-					Number:    int64(idx + 1),
-					Name:      stage.Id, // for v1, ID is the unique identifier per stage
-					Created:   now,
-					Updated:   now,
-					Status:    status,
-					OnSuccess: onSuccess,
-					OnFailure: onFailure,
-					DependsOn: dependsOn,
+				temp.Type = types.StageTypeApproval
+				// An approval stage without dependencies waits for the decision right away.
+				if temp.Status == enum.CIStatusPending {
+					temp.Status = enum.CIStatusWaitingOnApproval
+					temp.Started = now
 				}
-				prevStage = temp.Name
-				stages = append(stages, temp)
-			default:
-				return nil, fmt.Errorf("only CI and template stages are supported in v1 at the moment")
+				approvals[temp.Number] = approval
 			}
+
+			prevStage = temp.Name
+			stages = append(stages, temp)
 		}
 	default:
-		return nil, fmt.Errorf("unknown yaml: %w", err)
+		return nil, nil, fmt.Errorf("unknown yaml: %w", err)
 	}
-	return stages, nil
+	return stages, approvals, nil
 }
 
 // Checks whether YAML is V1 Yaml or drone Yaml.
@@ -507,11 +559,13 @@ func isV1Yaml(data []byte) bool {
 	return regexp.MustCompilePOSIX(`^spec:`).Match(data)
 }
 
-// createExecutionWithStages writes an execution along with its stages in a single transaction.
+// createExecutionWithStages writes an execution along with its stages, the steps of
+// the stages and the approvals of its approval stages in a single transaction.
 func (t *triggerer) createExecutionWithStages(
 	ctx context.Context,
 	execution *types.Execution,
 	stages []*types.Stage,
+	approvals map[int64]*types.Approval,
 ) error {
 	return t.tx.WithTx(ctx, func(ctx context.Context) error {
 		err := t.executionStore.Create(ctx, execution)
@@ -525,6 +579,25 @@ func (t *triggerer) createExecutionWithStages(
 			if err != nil {
 				return err
 			}
+
+			for _, step := range stage.Steps {
+				step.StageID = stage.ID
+				if err := t.stepStore.Create(ctx, step); err != nil {
+					return err
+				}
+			}
+
+			approval, ok := approvals[stage.Number]
+			if !ok {
+				continue
+			}
+			approval.ExecutionID = execution.ID
+			approval.StageID = stage.ID
+			approval.Created = stage.Created
+			approval.Updated = stage.Created
+			if err := t.approvalStore.Create(ctx, approval); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -573,6 +646,8 @@ func (t *triggerer) createExecutionWithError(
 		AuthorName:   base.AuthorName,
 		AuthorEmail:  base.AuthorEmail,
 		AuthorAvatar: base.AuthorAvatar,
+		Deploy:       base.Deploy,
+		DeployID:     base.DeployID,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
 		Created:      now,
@@ -36,6 +36,9 @@ func ProvideTriggerer(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	stepStore store.StepStore,
+	approvalStore store.ApprovalStore,
+	logStore store.LogStore,
 	tx dbtx.Transactor,
 	pipelineStore store.PipelineStore,
 	fileService file.Service,
@@ -47,7 +50,7 @@ func ProvideTriggerer(
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
 ) Triggerer {
-	return New(executionStore, checkStore, stageStore, pipelineStore,
+	return New(executionStore, checkStore, stageStore, stepStore, approvalStore, logStore, pipelineStore,
 		tx, repoStore, urlProvider, scheduler, fileService, converterService,
 		templateStore, pluginStore, publicAccess)
 }
@@ -23,22 +23,27 @@ import (
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	"github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
+	"github.com/harness/gitness/app/api/controller/gitspaceprebuild"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/controller/spacesettings"
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/controller/template"
 	"github.com/harness/gitness/app/api/controller/trigger"
@@ -51,13 +56,16 @@ import (
 	handlercapabilities "github.com/harness/gitness/app/api/handler/capabilities"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
+	handlerenvironment "github.com/harness/gitness/app/api/handler/environment"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
 	handlergitspace "github.com/harness/gitness/app/api/handler/gitspace"
+	handlergitspaceprebuild "github.com/harness/gitness/app/api/handler/gitspaceprebuild"
 	handlerinfraProvider "github.com/harness/gitness/app/api/handler/infraprovider"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
+	handlernotificationchannel "github.com/harness/gitness/app/api/handler/notificationchannel"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
@@ -65,9 +73,11 @@ import (
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	handlerreposettings "github.com/harness/gitness/app/api/handler/reposettings"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
+	handlerspacesettings "github.com/harness/gitness/app/api/handler/spacesettings"
 	handlersystem "github.com/harness/gitness/app/api/handler/system"
 	handlertemplate "github.com/harness/gitness/app/api/handler/template"
 	handlertrigger "github.com/harness/gitness/app/api/handler/trigger"
@@ -137,6 +147,11 @@ func NewAPIHandler(
 	gitspaceCtrl *gitspace.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	runnerCtrl *runner.Controller,
+	environmentCtrl *environment.Controller,
+	gitspacePrebuildCtrl *gitspaceprebuild.Controller,
+	spaceSettingsCtrl *spacesettings.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
 	usageSender usage.Sender,
 ) http.Handler {
 	// Use go-chi router for inner routing.
@@ -170,7 +185,8 @@ func NewAPIHandler(
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl, usageSender)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl, runnerCtrl,
+				environmentCtrl, gitspacePrebuildCtrl, spaceSettingsCtrl, notificationChannelCtrl, usageSender)
 		})
 	})
 
@@ -222,12 +238,19 @@ func setupRoutesV1WithAuth(r chi.Router,
 	migrateCtrl *migrate.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	runnerCtrl *runner.Controller,
+	environmentCtrl *environment.Controller,
+	gitspacePrebuildCtrl *gitspaceprebuild.Controller,
+	spaceSettingsCtrl *spacesettings.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
 	usageSender usage.Sender,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, spaceSettingsCtrl, userGroupCtrl, webhookCtrl, checkCtrl,
+		notificationChannelCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, environmentCtrl, gitspacePrebuildCtrl,
+		notificationChannelCtrl, usageSender)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
@@ -236,7 +259,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, runnerCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -249,9 +272,11 @@ func setupSpaces(
 	r chi.Router,
 	appCtx context.Context,
 	spaceCtrl *space.Controller,
+	spaceSettingsCtrl *spacesettings.Controller,
 	userGroupCtrl *usergroup.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -285,6 +310,11 @@ func setupSpaces(
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
 			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
 
+			r.Route("/settings", func(r chi.Router) {
+				r.Get("/gitspace", handlerspacesettings.HandleGitspaceFind(spaceSettingsCtrl))
+				r.Patch("/gitspace", handlerspacesettings.HandleGitspaceUpdate(spaceSettingsCtrl))
+			})
+
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
 				r.Post("/", handlerspace.HandleMembershipAdd(spaceCtrl))
@@ -294,8 +324,19 @@ func setupSpaces(
 				})
 			})
 
+			r.Route("/custom-roles", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleListCustomRoles(spaceCtrl))
+				r.Post("/", handlerspace.HandleCreateCustomRole(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamCustomRoleIdentifier), func(r chi.Router) {
+					r.Get("/", handlerspace.HandleFindCustomRole(spaceCtrl))
+					r.Patch("/", handlerspace.HandleUpdateCustomRole(spaceCtrl))
+					r.Delete("/", handlerspace.HandleDeleteCustomRole(spaceCtrl))
+				})
+			})
+
 			SetupSpaceLabels(r, spaceCtrl)
 			SetupWebhookSpace(r, webhookCtrl)
+			SetupNotificationChannelsSpace(r, notificationChannelCtrl)
 			SetupRulesSpace(r, spaceCtrl)
 
 			r.Get("/checks/recent", handlercheck.HandleCheckListRecentSpace(checkCtrl))
@@ -375,6 +416,9 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	environmentCtrl *environment.Controller,
+	gitspacePrebuildCtrl *gitspaceprebuild.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
 	usageSender usage.Sender,
 ) {
 	r.Route("/repos", func(r chi.Router) {
@@ -474,6 +518,8 @@ func setupRepos(r chi.Router,
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
+			r.Get("/pullreq-templates", handlerrepo.HandleListPullReqTemplates(repoCtrl))
+
 			r.With(
 				usage.Middleware(usageSender, false),
 			).Get(fmt.Sprintf("/archive/%s", request.PathParamArchiveGitRef), handlerrepo.HandleArchive(repoCtrl))
@@ -482,14 +528,22 @@ func setupRepos(r chi.Router,
 
 			SetupWebhookRepo(r, webhookCtrl)
 
+			SetupNotificationChannelsRepo(r, notificationChannelCtrl)
+
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
 
 			SetupChecks(r, checkCtrl)
 
 			SetupUploads(r, uploadCtrl)
 
+			SetupEnvironments(r, environmentCtrl)
+
+			SetupGitspacePrebuilds(r, gitspacePrebuildCtrl)
+
 			SetupRulesRepo(r, repoCtrl)
 
+			SetupRepoMembers(r, repoCtrl)
+
 			SetupRepoLabels(r, repoCtrl)
 		})
 	})
@@ -525,6 +579,34 @@ func SetupUploads(r chi.Router, uploadCtrl *upload.Controller) {
 	})
 }
 
+func SetupEnvironments(r chi.Router, environmentCtrl *environment.Controller) {
+	r.Route("/environments", func(r chi.Router) {
+		r.Get("/", handlerenvironment.HandleList(environmentCtrl))
+		r.Post("/", handlerenvironment.HandleCreate(environmentCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamEnvironmentIdentifier), func(r chi.Router) {
+			r.Get("/", handlerenvironment.HandleFind(environmentCtrl))
+			r.Patch("/", handlerenvironment.HandleUpdate(environmentCtrl))
+			r.Delete("/", handlerenvironment.HandleDelete(environmentCtrl))
+			r.Get("/deployments", handlerenvironment.HandleListDeployments(environmentCtrl))
+		})
+	})
+	r.Get("/deployments", handlerenvironment.HandleListCurrentDeployments(environmentCtrl))
+}
+
+func SetupGitspacePrebuilds(r chi.Router, gitspacePrebuildCtrl *gitspaceprebuild.Controller) {
+	r.Route("/gitspace-prebuilds", func(r chi.Router) {
+		r.Get("/", handlergitspaceprebuild.HandleList(gitspacePrebuildCtrl))
+		r.Post("/", handlergitspaceprebuild.HandleCreate(gitspacePrebuildCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamGitspacePrebuildIdentifier), func(r chi.Router) {
+			r.Get("/", handlergitspaceprebuild.HandleFind(gitspacePrebuildCtrl))
+			r.Patch("/", handlergitspaceprebuild.HandleUpdate(gitspacePrebuildCtrl))
+			r.Delete("/", handlergitspaceprebuild.HandleDelete(gitspacePrebuildCtrl))
+			r.Post("/trigger", handlergitspaceprebuild.HandleTrigger(gitspacePrebuildCtrl))
+			r.Get("/logs", handlergitspaceprebuild.HandleLogs(gitspacePrebuildCtrl))
+		})
+	})
+}
+
 func setupPipelines(
 	r chi.Router,
 	repoCtrl *repo.Controller,
@@ -541,6 +623,12 @@ func setupPipelines(
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
 			r.Patch("/", handlerpipeline.HandleUpdate(pipelineCtrl))
 			r.Delete("/", handlerpipeline.HandleDelete(pipelineCtrl))
+			r.Route("/caches", func(r chi.Router) {
+				r.Get("/", handlerpipeline.HandleListCaches(pipelineCtrl))
+				r.Get("/*", handlerpipeline.HandleDownloadCache(pipelineCtrl))
+				r.Put("/*", handlerpipeline.HandleUploadCache(pipelineCtrl))
+				r.Delete("/*", handlerpipeline.HandleDeleteCache(pipelineCtrl))
+			})
 			setupExecutions(r, executionCtrl, logCtrl)
 			setupTriggers(r, triggerCtrl)
 		})
@@ -620,7 +708,18 @@ func setupExecutions(
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamExecutionNumber), func(r chi.Router) {
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
+			r.Post("/retry", handlerexecution.HandleRetry(executionCtrl))
+			r.Post("/promote", handlerexecution.HandlePromote(executionCtrl))
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
+			r.Route(fmt.Sprintf("/stages/{%s}/approval", request.PathParamStageNumber), func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleFindApproval(executionCtrl))
+				r.Post("/", handlerexecution.HandleDecideApproval(executionCtrl))
+			})
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Get("/*", handlerexecution.HandleDownloadArtifact(executionCtrl))
+				r.Put("/*", handlerexecution.HandleUploadArtifact(executionCtrl))
+			})
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
 					request.PathParamStageNumber,
@@ -693,6 +792,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamReviewerID), func(r chi.Router) {
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
+					r.Post("/dismiss", handlerpullreq.HandleReviewDismiss(pullreqCtrl))
 				})
 				r.Route("/usergroups", func(r chi.Router) {
 					r.Put("/", handlerpullreq.HandleUserGroupReviewerAdd(pullreqCtrl))
@@ -706,6 +806,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			})
 			r.Route("/reviews", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
+				r.Post("/request", handlerpullreq.HandleReviewRequest(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
@@ -740,6 +841,30 @@ func setupPullReqLabels(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	})
 }
 
+func SetupNotificationChannelsSpace(r chi.Router, notificationChannelCtrl *notificationchannel.Controller) {
+	r.Route("/notification-channels", func(r chi.Router) {
+		r.Post("/", handlernotificationchannel.HandleCreateSpace(notificationChannelCtrl))
+		r.Get("/", handlernotificationchannel.HandleListSpace(notificationChannelCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationChannelIdentifier), func(r chi.Router) {
+			r.Get("/", handlernotificationchannel.HandleFindSpace(notificationChannelCtrl))
+			r.Patch("/", handlernotificationchannel.HandleUpdateSpace(notificationChannelCtrl))
+			r.Delete("/", handlernotificationchannel.HandleDeleteSpace(notificationChannelCtrl))
+		})
+	})
+}
+
+func SetupNotificationChannelsRepo(r chi.Router, notificationChannelCtrl *notificationchannel.Controller) {
+	r.Route("/notification-channels", func(r chi.Router) {
+		r.Post("/", handlernotificationchannel.HandleCreateRepo(notificationChannelCtrl))
+		r.Get("/", handlernotificationchannel.HandleListRepo(notificationChannelCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationChannelIdentifier), func(r chi.Router) {
+			r.Get("/", handlernotificationchannel.HandleFindRepo(notificationChannelCtrl))
+			r.Patch("/", handlernotificationchannel.HandleUpdateRepo(notificationChannelCtrl))
+			r.Delete("/", handlernotificationchannel.HandleDeleteRepo(notificationChannelCtrl))
+		})
+	})
+}
+
 func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 	r.Route("/webhooks", func(r chi.Router) {
 		r.Post("/", handlerwebhook.HandleCreateRepo(webhookCtrl))
@@ -772,6 +897,17 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	})
 }
 
+func SetupRepoMembers(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/members", func(r chi.Router) {
+		r.Get("/", handlerrepo.HandleMembershipList(repoCtrl))
+		r.Post("/", handlerrepo.HandleMembershipAdd(repoCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoMembershipID), func(r chi.Router) {
+			r.Delete("/", handlerrepo.HandleMembershipDelete(repoCtrl))
+			r.Patch("/", handlerrepo.HandleMembershipUpdate(repoCtrl))
+		})
+	})
+}
+
 func SetupRulesRepo(r chi.Router, repoCtrl *repo.Controller) {
 	r.Route("/rules", func(r chi.Router) {
 		r.Post("/", handlerrepo.HandleRuleCreate(repoCtrl))
@@ -792,6 +928,8 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 		r.Get("/", handleruser.HandleFind(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
+		r.Get("/notification-settings", handleruser.HandleFindNotificationSettings(userCtrl))
+		r.Patch("/notification-settings", handleruser.HandleUpdateNotificationSettings(userCtrl))
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
@@ -899,7 +1037,7 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, runnerCtrl *runner.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -913,6 +1051,15 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Route("/runners", func(r chi.Router) {
+			r.Get("/", handlerrunner.HandleList(runnerCtrl))
+			r.Post("/", handlerrunner.HandleCreate(runnerCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamRunnerIdentifier), func(r chi.Router) {
+				r.Get("/", handlerrunner.HandleFind(runnerCtrl))
+				r.Delete("/", handlerrunner.HandleDelete(runnerCtrl))
+			})
+		})
 	})
 }
 
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
+	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/app/api/middleware/nocache"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog/hlog"
+)
+
+// NewRPCHandler returns a new http.Handler exposing the execution manager to
+// remote runners, following the drone runner protocol.
+func NewRPCHandler(runnerCtrl *runner.Controller) http.Handler {
+	// Use go-chi router for inner routing.
+	r := chi.NewRouter()
+
+	r.Use(nocache.NoCache)
+	r.Use(middleware.Recoverer)
+	r.Use(logging.URLHandler("http.url"))
+	r.Use(hlog.MethodHandler("http.method"))
+	r.Use(logging.HLogRequestIDHandler())
+	r.Use(logging.HLogAccessLogHandler())
+
+	r.Route(RPCMount, func(r chi.Router) {
+		r.Use(handlerrunner.Authenticate(runnerCtrl))
+
+		r.Post("/ping", handlerrunner.HandlePing())
+		r.Post("/stage", handlerrunner.HandleRequest(runnerCtrl))
+		r.Route(fmt.Sprintf("/stage/{%s}", request.PathParamRunnerStageID), func(r chi.Router) {
+			r.Post("/", handlerrunner.HandleAccept(runnerCtrl))
+			r.Get("/", handlerrunner.HandleDetails(runnerCtrl))
+			r.Put("/", handlerrunner.HandleUpdateStage(runnerCtrl))
+		})
+		r.Route(fmt.Sprintf("/step/{%s}", request.PathParamRunnerStepID), func(r chi.Router) {
+			r.Put("/", handlerrunner.HandleUpdateStep(runnerCtrl))
+			r.Post("/logs/batch", handlerrunner.HandleBatch(runnerCtrl))
+			r.Post("/logs/upload", handlerrunner.HandleUpload(runnerCtrl))
+			r.Post("/card", handlerrunner.HandleCard())
+		})
+		r.Post(fmt.Sprintf("/build/{%s}/watch", request.PathParamRunnerBuildID), handlerrunner.HandleWatch(runnerCtrl))
+	})
+
+	return r
+}
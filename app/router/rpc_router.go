@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/logging"
+)
+
+// RPCMount is the path prefix of the remote runners protocol.
+const RPCMount = "/rpc/v2"
+
+type RPCRouter struct {
+	handler http.Handler
+}
+
+func NewRPCRouter(handler http.Handler) *RPCRouter {
+	return &RPCRouter{handler: handler}
+}
+
+func (r *RPCRouter) Handle(w http.ResponseWriter, req *http.Request) {
+	req = req.WithContext(logging.NewContext(req.Context(), WithLoggingRouter("rpc")))
+	r.handler.ServeHTTP(w, req)
+}
+
+func (r *RPCRouter) IsEligibleTraffic(req *http.Request) bool {
+	// The runners build all their calls from the server address and the fixed protocol prefix.
+	return strings.HasPrefix(req.URL.Path, RPCMount+"/")
+}
+
+func (r *RPCRouter) Name() string {
+	return "rpc"
+}
@@ -22,22 +22,27 @@ import (
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	"github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
+	"github.com/harness/gitness/app/api/controller/gitspaceprebuild"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/controller/spacesettings"
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/controller/template"
 	"github.com/harness/gitness/app/api/controller/trigger"
@@ -110,12 +115,17 @@ func ProvideRouter(
 	migrateCtrl *migrate.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	runnerCtrl *runner.Controller,
+	environmentCtrl *environment.Controller,
+	gitspacePrebuildCtrl *gitspaceprebuild.Controller,
+	spaceSettingsCtrl *spacesettings.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
 	urlProvider url.Provider,
 	openapi openapi.Service,
 	registryRouter router.AppRouter,
 	usageSender usage.Sender,
 ) *Router {
-	routers := make([]Interface, 4)
+	routers := make([]Interface, 5)
 
 	gitRoutingHost := GetGitRoutingHost(appCtx, urlProvider)
 	gitHandler := NewGitHandler(
@@ -127,14 +137,16 @@ func ProvideRouter(
 	)
 	routers[0] = NewGitRouter(gitHandler, gitRoutingHost)
 	routers[1] = router.NewRegistryRouter(registryRouter)
+	routers[2] = NewRPCRouter(NewRPCHandler(runnerCtrl))
 
 	apiHandler := NewAPIHandler(
 		appCtx, config,
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
 		githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
-		infraProviderCtrl, migrateCtrl, gitspaceCtrl, aiagentCtrl, capabilitiesCtrl, usageSender)
-	routers[2] = NewAPIRouter(apiHandler)
+		infraProviderCtrl, migrateCtrl, gitspaceCtrl, aiagentCtrl, capabilitiesCtrl, runnerCtrl, environmentCtrl,
+		gitspacePrebuildCtrl, spaceSettingsCtrl, notificationChannelCtrl, usageSender)
+	routers[3] = NewAPIRouter(apiHandler)
 
 	sec := NewSecure(config)
 	webHandler := NewWebHandler(
@@ -142,7 +154,7 @@ func ProvideRouter(
 		config.PublicResourceCreationEnabled,
 		config.Development.UISourceOverride,
 	)
-	routers[3] = NewWebRouter(webHandler)
+	routers[4] = NewWebRouter(webHandler)
 
 	return NewRouter(routers)
 }
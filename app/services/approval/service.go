@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "gitness:pipeline:approval-timeouts"
+	jobCron        = "* * * * *" // every minute
+	jobMaxDuration = 50 * time.Second
+)
+
+// ErrNotWaiting is returned when a decision is made on a stage that isn't waiting on approval anymore.
+var ErrNotWaiting = errors.New("stage is not waiting on approval")
+
+// Service applies the decisions made on the approval stages of pipeline executions,
+// and the default decision of the approvals that timed out.
+type Service struct {
+	approvalStore    store.ApprovalStore
+	stageStore       store.StageStore
+	executionStore   store.ExecutionStore
+	repoStore        store.RepoStore
+	executionManager manager.ExecutionManager
+	sseStreamer      sse.Streamer
+	scheduler        *job.Scheduler
+	executor         *job.Executor
+}
+
+func NewService(
+	approvalStore store.ApprovalStore,
+	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	repoStore store.RepoStore,
+	executionManager manager.ExecutionManager,
+	sseStreamer sse.Streamer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) *Service {
+	return &Service{
+		approvalStore:    approvalStore,
+		stageStore:       stageStore,
+		executionStore:   executionStore,
+		repoStore:        repoStore,
+		executionManager: executionManager,
+		sseStreamer:      sseStreamer,
+		scheduler:        scheduler,
+		executor:         executor,
+	}
+}
+
+// Register registers the job handler and schedules the recurring approval timeout job.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register job handler for approval timeouts: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, jobCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule approval timeouts job: %w", err)
+	}
+
+	return nil
+}
+
+// Decide records the decision on the approval and resumes the execution.
+// The decidedBy principal is nil if the default decision is applied after the timeout.
+func (s *Service) Decide(
+	ctx context.Context,
+	stage *types.Stage,
+	approval *types.Approval,
+	decision enum.ApprovalDecision,
+	decidedBy *int64,
+	comment string,
+) error {
+	if approval.IsDecided() || stage.Status != enum.CIStatusWaitingOnApproval {
+		return ErrNotWaiting
+	}
+
+	now := time.Now().UnixMilli()
+
+	approval.Decision = decision
+	approval.DecidedBy = decidedBy
+	approval.Comment = comment
+	approval.Decided = now
+	approval.Updated = now
+
+	// the optimistic lock makes sure only a single decision is applied.
+	err := s.approvalStore.Update(ctx, approval)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return ErrNotWaiting
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update approval: %w", err)
+	}
+
+	stage.Stopped = now
+	switch {
+	case decision == enum.ApprovalDecisionApproved:
+		stage.Status = enum.CIStatusSuccess
+	case decidedBy == nil:
+		stage.Status = enum.CIStatusFailure
+		stage.Error = "approval timed out"
+	default:
+		stage.Status = enum.CIStatusFailure
+		stage.Error = "approval rejected"
+	}
+
+	// the stage is completed like any other, which schedules the downstream stages.
+	if err := s.executionManager.AfterStage(ctx, stage); err != nil {
+		return fmt.Errorf("failed to complete approval stage: %w", err)
+	}
+
+	s.publish(ctx, stage.ExecutionID)
+
+	return nil
+}
+
+// Handle applies the default decision of all approvals that timed out.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	approvals, err := s.approvalStore.ListExpired(ctx, time.Now().UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list expired approvals: %w", err)
+	}
+
+	decided := 0
+	for _, approval := range approvals {
+		stage, err := s.stageStore.Find(ctx, approval.StageID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find stage of approval %d: %w", approval.ID, err)
+		}
+
+		err = s.Decide(ctx, stage, approval, approval.DefaultDecision, nil, "")
+		if errors.Is(err, ErrNotWaiting) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to apply default decision of approval %d: %w", approval.ID, err)
+		}
+
+		decided++
+	}
+
+	if decided == 0 {
+		return "", nil
+	}
+
+	result := fmt.Sprintf("applied default decision of %d timed out approvals", decided)
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) publish(ctx context.Context, executionID int64) {
+	execution, err := s.executionStore.Find(ctx, executionID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find execution to publish approval decision")
+		return
+	}
+
+	repo, err := s.repoStore.Find(ctx, execution.RepoID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find repo to publish approval decision")
+		return
+	}
+
+	execution.Stages, err = s.stageStore.List(ctx, executionID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list stages to publish approval decision")
+		return
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeExecutionApprovalDecided, execution)
+}
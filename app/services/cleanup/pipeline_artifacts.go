@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/artifact"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePipelineArtifacts        = "gitness:cleanup:pipeline-artifacts"
+	jobCronPipelineArtifacts        = "7 * * * *" // At minute 7 past every hour.
+	jobMaxDurationPipelineArtifacts = 10 * time.Minute
+)
+
+type pipelineArtifactsCleanupJob struct {
+	artifactService *artifact.Service
+}
+
+func newPipelineArtifactsCleanupJob(
+	artifactService *artifact.Service,
+) *pipelineArtifactsCleanupJob {
+	return &pipelineArtifactsCleanupJob{
+		artifactService: artifactService,
+	}
+}
+
+// Handle removes the pipeline artifacts and caches that passed their retention time.
+func (j *pipelineArtifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	log.Ctx(ctx).Info().Msg("start purging expired pipeline artifacts")
+
+	n, err := j.artifactService.Sweep(ctx, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to delete expired pipeline artifacts (deleted %d): %w", n, err)
+	}
+
+	result := "no expired pipeline artifacts found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d pipeline artifacts", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
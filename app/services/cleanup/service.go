@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/job"
 )
@@ -53,6 +54,7 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	artifactService       *artifact.Service
 }
 
 func NewService(
@@ -63,6 +65,7 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	artifactService *artifact.Service,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +80,7 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		artifactService:       artifactService,
 	}, nil
 }
 
@@ -126,6 +130,17 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePipelineArtifacts,
+		jobTypePipelineArtifacts,
+		jobCronPipelineArtifacts,
+		jobMaxDurationPipelineArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pipeline artifacts cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +175,14 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypePipelineArtifacts,
+		newPipelineArtifactsCleanupJob(
+			s.artifactService,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pipeline artifacts cleanup: %w", err)
+	}
 	return nil
 }
@@ -16,6 +16,7 @@ package cleanup
 
 import (
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/job"
 
@@ -34,6 +35,7 @@ func ProvideService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	artifactService *artifact.Service,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -43,5 +45,6 @@ func ProvideService(
 		tokenStore,
 		repoStore,
 		repoCtrl,
+		artifactService,
 	)
 }
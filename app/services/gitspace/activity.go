@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// RecordActivity probes the running gitspace for user activity and stores the time of
+// the most recent activity as the last heartbeat of the gitspace instance.
+func (c *Service) RecordActivity(ctx context.Context, config *types.GitspaceConfig) error {
+	lastActivity, err := c.orchestrator.GetLastActivity(ctx, *config)
+	if err != nil {
+		return fmt.Errorf("failed to get last activity of gitspace %s: %w", config.Identifier, err)
+	}
+
+	instance := config.GitspaceInstance
+	if lastActivity == 0 || (instance.LastHeartbeat != nil && *instance.LastHeartbeat >= lastActivity) {
+		return nil
+	}
+
+	instance.LastHeartbeat = &lastActivity
+	instance.LastUsed = &lastActivity
+	if err = c.UpdateInstance(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update last heartbeat of gitspace %s: %w", config.Identifier, err)
+	}
+
+	return nil
+}
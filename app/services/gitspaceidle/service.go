@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceidle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/gitspace"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "gitness:gitspace:idle-auto-stop"
+	jobCron        = "*/5 * * * *" // every 5 minutes
+	jobMaxDuration = 4 * time.Minute
+)
+
+type Config struct {
+	Enabled bool
+	// IdleTimeout is used for the spaces that don't have the idle timeout configured.
+	IdleTimeout time.Duration
+}
+
+// Service periodically records the activity of the running gitspaces
+// and stops the ones that have been idle for longer than the idle timeout of their space.
+type Service struct {
+	config                Config
+	gitspaceSvc           *gitspace.Service
+	gitspaceInstanceStore store.GitspaceInstanceStore
+	settings              *settings.Service
+	scheduler             *job.Scheduler
+	executor              *job.Executor
+}
+
+func NewService(
+	config Config,
+	gitspaceSvc *gitspace.Service,
+	gitspaceInstanceStore store.GitspaceInstanceStore,
+	settings *settings.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) *Service {
+	return &Service{
+		config:                config,
+		gitspaceSvc:           gitspaceSvc,
+		gitspaceInstanceStore: gitspaceInstanceStore,
+		settings:              settings,
+		scheduler:             scheduler,
+		executor:              executor,
+	}
+}
+
+// Register registers the job handler and schedules the recurring idle gitspace job.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register job handler for idle gitspaces: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, jobCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule idle gitspaces job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle records the activity of all running gitspaces and stops the idle ones.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	instances, err := s.gitspaceInstanceStore.List(ctx, &types.GitspaceInstanceFilter{
+		States: []enum.GitspaceInstanceStateType{enum.GitspaceInstanceStateRunning},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list running gitspace instances: %w", err)
+	}
+
+	timeouts := make(map[int64]time.Duration)
+	stopped := 0
+	for _, instance := range instances {
+		log := log.Ctx(ctx).With().Str("gitspace_instance", instance.Identifier).Logger()
+
+		config, err := s.gitspaceSvc.FindWithLatestInstanceByID(ctx, instance.GitSpaceConfigID, false)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to find gitspace config of running instance")
+			continue
+		}
+		if config.GitspaceInstance == nil || config.GitspaceInstance.ID != instance.ID {
+			continue
+		}
+
+		timeout, ok := timeouts[config.SpaceID]
+		if !ok {
+			timeout, err = s.idleTimeout(ctx, config.SpaceID)
+			if err != nil {
+				log.Warn().Err(err).Int64("space_id", config.SpaceID).Msg("failed to get idle timeout of space")
+				continue
+			}
+			timeouts[config.SpaceID] = timeout
+		}
+		if timeout <= 0 {
+			continue
+		}
+
+		// if the activity can't be fetched the last recorded heartbeat is used.
+		if err = s.gitspaceSvc.RecordActivity(ctx, config); err != nil {
+			log.Warn().Err(err).Msg("failed to record gitspace activity")
+		}
+
+		now := time.Now()
+		if !isIdle(config.GitspaceInstance, now, timeout) {
+			continue
+		}
+
+		if err = s.gitspaceSvc.GitspaceAutostopAction(ctx, *config, now); err != nil {
+			log.Warn().Err(err).Msg("failed to auto-stop idle gitspace")
+			continue
+		}
+
+		stopped++
+	}
+
+	if stopped == 0 {
+		return "", nil
+	}
+
+	result := fmt.Sprintf("stopped %d idle gitspaces", stopped)
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// idleTimeout returns the idle timeout configured for the space, or the default one.
+func (s *Service) idleTimeout(ctx context.Context, spaceID int64) (time.Duration, error) {
+	var timeoutInMins int64
+	found, err := s.settings.SpaceGet(ctx, spaceID, settings.KeyGitspaceIdleTimeoutInMins, &timeoutInMins)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get gitspace idle timeout of space %d: %w", spaceID, err)
+	}
+	if !found {
+		return s.config.IdleTimeout, nil
+	}
+
+	return time.Duration(timeoutInMins) * time.Minute, nil
+}
+
+// isIdle returns true if there was no activity in the gitspace instance for longer than the timeout.
+// Instances without any recorded activity are considered active since they were started.
+func isIdle(instance *types.GitspaceInstance, now time.Time, timeout time.Duration) bool {
+	var lastActive int64
+	for _, t := range []*int64{instance.ActiveTimeStarted, instance.LastUsed, instance.LastHeartbeat} {
+		if t != nil && *t > lastActive {
+			lastActive = *t
+		}
+	}
+
+	if lastActive == 0 {
+		return false
+	}
+
+	return now.Sub(time.UnixMilli(lastActive)) >= timeout
+}
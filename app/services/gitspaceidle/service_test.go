@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceidle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIdle(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	timeout := 30 * time.Minute
+	ago := func(d time.Duration) *int64 { return ptr.Int64(now.Add(-d).UnixMilli()) }
+
+	tests := []struct {
+		name     string
+		instance types.GitspaceInstance
+		expected bool
+	}{
+		{
+			name:     "no activity recorded",
+			instance: types.GitspaceInstance{},
+			expected: false,
+		},
+		{
+			name:     "started recently without heartbeat",
+			instance: types.GitspaceInstance{ActiveTimeStarted: ago(10 * time.Minute)},
+			expected: false,
+		},
+		{
+			name:     "started long ago without heartbeat",
+			instance: types.GitspaceInstance{ActiveTimeStarted: ago(time.Hour)},
+			expected: true,
+		},
+		{
+			name: "recent heartbeat",
+			instance: types.GitspaceInstance{
+				ActiveTimeStarted: ago(time.Hour),
+				LastHeartbeat:     ago(5 * time.Minute),
+			},
+			expected: false,
+		},
+		{
+			name: "heartbeat older than timeout",
+			instance: types.GitspaceInstance{
+				ActiveTimeStarted: ago(2 * time.Hour),
+				LastUsed:          ago(time.Hour),
+				LastHeartbeat:     ago(31 * time.Minute),
+			},
+			expected: true,
+		},
+		{
+			name: "restarted after last heartbeat",
+			instance: types.GitspaceInstance{
+				ActiveTimeStarted: ago(time.Minute),
+				LastHeartbeat:     ago(2 * time.Hour),
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isIdle(&test.instance, now, timeout))
+		})
+	}
+}
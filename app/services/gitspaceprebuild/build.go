@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	buildJobType = "gitness:gitspace:prebuild"
+
+	// gitReferenceNamePrefixBranch is the prefix of references of type branch.
+	gitReferenceNamePrefixBranch = "refs/heads/"
+
+	// maxBuildsPerJob limits the number of builds of a single job, a build is repeated if the branch
+	// has been updated while the previous one was running.
+	maxBuildsPerJob = 5
+)
+
+type buildJobInput struct {
+	PrebuildID int64 `json:"prebuild_id"`
+}
+
+func (s *Service) scheduleBuild(ctx context.Context, prebuild *types.GitspacePrebuild) error {
+	data, err := json.Marshal(buildJobInput{PrebuildID: prebuild.ID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal gitspace prebuild job input: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        fmt.Sprintf("gitspace-prebuild-%d-%d", prebuild.ID, time.Now().UnixMilli()),
+		Type:       buildJobType,
+		MaxRetries: 0,
+		Timeout:    maxBuildsPerJob * s.config.BuildTimeout,
+		Data:       string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule gitspace prebuild job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle builds the prebuild image of the latest requested commit of the prebuild.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input buildJobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal gitspace prebuild job input: %w", err)
+	}
+
+	for range maxBuildsPerJob {
+		prebuild, err := s.prebuildStore.Find(ctx, input.PrebuildID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find gitspace prebuild: %w", err)
+		}
+
+		if prebuild.Disabled || prebuild.CommitSHA == "" {
+			return "", nil
+		}
+
+		prebuild, err = s.build(ctx, prebuild)
+		if err != nil {
+			return "", err
+		}
+
+		if prebuild.State != enum.GitspacePrebuildStatePending {
+			return "", nil
+		}
+	}
+
+	return "", nil
+}
+
+// build builds the prebuild image of the commit of the prebuild. A failure of the build itself
+// is recorded in the prebuild, only the failures to store the result are returned.
+func (s *Service) build(ctx context.Context, prebuild *types.GitspacePrebuild) (*types.GitspacePrebuild, error) {
+	sha := prebuild.CommitSHA
+
+	prebuild, err := s.prebuildStore.UpdateOptLock(ctx, prebuild, func(prebuild *types.GitspacePrebuild) error {
+		prebuild.State = enum.GitspacePrebuildStateBuilding
+		prebuild.ErrorMessage = nil
+		prebuild.BuildStarted = time.Now().UnixMilli()
+		prebuild.BuildFinished = 0
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark gitspace prebuild as building: %w", err)
+	}
+
+	logger := newBuildLogger()
+	imageName := getImageName(prebuild, sha)
+
+	buildErr := s.buildImage(ctx, prebuild, imageName, logger)
+	if buildErr != nil {
+		logger.Error("Prebuild failed", buildErr)
+	} else {
+		logger.Info("Prebuild image " + imageName + " created successfully")
+	}
+
+	if err = s.prebuildStore.UpdateLogs(ctx, prebuild.ID, logger.String()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to store gitspace prebuild logs")
+	}
+
+	oldImage := prebuild.Image
+
+	prebuild, err = s.prebuildStore.UpdateOptLock(ctx, prebuild, func(prebuild *types.GitspacePrebuild) error {
+		prebuild.BuildFinished = time.Now().UnixMilli()
+
+		switch {
+		case prebuild.CommitSHA != sha:
+			// the branch has been updated during the build, the new commit is built next.
+			prebuild.State = enum.GitspacePrebuildStatePending
+		case buildErr != nil:
+			prebuild.State = enum.GitspacePrebuildStateFailed
+			errorMessage := buildErr.Error()
+			prebuild.ErrorMessage = &errorMessage
+		default:
+			prebuild.State = enum.GitspacePrebuildStateReady
+		}
+
+		if buildErr == nil {
+			prebuild.Image = imageName
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store gitspace prebuild build result: %w", err)
+	}
+
+	if buildErr == nil && oldImage != "" && oldImage != imageName {
+		if err := s.orchestrator.RemovePrebuildImage(ctx, oldImage); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("image", oldImage).Msg("failed to remove previous gitspace prebuild image")
+		}
+	}
+
+	return prebuild, nil
+}
+
+func (s *Service) buildImage(
+	ctx context.Context,
+	prebuild *types.GitspacePrebuild,
+	imageName string,
+	logger *buildLogger,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.BuildTimeout)
+	defer cancel()
+
+	gitspaceConfig, err := s.getGitspaceConfig(ctx, prebuild)
+	if err != nil {
+		return err
+	}
+
+	return s.orchestrator.CreatePrebuildImage(ctx, gitspaceConfig, imageName, logger)
+}
+
+// getGitspaceConfig returns the gitspace config the prebuild image is created from.
+// The repository is cloned with the credentials of the creator of the prebuild.
+func (s *Service) getGitspaceConfig(
+	ctx context.Context,
+	prebuild *types.GitspacePrebuild,
+) (types.GitspaceConfig, error) {
+	repo, err := s.repoStore.Find(ctx, prebuild.RepoID)
+	if err != nil {
+		return types.GitspaceConfig{}, fmt.Errorf("failed to find repository of gitspace prebuild: %w", err)
+	}
+
+	creator, err := s.principalStore.Find(ctx, prebuild.CreatedBy)
+	if err != nil {
+		return types.GitspaceConfig{}, fmt.Errorf("failed to find creator of gitspace prebuild: %w", err)
+	}
+
+	repoPath := repo.Path
+
+	return types.GitspaceConfig{
+		Identifier: fmt.Sprintf("prebuild-%d", prebuild.ID),
+		Name:       prebuild.Identifier,
+		SpaceID:    repo.ParentID,
+		SpacePath:  path.Dir(repo.Path),
+		CodeRepo: types.CodeRepo{
+			URL:    s.urlProvider.GenerateGITCloneURL(ctx, repo.Path),
+			Ref:    &repoPath,
+			Type:   enum.CodeRepoTypeGitness,
+			Branch: prebuild.Branch,
+		},
+		GitspaceUser: types.GitspaceUser{
+			ID:          &creator.ID,
+			Identifier:  creator.UID,
+			Email:       creator.Email,
+			DisplayName: creator.DisplayName,
+		},
+	}, nil
+}
+
+// getImageName returns the name of the prebuild image of the commit.
+func getImageName(prebuild *types.GitspacePrebuild, sha string) string {
+	const shortSHALength = 12
+	if len(sha) > shortSHALength {
+		sha = sha[:shortSHALength]
+	}
+
+	return fmt.Sprintf("gitspace-prebuild-%d:%s", prebuild.ID, sha)
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	cleanupJobType        = "gitness:gitspace:prebuild-cleanup"
+	cleanupJobCron        = "15 * * * *" // every hour
+	cleanupJobMaxDuration = 30 * time.Minute
+)
+
+// cleanupJob removes the images of the prebuilds that haven't been rebuilt within the retention period
+// and fails the builds that have been interrupted, e.g. by a restart of the server.
+type cleanupJob struct {
+	service *Service
+}
+
+func (j *cleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+	now := time.Now()
+
+	interrupted, err := s.prebuildStore.ListByState(ctx,
+		enum.GitspacePrebuildStateBuilding, now.Add(-s.config.BuildTimeout).UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list running gitspace prebuilds: %w", err)
+	}
+
+	for _, prebuild := range interrupted {
+		_, err = s.prebuildStore.UpdateOptLock(ctx, prebuild, func(prebuild *types.GitspacePrebuild) error {
+			errorMessage := "prebuild did not finish in time"
+			prebuild.State = enum.GitspacePrebuildStateFailed
+			prebuild.ErrorMessage = &errorMessage
+			prebuild.BuildFinished = now.UnixMilli()
+			return nil
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("prebuild_id", prebuild.ID).
+				Msg("failed to mark interrupted gitspace prebuild as failed")
+		}
+	}
+
+	removed := 0
+	for _, state := range []enum.GitspacePrebuildState{
+		enum.GitspacePrebuildStateReady,
+		enum.GitspacePrebuildStateFailed,
+	} {
+		prebuilds, err := s.prebuildStore.ListByState(ctx, state, now.Add(-s.config.Retention).UnixMilli())
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s gitspace prebuilds: %w", state, err)
+		}
+
+		for _, prebuild := range prebuilds {
+			if err := s.markStale(ctx, prebuild); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Int64("prebuild_id", prebuild.ID).
+					Msg("failed to clean up stale gitspace prebuild")
+				continue
+			}
+			removed++
+		}
+	}
+
+	return fmt.Sprintf("failed %d interrupted prebuilds, cleaned up %d stale prebuilds",
+		len(interrupted), removed), nil
+}
+
+// markStale removes the image of the prebuild and marks it as stale.
+// The prebuild image is created again on the next update of the branch.
+func (s *Service) markStale(ctx context.Context, prebuild *types.GitspacePrebuild) error {
+	if err := s.RemoveImage(ctx, prebuild); err != nil {
+		return fmt.Errorf("failed to remove gitspace prebuild image: %w", err)
+	}
+
+	_, err := s.prebuildStore.UpdateOptLock(ctx, prebuild, func(prebuild *types.GitspacePrebuild) error {
+		prebuild.State = enum.GitspacePrebuildStateStale
+		prebuild.Image = ""
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark gitspace prebuild as stale: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"strings"
+	"sync"
+)
+
+// maxBuildLogSize limits the size of the stored logs of a prebuild build, the rest of the output is dropped.
+const maxBuildLogSize = 1 << 20
+
+// buildLogger collects the output of a prebuild build, so it can be stored with the prebuild.
+type buildLogger struct {
+	mx        sync.Mutex
+	buf       strings.Builder
+	truncated bool
+}
+
+func newBuildLogger() *buildLogger {
+	return &buildLogger{}
+}
+
+func (l *buildLogger) Info(msg string) {
+	l.write("INFO", msg)
+}
+
+func (l *buildLogger) Debug(msg string) {
+	l.write("DEBUG", msg)
+}
+
+func (l *buildLogger) Warn(msg string) {
+	l.write("WARN", msg)
+}
+
+func (l *buildLogger) Error(msg string, err error) {
+	if err != nil {
+		msg += ": " + err.Error()
+	}
+	l.write("ERROR", msg)
+}
+
+func (l *buildLogger) write(level, msg string) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.truncated {
+		return
+	}
+
+	line := level + ": " + msg + "\n"
+	if l.buf.Len()+len(line) > maxBuildLogSize {
+		l.buf.WriteString("... output truncated\n")
+		l.truncated = true
+		return
+	}
+
+	l.buf.WriteString(line)
+}
+
+// String returns the collected output.
+func (l *buildLogger) String() string {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	return l.buf.String()
+}
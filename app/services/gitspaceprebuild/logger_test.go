@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildLogger(t *testing.T) {
+	logger := newBuildLogger()
+	logger.Info("cloning")
+	logger.Debug("details")
+	logger.Warn("slow")
+	logger.Error("failed", errors.New("boom"))
+
+	assert.Equal(t, "INFO: cloning\nDEBUG: details\nWARN: slow\nERROR: failed: boom\n", logger.String())
+}
+
+func TestBuildLoggerTruncates(t *testing.T) {
+	logger := newBuildLogger()
+	line := strings.Repeat("x", 1024)
+	for range 2 * maxBuildLogSize / len(line) {
+		logger.Info(line)
+	}
+	logger.Info("after truncation")
+
+	logs := logger.String()
+	assert.LessOrEqual(t, len(logs), maxBuildLogSize+len("... output truncated\n"))
+	assert.True(t, strings.HasSuffix(logs, "... output truncated\n"))
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitspaceprebuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/gitspace/orchestrator"
+	"github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const groupGitEvents = "gitness:gitspaceprebuild"
+
+// ErrPrebuildsDisabled is returned when a build is requested while the prebuilds are turned off.
+var ErrPrebuildsDisabled = errors.New("gitspace prebuilds are not enabled")
+
+type Config struct {
+	// Enabled turns on building the prebuild images on branch updates.
+	Enabled         bool
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	// BuildTimeout is the maximum duration of a single prebuild image build.
+	BuildTimeout time.Duration
+	// Retention is the duration after which the image of a prebuild that wasn't rebuilt is removed.
+	Retention time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.BuildTimeout <= 0 {
+		return errors.New("config.BuildTimeout has to be a positive duration")
+	}
+	if c.Retention <= 0 {
+		return errors.New("config.Retention has to be a positive duration")
+	}
+	return nil
+}
+
+// Service builds the prebuild images of the repository branches when the branches are updated
+// and removes the images of the deleted branches and of the prebuilds that haven't been rebuilt for a while.
+type Service struct {
+	config         Config
+	orchestrator   orchestrator.Orchestrator
+	prebuildStore  store.GitspacePrebuildStore
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+	urlProvider    urlprovider.Provider
+	scheduler      *job.Scheduler
+	executor       *job.Executor
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	orchestrator orchestrator.Orchestrator,
+	prebuildStore store.GitspacePrebuildStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	urlProvider urlprovider.Provider,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided gitspace prebuild service config is invalid: %w", err)
+	}
+	service := &Service{
+		config:         config,
+		orchestrator:   orchestrator,
+		prebuildStore:  prebuildStore,
+		repoStore:      repoStore,
+		principalStore: principalStore,
+		urlProvider:    urlProvider,
+		scheduler:      scheduler,
+		executor:       executor,
+	}
+	if !config.Enabled {
+		return service, nil
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, groupGitEvents, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for gitspace prebuilds: %w", err)
+	}
+
+	return service, nil
+}
+
+// Register registers the job handlers and schedules the recurring prebuild cleanup job.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	if err := s.executor.Register(buildJobType, s); err != nil {
+		return fmt.Errorf("failed to register job handler for gitspace prebuild builds: %w", err)
+	}
+
+	if err := s.executor.Register(cleanupJobType, &cleanupJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register job handler for gitspace prebuild cleanup: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, cleanupJobType, cleanupJobType, cleanupJobCron, cleanupJobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule gitspace prebuild cleanup job: %w", err)
+	}
+
+	return nil
+}
+
+// Trigger requests a build of the prebuild for the provided commit.
+// If a build of the prebuild is already running, the commit is built after it finishes.
+func (s *Service) Trigger(ctx context.Context, prebuild *types.GitspacePrebuild, sha string) error {
+	if !s.config.Enabled {
+		return ErrPrebuildsDisabled
+	}
+
+	running := false
+	prebuild, err := s.prebuildStore.UpdateOptLock(ctx, prebuild, func(prebuild *types.GitspacePrebuild) error {
+		running = prebuild.State == enum.GitspacePrebuildStateBuilding &&
+			time.Since(time.UnixMilli(prebuild.BuildStarted)) < s.config.BuildTimeout
+		prebuild.CommitSHA = sha
+		if !running {
+			prebuild.State = enum.GitspacePrebuildStatePending
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update gitspace prebuild: %w", err)
+	}
+
+	if running {
+		return nil
+	}
+
+	return s.scheduleBuild(ctx, prebuild)
+}
+
+// RemoveImage removes the image of a prebuild. It's used when the prebuild is deleted.
+func (s *Service) RemoveImage(ctx context.Context, prebuild *types.GitspacePrebuild) error {
+	if prebuild.Image == "" {
+		return nil
+	}
+
+	return s.orchestrator.RemovePrebuildImage(ctx, prebuild.Image)
+}
+
+func (s *Service) handleEventBranchCreated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload],
+) error {
+	return s.handleBranchChange(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.SHA)
+}
+
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	return s.handleBranchChange(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.NewSHA)
+}
+
+func (s *Service) handleBranchChange(ctx context.Context, repoID int64, ref, sha string) error {
+	prebuild, err := s.findPrebuild(ctx, repoID, ref)
+	if err != nil || prebuild == nil {
+		return err
+	}
+
+	if prebuild.Disabled || prebuild.CommitSHA == sha {
+		return nil
+	}
+
+	return s.Trigger(ctx, prebuild, sha)
+}
+
+func (s *Service) handleEventBranchDeleted(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload],
+) error {
+	prebuild, err := s.findPrebuild(ctx, event.Payload.RepoID, event.Payload.Ref)
+	if err != nil || prebuild == nil {
+		return err
+	}
+
+	if err := s.RemoveImage(ctx, prebuild); err != nil {
+		return fmt.Errorf("failed to remove image of gitspace prebuild: %w", err)
+	}
+
+	if err := s.prebuildStore.Delete(ctx, prebuild.ID); err != nil {
+		return fmt.Errorf("failed to delete gitspace prebuild of deleted branch: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", prebuild.RepoID).
+		Str("branch", prebuild.Branch).
+		Msg("deleted gitspace prebuild of deleted branch")
+
+	return nil
+}
+
+// findPrebuild returns the prebuild of the branch, or nil if the branch has no prebuild.
+func (s *Service) findPrebuild(ctx context.Context, repoID int64, ref string) (*types.GitspacePrebuild, error) {
+	branch, ok := strings.CutPrefix(ref, gitReferenceNamePrefixBranch)
+	if !ok {
+		return nil, nil
+	}
+
+	prebuild, err := s.prebuildStore.FindByBranch(ctx, repoID, branch)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gitspace prebuild of branch: %w", err)
+	}
+
+	return prebuild, nil
+}
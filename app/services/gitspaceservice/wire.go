@@ -16,7 +16,9 @@ package gitspaceservice
 
 import (
 	"github.com/harness/gitness/app/services/gitspace"
+	"github.com/harness/gitness/app/services/gitspaceidle"
 	"github.com/harness/gitness/app/services/gitspaceinfraevent"
+	"github.com/harness/gitness/app/services/gitspaceprebuild"
 	"github.com/harness/gitness/app/services/infraprovider"
 
 	"github.com/google/wire"
@@ -25,5 +27,7 @@ import (
 var WireSet = wire.NewSet(
 	gitspace.WireSet,
 	gitspaceinfraevent.WireSet,
+	gitspaceidle.WireSet,
+	gitspaceprebuild.WireSet,
 	infraprovider.WireSet,
 )
@@ -0,0 +1,234 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/gomodule"
+	"github.com/harness/gitness/registry/app/pkg/gomodule/utils"
+	"github.com/harness/gitness/registry/app/store/database"
+	registrytypes "github.com/harness/gitness/registry/types"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// handleEventTagCreated publishes a module version for a semver tag. Tags of
+// modules in a subdirectory carry the directory as prefix, like the go command
+// expects them: the tag "api/v1.2.0" is the version v1.2.0 of the module in
+// the "api" directory.
+func (s *Service) handleEventTagCreated(
+	ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload],
+) error {
+	dir, version := path.Split(strings.TrimPrefix(event.Payload.Ref, api.TagPrefix))
+	dir = strings.TrimSuffix(dir, "/")
+	if !semver.IsValid(version) || semver.Canonical(version) != version {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+	rootSpace, err := s.spaceStore.GetRootSpace(ctx, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to find root space of repo: %w", err)
+	}
+	registry, err := s.registryStore.GetByRootParentIDAndName(ctx, rootSpace.ID, s.config.RegistryIdentifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find go registry: %w", err)
+	}
+	if registry.PackageType != artifact.PackageTypeGO {
+		return nil
+	}
+	canPublish, err := s.canPublishToRegistry(ctx, repo, registry)
+	if err != nil {
+		return err
+	}
+	if !canPublish {
+		log.Ctx(ctx).Info().Msgf("private repo %s can't publish to go registry %s outside of its spaces, skipping tag %s",
+			repo.Path, registry.Name, event.Payload.Ref)
+		return nil
+	}
+
+	commit, err := s.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   event.Payload.SHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get commit of tag: %w", err)
+	}
+
+	archiveFile, err := s.createTempFile(ctx, "gomodule-archive-*.zip")
+	if err != nil {
+		return err
+	}
+	defer s.removeTempFile(ctx, archiveFile)
+	params := api.ArchiveParams{
+		Format:  api.ArchiveFormatZip,
+		Treeish: commit.Commit.SHA.String(),
+	}
+	if dir != "" {
+		params.Paths = []string{dir}
+	}
+	err = s.git.Archive(ctx, git.ArchiveParams{
+		ReadParams:    git.CreateReadParams(repo),
+		ArchiveParams: params,
+	}, archiveFile)
+	if err != nil {
+		return fmt.Errorf("failed to create archive of tag: %w", err)
+	}
+	archive, err := openZip(archiveFile)
+	if err != nil {
+		return fmt.Errorf("failed to open archive of tag: %w", err)
+	}
+
+	mod, err := utils.ReadArchiveModFile(archive, dir)
+	if err != nil {
+		return events.NewDiscardEventErrorf("tag %s has no module: %s", event.Payload.Ref, err)
+	}
+	modulePath, err := utils.ModulePath(mod)
+	if err != nil {
+		return events.NewDiscardEventErrorf("tag %s has an invalid module: %s", event.Payload.Ref, err)
+	}
+	if err = utils.ValidateVersion(modulePath, version); err != nil {
+		return events.NewDiscardEventErrorf("tag %s is not a version of %s: %s", event.Payload.Ref, modulePath, err)
+	}
+
+	info := pkg.GoModuleArtifactInfo{
+		ArtifactInfo: &pkg.ArtifactInfo{
+			BaseInfo: &pkg.BaseInfo{
+				PathRoot:       rootSpace.Identifier,
+				RootIdentifier: rootSpace.Identifier,
+				RootParentID:   rootSpace.ID,
+				ParentID:       registry.ParentID,
+			},
+			RegIdentifier: registry.Name,
+			Image:         modulePath,
+		},
+		RegistryID: registry.ID,
+		Version:    version,
+	}
+	exists, err := s.localRegistry.VersionExists(ctx, info)
+	if err != nil {
+		return fmt.Errorf("failed to check module version: %w", err)
+	}
+	if exists {
+		log.Ctx(ctx).Info().Msgf("module %s version %s already exists, skipping tag %s",
+			modulePath, version, event.Payload.Ref)
+		return nil
+	}
+
+	zipFile, err := s.createTempFile(ctx, "gomodule-*.zip")
+	if err != nil {
+		return err
+	}
+	defer s.removeTempFile(ctx, zipFile)
+	err = utils.CreateZipFromArchive(zipFile, module.Version{Path: modulePath, Version: version}, archive, dir)
+	if err != nil {
+		return events.NewDiscardEventErrorf("failed to create module zip of tag %s: %s", event.Payload.Ref, err)
+	}
+	if err = zipFile.Close(); err != nil {
+		return fmt.Errorf("failed to write module zip: %w", err)
+	}
+
+	errs := s.localRegistry.PublishModule(ctx, info, gomodule.Module{
+		ZipFile: zipFile.Name(),
+		Mod:     mod,
+		Time:    commit.Commit.Committer.When,
+		Origin: &database.GoOrigin{
+			VCS:  "git",
+			URL:  s.urlProvider.GenerateGITCloneURL(ctx, repo.Path),
+			Ref:  event.Payload.Ref,
+			Hash: commit.Commit.SHA.String(),
+		},
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to publish module %s version %s: %w", modulePath, version, errs[0])
+	}
+
+	log.Ctx(ctx).Info().Msgf("published module %s version %s from tag %s of repo %s",
+		modulePath, version, event.Payload.Ref, repo.Path)
+	return nil
+}
+
+// canPublishToRegistry returns true if the registry is in the space of the repository or one of its ancestors,
+// or the repository is public.
+func (s *Service) canPublishToRegistry(
+	ctx context.Context,
+	repo *types.Repository,
+	registry *registrytypes.Registry,
+) (bool, error) {
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get parent spaces of repo: %w", err)
+	}
+	if slices.Contains(spaceIDs, registry.ParentID) {
+		return true, nil
+	}
+
+	isPublic, err := s.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
+	if err != nil {
+		return false, fmt.Errorf("failed to check public access of repo: %w", err)
+	}
+
+	return isPublic, nil
+}
+
+func (s *Service) createTempFile(ctx context.Context, pattern string) (*os.File, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to create temporary file")
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	return f, nil
+}
+
+func (s *Service) removeTempFile(ctx context.Context, f *os.File) {
+	_ = f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove temporary file %s", f.Name())
+	}
+}
+
+func openZip(f *os.File) (*zip.Reader, error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(f, stat.Size())
+}
@@ -0,0 +1,148 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"context"
+	"testing"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	registrystore "github.com/harness/gitness/registry/app/store"
+	registrytypes "github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rootSpaceID  = 1
+	teamSpaceID  = 2
+	otherSpaceID = 3
+)
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s fakeRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+// fakeSpaceStore holds the spaces root > team and root > other.
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) GetRootSpace(context.Context, int64) (*types.Space, error) {
+	return &types.Space{ID: rootSpaceID, Identifier: "root"}, nil
+}
+
+func (fakeSpaceStore) GetAncestorIDs(_ context.Context, spaceID int64) ([]int64, error) {
+	if spaceID == rootSpaceID {
+		return []int64{rootSpaceID}, nil
+	}
+	return []int64{spaceID, rootSpaceID}, nil
+}
+
+type fakeRegistryStore struct {
+	registrystore.RegistryRepository
+	registry *registrytypes.Registry
+}
+
+func (s fakeRegistryStore) GetByRootParentIDAndName(
+	context.Context,
+	int64,
+	string,
+) (*registrytypes.Registry, error) {
+	return s.registry, nil
+}
+
+type fakePublicAccess struct {
+	publicaccess.Service
+	public bool
+}
+
+func (s fakePublicAccess) Get(context.Context, enum.PublicResourceType, string) (bool, error) {
+	return s.public, nil
+}
+
+// fakeGit fails the test if the tag is read, which happens only when the module is about to be published.
+type fakeGit struct {
+	git.Interface
+	t *testing.T
+}
+
+func (g fakeGit) GetCommit(context.Context, *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	g.t.Fatal("the tag of the private repo was read for publishing")
+	return nil, nil
+}
+
+func TestHandleEventTagCreated_PrivateRepo(t *testing.T) {
+	s := &Service{
+		config:     Config{RegistryIdentifier: "go"},
+		git:        fakeGit{t: t},
+		repoStore:  fakeRepoStore{repo: &types.Repository{ID: 1, ParentID: teamSpaceID, Path: "root/team/repo"}},
+		spaceStore: fakeSpaceStore{},
+		registryStore: fakeRegistryStore{registry: &registrytypes.Registry{
+			Name:        "go",
+			ParentID:    otherSpaceID,
+			PackageType: artifact.PackageTypeGO,
+		}},
+		publicAccess: fakePublicAccess{},
+	}
+
+	err := s.handleEventTagCreated(context.Background(), &events.Event[*gitevents.TagCreatedPayload]{
+		Payload: &gitevents.TagCreatedPayload{RepoID: 1, Ref: "refs/tags/v1.0.0"},
+	})
+	require.NoError(t, err)
+}
+
+func TestCanPublishToRegistry(t *testing.T) {
+	repo := &types.Repository{ParentID: teamSpaceID, Path: "root/team/repo"}
+
+	tests := []struct {
+		name            string
+		registrySpaceID int64
+		public          bool
+		expected        bool
+	}{
+		{name: "registry in the space of the repo", registrySpaceID: teamSpaceID, expected: true},
+		{name: "registry in an ancestor space", registrySpaceID: rootSpaceID, expected: true},
+		{name: "private repo and registry in another space", registrySpaceID: otherSpaceID},
+		{name: "public repo and registry in another space", registrySpaceID: otherSpaceID, public: true, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{
+				spaceStore:   fakeSpaceStore{},
+				publicAccess: fakePublicAccess{public: test.public},
+			}
+
+			ok, err := s.canPublishToRegistry(context.Background(), repo,
+				&registrytypes.Registry{ParentID: test.registrySpaceID})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
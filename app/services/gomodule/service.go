@@ -0,0 +1,132 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/registry/app/pkg/gomodule"
+	registrystore "github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/stream"
+)
+
+const groupGitEvents = "gitness:gomodule"
+
+type Config struct {
+	// Enabled turns on publishing a module version for the semver tags pushed to the repositories.
+	Enabled bool
+	// RegistryIdentifier is the identifier of the Go registry, in the root space of
+	// a repository, the versions of the repository are published to.
+	RegistryIdentifier string
+	EventReaderName    string
+	Concurrency        int
+	MaxRetries         int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.RegistryIdentifier == "" {
+		return errors.New("config.RegistryIdentifier is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service publishes the versions of the Go modules hosted in the repositories
+// to the Go registry of their root space when a semver tag is pushed.
+// Private repositories only publish to a registry in their own space or one of its ancestors,
+// so their code isn't exposed to the users of a registry in an unrelated space.
+type Service struct {
+	config        Config
+	git           git.Interface
+	repoStore     store.RepoStore
+	spaceStore    store.SpaceStore
+	registryStore registrystore.RegistryRepository
+	localRegistry *gomodule.LocalRegistry
+	urlProvider   urlprovider.Provider
+	publicAccess  publicaccess.Service
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	git git.Interface,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	registryStore registrystore.RegistryRepository,
+	localRegistry *gomodule.LocalRegistry,
+	urlProvider urlprovider.Provider,
+	publicAccess publicaccess.Service,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided go module service config is invalid: %w", err)
+	}
+	service := &Service{
+		config:        config,
+		git:           git,
+		repoStore:     repoStore,
+		spaceStore:    spaceStore,
+		registryStore: registryStore,
+		localRegistry: localRegistry,
+		urlProvider:   urlProvider,
+		publicAccess:  publicAccess,
+	}
+	if !config.Enabled {
+		return service, nil
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, groupGitEvents, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 5 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for go modules: %w", err)
+	}
+
+	return service, nil
+}
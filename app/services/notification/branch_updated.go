@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqBranchUpdatedPayload struct {
@@ -47,7 +48,11 @@ func (s *Service) notifyPullReqBranchUpdated(
 		return nil
 	}
 
-	err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
+	err = s.sendPullReqNotification(ctx, event.ID, enum.NotificationTriggerPullReqBranchUpdated, payload.Base,
+		reviewers, pullReqSummary(payload.action(), payload.Base),
+		func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+			return c.SendPullReqBranchUpdated(ctx, recipients, payload)
+		})
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
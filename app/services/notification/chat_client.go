@@ -0,0 +1,305 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	chatRequestTimeout = 10 * time.Second
+	// chatMaxResponseBodySize is the max number of response body bytes read for error reporting.
+	chatMaxResponseBodySize = 1024
+)
+
+var _ Client = (*ChatClient)(nil)
+
+// ChatClient is a Client that posts notifications to the chat notification channels (incoming webhooks)
+// configured for the repository or any of its parent spaces that are subscribed to the notification.
+// Delivery is best-effort: failures are logged and don't fail the notification.
+type ChatClient struct {
+	channelStore store.NotificationChannelStore
+	spaceStore   store.SpaceStore
+	encrypter    encrypt.Encrypter
+	httpClient   *http.Client
+}
+
+func NewChatClient(
+	config Config,
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+	encrypter encrypt.Encrypter,
+) *ChatClient {
+	return &ChatClient{
+		channelStore: channelStore,
+		spaceStore:   spaceStore,
+		encrypter:    encrypter,
+		httpClient:   webhook.NewHTTPClient(config.ChatAllowLoopback, config.ChatAllowPrivateNetwork, false),
+	}
+}
+
+func (c *ChatClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerCommentPRAuthor, recipients, payload.Base,
+		payload.action(), payload.Text)
+}
+
+func (c *ChatClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerCommentMentions, recipients, payload.Base,
+		fmt.Sprintf("@%s mentioned %s in a comment on", payload.Commenter.DisplayName, mentionNames(recipients)),
+		payload.Text)
+}
+
+func (c *ChatClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerCommentParticipants, recipients, payload.Base,
+		fmt.Sprintf("@%s replied in a discussion on", payload.Commenter.DisplayName), payload.Text)
+}
+
+func (c *ChatClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerReviewerAdded, recipients, payload.Base,
+		payload.action(), "")
+}
+
+func (c *ChatClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerPullReqBranchUpdated, recipients, payload.Base,
+		payload.action(), "Latest commit: "+payload.NewSHA)
+}
+
+func (c *ChatClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerReviewSubmitted, recipients, payload.Base,
+		payload.action(), "")
+}
+
+func (c *ChatClient) SendReviewDismissed(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewDismissedPayload,
+) error {
+	text := ""
+	if payload.Reason != "" {
+		text = "Reason: " + payload.Reason
+	}
+
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerReviewDismissed, recipients, payload.Base,
+		payload.action(), text)
+}
+
+func (c *ChatClient) SendReviewRequested(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewRequestedPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerReviewRequested, recipients, payload.Base,
+		payload.action(), "")
+}
+
+func (c *ChatClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.postPullReqMessage(ctx, enum.NotificationTriggerPullReqStateChanged, recipients, payload.Base,
+		payload.action(), "")
+}
+
+func (c *ChatClient) SendReviewReminder(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewReminderPayload,
+) error {
+	return c.postDigestMessages(ctx, enum.NotificationTriggerReviewReminder, recipients, payload,
+		"%s, %d pull request(s) are awaiting your review")
+}
+
+func (c *ChatClient) SendReviewEscalation(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewReminderPayload,
+) error {
+	return c.postDigestMessages(ctx, enum.NotificationTriggerReviewEscalation, recipients, payload,
+		"%s, reviews of %d pull request(s) are overdue")
+}
+
+// SendDigest doesn't post anything, the daily digests are personal and only sent by email.
+func (c *ChatClient) SendDigest(context.Context, []*types.PrincipalInfo, *DigestPayload) error {
+	return nil
+}
+
+// postPullReqMessage posts a message about a single pull request. The action is followed by the pull request.
+func (c *ChatClient) postPullReqMessage(
+	ctx context.Context,
+	trigger enum.NotificationTrigger,
+	recipients []*types.PrincipalInfo,
+	base *BasePullReqPayload,
+	action string,
+	text string,
+) error {
+	c.post(ctx, base.Repo, &chatMessage{
+		Trigger:    trigger,
+		Repo:       base.Repo.Path,
+		Title:      pullReqSummary(action, base),
+		Text:       text,
+		Link:       &chatLink{Text: fmt.Sprintf("View pull request #%d", base.PullReq.Number), URL: base.PullReqURL},
+		Recipients: recipients,
+	})
+
+	return nil
+}
+
+// postDigestMessages posts a digest message per repository of the pull requests in the digest,
+// mentioning the recipients.
+func (c *ChatClient) postDigestMessages(
+	ctx context.Context,
+	trigger enum.NotificationTrigger,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewReminderPayload,
+	titleFormat string,
+) error {
+	var repos []*types.Repository
+	items := make(map[int64][]chatLink)
+	for _, pr := range payload.PullReqs {
+		if _, ok := items[pr.Repo.ID]; !ok {
+			repos = append(repos, pr.Repo)
+		}
+
+		items[pr.Repo.ID] = append(items[pr.Repo.ID], chatLink{
+			Text: fmt.Sprintf("#%d: %s (waiting for %d hours)", pr.PullReq.Number, pr.PullReq.Title, pr.PendingHours),
+			URL:  pr.PullReqURL,
+		})
+	}
+
+	for _, repo := range repos {
+		c.post(ctx, repo, &chatMessage{
+			Trigger:    trigger,
+			Repo:       repo.Path,
+			Title:      fmt.Sprintf(titleFormat, mentionNames(recipients), len(items[repo.ID])),
+			Items:      items[repo.ID],
+			Recipients: recipients,
+		})
+	}
+
+	return nil
+}
+
+// post sends the message to all enabled channels of the repository and its parent spaces
+// that are subscribed to the message trigger.
+func (c *ChatClient) post(ctx context.Context, repo *types.Repository, msg *chatMessage) {
+	spaceIDs, err := c.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get parent spaces for chat notification")
+		return
+	}
+
+	channels, err := c.channelStore.ListEnabled(ctx, repo.ID, spaceIDs)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list notification channels")
+		return
+	}
+
+	for _, channel := range channels {
+		if !channel.HasTrigger(msg.Trigger) {
+			continue
+		}
+
+		if err := c.send(ctx, channel, msg); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("notification_channel", channel.Identifier).
+				Str("notification_trigger", string(msg.Trigger)).
+				Msg("failed to post chat notification")
+		}
+	}
+}
+
+func (c *ChatClient) send(ctx context.Context, channel *types.NotificationChannel, msg *chatMessage) error {
+	body, err := formatChatMessage(channel.Type, msg)
+	if err != nil {
+		return err
+	}
+
+	url, err := c.encrypter.Decrypt([]byte(channel.URL))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt channel url: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chatRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, chatMaxResponseBodySize))
+		return fmt.Errorf("received unexpected response status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// mentionNames returns the display names of the principals, joined for use in a message.
+func mentionNames(principals []*types.PrincipalInfo) string {
+	names := make([]string, len(principals))
+	for i, principal := range principals {
+		names[i] = "@" + principal.DisplayName
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type plainEncrypter struct{}
+
+func (plainEncrypter) Encrypt(plaintext string) ([]byte, error)  { return []byte(plaintext), nil }
+func (plainEncrypter) Decrypt(ciphertext []byte) (string, error) { return string(ciphertext), nil }
+
+type stubSpaceStore struct {
+	store.SpaceStore
+	ancestorIDs []int64
+}
+
+func (s stubSpaceStore) GetAncestorIDs(context.Context, int64) ([]int64, error) {
+	return s.ancestorIDs, nil
+}
+
+type stubChannelStore struct {
+	store.NotificationChannelStore
+	channels []*types.NotificationChannel
+}
+
+func (s stubChannelStore) ListEnabled(context.Context, int64, []int64) ([]*types.NotificationChannel, error) {
+	return s.channels, nil
+}
+
+func TestChatClient_SendReviewerAdded(t *testing.T) {
+	type request struct {
+		path string
+		body map[string]any
+	}
+
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		body := map[string]any{}
+		require.NoError(t, json.Unmarshal(data, &body))
+
+		requests = append(requests, request{path: r.URL.Path, body: body})
+
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	triggers := []enum.NotificationTrigger{enum.NotificationTriggerReviewerAdded}
+	channels := []*types.NotificationChannel{
+		{Identifier: "failing", Type: enum.NotificationChannelTypeWebhook, URL: srv.URL + "/failing",
+			Triggers: triggers},
+		{Identifier: "slack", Type: enum.NotificationChannelTypeSlack, URL: srv.URL + "/slack", Triggers: triggers},
+		{Identifier: "teams", Type: enum.NotificationChannelTypeMSTeams, URL: srv.URL + "/teams", Triggers: triggers},
+		{Identifier: "other", Type: enum.NotificationChannelTypeWebhook, URL: srv.URL + "/other",
+			Triggers: []enum.NotificationTrigger{enum.NotificationTriggerReviewSubmitted}},
+	}
+
+	client := NewChatClient(
+		Config{ChatAllowLoopback: true},
+		stubChannelStore{channels: channels},
+		stubSpaceStore{ancestorIDs: []int64{1}},
+		plainEncrypter{},
+	)
+
+	reviewer := &types.PrincipalInfo{ID: 2, DisplayName: "jane"}
+	err := client.SendReviewerAdded(context.Background(), []*types.PrincipalInfo{reviewer}, &ReviewerAddedPayload{
+		Base: &BasePullReqPayload{
+			Repo:       &types.Repository{ID: 3, ParentID: 1, Path: "space/repo"},
+			PullReq:    &types.PullReq{Number: 7, Title: "Fix <bug>"},
+			PullReqURL: "http://localhost/pulls/7",
+		},
+		Reviewer: reviewer,
+	})
+	require.NoError(t, err, "chat delivery failures must not fail the notification")
+
+	require.Len(t, requests, 3)
+
+	assert.Equal(t, "/failing", requests[0].path)
+	assert.Equal(t, "reviewer_added", requests[0].body["trigger"])
+	assert.Equal(t, "http://localhost/pulls/7", requests[0].body["url"])
+
+	assert.Equal(t, "/slack", requests[1].path)
+	assert.Equal(t,
+		"[space/repo] *@jane was added as a reviewer of pull request #7: Fix &lt;bug&gt;*\n"+
+			"<http://localhost/pulls/7|View pull request #7>",
+		requests[1].body["text"])
+
+	assert.Equal(t, "/teams", requests[2].path)
+	assert.Equal(t, "MessageCard", requests[2].body["@type"])
+	assert.Equal(t, "[space/repo] @jane was added as a reviewer of pull request #7: Fix <bug>",
+		requests[2].body["title"])
+}
+
+func TestFormatChatMessage_Digest(t *testing.T) {
+	msg := &chatMessage{
+		Trigger: enum.NotificationTriggerReviewReminder,
+		Repo:    "space/repo",
+		Title:   "@jane, 2 pull request(s) are awaiting your review",
+		Items: []chatLink{
+			{Text: "#1: First (waiting for 30 hours)", URL: "http://localhost/pulls/1"},
+			{Text: "#2: Second (waiting for 50 hours)", URL: "http://localhost/pulls/2"},
+		},
+	}
+
+	data, err := formatChatMessage(enum.NotificationChannelTypeSlack, msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "[space/repo] *@jane, 2 pull request(s) are awaiting your review*\n`+
+		`• <http://localhost/pulls/1|#1: First (waiting for 30 hours)>\n`+
+		`• <http://localhost/pulls/2|#2: Second (waiting for 50 hours)>"}`, string(data))
+
+	data, err = formatChatMessage(enum.NotificationChannelTypeMSTeams, msg)
+	require.NoError(t, err)
+	card := msTeamsMessage{}
+	require.NoError(t, json.Unmarshal(data, &card))
+	assert.Equal(t, "- [#1: First (waiting for 30 hours)](http://localhost/pulls/1)\n\n"+
+		"- [#2: Second (waiting for 50 hours)](http://localhost/pulls/2)", card.Text)
+	assert.Empty(t, card.PotentialAction)
+
+	_, err = formatChatMessage("unknown", msg)
+	assert.Error(t, err)
+}
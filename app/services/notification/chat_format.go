@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// chatMessage is the channel agnostic content of a chat notification.
+type chatMessage struct {
+	Trigger    enum.NotificationTrigger
+	Repo       string
+	Title      string
+	Text       string
+	Link       *chatLink
+	Items      []chatLink
+	Recipients []*types.PrincipalInfo
+}
+
+type chatLink struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// formatChatMessage returns the request body of the message for the provided channel type.
+func formatChatMessage(channelType enum.NotificationChannelType, msg *chatMessage) ([]byte, error) {
+	var body any
+	switch channelType {
+	case enum.NotificationChannelTypeSlack:
+		body = formatSlackMessage(msg)
+	case enum.NotificationChannelTypeMSTeams:
+		body = formatMSTeamsMessage(msg)
+	case enum.NotificationChannelTypeWebhook:
+		body = formatWebhookMessage(msg)
+	default:
+		return nil, fmt.Errorf("unsupported notification channel type %q", channelType)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	return data, nil
+}
+
+// slackMessage is the payload of a Slack incoming webhook, using the mrkdwn format.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func formatSlackMessage(msg *chatMessage) slackMessage {
+	link := func(l chatLink) string {
+		return fmt.Sprintf("<%s|%s>", l.URL, slackEscaper.Replace(l.Text))
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("[")
+	sb.WriteString(slackEscaper.Replace(msg.Repo))
+	sb.WriteString("] *")
+	sb.WriteString(slackEscaper.Replace(msg.Title))
+	sb.WriteString("*")
+
+	if msg.Text != "" {
+		sb.WriteString("\n>")
+		sb.WriteString(strings.ReplaceAll(slackEscaper.Replace(msg.Text), "\n", "\n>"))
+	}
+
+	for _, item := range msg.Items {
+		sb.WriteString("\n• ")
+		sb.WriteString(link(item))
+	}
+
+	if msg.Link != nil {
+		sb.WriteString("\n")
+		sb.WriteString(link(*msg.Link))
+	}
+
+	return slackMessage{Text: sb.String()}
+}
+
+// msTeamsMessage is the payload of a Microsoft Teams incoming webhook (legacy actionable message card).
+type msTeamsMessage struct {
+	Type            string          `json:"@type"`
+	Context         string          `json:"@context"`
+	Summary         string          `json:"summary"`
+	Title           string          `json:"title"`
+	Text            string          `json:"text,omitempty"`
+	PotentialAction []msTeamsAction `json:"potentialAction,omitempty"`
+}
+
+type msTeamsAction struct {
+	Type    string          `json:"@type"`
+	Name    string          `json:"name"`
+	Targets []msTeamsTarget `json:"targets"`
+}
+
+type msTeamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func formatMSTeamsMessage(msg *chatMessage) msTeamsMessage {
+	lines := make([]string, 0, len(msg.Items)+1)
+	if msg.Text != "" {
+		lines = append(lines, msg.Text)
+	}
+	for _, item := range msg.Items {
+		lines = append(lines, fmt.Sprintf("- [%s](%s)", item.Text, item.URL))
+	}
+
+	card := msTeamsMessage{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: msg.Title,
+		Title:   fmt.Sprintf("[%s] %s", msg.Repo, msg.Title),
+		Text:    strings.Join(lines, "\n\n"),
+	}
+
+	if msg.Link != nil {
+		card.PotentialAction = []msTeamsAction{{
+			Type:    "OpenUri",
+			Name:    msg.Link.Text,
+			Targets: []msTeamsTarget{{OS: "default", URI: msg.Link.URL}},
+		}}
+	}
+
+	return card
+}
+
+// webhookMessage is the payload posted to generic webhook channels.
+type webhookMessage struct {
+	Trigger    enum.NotificationTrigger `json:"trigger"`
+	Repo       string                   `json:"repo"`
+	Title      string                   `json:"title"`
+	Text       string                   `json:"text,omitempty"`
+	URL        string                   `json:"url,omitempty"`
+	Items      []chatLink               `json:"items,omitempty"`
+	Recipients []*types.PrincipalInfo   `json:"recipients"`
+}
+
+func formatWebhookMessage(msg *chatMessage) webhookMessage {
+	m := webhookMessage{
+		Trigger:    msg.Trigger,
+		Repo:       msg.Repo,
+		Title:      msg.Title,
+		Text:       msg.Text,
+		Items:      msg.Items,
+		Recipients: msg.Recipients,
+	}
+	if msg.Link != nil {
+		m.URL = msg.Link.URL
+	}
+
+	return m
+}
@@ -21,7 +21,7 @@ import (
 )
 
 // Client is an interface for sending notifications, such as emails, Slack messages etc.
-// It is implemented by MailClient and in future we can have other implementations for other channels like Slack etc.
+// It is implemented by MailClient, and by ChatClient for the chat notification channels.
 type Client interface {
 	SendCommentPRAuthor(
 		ctx context.Context,
@@ -58,4 +58,29 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendReviewReminder(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *ReviewReminderPayload,
+	) error
+	SendReviewEscalation(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *ReviewReminderPayload,
+	) error
+	SendReviewDismissed(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *ReviewDismissedPayload,
+	) error
+	SendReviewRequested(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *ReviewRequestedPayload,
+	) error
+	SendDigest(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *DigestPayload,
+	) error
 }
@@ -49,7 +49,11 @@ func (s *Service) notifyCommentCreated(
 	}
 
 	if len(mentions) > 0 {
-		err = s.notificationClient.SendCommentMentions(ctx, mentions, payload)
+		err = s.sendPullReqNotification(ctx, event.ID, gitnessenum.NotificationTriggerCommentMentions, payload.Base,
+			mentions, pullReqSummary(payload.action(), payload.Base),
+			func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+				return c.SendCommentMentions(ctx, recipients, payload)
+			})
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to mentions for event %s for pullReqID %d: %w",
@@ -61,7 +65,11 @@ func (s *Service) notifyCommentCreated(
 	}
 
 	if len(participants) > 0 {
-		err = s.notificationClient.SendCommentParticipants(ctx, participants, payload)
+		err = s.sendPullReqNotification(ctx, event.ID, gitnessenum.NotificationTriggerCommentParticipants, payload.Base,
+			participants, pullReqSummary(payload.action(), payload.Base),
+			func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+				return c.SendCommentParticipants(ctx, recipients, payload)
+			})
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to participants for event %s for pullReqID %d: %w",
@@ -73,11 +81,11 @@ func (s *Service) notifyCommentCreated(
 	}
 
 	if author != nil {
-		err = s.notificationClient.SendCommentPRAuthor(
-			ctx,
-			[]*types.PrincipalInfo{author},
-			payload,
-		)
+		err = s.sendPullReqNotification(ctx, event.ID, gitnessenum.NotificationTriggerCommentPRAuthor, payload.Base,
+			[]*types.PrincipalInfo{author}, pullReqSummary(payload.action(), payload.Base),
+			func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+				return c.SendCommentPRAuthor(ctx, recipients, payload)
+			})
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to author for event %s for pullReqID %d: %w",
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDigests        = "gitness:notification:digests"
+	jobCronDigests        = "0 7 * * *" // Every day at 07:00.
+	jobMaxDurationDigests = 30 * time.Minute
+)
+
+// DigestRepo holds the queued notifications about the pull requests of a repository.
+type DigestRepo struct {
+	Repo  *types.Repository
+	Items []*types.NotificationDigestItem
+}
+
+// DigestPayload is the daily digest of the notifications queued for a single recipient.
+type DigestPayload struct {
+	Recipient *types.PrincipalInfo
+	Repos     []*DigestRepo
+	Count     int
+}
+
+// digestJob is the job handler that sends the daily digest emails.
+type digestJob struct {
+	service *Service
+}
+
+// Handle sends a single email to every user with queued notifications and removes the sent notifications.
+func (j digestJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+
+	principalIDs, err := s.notificationDigestStore.ListPrincipalIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list users with queued notifications: %w", err)
+	}
+
+	repos := make(map[int64]*types.Repository)
+	sent := 0
+	for _, principalID := range principalIDs {
+		if err := s.sendDigest(ctx, principalID, repos); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("principal_id", principalID).Msg("failed to send digest")
+			continue
+		}
+
+		sent++
+	}
+
+	if sent == 0 {
+		return "", nil
+	}
+
+	result := fmt.Sprintf("sent %d digests", sent)
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// sendDigest emails the queued notifications of the user grouped by repository.
+// The repos map caches the repositories across users.
+func (s *Service) sendDigest(ctx context.Context, principalID int64, repos map[int64]*types.Repository) error {
+	recipient, err := s.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to get recipient: %w", err)
+	}
+
+	items, err := s.notificationDigestStore.List(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to list queued notifications: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	payload := &DigestPayload{Recipient: recipient, Count: len(items)}
+	digestRepos := make(map[int64]*DigestRepo)
+	for _, item := range items {
+		digestRepo, ok := digestRepos[item.RepoID]
+		if !ok {
+			repo, ok := repos[item.RepoID]
+			if !ok {
+				repo, err = s.repoStore.Find(ctx, item.RepoID)
+				if err != nil {
+					return fmt.Errorf("failed to find repo: %w", err)
+				}
+				repos[item.RepoID] = repo
+			}
+
+			digestRepo = &DigestRepo{Repo: repo}
+			digestRepos[item.RepoID] = digestRepo
+			payload.Repos = append(payload.Repos, digestRepo)
+		}
+
+		digestRepo.Items = append(digestRepo.Items, item)
+	}
+
+	err = s.notificationClient.SendDigest(ctx, []*types.PrincipalInfo{recipient}, payload)
+	if err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+
+	err = s.notificationDigestStore.Delete(ctx, principalID, items[len(items)-1].ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete sent notifications: %w", err)
+	}
+
+	return nil
+}
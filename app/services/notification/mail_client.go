@@ -32,6 +32,15 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateReviewReminder       = "review_reminder.html"
+	TemplateReviewEscalation     = "review_escalation.html"
+	TemplateReviewDismissed      = "review_dismissed.html"
+	TemplateReviewRequested      = "review_requested.html"
+	TemplateDigest               = "digest.html"
+
+	subjectReviewReminder   = "%d pull request(s) awaiting your review"
+	subjectReviewEscalation = "%d pull request(s) with overdue reviews"
+	subjectDigest           = "Your daily summary: %d notification(s)"
 )
 
 type MailClient struct {
@@ -118,6 +127,44 @@ func (m MailClient) SendReviewerAdded(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendReviewDismissed(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewDismissedPayload,
+) error {
+	email, err := GenerateEmailFromPayload(
+		TemplateReviewDismissed,
+		recipients,
+		payload.Base,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.ReviewDismissedEvent, err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
+func (m MailClient) SendReviewRequested(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewRequestedPayload,
+) error {
+	email, err := GenerateEmailFromPayload(
+		TemplateReviewRequested,
+		recipients,
+		payload.Base,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.ReviewRequestedEvent, err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
 func (m MailClient) SendPullReqBranchUpdated(
 	ctx context.Context,
 	recipients []*types.PrincipalInfo,
@@ -169,6 +216,60 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendReviewReminder(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewReminderPayload,
+) error {
+	email, err := GenerateDigestEmail(
+		TemplateReviewReminder,
+		fmt.Sprintf(subjectReviewReminder, len(payload.PullReqs)),
+		recipients,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail request for review reminder: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
+func (m MailClient) SendReviewEscalation(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewReminderPayload,
+) error {
+	email, err := GenerateDigestEmail(
+		TemplateReviewEscalation,
+		fmt.Sprintf(subjectReviewEscalation, len(payload.PullReqs)),
+		recipients,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail request for review escalation: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
+func (m MailClient) SendDigest(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *DigestPayload,
+) error {
+	email, err := GenerateDigestEmail(
+		TemplateDigest,
+		fmt.Sprintf(subjectDigest, payload.Count),
+		recipients,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail request for digest: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
@@ -212,6 +313,25 @@ func GenerateEmailFromPayload(
 	return &email, nil
 }
 
+// GenerateDigestEmail generates an email that isn't related to a single pull request.
+func GenerateDigestEmail(
+	templateName string,
+	subject string,
+	recipients []*types.PrincipalInfo,
+	payload interface{},
+) (*mailer.Payload, error) {
+	body, err := GetHTMLBody(templateName, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var email mailer.Payload
+	email.Body = string(body)
+	email.Subject = subject
+	email.ToRecipients = RetrieveEmailsFromPrincipals(recipients)
+	return &email, nil
+}
+
 func RetrieveEmailsFromPrincipals(principals []*types.PrincipalInfo) []string {
 	emails := make([]string, len(principals))
 	for i, principal := range principals {
@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pullReqSender sends a pull request notification to the recipients using the provided client.
+type pullReqSender func(ctx context.Context, client Client, recipients []*types.PrincipalInfo) error
+
+// sendPullReqNotification delivers a pull request notification to the chat channels of the repository
+// and, according to their notification settings, emails it to the recipients instantly
+// or queues it for their daily digest. The email and the chat post are delivered independently,
+// a failure of one doesn't prevent the other. Chat posts are best effort and their failures are only logged,
+// so the returned error, and the retry of the event it causes, concern the email alone.
+// The digest items are keyed by the ID of the event, so a retried event doesn't queue them again.
+func (s *Service) sendPullReqNotification(
+	ctx context.Context,
+	eventID string,
+	trigger enum.NotificationTrigger,
+	base *BasePullReqPayload,
+	recipients []*types.PrincipalInfo,
+	summary string,
+	send pullReqSender,
+) error {
+	instant, err := s.applyNotificationPreferences(ctx, eventID, trigger, base, recipients, summary)
+	if err != nil {
+		return err
+	}
+
+	// chat channels are configured per repository and space, so they don't depend on the users' settings.
+	if err := send(ctx, s.chatClient, recipients); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("event_id", eventID).Int64("repo_id", base.Repo.ID).
+			Msg("failed to post chat notification")
+	}
+
+	if len(instant) == 0 {
+		return nil
+	}
+
+	if err := send(ctx, s.notificationClient, instant); err != nil {
+		return fmt.Errorf("failed to send email notification: %w", err)
+	}
+
+	return nil
+}
+
+// applyNotificationPreferences returns the recipients that should be notified instantly.
+// Recipients that ignore the repository or turned the notification off are dropped,
+// and the notification is queued for the daily digest of the recipients that chose it.
+func (s *Service) applyNotificationPreferences(
+	ctx context.Context,
+	eventID string,
+	trigger enum.NotificationTrigger,
+	base *BasePullReqPayload,
+	recipients []*types.PrincipalInfo,
+	summary string,
+) ([]*types.PrincipalInfo, error) {
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+
+	principalIDs := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		principalIDs[i] = recipient.ID
+	}
+
+	modes, err := s.notificationPreferenceStore.MapRepoModes(ctx, base.Repo.ID, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository notification modes: %w", err)
+	}
+
+	deliveries, err := s.notificationPreferenceStore.MapDeliveries(ctx, trigger, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification deliveries: %w", err)
+	}
+
+	instant := make([]*types.PrincipalInfo, 0, len(recipients))
+	for _, recipient := range recipients {
+		if modes[recipient.ID] == enum.RepoNotificationModeIgnore {
+			continue
+		}
+
+		switch deliveries[recipient.ID] {
+		case enum.NotificationDeliveryOff:
+		case enum.NotificationDeliveryDigest:
+			err = s.notificationDigestStore.Create(ctx, &types.NotificationDigestItem{
+				PrincipalID: recipient.ID,
+				RepoID:      base.Repo.ID,
+				EventID:     eventID,
+				Trigger:     trigger,
+				Title:       summary,
+				URL:         base.PullReqURL,
+				Created:     time.Now().UnixMilli(),
+			})
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Int64("principal_id", recipient.ID).
+					Msg("failed to queue notification for the digest")
+			}
+		case enum.NotificationDeliveryInstant, "":
+			instant = append(instant, recipient)
+		}
+	}
+
+	return instant, nil
+}
+
+// filterReviewDigest removes the pull requests of the repositories ignored by the recipient from the digest.
+// It returns false if the recipient turned the notification off.
+// Review reminders are digests already, so they are never queued for the daily digest.
+func (s *Service) filterReviewDigest(
+	ctx context.Context,
+	trigger enum.NotificationTrigger,
+	digest *ReviewReminderPayload,
+) (*ReviewReminderPayload, bool, error) {
+	deliveries, err := s.notificationPreferenceStore.MapDeliveries(ctx, trigger, []int64{digest.Recipient.ID})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get notification deliveries: %w", err)
+	}
+
+	if deliveries[digest.Recipient.ID] == enum.NotificationDeliveryOff {
+		return nil, false, nil
+	}
+
+	repoPreferences, err := s.notificationPreferenceStore.ListRepos(ctx, digest.Recipient.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list repository notification modes: %w", err)
+	}
+
+	ignored := make(map[int64]bool)
+	for _, preference := range repoPreferences {
+		ignored[preference.RepoID] = preference.Mode == enum.RepoNotificationModeIgnore
+	}
+
+	filtered := &ReviewReminderPayload{Recipient: digest.Recipient}
+	for _, pr := range digest.PullReqs {
+		if !ignored[pr.Repo.ID] {
+			filtered.PullReqs = append(filtered.PullReqs, pr)
+		}
+	}
+
+	return filtered, len(filtered.PullReqs) > 0, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPreferenceStore struct {
+	store.NotificationPreferenceStore
+	deliveries map[int64]enum.NotificationDelivery
+	modes      map[int64]enum.RepoNotificationMode
+}
+
+func (s stubPreferenceStore) MapDeliveries(
+	context.Context,
+	enum.NotificationTrigger,
+	[]int64,
+) (map[int64]enum.NotificationDelivery, error) {
+	return s.deliveries, nil
+}
+
+func (s stubPreferenceStore) MapRepoModes(
+	context.Context,
+	int64,
+	[]int64,
+) (map[int64]enum.RepoNotificationMode, error) {
+	return s.modes, nil
+}
+
+func (s stubPreferenceStore) ListRepos(context.Context, int64) ([]*types.RepoNotificationPreference, error) {
+	return nil, nil
+}
+
+type stubDigestStore struct {
+	store.NotificationDigestStore
+	items []*types.NotificationDigestItem
+}
+
+func (s *stubDigestStore) Create(_ context.Context, item *types.NotificationDigestItem) error {
+	s.items = append(s.items, item)
+	return nil
+}
+
+// recordingClient records the recipients of the notifications it's asked to send.
+type recordingClient struct {
+	Client
+	recipients [][]int64
+	err        error
+}
+
+func (c *recordingClient) SendReviewerAdded(
+	_ context.Context,
+	recipients []*types.PrincipalInfo,
+	_ *ReviewerAddedPayload,
+) error {
+	ids := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.ID
+	}
+	c.recipients = append(c.recipients, ids)
+	return c.err
+}
+
+func TestSendPullReqNotification(t *testing.T) {
+	mail := &recordingClient{}
+	chat := &recordingClient{}
+	digests := &stubDigestStore{}
+
+	s := &Service{
+		notificationClient: mail,
+		chatClient:         chat,
+		notificationPreferenceStore: stubPreferenceStore{
+			deliveries: map[int64]enum.NotificationDelivery{
+				2: enum.NotificationDeliveryDigest,
+				3: enum.NotificationDeliveryOff,
+				4: enum.NotificationDeliveryInstant,
+			},
+			modes: map[int64]enum.RepoNotificationMode{
+				4: enum.RepoNotificationModeIgnore,
+				5: enum.RepoNotificationModeWatch,
+			},
+		},
+		notificationDigestStore: digests,
+	}
+
+	recipients := []*types.PrincipalInfo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+	payload := &ReviewerAddedPayload{
+		Base: &BasePullReqPayload{
+			Repo:       &types.Repository{ID: 10},
+			PullReq:    &types.PullReq{Number: 7, Title: "Title"},
+			PullReqURL: "http://localhost/pulls/7",
+		},
+		Reviewer: &types.PrincipalInfo{DisplayName: "jane"},
+	}
+
+	err := s.sendPullReqNotification(context.Background(), "event", enum.NotificationTriggerReviewerAdded, payload.Base,
+		recipients, pullReqSummary(payload.action(), payload.Base),
+		func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+			return c.SendReviewerAdded(ctx, recipients, payload)
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]int64{{1, 5}}, mail.recipients, "only users with instant delivery are emailed")
+	assert.Equal(t, [][]int64{{1, 2, 3, 4, 5}}, chat.recipients, "chat channels don't depend on user settings")
+
+	require.Len(t, digests.items, 1)
+	assert.Equal(t, int64(2), digests.items[0].PrincipalID)
+	assert.Equal(t, int64(10), digests.items[0].RepoID)
+	assert.Equal(t, "@jane was added as a reviewer of pull request #7: Title", digests.items[0].Title)
+	assert.Equal(t, "http://localhost/pulls/7", digests.items[0].URL)
+}
+
+func TestSendPullReqNotification_DeliversIndependently(t *testing.T) {
+	errMail := errors.New("mail server unavailable")
+	errChat := errors.New("chat unavailable")
+
+	mail := &recordingClient{err: errMail}
+	chat := &recordingClient{}
+
+	s := &Service{
+		notificationClient:          mail,
+		chatClient:                  chat,
+		notificationPreferenceStore: stubPreferenceStore{},
+		notificationDigestStore:     &stubDigestStore{},
+	}
+
+	payload := &ReviewerAddedPayload{
+		Base:     &BasePullReqPayload{Repo: &types.Repository{ID: 10}, PullReq: &types.PullReq{Number: 7}},
+		Reviewer: &types.PrincipalInfo{DisplayName: "jane"},
+	}
+	send := func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+		return c.SendReviewerAdded(ctx, recipients, payload)
+	}
+
+	err := s.sendPullReqNotification(context.Background(), "event", enum.NotificationTriggerReviewerAdded, payload.Base,
+		[]*types.PrincipalInfo{{ID: 1}}, "", send)
+	require.ErrorIs(t, err, errMail)
+	assert.Equal(t, [][]int64{{1}}, chat.recipients, "a failed email doesn't skip the chat post")
+
+	// a failed chat post isn't returned, so it doesn't cause a retry that emails the recipients again.
+	mail.err = nil
+	chat.err = errChat
+	err = s.sendPullReqNotification(context.Background(), "event", enum.NotificationTriggerReviewerAdded, payload.Base,
+		[]*types.PrincipalInfo{{ID: 1}}, "", send)
+	require.NoError(t, err)
+	assert.Equal(t, [][]int64{{1}, {1}}, mail.recipients)
+}
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqState string
@@ -51,11 +52,7 @@ func (s *Service) notifyPullReqStateMerged(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
+	if err = s.sendPullReqStateChanged(ctx, event.ID, recipients, payload); err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
 			pullreqevents.MergedEvent,
@@ -80,11 +77,7 @@ func (s *Service) notifyPullReqStateClosed(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
+	if err = s.sendPullReqStateChanged(ctx, event.ID, recipients, payload); err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
 			pullreqevents.ClosedEvent,
@@ -109,11 +102,7 @@ func (s *Service) notifyPullReqStateReOpened(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
+	if err = s.sendPullReqStateChanged(ctx, event.ID, recipients, payload); err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
 			pullreqevents.ReopenedEvent,
@@ -124,6 +113,19 @@ func (s *Service) notifyPullReqStateReOpened(
 	return nil
 }
 
+func (s *Service) sendPullReqStateChanged(
+	ctx context.Context,
+	eventID string,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return s.sendPullReqNotification(ctx, eventID, enum.NotificationTriggerPullReqStateChanged, payload.Base,
+		recipients, pullReqSummary(payload.action(), payload.Base),
+		func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+			return c.SendPullReqStateChanged(ctx, recipients, payload)
+		})
+}
+
 func (s *Service) processPullReqStateChangedEvent(
 	ctx context.Context,
 	baseEvent pullreqevents.Base,
@@ -0,0 +1,549 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeReviewReminders        = "gitness:notification:review-reminders"
+	jobCronReviewReminders        = "23 * * * *" // At minute 23 past every hour.
+	jobMaxDurationReviewReminders = 15 * time.Minute
+)
+
+// ReviewReminderPullReq is a pull request with reviews pending for longer than the SLA of its repository.
+type ReviewReminderPullReq struct {
+	Repo             *types.Repository
+	PullReq          *types.PullReq
+	PullReqURL       string
+	PendingReviewers []*types.PrincipalInfo
+	// PendingHours is the number of hours the longest pending review has been waiting for.
+	PendingHours int64
+}
+
+// ReviewReminderPayload is a digest of pull requests with pending reviews sent to a single recipient.
+type ReviewReminderPayload struct {
+	Recipient *types.PrincipalInfo
+	PullReqs  []*ReviewReminderPullReq
+}
+
+// reviewSLA holds the review reminder settings of a repository.
+type reviewSLA struct {
+	reminder         time.Duration
+	escalation       time.Duration
+	escalationGroups []int64
+}
+
+// reviewDigests collects the pull requests to notify each recipient about.
+type reviewDigests map[int64]*ReviewReminderPayload
+
+func (d reviewDigests) add(recipient *types.PrincipalInfo, pr *ReviewReminderPullReq) {
+	digest, ok := d[recipient.ID]
+	if !ok {
+		digest = &ReviewReminderPayload{Recipient: recipient}
+		d[recipient.ID] = digest
+	}
+
+	digest.PullReqs = append(digest.PullReqs, pr)
+}
+
+// reviewChatPost is a chat post about a single pull request that mentions the recipients.
+type reviewChatPost struct {
+	pullReq    *ReviewReminderPullReq
+	recipients []*types.PrincipalInfo
+}
+
+// reviewNotifications collects the review notifications of a single type that are due.
+// Emails are sent as a digest per recipient, while the chat channels get a single post per pull request.
+// Each channel keeps its own record of the sent notifications, so a failure of one doesn't resend the other.
+type reviewNotifications struct {
+	emailType enum.PullReqReviewReminderType
+	chatType  enum.PullReqReviewReminderType
+	digests   reviewDigests
+	chatPosts map[int64]*reviewChatPost
+}
+
+func newReviewNotifications(
+	emailType enum.PullReqReviewReminderType,
+	chatType enum.PullReqReviewReminderType,
+) *reviewNotifications {
+	return &reviewNotifications{
+		emailType: emailType,
+		chatType:  chatType,
+		digests:   reviewDigests{},
+		chatPosts: map[int64]*reviewChatPost{},
+	}
+}
+
+// addIfDue adds the pull request to the email digest of the recipient and to the chat post about the pull request,
+// each if no notification was sent through the channel during the last interval.
+func (n *reviewNotifications) addIfDue(
+	recipient *types.PrincipalInfo,
+	pr *ReviewReminderPullReq,
+	pendingSince int64,
+	lastSent map[enum.PullReqReviewReminderType]map[int64]int64,
+	interval time.Duration,
+	now time.Time,
+) {
+	if isReviewNotificationDue(pendingSince, lastSent[n.emailType][recipient.ID], interval, now) {
+		n.digests.add(recipient, pr)
+	}
+
+	if isReviewNotificationDue(pendingSince, lastSent[n.chatType][recipient.ID], interval, now) {
+		post, ok := n.chatPosts[pr.PullReq.ID]
+		if !ok {
+			post = &reviewChatPost{pullReq: pr}
+			n.chatPosts[pr.PullReq.ID] = post
+		}
+
+		post.recipients = append(post.recipients, recipient)
+	}
+}
+
+// Register registers the job handlers and schedules the recurring review reminder and digest jobs.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobTypeReviewReminders, s); err != nil {
+		return fmt.Errorf("failed to register job handler for review reminders: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(
+		ctx,
+		jobTypeReviewReminders,
+		jobTypeReviewReminders,
+		jobCronReviewReminders,
+		jobMaxDurationReviewReminders,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule review reminders job: %w", err)
+	}
+
+	if err := s.executor.Register(jobTypeDigests, digestJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register job handler for digests: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeDigests,
+		jobTypeDigests,
+		jobCronDigests,
+		jobMaxDurationDigests,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule digests job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle sends digest reminders to the reviewers of open pull requests that haven't submitted a review
+// within the SLA of the repository, and escalates the reviews still pending after the escalation SLA.
+// Every notification is recorded, so a recipient is notified about a pull request at most once per SLA interval.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	repoIDs, err := s.listRepoIDsWithReviewSLA(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	reminders := newReviewNotifications(
+		enum.PullReqReviewReminderTypeReminder,
+		enum.PullReqReviewReminderTypeChatReminder,
+	)
+	escalations := newReviewNotifications(
+		enum.PullReqReviewReminderTypeEscalation,
+		enum.PullReqReviewReminderTypeChatEscalation,
+	)
+
+	for _, repoID := range repoIDs {
+		err = s.collectPendingReviews(ctx, repoID, now, reminders, escalations)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to process pending reviews of repo")
+		}
+	}
+
+	sentReminders := s.sendReviewNotifications(ctx, reminders, now)
+	sentEscalations := s.sendReviewNotifications(ctx, escalations, now)
+
+	if sentReminders == 0 && sentEscalations == 0 {
+		return "", nil
+	}
+
+	result := fmt.Sprintf("sent %d review reminders and %d review escalations", sentReminders, sentEscalations)
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) listRepoIDsWithReviewSLA(ctx context.Context) ([]int64, error) {
+	var repoIDs []int64
+	seen := map[int64]struct{}{}
+
+	for _, key := range []settings.Key{settings.KeyReviewReminderSLAInHours, settings.KeyReviewEscalationSLAInHours} {
+		ids, err := s.settings.RepoListIDs(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repos with review SLA: %w", err)
+		}
+
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+
+			seen[id] = struct{}{}
+			repoIDs = append(repoIDs, id)
+		}
+	}
+
+	return repoIDs, nil
+}
+
+func (s *Service) getReviewSLA(ctx context.Context, repoID int64) (reviewSLA, error) {
+	var reminderInHours, escalationInHours, escalationUserGroupID int64
+	err := s.settings.RepoMap(ctx, repoID,
+		settings.Mapping(settings.KeyReviewReminderSLAInHours, &reminderInHours),
+		settings.Mapping(settings.KeyReviewEscalationSLAInHours, &escalationInHours),
+		settings.Mapping(settings.KeyReviewEscalationUserGroupID, &escalationUserGroupID),
+	)
+	if err != nil {
+		return reviewSLA{}, fmt.Errorf("failed to get review SLA settings: %w", err)
+	}
+
+	sla := reviewSLA{
+		reminder:   time.Duration(reminderInHours) * time.Hour,
+		escalation: time.Duration(escalationInHours) * time.Hour,
+	}
+	if escalationUserGroupID > 0 {
+		sla.escalationGroups = []int64{escalationUserGroupID}
+	}
+
+	return sla, nil
+}
+
+// collectPendingReviews adds the open pull requests of the repository with overdue reviews to the digests.
+func (s *Service) collectPendingReviews(
+	ctx context.Context,
+	repoID int64,
+	now time.Time,
+	reminders *reviewNotifications,
+	escalations *reviewNotifications,
+) error {
+	sla, err := s.getReviewSLA(ctx, repoID)
+	if err != nil {
+		return err
+	}
+
+	if sla.reminder <= 0 && sla.escalation <= 0 {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	const largeLimit = 1000000
+
+	pullReqs, err := s.pullReqStore.List(ctx, &types.PullReqFilter{
+		Size:         largeLimit,
+		TargetRepoID: repoID,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list open pull requests: %w", err)
+	}
+
+	for _, pr := range pullReqs {
+		if pr.IsDraft {
+			continue
+		}
+
+		err = s.collectPendingReviewsOfPullReq(ctx, repo, pr, sla, now, reminders, escalations)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("pullreq_id", pr.ID).
+				Msg("failed to process pending reviews of pull request")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) collectPendingReviewsOfPullReq(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	sla reviewSLA,
+	now time.Time,
+	reminders *reviewNotifications,
+	escalations *reviewNotifications,
+) error {
+	reviewers, err := s.pullReqReviewersStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list reviewers: %w", err)
+	}
+
+	pending := pendingReviewers(reviewers, pr.CreatedBy)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	history, err := s.reviewReminderStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list review reminders: %w", err)
+	}
+
+	lastSent := make(map[enum.PullReqReviewReminderType]map[int64]int64)
+	for _, reminder := range history {
+		if lastSent[reminder.Type] == nil {
+			lastSent[reminder.Type] = make(map[int64]int64)
+		}
+		lastSent[reminder.Type][reminder.PrincipalID] = reminder.Updated
+	}
+
+	pendingSince := pending[0].Created
+	pendingInfos := make([]*types.PrincipalInfo, len(pending))
+	for i, reviewer := range pending {
+		pendingSince = min(pendingSince, reviewer.Created)
+		pendingInfos[i] = &reviewer.Reviewer
+	}
+
+	entry := &ReviewReminderPullReq{
+		Repo:             repo,
+		PullReq:          pr,
+		PullReqURL:       s.urlProvider.GenerateUIPRURL(ctx, repo.Path, pr.Number),
+		PendingReviewers: pendingInfos,
+		PendingHours:     int64(now.Sub(time.UnixMilli(pendingSince)) / time.Hour),
+	}
+
+	if sla.reminder > 0 {
+		for _, reviewer := range pending {
+			reminders.addIfDue(&reviewer.Reviewer, entry, reviewer.Created, lastSent, sla.reminder, now)
+		}
+	}
+
+	if sla.escalation <= 0 || now.Sub(time.UnixMilli(pendingSince)) < sla.escalation {
+		return nil
+	}
+
+	recipients, err := s.getEscalationRecipients(ctx, repo, pr, reviewers, sla)
+	if err != nil {
+		return fmt.Errorf("failed to get escalation recipients: %w", err)
+	}
+
+	for _, recipient := range recipients {
+		escalations.addIfDue(recipient, entry, pendingSince, lastSent, sla.escalation, now)
+	}
+
+	return nil
+}
+
+// getEscalationRecipients returns the members of the escalation user group, if configured,
+// or the code owners of the files changed by the pull request. The code owners are used as well
+// when the escalation user group doesn't resolve to any user.
+func (s *Service) getEscalationRecipients(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	reviewers []*types.PullReqReviewer,
+	sla reviewSLA,
+) ([]*types.PrincipalInfo, error) {
+	var ids []int64
+
+	if len(sla.escalationGroups) > 0 {
+		userIDs, err := s.userGroupService.ListUserIDsByGroupIDs(ctx, sla.escalationGroups)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users of escalation user group: %w", err)
+		}
+
+		if len(userIDs) == 0 {
+			log.Ctx(ctx).Debug().Ints64("user_group_ids", sla.escalationGroups).
+				Msg("escalation user group has no members, escalating to code owners")
+		}
+
+		ids = userIDs
+	}
+
+	if len(ids) == 0 {
+		ownerIDs, err := s.getCodeOwnerIDs(ctx, repo, pr, reviewers)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = ownerIDs
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	infos, err := s.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal infos of escalation recipients: %w", err)
+	}
+
+	recipients := make([]*types.PrincipalInfo, 0, len(infos))
+	for _, info := range infos {
+		if info.ID == pr.CreatedBy {
+			continue
+		}
+		recipients = append(recipients, info)
+	}
+
+	return recipients, nil
+}
+
+// getCodeOwnerIDs returns the IDs of the code owners of the files changed by the pull request.
+func (s *Service) getCodeOwnerIDs(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	reviewers []*types.PullReqReviewer,
+) ([]int64, error) {
+	evaluation, err := s.codeOwners.Evaluate(ctx, repo.Core(), pr, reviewers)
+	if errors.Is(err, codeowners.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate code owners: %w", err)
+	}
+
+	var ids []int64
+	for _, entry := range evaluation.EvaluationEntries {
+		for _, owner := range entry.OwnerEvaluations {
+			ids = append(ids, owner.Owner.ID)
+		}
+		for _, group := range entry.UserGroupOwnerEvaluations {
+			for _, owner := range group.Evaluations {
+				ids = append(ids, owner.Owner.ID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// sendReviewNotifications posts the review notifications to the chat channels, sends the email digests
+// and records the notifications of each channel. Returns the number of sent digests.
+func (s *Service) sendReviewNotifications(
+	ctx context.Context,
+	notifications *reviewNotifications,
+	now time.Time,
+) int {
+	trigger := enum.NotificationTriggerReviewReminder
+	send := Client.SendReviewReminder
+	if notifications.emailType == enum.PullReqReviewReminderTypeEscalation {
+		trigger = enum.NotificationTriggerReviewEscalation
+		send = Client.SendReviewEscalation
+	}
+
+	// chat channels are configured per repository and space, so they don't depend on the users' settings.
+	for _, post := range notifications.chatPosts {
+		payload := &ReviewReminderPayload{PullReqs: []*ReviewReminderPullReq{post.pullReq}}
+		if err := send(s.chatClient, ctx, post.recipients, payload); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("pullreq_id", post.pullReq.PullReq.ID).
+				Msgf("failed to post review %s to chat channels", notifications.emailType)
+			continue
+		}
+
+		for _, recipient := range post.recipients {
+			s.recordReviewNotification(ctx, post.pullReq.PullReq.ID, recipient.ID, notifications.chatType, now)
+		}
+	}
+
+	sent := 0
+	for _, digest := range notifications.digests {
+		filtered, ok, err := s.filterReviewDigest(ctx, trigger, digest)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("principal_id", digest.Recipient.ID).
+				Msgf("failed to apply notification settings to review %s", notifications.emailType)
+			continue
+		}
+
+		if ok {
+			err := send(s.notificationClient, ctx, []*types.PrincipalInfo{digest.Recipient}, filtered)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Int64("principal_id", digest.Recipient.ID).
+					Msgf("failed to send review %s", notifications.emailType)
+				continue
+			}
+
+			sent++
+		}
+
+		for _, pr := range digest.PullReqs {
+			s.recordReviewNotification(ctx, pr.PullReq.ID, digest.Recipient.ID, notifications.emailType, now)
+		}
+	}
+
+	return sent
+}
+
+func (s *Service) recordReviewNotification(
+	ctx context.Context,
+	pullReqID int64,
+	principalID int64,
+	reminderType enum.PullReqReviewReminderType,
+	now time.Time,
+) {
+	err := s.reviewReminderStore.Upsert(ctx, &types.PullReqReviewReminder{
+		PullReqID:   pullReqID,
+		PrincipalID: principalID,
+		Type:        reminderType,
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to record review %s", reminderType)
+	}
+}
+
+// pendingReviewers returns the requested reviewers that haven't submitted a review yet.
+func pendingReviewers(reviewers []*types.PullReqReviewer, authorID int64) []*types.PullReqReviewer {
+	pending := make([]*types.PullReqReviewer, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if reviewer.PrincipalID == authorID ||
+			reviewer.Type == enum.PullReqReviewerTypeSelfAssigned ||
+			reviewer.ReviewDecision != enum.PullReqReviewDecisionPending {
+			continue
+		}
+
+		pending = append(pending, reviewer)
+	}
+
+	return pending
+}
+
+// isReviewNotificationDue returns true if the review has been pending for at least the provided interval
+// and no notification about it was sent during the last interval.
+func isReviewNotificationDue(pendingSince, lastSent int64, interval time.Duration, now time.Time) bool {
+	if now.Sub(time.UnixMilli(pendingSince)) < interval {
+		return false
+	}
+
+	return lastSent == 0 || now.Sub(time.UnixMilli(lastSent)) >= interval
+}
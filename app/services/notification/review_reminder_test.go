@@ -0,0 +1,236 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingReviewers(t *testing.T) {
+	const authorID = 1
+	reviewers := []*types.PullReqReviewer{
+		{PrincipalID: 2, Type: enum.PullReqReviewerTypeRequested, ReviewDecision: enum.PullReqReviewDecisionPending},
+		{PrincipalID: 3, Type: enum.PullReqReviewerTypeAssigned, ReviewDecision: enum.PullReqReviewDecisionApproved},
+		{PrincipalID: 4, Type: enum.PullReqReviewerTypeSelfAssigned, ReviewDecision: enum.PullReqReviewDecisionPending},
+		{PrincipalID: 5, Type: enum.PullReqReviewerTypeAssigned, ReviewDecision: enum.PullReqReviewDecisionPending},
+		{PrincipalID: authorID, Type: enum.PullReqReviewerTypeRequested, ReviewDecision: enum.PullReqReviewDecisionPending},
+	}
+
+	var ids []int64
+	for _, reviewer := range pendingReviewers(reviewers, authorID) {
+		ids = append(ids, reviewer.PrincipalID)
+	}
+
+	assert.Equal(t, []int64{2, 5}, ids)
+}
+
+func TestIsReviewNotificationDue(t *testing.T) {
+	now := time.Now()
+	hoursAgo := func(h int) int64 { return now.Add(-time.Duration(h) * time.Hour).UnixMilli() }
+
+	tests := []struct {
+		name         string
+		pendingSince int64
+		lastSent     int64
+		expected     bool
+	}{
+		{name: "within SLA", pendingSince: hoursAgo(5), expected: false},
+		{name: "overdue, never notified", pendingSince: hoursAgo(30), expected: true},
+		{name: "overdue, notified during the interval", pendingSince: hoursAgo(30), lastSent: hoursAgo(6),
+			expected: false},
+		{name: "overdue, notified before the interval", pendingSince: hoursAgo(60), lastSent: hoursAgo(25),
+			expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			due := isReviewNotificationDue(test.pendingSince, test.lastSent, 24*time.Hour, now)
+			assert.Equal(t, test.expected, due)
+		})
+	}
+}
+
+type fakeUserGroupService struct {
+	usergroup.SearchService
+	userIDs []int64
+}
+
+func (s fakeUserGroupService) ListUserIDsByGroupIDs(context.Context, []int64) ([]int64, error) {
+	return s.userIDs, nil
+}
+
+type fakePrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (fakePrincipalInfoCache) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	infos := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		infos[id] = &types.PrincipalInfo{ID: id}
+	}
+	return infos, nil
+}
+
+// fakeGit holds a repository without a code owners file.
+type fakeGit struct {
+	git.Interface
+	lookups int
+}
+
+func (g *fakeGit) GetTreeNode(context.Context, *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	g.lookups++
+	return nil, errors.NotFound("path not found")
+}
+
+func TestGetEscalationRecipients(t *testing.T) {
+	const authorID = 1
+	repo := &types.Repository{ID: 1, GitUID: "repo", DefaultBranch: "main"}
+	pr := &types.PullReq{ID: 1, CreatedBy: authorID, TargetBranch: "main"}
+
+	tests := []struct {
+		name         string
+		groups       []int64
+		groupUserIDs []int64
+		expected     []int64
+		lookups      int
+	}{
+		{
+			name:         "group members",
+			groups:       []int64{10},
+			groupUserIDs: []int64{2, 3, authorID},
+			expected:     []int64{2, 3},
+		},
+		{
+			name:    "group without members falls back to code owners",
+			groups:  []int64{10},
+			lookups: 1,
+		},
+		{
+			name:    "code owners",
+			lookups: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitFake := &fakeGit{}
+			s := &Service{
+				principalInfoCache: fakePrincipalInfoCache{},
+				userGroupService:   fakeUserGroupService{userIDs: test.groupUserIDs},
+				codeOwners: codeowners.New(nil, gitFake,
+					codeowners.Config{FilePaths: []string{"CODEOWNERS"}}, nil, nil),
+			}
+
+			recipients, err := s.getEscalationRecipients(context.Background(), repo, pr, nil,
+				reviewSLA{escalationGroups: test.groups})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(recipients))
+			for _, recipient := range recipients {
+				ids = append(ids, recipient.ID)
+			}
+
+			assert.ElementsMatch(t, test.expected, ids)
+			assert.Equal(t, test.lookups, gitFake.lookups)
+		})
+	}
+}
+
+type stubReviewReminderStore struct {
+	store.PullReqReviewReminderStore
+	recorded map[enum.PullReqReviewReminderType][]int64
+}
+
+func (s *stubReviewReminderStore) Upsert(_ context.Context, reminder *types.PullReqReviewReminder) error {
+	s.recorded[reminder.Type] = append(s.recorded[reminder.Type], reminder.PrincipalID)
+	return nil
+}
+
+// reminderClient records the recipients of the review reminders it's asked to send.
+type reminderClient struct {
+	Client
+	recipients [][]int64
+	err        error
+}
+
+func (c *reminderClient) SendReviewReminder(
+	_ context.Context,
+	recipients []*types.PrincipalInfo,
+	_ *ReviewReminderPayload,
+) error {
+	ids := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.ID
+	}
+	c.recipients = append(c.recipients, ids)
+	return c.err
+}
+
+func TestSendReviewNotifications(t *testing.T) {
+	now := time.Now()
+	pendingSince := now.Add(-3 * time.Hour).UnixMilli()
+	pr := &ReviewReminderPullReq{
+		Repo:    &types.Repository{ID: 1},
+		PullReq: &types.PullReq{ID: 7},
+	}
+
+	mail := &reminderClient{err: errors.Internal(nil, "mail server unavailable")}
+	chat := &reminderClient{}
+	reminders := &stubReviewReminderStore{recorded: map[enum.PullReqReviewReminderType][]int64{}}
+
+	s := &Service{
+		notificationClient:          mail,
+		chatClient:                  chat,
+		notificationPreferenceStore: stubPreferenceStore{},
+		reviewReminderStore:         reminders,
+	}
+
+	notifications := newReviewNotifications(
+		enum.PullReqReviewReminderTypeReminder,
+		enum.PullReqReviewReminderTypeChatReminder,
+	)
+	lastSent := map[enum.PullReqReviewReminderType]map[int64]int64{
+		// the chat post mentioning the reviewer 3 was sent already, the email wasn't.
+		enum.PullReqReviewReminderTypeChatReminder: {3: now.Add(-time.Minute).UnixMilli()},
+	}
+	for _, id := range []int64{2, 3, 4} {
+		notifications.addIfDue(&types.PrincipalInfo{ID: id}, pr, pendingSince, lastSent, time.Hour, now)
+	}
+
+	sent := s.sendReviewNotifications(context.Background(), notifications, now)
+	assert.Equal(t, 0, sent)
+
+	require.Len(t, chat.recipients, 1, "the chat channels get a single post per pull request")
+	assert.ElementsMatch(t, []int64{2, 4}, chat.recipients[0])
+	assert.Len(t, mail.recipients, 3)
+
+	assert.ElementsMatch(t, []int64{2, 4}, reminders.recorded[enum.PullReqReviewReminderTypeChatReminder],
+		"the chat post is recorded although the emails failed")
+	assert.Empty(t, reminders.recorded[enum.PullReqReviewReminderTypeReminder],
+		"the failed emails aren't recorded, so they are sent again")
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReviewRequestedPayload struct {
+	Base        *BasePullReqPayload
+	RequestedBy *types.PrincipalInfo
+	Reviewer    *types.PrincipalInfo
+}
+
+func (s *Service) notifyReviewRequested(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewRequestedPayload],
+) error {
+	payload, recipients, err := s.processReviewRequestedEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for pullReqID %d: %w",
+			pullreqevents.ReviewRequestedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	err = s.sendPullReqNotification(ctx, event.ID, enum.NotificationTriggerReviewRequested, payload.Base, recipients,
+		pullReqSummary(payload.action(), payload.Base),
+		func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+			return c.SendReviewRequested(ctx, recipients, payload)
+		})
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send notification for event %s for pullReqID %d: %w",
+			pullreqevents.ReviewRequestedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) processReviewRequestedEvent(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewRequestedPayload],
+) (*ReviewRequestedPayload, []*types.PrincipalInfo, error) {
+	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
+	}
+
+	requestedBy, err := s.principalInfoCache.Get(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get principal from principalInfoCache: %w", err)
+	}
+
+	reviewer, err := s.principalInfoCache.Get(ctx, event.Payload.ReviewerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get reviewer from principalInfoCache: %w", err)
+	}
+
+	return &ReviewRequestedPayload{
+		Base:        base,
+		RequestedBy: requestedBy,
+		Reviewer:    reviewer,
+	}, []*types.PrincipalInfo{reviewer}, nil
+}
@@ -45,11 +45,11 @@ func (s *Service) notifyReviewSubmitted(
 		)
 	}
 
-	err = s.notificationClient.SendReviewSubmitted(
-		ctx,
-		recipients,
-		notificationPayload,
-	)
+	err = s.sendPullReqNotification(ctx, event.ID, enum.NotificationTriggerReviewSubmitted, notificationPayload.Base,
+		recipients, pullReqSummary(notificationPayload.action(), notificationPayload.Base),
+		func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+			return c.SendReviewSubmitted(ctx, recipients, notificationPayload)
+		})
 
 	if err != nil {
 		return fmt.Errorf(
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type ReviewerAddedPayload struct {
@@ -42,7 +43,11 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
-	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
+	err = s.sendPullReqNotification(ctx, event.ID, enum.NotificationTriggerReviewerAdded, payload.Base, recipients,
+		pullReqSummary(payload.action(), payload.Base),
+		func(ctx context.Context, c Client, recipients []*types.PrincipalInfo) error {
+			return c.SendReviewerAdded(ctx, recipients, payload)
+		})
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
@@ -23,9 +23,13 @@ import (
 	"path"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
 )
@@ -82,11 +86,16 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// ChatAllowLoopback and ChatAllowPrivateNetwork control the hosts chat notification channels can post to.
+	ChatAllowLoopback       bool
+	ChatAllowPrivateNetwork bool
 }
 
 type Service struct {
 	config                Config
 	notificationClient    Client
+	chatClient            Client
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
@@ -96,12 +105,22 @@ type Service struct {
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
 	urlProvider           url.Provider
+	reviewReminderStore   store.PullReqReviewReminderStore
+	settings              *settings.Service
+	codeOwners            *codeowners.Service
+	userGroupService      usergroup.SearchService
+	scheduler             *job.Scheduler
+	executor              *job.Executor
+
+	notificationPreferenceStore store.NotificationPreferenceStore
+	notificationDigestStore     store.NotificationDigestStore
 }
 
 func NewService(
 	ctx context.Context,
 	config Config,
 	notificationClient Client,
+	chatClient Client,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
@@ -111,10 +130,19 @@ func NewService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	reviewReminderStore store.PullReqReviewReminderStore,
+	settings *settings.Service,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.SearchService,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	notificationPreferenceStore store.NotificationPreferenceStore,
+	notificationDigestStore store.NotificationDigestStore,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
+		chatClient:            chatClient,
 		prReaderFactory:       prReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
@@ -124,6 +152,15 @@ func NewService(
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
 		urlProvider:           urlProvider,
+		reviewReminderStore:   reviewReminderStore,
+		settings:              settings,
+		codeOwners:            codeOwners,
+		userGroupService:      userGroupService,
+		scheduler:             scheduler,
+		executor:              executor,
+
+		notificationPreferenceStore: notificationPreferenceStore,
+		notificationDigestStore:     notificationDigestStore,
 	}
 
 	_, err := service.prReaderFactory.Launch(
@@ -142,6 +179,8 @@ func NewService(
 			_ = r.RegisterCommentCreated(service.notifyCommentCreated)
 			_ = r.RegisterBranchUpdated(service.notifyPullReqBranchUpdated)
 			_ = r.RegisterReviewSubmitted(service.notifyReviewSubmitted)
+			_ = r.RegisterReviewDismissed(service.notifyReviewDismissed)
+			_ = r.RegisterReviewRequested(service.notifyReviewRequested)
 
 			// state changes
 			_ = r.RegisterMerged(service.notifyPullReqStateMerged)
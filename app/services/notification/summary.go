@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// pullReqSummary returns a single line summary of a notification about a pull request,
+// used by chat messages and digest emails. The action is followed by the pull request.
+func pullReqSummary(action string, base *BasePullReqPayload) string {
+	return fmt.Sprintf("%s pull request #%d: %s", action, base.PullReq.Number, base.PullReq.Title)
+}
+
+func (p *CommentPayload) action() string {
+	return fmt.Sprintf("@%s commented on", p.Commenter.DisplayName)
+}
+
+func (p *ReviewerAddedPayload) action() string {
+	return fmt.Sprintf("@%s was added as a reviewer of", p.Reviewer.DisplayName)
+}
+
+func (p *PullReqBranchUpdatedPayload) action() string {
+	return fmt.Sprintf("@%s pushed new commits to", p.Committer.DisplayName)
+}
+
+func (p *ReviewSubmittedPayload) action() string {
+	action := "reviewed"
+	switch p.Decision {
+	case enum.PullReqReviewDecisionApproved:
+		action = "approved"
+	case enum.PullReqReviewDecisionChangeReq:
+		action = "requested changes on"
+	case enum.PullReqReviewDecisionPending, enum.PullReqReviewDecisionReviewed:
+	}
+
+	return fmt.Sprintf("@%s %s", p.Reviewer.DisplayName, action)
+}
+
+func (p *PullReqStateChangedPayload) action() string {
+	return fmt.Sprintf("@%s %s", p.ChangedBy.DisplayName, p.State)
+}
+
+func (p *ReviewDismissedPayload) action() string {
+	if p.Automatic {
+		return fmt.Sprintf("The approval of @%s was dismissed after new commits changed viewed files of",
+			p.Reviewer.DisplayName)
+	}
+
+	review := "review"
+	if p.Decision == enum.PullReqReviewDecisionChangeReq {
+		review = "change request"
+	}
+
+	return fmt.Sprintf("@%s dismissed the %s of @%s on", p.DismissedBy.DisplayName, review, p.Reviewer.DisplayName)
+}
+
+func (p *ReviewRequestedPayload) action() string {
+	return fmt.Sprintf("@%s requested a new review from @%s of", p.RequestedBy.DisplayName, p.Reviewer.DisplayName)
+}
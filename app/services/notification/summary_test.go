@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewDismissedPayload_Action(t *testing.T) {
+	admin := &types.PrincipalInfo{DisplayName: "admin"}
+	reviewer := &types.PrincipalInfo{DisplayName: "reviewer"}
+
+	tests := []struct {
+		name     string
+		payload  *ReviewDismissedPayload
+		expected string
+	}{
+		{
+			name: "change request",
+			payload: &ReviewDismissedPayload{
+				DismissedBy: admin,
+				Reviewer:    reviewer,
+				Decision:    enum.PullReqReviewDecisionChangeReq,
+				Reason:      "addressed offline",
+			},
+			expected: "@admin dismissed the change request of @reviewer on",
+		},
+		{
+			name: "stale approval",
+			payload: &ReviewDismissedPayload{
+				DismissedBy: admin,
+				Reviewer:    reviewer,
+				Decision:    enum.PullReqReviewDecisionApproved,
+				Automatic:   true,
+			},
+			expected: "The approval of @reviewer was dismissed after new commits changed viewed files of",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.payload.action())
+		})
+	}
+}
@@ -18,22 +18,29 @@ import (
 	"context"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideMailClient,
+	ProvideChatClient,
 	ProvideNotificationService,
 )
 
 func ProvideNotificationService(
 	ctx context.Context,
 	notificationClient Client,
+	chatClient *ChatClient,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
 	pullReqStore store.PullReqStore,
@@ -44,11 +51,20 @@ func ProvideNotificationService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	reviewReminderStore store.PullReqReviewReminderStore,
+	settings *settings.Service,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.SearchService,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	notificationPreferenceStore store.NotificationPreferenceStore,
+	notificationDigestStore store.NotificationDigestStore,
 ) (*Service, error) {
 	return NewService(
 		ctx,
 		pullReqConfig,
 		notificationClient,
+		chatClient,
 		prReaderFactory,
 		pullReqStore,
 		repoStore,
@@ -58,9 +74,26 @@ func ProvideNotificationService(
 		pullReqActivityStore,
 		spacePathStore,
 		urlProvider,
+		reviewReminderStore,
+		settings,
+		codeOwners,
+		userGroupService,
+		scheduler,
+		executor,
+		notificationPreferenceStore,
+		notificationDigestStore,
 	)
 }
 
+func ProvideChatClient(
+	config Config,
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+	encrypter encrypt.Encrypter,
+) *ChatClient {
+	return NewChatClient(config, channelStore, spaceStore, encrypter)
+}
+
 func ProvideMailClient(mailer mailer.Mailer) Client {
 	return NewMailClient(mailer)
 }
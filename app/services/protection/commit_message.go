@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// Placeholders supported by the merge commit message template.
+const (
+	CommitMessagePlaceholderNumber       = "{number}"
+	CommitMessagePlaceholderTitle        = "{title}"
+	CommitMessagePlaceholderDescription  = "{description}"
+	CommitMessagePlaceholderSourceBranch = "{source_branch}"
+	CommitMessagePlaceholderTargetBranch = "{target_branch}"
+	CommitMessagePlaceholderAuthor       = "{author}"
+	CommitMessagePlaceholderCoAuthors    = "{co_authors}"
+	CommitMessagePlaceholderReviewers    = "{reviewers}"
+)
+
+var (
+	commitMessagePlaceholders = []string{
+		CommitMessagePlaceholderNumber,
+		CommitMessagePlaceholderTitle,
+		CommitMessagePlaceholderDescription,
+		CommitMessagePlaceholderSourceBranch,
+		CommitMessagePlaceholderTargetBranch,
+		CommitMessagePlaceholderAuthor,
+		CommitMessagePlaceholderCoAuthors,
+		CommitMessagePlaceholderReviewers,
+	}
+
+	commitMessagePlaceholderRegex = regexp.MustCompile(`\{[a-z_]+\}`)
+)
+
+// CommitMessageIdentity is a person referenced in a merge commit message.
+type CommitMessageIdentity struct {
+	Name  string
+	Email string
+}
+
+func (i CommitMessageIdentity) String() string {
+	return fmt.Sprintf("%s <%s>", i.Name, i.Email)
+}
+
+// CommitMessageValues holds the values used to replace the placeholders of a merge commit message template.
+type CommitMessageValues struct {
+	Number       int64
+	Title        string
+	Description  string
+	SourceBranch string
+	TargetBranch string
+	Author       CommitMessageIdentity
+	// CoAuthors are the authors of the pull request commits other than the pull request author.
+	CoAuthors []CommitMessageIdentity
+	// Reviewers are the reviewers who approved the pull request.
+	Reviewers []CommitMessageIdentity
+}
+
+// CommitMessageTemplateUses returns true if the template contains the provided placeholder.
+func CommitMessageTemplateUses(template, placeholder string) bool {
+	return strings.Contains(template, placeholder)
+}
+
+// RenderCommitMessageTemplate replaces all placeholders in the template with the provided values.
+// The first line of the result is returned as the commit title and the remaining lines as the commit message.
+func RenderCommitMessageTemplate(template string, values CommitMessageValues) (string, string) {
+	r := strings.NewReplacer(
+		CommitMessagePlaceholderNumber, strconv.FormatInt(values.Number, 10),
+		CommitMessagePlaceholderTitle, values.Title,
+		CommitMessagePlaceholderDescription, values.Description,
+		CommitMessagePlaceholderSourceBranch, values.SourceBranch,
+		CommitMessagePlaceholderTargetBranch, values.TargetBranch,
+		CommitMessagePlaceholderAuthor, values.Author.String(),
+		CommitMessagePlaceholderCoAuthors, commitMessageTrailers("Co-authored-by", values.CoAuthors),
+		CommitMessagePlaceholderReviewers, commitMessageTrailers("Reviewed-by", values.Reviewers),
+	)
+
+	title, message, _ := strings.Cut(strings.TrimSpace(r.Replace(template)), "\n")
+
+	return strings.TrimSpace(title), strings.TrimSpace(message)
+}
+
+func commitMessageTrailers(key string, identities []CommitMessageIdentity) string {
+	lines := make([]string, len(identities))
+	for i, identity := range identities {
+		lines[i] = key + ": " + identity.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func validateCommitMessageTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return nil
+	}
+
+	for _, placeholder := range commitMessagePlaceholderRegex.FindAllString(template, -1) {
+		if !slices.Contains(commitMessagePlaceholders, placeholder) {
+			return fmt.Errorf("unrecognized placeholder %s, supported placeholders are: %s",
+				placeholder, strings.Join(commitMessagePlaceholders, ", "))
+		}
+	}
+
+	title, _, _ := strings.Cut(strings.TrimSpace(template), "\n")
+	if strings.Contains(title, CommitMessagePlaceholderCoAuthors) ||
+		strings.Contains(title, CommitMessagePlaceholderReviewers) ||
+		strings.Contains(title, CommitMessagePlaceholderDescription) {
+		return fmt.Errorf("placeholders %s, %s and %s can't be used in the first line of the template",
+			CommitMessagePlaceholderDescription, CommitMessagePlaceholderCoAuthors, CommitMessagePlaceholderReviewers)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"testing"
+)
+
+func TestRenderCommitMessageTemplate(t *testing.T) {
+	values := CommitMessageValues{
+		Number:       7,
+		Title:        "feat: add templates",
+		Description:  "Adds commit message templates.",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		Author:       CommitMessageIdentity{Name: "Jane", Email: "jane@example.com"},
+		CoAuthors: []CommitMessageIdentity{
+			{Name: "Max", Email: "max@example.com"},
+		},
+		Reviewers: []CommitMessageIdentity{
+			{Name: "Ann", Email: "ann@example.com"},
+			{Name: "Bob", Email: "bob@example.com"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		template   string
+		expTitle   string
+		expMessage string
+	}{
+		{
+			name:     "title-only",
+			template: "{title} (#{number})",
+			expTitle: "feat: add templates (#7)",
+		},
+		{
+			name:     "title-and-message",
+			template: "{title} (#{number})\n\n{description}\n\n{co_authors}\n{reviewers}",
+			expTitle: "feat: add templates (#7)",
+			expMessage: "Adds commit message templates.\n\n" +
+				"Co-authored-by: Max <max@example.com>\n" +
+				"Reviewed-by: Ann <ann@example.com>\n" +
+				"Reviewed-by: Bob <bob@example.com>",
+		},
+		{
+			name:       "branches-and-author",
+			template:   "Merge {source_branch} into {target_branch}\n\nAuthor: {author}",
+			expTitle:   "Merge feature into main",
+			expMessage: "Author: Jane <jane@example.com>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			title, message := RenderCommitMessageTemplate(test.template, values)
+			if title != test.expTitle {
+				t.Errorf("title mismatch: want=%q got=%q", test.expTitle, title)
+			}
+			if message != test.expMessage {
+				t.Errorf("message mismatch: want=%q got=%q", test.expMessage, message)
+			}
+		})
+	}
+}
+
+func TestValidateCommitMessageTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expErr   bool
+	}{
+		{name: "empty", template: ""},
+		{name: "valid", template: "{title} (#{number})\n\n{co_authors}"},
+		{name: "unknown-placeholder", template: "{title} {unknown}", expErr: true},
+		{name: "trailers-in-title", template: "{title} {reviewers}", expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateCommitMessageTemplate(test.template)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -85,6 +85,13 @@ func (v *Branch) RequiredChecks(
 	}, nil
 }
 
+func (v *Branch) ApprovalPolicy(
+	ctx context.Context,
+	in ApprovalPolicyInput,
+) (ApprovalPolicyOutput, error) {
+	return v.PullReq.ApprovalPolicy(ctx, in)
+}
+
 func (v *Branch) RefChangeVerify(
 	ctx context.Context,
 	in RefChangeVerifyInput,
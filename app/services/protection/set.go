@@ -58,6 +58,10 @@ func (s ruleSet) MergeVerify(
 			out.RequiresCodeOwnersApprovalLatest = out.RequiresCodeOwnersApprovalLatest || rOut.RequiresCodeOwnersApprovalLatest
 			out.RequiresCommentResolution = out.RequiresCommentResolution || rOut.RequiresCommentResolution
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
+			if out.CommitMessageTemplate == "" {
+				// the first matching rule that defines a commit message template wins
+				out.CommitMessageTemplate = rOut.CommitMessageTemplate
+			}
 
 			return nil
 		})
@@ -104,6 +108,30 @@ func (s ruleSet) RequiredChecks(
 	}, nil
 }
 
+func (s ruleSet) ApprovalPolicy(
+	ctx context.Context,
+	in ApprovalPolicyInput,
+) (ApprovalPolicyOutput, error) {
+	var out ApprovalPolicyOutput
+
+	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
+		func(_ *types.RuleInfoInternal, p Protection) error {
+			rOut, err := p.ApprovalPolicy(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			out.DismissStaleApprovals = out.DismissStaleApprovals || rOut.DismissStaleApprovals
+
+			return nil
+		})
+	if err != nil {
+		return ApprovalPolicyOutput{}, fmt.Errorf("failed to process each rule in ruleSet: %w", err)
+	}
+
+	return out, nil
+}
+
 func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations []types.RuleViolations
 
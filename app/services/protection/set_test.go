@@ -16,6 +16,7 @@ package protection
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -462,6 +463,77 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 	}
 }
 
+func TestRuleSet_ApprovalPolicy(t *testing.T) {
+	rule := func(id int64, pattern, definition string) types.RuleInfoInternal {
+		return types.RuleInfoInternal{
+			RuleInfo: types.RuleInfo{
+				RepoPath:   "space/repo",
+				ID:         id,
+				Identifier: fmt.Sprintf("rule%d", id),
+				Type:       TypeBranch,
+				State:      enum.RuleStateActive,
+			},
+			Pattern:    []byte(pattern),
+			Definition: []byte(definition),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		rules  []types.RuleInfoInternal
+		expOut ApprovalPolicyOutput
+	}{
+		{
+			name:   "empty",
+			rules:  []types.RuleInfoInternal{},
+			expOut: ApprovalPolicyOutput{},
+		},
+		{
+			name: "dismiss-stale-approvals",
+			rules: []types.RuleInfoInternal{
+				rule(1, `{"default":true}`, `{"pullreq":{"approvals":{"require_minimum_count":1}}}`),
+				rule(2, `{"default":true}`, `{"pullreq":{"approvals":{"dismiss_stale_approvals":true}}}`),
+			},
+			expOut: ApprovalPolicyOutput{DismissStaleApprovals: true},
+		},
+		{
+			name: "dismiss-stale-approvals-other-branch",
+			rules: []types.RuleInfoInternal{
+				rule(1, `{"include":["release/*"]}`, `{"pullreq":{"approvals":{"dismiss_stale_approvals":true}}}`),
+			},
+			expOut: ApprovalPolicyOutput{},
+		},
+	}
+
+	ctx := context.Background()
+
+	m := NewManager(nil)
+	_ = m.Register(TypeBranch, func() Definition {
+		return &Branch{}
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := ruleSet{
+				rules:   test.rules,
+				manager: m,
+			}
+
+			out, err := set.ApprovalPolicy(ctx, ApprovalPolicyInput{
+				Repo:    &types.RepositoryCore{ID: 1, DefaultBranch: "main"},
+				PullReq: &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "main"},
+			})
+			if err != nil {
+				t.Errorf("got error: %s", err.Error())
+			}
+
+			if want, got := test.expOut, out; !reflect.DeepEqual(want, got) {
+				t.Errorf("output: want=%+v got=%+v", want, got)
+			}
+		})
+	}
+}
+
 func TestIntersectSorted(t *testing.T) {
 	tests := []struct {
 		name string
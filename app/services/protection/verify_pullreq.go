@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/harness/gitness/app/services/codeowners"
@@ -32,6 +33,7 @@ type (
 	MergeVerifier interface {
 		MergeVerify(ctx context.Context, in MergeVerifyInput) (MergeVerifyOutput, []types.RuleViolations, error)
 		RequiredChecks(ctx context.Context, in RequiredChecksInput) (RequiredChecksOutput, error)
+		ApprovalPolicy(ctx context.Context, in ApprovalPolicyInput) (ApprovalPolicyOutput, error)
 	}
 
 	MergeVerifyInput struct {
@@ -57,6 +59,7 @@ type (
 		RequiresCodeOwnersApprovalLatest    bool
 		RequiresCommentResolution           bool
 		RequiresNoChangeRequests            bool
+		CommitMessageTemplate               string
 	}
 
 	RequiredChecksInput struct {
@@ -71,6 +74,17 @@ type (
 		RequiredIdentifiers   map[string]struct{}
 		BypassableIdentifiers map[string]struct{}
 	}
+
+	ApprovalPolicyInput struct {
+		Repo    *types.RepositoryCore
+		PullReq *types.PullReq
+	}
+
+	ApprovalPolicyOutput struct {
+		// DismissStaleApprovals is set if approvals should be dismissed when new commits change
+		// files the reviewer viewed since the approved commit, or any file if the reviewer viewed none.
+		DismissStaleApprovals bool
+	}
 )
 
 // ensures that the DefPullReq type implements Sanitizer and MergeVerifier interface.
@@ -93,6 +107,7 @@ const (
 	codePullReqMergeStrategiesAllowed = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
 	codePullReqMergeBlock             = "pullreq.merge.blocked"
+	codePullReqMergeTitlePattern      = "pullreq.merge.require_title_pattern"
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
@@ -110,6 +125,7 @@ func (v *DefPullReq) MergeVerify(
 	out.DeleteSourceBranch = v.Merge.DeleteBranch
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
+	out.CommitMessageTemplate = v.Merge.CommitMessageTemplate
 
 	// output that depends on approval of latest commit
 	if v.Approvals.RequireLatestCommit {
@@ -251,6 +267,18 @@ func (v *DefPullReq) MergeVerify(
 			"The merge for the branch %s is not allowed.", in.PullReq.TargetBranch)
 	}
 
+	if v.Merge.RequireTitlePattern != "" {
+		titleRegex, err := regexp.Compile(v.Merge.RequireTitlePattern)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to compile title pattern: %w", err)
+		}
+
+		if !titleRegex.MatchString(in.PullReq.Title) {
+			violations.Addf(codePullReqMergeTitlePattern,
+				"The pull request title must match the pattern %q.", v.Merge.RequireTitlePattern)
+		}
+	}
+
 	if len(violations.Violations) > 0 {
 		return out, []types.RuleViolations{violations}, nil
 	}
@@ -271,11 +299,21 @@ func (v *DefPullReq) RequiredChecks(
 	}, nil
 }
 
+func (v *DefPullReq) ApprovalPolicy(
+	_ context.Context,
+	_ ApprovalPolicyInput,
+) (ApprovalPolicyOutput, error) {
+	return ApprovalPolicyOutput{
+		DismissStaleApprovals: v.Approvals.DismissStaleApprovals,
+	}, nil
+}
+
 type DefApprovals struct {
 	RequireCodeOwners      bool `json:"require_code_owners,omitempty"`
 	RequireMinimumCount    int  `json:"require_minimum_count,omitempty"`
 	RequireLatestCommit    bool `json:"require_latest_commit,omitempty"`
 	RequireNoChangeRequest bool `json:"require_no_change_request,omitempty"`
+	DismissStaleApprovals  bool `json:"dismiss_stale_approvals,omitempty"`
 }
 
 func (v *DefApprovals) Sanitize() error {
@@ -349,9 +387,26 @@ type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
 	Block             bool               `json:"block,omitempty"`
+
+	// RequireTitlePattern is a regular expression the pull request title must match.
+	RequireTitlePattern string `json:"require_title_pattern,omitempty"`
+	// CommitMessageTemplate is the template of the commit message used by the merge and squash merge methods.
+	CommitMessageTemplate string `json:"commit_message_template,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
+	v.RequireTitlePattern = strings.TrimSpace(v.RequireTitlePattern)
+	if v.RequireTitlePattern != "" {
+		if _, err := regexp.Compile(v.RequireTitlePattern); err != nil {
+			return fmt.Errorf("invalid title pattern: %w", err)
+		}
+	}
+
+	v.CommitMessageTemplate = strings.TrimSpace(v.CommitMessageTemplate)
+	if err := validateCommitMessageTemplate(v.CommitMessageTemplate); err != nil {
+		return fmt.Errorf("invalid commit message template: %w", err)
+	}
+
 	m := make(map[enum.MergeMethod]struct{}, 0)
 	for _, strategy := range v.StrategiesAllowed {
 		if _, ok := strategy.Sanitize(); !ok {
@@ -463,6 +463,40 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqMergeTitlePattern + "-fail",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireTitlePattern:   `^(feat|fix|chore)(\(.+\))?: .+`,
+					CommitMessageTemplate: "{title} (#{number})",
+				},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodSquash,
+				PullReq: &types.PullReq{Title: "Add a new feature"},
+			},
+			expCodes:  []string{codePullReqMergeTitlePattern},
+			expParams: [][]any{{`^(feat|fix|chore)(\(.+\))?: .+`}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:        enum.MergeMethods,
+				CommitMessageTemplate: "{title} (#{number})",
+			},
+		},
+		{
+			name: codePullReqMergeTitlePattern + "-success",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireTitlePattern: `^(feat|fix|chore)(\(.+\))?: .+`,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodSquash,
+				PullReq: &types.PullReq{Title: "feat(api): add a new feature"},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 	}
 
 	for _, test := range tests {
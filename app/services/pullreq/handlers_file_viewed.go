@@ -27,7 +27,8 @@ import (
 )
 
 // handleFileViewedOnBranchUpdate handles pull request Branch Updated events.
-// It marks existing file reviews as obsolete for the PR depending on the change to the file.
+// It marks existing file reviews as obsolete for the PR depending on the change to the file,
+// and dismisses stale approvals if the protection rules require it.
 //
 // The major reason of this handler is to allow detect changes that occurred to a file since last reviewed,
 // even if the file content is the same - e.g. file got deleted and readded with the same content.
@@ -38,24 +39,60 @@ func (s *Service) handleFileViewedOnBranchUpdate(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("failed to get repo git info: %w", err)
 	}
+	obsoletePaths, err := s.listChangedPaths(ctx, repoGit.GitUID, event.Payload.OldSHA, event.Payload.NewSHA)
+	if err != nil {
+		return err
+	}
+
+	if len(obsoletePaths) == 0 {
+		return nil
+	}
+
+	err = s.fileViewStore.MarkObsolete(
+		ctx,
+		event.Payload.PullReqID,
+		obsoletePaths)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to mark files obsolete for repo %d and pr %d: %w",
+			repoGit.ID,
+			event.Payload.PullReqID,
+			err)
+	}
+
+	err = s.dismissStaleApprovals(ctx, repoGit, event.Payload, obsoletePaths)
+	if err != nil {
+		return fmt.Errorf("failed to dismiss stale approvals for pr %d: %w", event.Payload.PullReqID, err)
+	}
+
+	return nil
+}
+
+// listChangedPaths returns the paths of the files changed between the two commits.
+func (s *Service) listChangedPaths(
+	ctx context.Context,
+	repoUID string,
+	baseSHA string,
+	headSHA string,
+) ([]string, error) {
 	reader := git.NewStreamReader(s.git.Diff(ctx, &git.DiffParams{
 		ReadParams: git.ReadParams{
-			RepoUID: repoGit.GitUID,
+			RepoUID: repoUID,
 		},
-		BaseRef:      event.Payload.OldSHA,
-		HeadRef:      event.Payload.NewSHA,
+		BaseRef:      baseSHA,
+		HeadRef:      headSHA,
 		MergeBase:    false, // we want the direct changes
 		IncludePatch: false, // we don't care about the actual file changes
 	}))
 
-	obsoletePaths := []string{}
+	changedPaths := []string{}
 	for {
 		fileDiff, err := reader.Next()
 		if errors.Is(err, io.EOF) {
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("failed to read next file diff: %w", err)
+			return nil, fmt.Errorf("failed to read next file diff: %w", err)
 		}
 
 		// DELETED: mark as obsolete - handles open pr file deletions
@@ -65,34 +102,18 @@ func (s *Service) handleFileViewedOnBranchUpdate(ctx context.Context,
 		// This strategy leads to a behavior very similar to what github is doing
 		switch fileDiff.Status {
 		case enum.FileDiffStatusAdded:
-			obsoletePaths = append(obsoletePaths, fileDiff.Path)
+			changedPaths = append(changedPaths, fileDiff.Path)
 		case enum.FileDiffStatusDeleted:
-			obsoletePaths = append(obsoletePaths, fileDiff.OldPath)
+			changedPaths = append(changedPaths, fileDiff.OldPath)
 		case enum.FileDiffStatusRenamed:
-			obsoletePaths = append(obsoletePaths, fileDiff.OldPath, fileDiff.Path)
+			changedPaths = append(changedPaths, fileDiff.OldPath, fileDiff.Path)
 		case enum.FileDiffStatusModified:
-			obsoletePaths = append(obsoletePaths, fileDiff.Path)
+			changedPaths = append(changedPaths, fileDiff.Path)
 		case enum.FileDiffStatusCopied:
 		case enum.FileDiffStatusUndefined:
 			// other cases we don't care
 		}
 	}
 
-	if len(obsoletePaths) == 0 {
-		return nil
-	}
-
-	err = s.fileViewStore.MarkObsolete(
-		ctx,
-		event.Payload.PullReqID,
-		obsoletePaths)
-	if err != nil {
-		return fmt.Errorf(
-			"failed to mark files obsolete for repo %d and pr %d: %w",
-			repoGit.ID,
-			event.Payload.PullReqID,
-			err)
-	}
-
-	return nil
+	return changedPaths, nil
 }
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// dismissStaleApprovals dismisses approvals of reviewers whose approved commit is outdated by the update,
+// if a protection rule of the target branch requires it. An approval is stale if any of the files changed
+// since the approved commit was viewed by the reviewer, or if the reviewer didn't view any file.
+// Approvals of reviewers who viewed only files that didn't change since are kept.
+func (s *Service) dismissStaleApprovals(
+	ctx context.Context,
+	targetRepo *types.RepositoryCore,
+	payload *pullreqevents.BranchUpdatedPayload,
+	changedPaths []string,
+) error {
+	pr, err := s.pullreqStore.Find(ctx, payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	policy, err := protectionRules.ApprovalPolicy(ctx, protection.ApprovalPolicyInput{
+		Repo:    targetRepo,
+		PullReq: pr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get approval policy: %w", err)
+	}
+
+	if !policy.DismissStaleApprovals {
+		return nil
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision != enum.PullReqReviewDecisionApproved || reviewer.SHA == payload.NewSHA {
+			continue
+		}
+
+		err = s.dismissStaleApproval(ctx, targetRepo, pr, reviewer, payload, changedPaths)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", pr.ID).
+				Int64("reviewer_id", reviewer.PrincipalID).
+				Msg("failed to dismiss stale approval")
+		}
+	}
+
+	return nil
+}
+
+// dismissStaleApproval dismisses the approval of the reviewer if it's stale.
+func (s *Service) dismissStaleApproval(
+	ctx context.Context,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	reviewer *types.PullReqReviewer,
+	payload *pullreqevents.BranchUpdatedPayload,
+	changedPaths []string,
+) error {
+	// the paths changed by this update are the paths changed since the approval,
+	// unless the reviewer approved an older commit.
+	changedSinceApproval := changedPaths
+	if reviewer.SHA != "" && reviewer.SHA != payload.OldSHA {
+		var err error
+		changedSinceApproval, err = s.listChangedPaths(ctx, targetRepo.GitUID, reviewer.SHA, payload.NewSHA)
+		if err != nil {
+			// the approved commit might be gone, e.g. after a force push
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", pr.ID).
+				Int64("reviewer_id", reviewer.PrincipalID).
+				Msg("failed to list files changed since the approval, using the files changed by the update")
+			changedSinceApproval = changedPaths
+		}
+	}
+
+	if len(changedSinceApproval) == 0 {
+		return nil
+	}
+
+	stale, err := s.isApprovalStale(ctx, pr.ID, reviewer.PrincipalID, changedSinceApproval)
+	if err != nil {
+		return err
+	}
+
+	if !stale {
+		return nil
+	}
+
+	return s.dismissApproval(ctx, targetRepo, pr, reviewer, payload.PrincipalID)
+}
+
+// isApprovalStale returns true if the principal viewed any of the changed files
+// or, as it's unknown which files were reviewed, if the principal didn't view any file.
+func (s *Service) isApprovalStale(
+	ctx context.Context,
+	prID int64,
+	principalID int64,
+	changedPaths []string,
+) (bool, error) {
+	fileViews, err := s.fileViewStore.List(ctx, prID, principalID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list file views of reviewer: %w", err)
+	}
+
+	if len(fileViews) == 0 {
+		return true, nil
+	}
+
+	changed := make(map[string]struct{}, len(changedPaths))
+	for _, path := range changedPaths {
+		changed[path] = struct{}{}
+	}
+
+	for _, fileView := range fileViews {
+		if _, ok := changed[fileView.Path]; ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *Service) dismissApproval(
+	ctx context.Context,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	reviewer *types.PullReqReviewer,
+	principalID int64,
+) error {
+	approvedSHA := reviewer.SHA
+
+	reviewer.ReviewDecision = enum.PullReqReviewDecisionPending
+	if err := s.reviewerStore.Update(ctx, reviewer); err != nil {
+		return fmt.Errorf("failed to dismiss approval of reviewer %d: %w", reviewer.PrincipalID, err)
+	}
+
+	err := func() error {
+		payload := &types.PullRequestActivityPayloadReviewDismiss{
+			CommitSHA:   approvedSHA,
+			Decision:    enum.PullReqReviewDecisionApproved,
+			PrincipalID: reviewer.PrincipalID,
+			Automatic:   true,
+		}
+
+		metadata := &types.PullReqActivityMetadata{
+			Mentions: &types.PullReqActivityMentionsMetadata{IDs: []int64{reviewer.PrincipalID}},
+		}
+
+		var err error
+		if pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request activity: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after approval dismissal")
+	}
+
+	s.pullreqEvReporter.ReviewDismissed(ctx, &pullreqevents.ReviewDismissedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		ReviewerID: reviewer.PrincipalID,
+		Decision:   enum.PullReqReviewDecisionApproved,
+		Automatic:  true,
+	})
+
+	s.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return nil
+}
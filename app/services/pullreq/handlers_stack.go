@@ -0,0 +1,321 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/bootstrap"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	errPRTargetChanged = errors.New("PR target branch has changed")
+)
+
+// retargetStackedPullReqsOnBranchDeleted handles git Branch Deleted events.
+// Once the source branch of a merged pull request is deleted, every open pull request that targets it
+// (a stacked pull request) is retargeted to the target branch of the merged pull request. If the commits of
+// the merged pull request didn't end up in the target branch as they are (squash or rebase merge), the stacked
+// pull request's source branch is rebased so that it contains only its own commits.
+// Stacked pull requests are left untouched as long as the branch they target exists.
+func (s *Service) retargetStackedPullReqsOnBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload],
+) error {
+	branch, err := getBranchFromRef(event.Payload.Ref)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Send()
+		return nil
+	}
+
+	// stacking is supported only for pull requests within the same repository.
+	merged, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Page:         0,
+		Size:         1,
+		SourceRepoID: event.Payload.RepoID,
+		SourceBranch: branch,
+		TargetRepoID: event.Payload.RepoID,
+		States:       []enum.PullReqState{enum.PullReqStateMerged},
+		Sort:         enum.PullReqSortMerged,
+		Order:        enum.OrderDesc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request: %w", err)
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo info: %w", err)
+	}
+
+	const largeLimit = 1000000
+
+	stacked, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Page:         0,
+		Size:         largeLimit,
+		TargetRepoID: event.Payload.RepoID,
+		TargetBranch: branch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list stacked pull requests: %w", err)
+	}
+
+	for _, pr := range stacked {
+		if pr.SourceRepoID != pr.TargetRepoID || pr.SourceBranch == merged[0].TargetBranch {
+			continue
+		}
+
+		err = s.retargetStackedPullReq(ctx, repo, merged[0], pr, event.Payload.PrincipalID)
+		if err != nil {
+			log.Ctx(ctx).Err(err).Msgf("failed to retarget stacked pull request %d", pr.Number)
+		}
+	}
+
+	return nil
+}
+
+// retargetStackedPullReq changes the target branch of a stacked pull request
+// to the target branch of the merged pull request and rebases it if needed.
+func (s *Service) retargetStackedPullReq(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	merged *types.PullReq,
+	pr *types.PullReq,
+	principalID int64,
+) error {
+	readParams := git.CreateReadParams(repo)
+	oldTargetBranch := pr.TargetBranch
+	newTargetBranch := merged.TargetBranch
+
+	targetBranch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: newTargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get new target branch: %w", err)
+	}
+
+	mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       pr.SourceSHA,
+		Ref2:       newTargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get merge base with the new target branch: %w", err)
+	}
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.State != enum.PullReqStateOpen {
+			return errPRNotOpen
+		}
+		if pr.TargetBranch != oldTargetBranch {
+			return errPRTargetChanged
+		}
+
+		pr.ActivitySeq++
+		pr.TargetBranch = newTargetBranch
+		pr.MergeBaseSHA = mergeBaseInfo.MergeBaseSHA.String()
+
+		// reset merge-check fields for new run
+
+		pr.MergeSHA = nil
+		pr.Stats.DiffStats.Commits = nil
+		pr.Stats.DiffStats.FilesChanged = nil
+		pr.MarkAsMergeUnchecked()
+
+		return nil
+	})
+	if errors.Is(err, errPRNotOpen) || errors.Is(err, errPRTargetChanged) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update target branch of pull request: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadTargetBranchChange{
+		Old:                 oldTargetBranch,
+		New:                 newTargetBranch,
+		MergedPullReqNumber: merged.Number,
+	}
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for target branch change")
+	}
+
+	s.pullreqEvReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: newTargetBranch,
+		SourceSHA:       pr.SourceSHA,
+	})
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	err = s.rebaseStackedPullReq(ctx, repo, pr, merged.SourceSHA, targetBranch.Branch.SHA)
+	if err != nil {
+		// non-critical error, the pull request can still be rebased manually.
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to rebase stacked pull request %d", pr.Number)
+	}
+
+	return nil
+}
+
+// rebaseStackedPullReq rebases the source branch of a retargeted pull request onto its new target branch,
+// but only if the source branch contains the commits of the merged pull request and the new target branch doesn't.
+// Only the commits added on top of the merged pull request are replayed. On conflicts the branch is left as is,
+// as it is if the protection rules of the source branch don't allow the system to force push to it.
+// The resulting branch update updates the pull request through the regular branch update flow.
+func (s *Service) rebaseStackedPullReq(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	mergedSourceSHA string,
+	targetSHA sha.SHA,
+) error {
+	upstreamSHA, err := sha.New(mergedSourceSHA)
+	if err != nil {
+		return fmt.Errorf("invalid source SHA of the merged pull request: %w", err)
+	}
+
+	sourceSHA, err := sha.New(pr.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("invalid source SHA of the pull request: %w", err)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	inTarget, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   upstreamSHA,
+		DescendantCommitSHA: targetSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check if the target branch contains the merged commits: %w", err)
+	}
+
+	if inTarget.Ancestor {
+		// The merged commits are part of the target branch (merge or fast-forward) - nothing to do.
+		return nil
+	}
+
+	inSource, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   upstreamSHA,
+		DescendantCommitSHA: sourceSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check if the source branch contains the merged commits: %w", err)
+	}
+
+	if !inSource.Ancestor {
+		// The source branch isn't based on the latest commit of the merged pull request.
+		return nil
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:     &bootstrap.NewSystemServiceSession().Principal,
+		Repo:      repo,
+		RefAction: protection.RefActionUpdateForce,
+		RefType:   protection.RefTypeBranch,
+		RefNames:  []string{pr.SourceBranch},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		log.Ctx(ctx).Info().Msgf("stacked pull request %d can't be rebased automatically: %s",
+			pr.Number, protection.GenerateErrorMessageForBlockingViolations(violations))
+		return nil
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, repo.ID, repo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	sourceBranchRef, err := git.GetRefPath(pr.SourceBranch, gitenum.RefTypeBranch)
+	if err != nil {
+		return fmt.Errorf("failed to generate source branch ref name: %w", err)
+	}
+
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams: writeParams,
+		BaseSHA:     targetSHA,
+		HeadRepoUID: repo.GitUID,
+		HeadBranch:  pr.SourceBranch,
+		Refs: []git.RefUpdate{{
+			Name: sourceBranchRef,
+			Old:  sourceSHA,
+			New:  sha.SHA{}, // update to the result of the rebase
+		}},
+		HeadExpectedSHA:   sourceSHA,
+		Method:            gitenum.MergeMethodRebase,
+		RebaseUpstreamSHA: upstreamSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("rebase execution failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		log.Ctx(ctx).Info().Msgf("stacked pull request %d can't be rebased automatically, conflicting files: %v",
+			pr.Number, mergeOutput.ConflictFiles)
+	}
+
+	return nil
+}
+
+// mergeCheckOnTargetBranchChange handles pull request Target Branch Changed events.
+// It runs the mergeability check against the new target branch.
+func (s *Service) mergeCheckOnTargetBranchChange(ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchChangedPayload],
+) error {
+	return s.updateMergeData(
+		ctx,
+		event.Payload.TargetRepoID,
+		event.Payload.Number,
+		sha.None.String(),
+		event.Payload.SourceSHA,
+	)
+}
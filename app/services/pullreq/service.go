@@ -25,6 +25,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -47,6 +48,8 @@ type Service struct {
 	principalInfoCache  store.PrincipalInfoCache
 	codeCommentMigrator *codecomments.Migrator
 	fileViewStore       store.PullReqFileViewStore
+	reviewerStore       store.PullReqReviewerStore
+	protectionManager   *protection.Manager
 	sseStreamer         sse.Streamer
 	urlProvider         url.Provider
 
@@ -70,6 +73,8 @@ func New(ctx context.Context,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	reviewerStore store.PullReqReviewerStore,
+	protectionManager *protection.Manager,
 	principalInfoCache store.PrincipalInfoCache,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
@@ -87,6 +92,8 @@ func New(ctx context.Context,
 		urlProvider:         urlProvider,
 		codeCommentMigrator: codeCommentMigrator,
 		fileViewStore:       fileViewStore,
+		reviewerStore:       reviewerStore,
+		protectionManager:   protectionManager,
 		cancelMergeability:  make(map[string]context.CancelFunc),
 		pubsub:              bus,
 		sseStreamer:         sseStreamer,
@@ -180,6 +187,26 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// retarget (and rebase) stacked pull requests when the branch of the merged pull request they depend on is deleted
+	const groupPullReqStack = "gitness:pullreq:stack"
+	_, err = gitReaderFactory.Launch(ctx, groupPullReqStack, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchDeleted(service.retargetStackedPullReqsOnBranchDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	// mergeability check
 	const groupPullReqMergeable = "gitness:pullreq:mergeable"
 	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqMergeable, config.InstanceID,
@@ -195,6 +222,7 @@ func New(ctx context.Context,
 			_ = r.RegisterCreated(service.mergeCheckOnCreated)
 			_ = r.RegisterBranchUpdated(service.mergeCheckOnBranchUpdate)
 			_ = r.RegisterReopened(service.mergeCheckOnReopen)
+			_ = r.RegisterTargetBranchChanged(service.mergeCheckOnTargetBranchChange)
 
 			return nil
 		})
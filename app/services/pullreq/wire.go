@@ -55,6 +55,8 @@ func ProvideService(ctx context.Context,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	reviewerStore store.PullReqReviewerStore,
+	protectionManager *protection.Manager,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
@@ -72,6 +74,8 @@ func ProvideService(ctx context.Context,
 		codeCommentView,
 		codeCommentMigrator,
 		fileViewStore,
+		reviewerStore,
+		protectionManager,
 		principalInfoCache,
 		pubsub,
 		urlProvider,
@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultTemplatePath is the path of the template that's applied when no template is selected.
+	DefaultTemplatePath = ".harness/pull_request_template.md"
+	// NamedTemplatesDir is the directory containing additional, named templates.
+	NamedTemplatesDir = ".github/PULL_REQUEST_TEMPLATE"
+
+	templateExt = ".md"
+
+	// maxTemplateSize is the maximum size of a template file; larger files are ignored.
+	// It matches the maximum length of a pull request description.
+	maxTemplateSize = 64 << 10
+)
+
+// Service loads pull request description templates from a repository.
+type Service struct {
+	git git.Interface
+}
+
+func New(git git.Interface) *Service {
+	return &Service{
+		git: git,
+	}
+}
+
+// List returns all pull request templates available on the provided git reference.
+// The default template (if present) is always returned first, named templates follow sorted by name.
+func (s *Service) List(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+) ([]*types.PullReqTemplate, error) {
+	readParams := git.CreateReadParams(repo)
+
+	templates := make([]*types.PullReqTemplate, 0)
+
+	defaultTemplate, err := s.getDefault(ctx, readParams, gitRef)
+	if err != nil {
+		return nil, err
+	}
+	if defaultTemplate != nil {
+		templates = append(templates, defaultTemplate)
+	}
+
+	namedTemplates, err := s.listNamed(ctx, readParams, gitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(templates, namedTemplates...), nil
+}
+
+// Find returns the pull request template with the provided name from the provided git reference.
+// If the name is empty the default template is returned. It returns nil if the repository has no default template.
+func (s *Service) Find(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	readParams := git.CreateReadParams(repo)
+
+	if name == "" {
+		return s.getDefault(ctx, readParams, gitRef)
+	}
+
+	namedTemplates, err := s.listNamed(ctx, readParams, gitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range namedTemplates {
+		if strings.EqualFold(template.Name, name) {
+			return template, nil
+		}
+	}
+
+	return nil, errors.NotFound("Pull request template %q not found", name)
+}
+
+func (s *Service) getDefault(
+	ctx context.Context,
+	readParams git.ReadParams,
+	gitRef string,
+) (*types.PullReqTemplate, error) {
+	node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       DefaultTemplatePath,
+	})
+	if errors.IsNotFound(err) {
+		return nil, nil //nolint:nilnil // the repository has no default template
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default pull request template node: %w", err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return nil, nil //nolint:nilnil // not a file, treat it as missing
+	}
+
+	content, ok, err := s.readTemplate(ctx, readParams, node.Node.SHA, DefaultTemplatePath)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &types.PullReqTemplate{
+		Name:    templateName(DefaultTemplatePath),
+		Path:    DefaultTemplatePath,
+		Default: true,
+		Content: content,
+	}, nil
+}
+
+func (s *Service) listNamed(
+	ctx context.Context,
+	readParams git.ReadParams,
+	gitRef string,
+) ([]*types.PullReqTemplate, error) {
+	output, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       NamedTemplatesDir,
+	})
+	if errors.IsNotFound(err) {
+		return []*types.PullReqTemplate{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request templates: %w", err)
+	}
+
+	templates := make([]*types.PullReqTemplate, 0, len(output.Nodes))
+	for _, node := range output.Nodes {
+		if node.Type != git.TreeNodeTypeBlob || !strings.EqualFold(path.Ext(node.Name), templateExt) {
+			continue
+		}
+
+		content, ok, err := s.readTemplate(ctx, readParams, node.SHA, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		templates = append(templates, &types.PullReqTemplate{
+			Name:    templateName(node.Name),
+			Path:    node.Path,
+			Content: content,
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
+
+// readTemplate reads the content of a template file.
+// It returns false if the file is too large to be used as a template.
+func (s *Service) readTemplate(
+	ctx context.Context,
+	readParams git.ReadParams,
+	sha string,
+	filePath string,
+) (string, bool, error) {
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        sha,
+		SizeLimit:  maxTemplateSize,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get pull request template %q: %w", filePath, err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if output.Size > maxTemplateSize {
+		log.Ctx(ctx).Warn().Msgf("pull request template %q exceeds the maximum size of %d bytes, skipping",
+			filePath, maxTemplateSize)
+		return "", false, nil
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read pull request template %q: %w", filePath, err)
+	}
+
+	return string(content), true, nil
+}
+
+func templateName(filePath string) string {
+	name := path.Base(filePath)
+	return name[:len(name)-len(path.Ext(name))]
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type gitStub struct {
+	git.Interface
+
+	files map[string]string
+}
+
+func (g *gitStub) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	if _, ok := g.files[params.Path]; !ok {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+
+	return &git.GetTreeNodeOutput{
+		Node: git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			Mode: git.TreeNodeModeFile,
+			SHA:  params.Path,
+			Path: params.Path,
+		},
+	}, nil
+}
+
+func (g *gitStub) ListTreeNodes(_ context.Context, params *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	nodes := make([]git.TreeNode, 0)
+	for filePath := range g.files {
+		name, ok := strings.CutPrefix(filePath, params.Path+"/")
+		if !ok || strings.Contains(name, "/") {
+			continue
+		}
+
+		nodes = append(nodes, git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			Mode: git.TreeNodeModeFile,
+			SHA:  filePath,
+			Name: name,
+			Path: filePath,
+		})
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+
+	return &git.ListTreeNodeOutput{Nodes: nodes}, nil
+}
+
+func (g *gitStub) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	// the stub uses the file path as the blob SHA
+	content := g.files[params.SHA]
+
+	return &git.GetBlobOutput{
+		Size:    int64(len(content)),
+		Content: io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func TestService_List(t *testing.T) {
+	svc := New(&gitStub{files: map[string]string{
+		DefaultTemplatePath:                      "## Summary",
+		NamedTemplatesDir + "/feature.md":        "## Feature",
+		NamedTemplatesDir + "/bugfix.md":         "## Bugfix",
+		NamedTemplatesDir + "/notes.txt":         "not a template",
+		NamedTemplatesDir + "/large.md":          strings.Repeat("a", maxTemplateSize+1),
+		NamedTemplatesDir + "/nested/ignored.md": "nested",
+	}})
+
+	templates, err := svc.List(context.Background(), &types.RepositoryCore{}, "main")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*types.PullReqTemplate{
+		{Name: "pull_request_template", Path: DefaultTemplatePath, Default: true, Content: "## Summary"},
+		{Name: "bugfix", Path: NamedTemplatesDir + "/bugfix.md", Content: "## Bugfix"},
+		{Name: "feature", Path: NamedTemplatesDir + "/feature.md", Content: "## Feature"},
+	}, templates)
+}
+
+func TestService_Find(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.RepositoryCore{}
+
+	t.Run("no templates", func(t *testing.T) {
+		svc := New(&gitStub{})
+
+		template, err := svc.Find(ctx, repo, "main", "")
+		require.NoError(t, err)
+		assert.Nil(t, template)
+
+		_, err = svc.Find(ctx, repo, "main", "feature")
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("default and named", func(t *testing.T) {
+		svc := New(&gitStub{files: map[string]string{
+			DefaultTemplatePath:               "## Summary",
+			NamedTemplatesDir + "/Feature.md": "## Feature",
+		}})
+
+		template, err := svc.Find(ctx, repo, "main", "")
+		require.NoError(t, err)
+		require.NotNil(t, template)
+		assert.True(t, template.Default)
+		assert.Equal(t, "## Summary", template.Content)
+
+		template, err = svc.Find(ctx, repo, "main", "feature")
+		require.NoError(t, err)
+		require.NotNil(t, template)
+		assert.False(t, template.Default)
+		assert.Equal(t, "## Feature", template.Content)
+	})
+}
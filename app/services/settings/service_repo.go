@@ -16,6 +16,7 @@ package settings
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/types/enum"
 )
@@ -79,3 +80,20 @@ func (s *Service) RepoMap(
 		handlers...,
 	)
 }
+
+// RepoListIDs returns the IDs of all repos that have the setting with the given key.
+func (s *Service) RepoListIDs(
+	ctx context.Context,
+	key Key,
+) ([]int64, error) {
+	ids, err := s.settingsStore.ListScopeIDs(
+		ctx,
+		enum.SettingsScopeRepo,
+		string(key),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repos with setting from store: %w", err)
+	}
+
+	return ids, nil
+}
@@ -24,4 +24,19 @@ var (
 	DefaultFileSizeLimit             = int64(1e+8) // 100 MB
 	KeyInstallID                 Key = "install_id"
 	DefaultInstallID                 = string("")
+	// KeyGitspaceIdleTimeoutInMins [int64] is the number of minutes after which idle gitspaces are stopped.
+	// The timeout is disabled if set to 0.
+	KeyGitspaceIdleTimeoutInMins Key = "gitspace_idle_timeout_in_mins"
+	// KeyReviewReminderSLAInHours [int64] is the number of hours after which reviewers that haven't submitted
+	// a review of an open pull request are reminded. Reminders are disabled if set to 0.
+	KeyReviewReminderSLAInHours     Key = "review_reminder_sla_in_hours"
+	DefaultReviewReminderSLAInHours     = int64(0)
+	// KeyReviewEscalationSLAInHours [int64] is the number of hours after which still pending reviews
+	// are escalated to the code owners (or to the escalation user group). Escalation is disabled if set to 0.
+	KeyReviewEscalationSLAInHours     Key = "review_escalation_sla_in_hours"
+	DefaultReviewEscalationSLAInHours     = int64(0)
+	// KeyReviewEscalationUserGroupID [int64] is the ID of the user group pending reviews are escalated to.
+	// Pending reviews are escalated to the code owners if set to 0.
+	KeyReviewEscalationUserGroupID     Key = "review_escalation_usergroup_id"
+	DefaultReviewEscalationUserGroupID     = int64(0)
 )
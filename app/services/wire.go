@@ -15,10 +15,15 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/pipeline/reclaimer"
+	"github.com/harness/gitness/app/services/approval"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspaceevent"
+	"github.com/harness/gitness/app/services/gitspaceidle"
 	"github.com/harness/gitness/app/services/gitspaceinfraevent"
+	"github.com/harness/gitness/app/services/gitspaceprebuild"
+	"github.com/harness/gitness/app/services/gomodule"
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
@@ -46,8 +51,11 @@ type Services struct {
 	RepoSizeCalculator    *repo.SizeCalculator
 	Repo                  *repo.Service
 	Cleanup               *cleanup.Service
+	StageReclaimer        *reclaimer.Service
+	Approval              *approval.Service
 	Notification          *notification.Service
 	Keywordsearch         *keywordsearch.Service
+	GoModule              *gomodule.Service
 	GitspaceService       *GitspaceServices
 	Instrumentation       instrument.Service
 	instrumentConsumer    instrument.Consumer
@@ -59,6 +67,8 @@ type GitspaceServices struct {
 	infraProvider         *infraprovider.Service
 	gitspace              *gitspace.Service
 	gitspaceInfraEventSvc *gitspaceinfraevent.Service
+	GitspaceIdle          *gitspaceidle.Service
+	GitspacePrebuild      *gitspaceprebuild.Service
 }
 
 func ProvideGitspaceServices(
@@ -66,12 +76,16 @@ func ProvideGitspaceServices(
 	infraProviderSvc *infraprovider.Service,
 	gitspaceSvc *gitspace.Service,
 	gitspaceInfraEventSvc *gitspaceinfraevent.Service,
+	gitspaceIdleSvc *gitspaceidle.Service,
+	gitspacePrebuildSvc *gitspaceprebuild.Service,
 ) *GitspaceServices {
 	return &GitspaceServices{
 		GitspaceEvent:         gitspaceEventSvc,
 		infraProvider:         infraProviderSvc,
 		gitspace:              gitspaceSvc,
 		gitspaceInfraEventSvc: gitspaceInfraEventSvc,
+		GitspaceIdle:          gitspaceIdleSvc,
+		GitspacePrebuild:      gitspacePrebuildSvc,
 	}
 }
 
@@ -84,8 +98,11 @@ func ProvideServices(
 	repoSizeCalculator *repo.SizeCalculator,
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
+	stageReclaimer *reclaimer.Service,
+	approvalSvc *approval.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	goModuleSvc *gomodule.Service,
 	gitspaceSvc *GitspaceServices,
 	instrumentation instrument.Service,
 	instrumentConsumer instrument.Consumer,
@@ -100,8 +117,11 @@ func ProvideServices(
 		RepoSizeCalculator:    repoSizeCalculator,
 		Repo:                  repo,
 		Cleanup:               cleanupSvc,
+		StageReclaimer:        stageReclaimer,
+		Approval:              approvalSvc,
 		Notification:          notificationSvc,
 		Keywordsearch:         keywordsearchSvc,
+		GoModule:              goModuleSvc,
 		GitspaceService:       gitspaceSvc,
 		Instrumentation:       instrumentation,
 		instrumentConsumer:    instrumentConsumer,
@@ -327,6 +327,13 @@ type (
 			key string,
 			value json.RawMessage,
 		) error
+
+		// ListScopeIDs returns the IDs of all scopes of the given type that have the setting with the given key.
+		ListScopeIDs(
+			ctx context.Context,
+			scope enum.SettingsScope,
+			key string,
+		) ([]int64, error)
 	}
 
 	// MembershipStore defines the membership data storage.
@@ -346,6 +353,54 @@ type (
 		) ([]types.MembershipSpace, error)
 	}
 
+	// CustomRoleStore defines the custom membership role data storage.
+	CustomRoleStore interface {
+		// Find returns a custom role given an ID.
+		Find(ctx context.Context, id int64) (*types.CustomRole, error)
+
+		// FindByIdentifier returns a custom role of a space given its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.CustomRole, error)
+
+		// Create creates a new custom role.
+		Create(ctx context.Context, role *types.CustomRole) error
+
+		// Update tries to update a custom role.
+		Update(ctx context.Context, role *types.CustomRole) error
+
+		// UpdateOptLock updates the custom role using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context, role *types.CustomRole,
+			mutateFn func(role *types.CustomRole) error,
+		) (*types.CustomRole, error)
+
+		// Delete deletes a custom role given an ID.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the custom roles defined in any of the provided spaces.
+		List(ctx context.Context, spaceIDs []int64) ([]*types.CustomRole, error)
+
+		// CountMemberships returns the number of memberships the custom role is assigned to.
+		CountMemberships(ctx context.Context, id int64) (int64, error)
+	}
+
+	// RepoMembershipStore defines the repository membership data storage.
+	RepoMembershipStore interface {
+		// Find returns a repository membership given its ID.
+		Find(ctx context.Context, id int64) (*types.RepoMembership, error)
+
+		// Create creates a new repository membership.
+		Create(ctx context.Context, membership *types.RepoMembership) error
+
+		// Update updates the role of a repository membership.
+		Update(ctx context.Context, membership *types.RepoMembership) error
+
+		// Delete deletes a repository membership given its ID.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all memberships of a repository.
+		List(ctx context.Context, repoID int64) ([]*types.RepoMembership, error)
+	}
+
 	// PublicAccessStore defines the publicly accessible resources data storage.
 	PublicAccessStore interface {
 		Find(ctx context.Context, typ enum.PublicResourceType, id int64) (bool, error)
@@ -535,6 +590,101 @@ type (
 		List(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqFileView, error)
 	}
 
+	// NotificationChannelStore defines the notification channel data storage.
+	NotificationChannelStore interface {
+		// Find finds the notification channel by id.
+		Find(ctx context.Context, id int64) (*types.NotificationChannel, error)
+
+		// FindByIdentifier finds the notification channel with the given identifier for the given parent.
+		FindByIdentifier(
+			ctx context.Context,
+			parentType enum.ParentResourceType,
+			parentID int64,
+			identifier string,
+		) (*types.NotificationChannel, error)
+
+		// Create creates a new notification channel.
+		Create(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Update updates an existing notification channel.
+		Update(ctx context.Context, channel *types.NotificationChannel) error
+
+		// UpdateOptLock updates the notification channel using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			channel *types.NotificationChannel,
+			mutateFn func(channel *types.NotificationChannel) error,
+		) (*types.NotificationChannel, error)
+
+		// Delete deletes the notification channel with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the notification channels of the given parent.
+		List(
+			ctx context.Context,
+			parentType enum.ParentResourceType,
+			parentID int64,
+		) ([]*types.NotificationChannel, error)
+
+		// ListEnabled lists the enabled notification channels of the repo and of the provided spaces.
+		ListEnabled(ctx context.Context, repoID int64, spaceIDs []int64) ([]*types.NotificationChannel, error)
+	}
+
+	// PullReqReviewReminderStore stores the history of notifications sent about pending pull request reviews.
+	PullReqReviewReminderStore interface {
+		// Upsert inserts a reminder entry or, if it already exists, increases its count and updates its time.
+		Upsert(ctx context.Context, reminder *types.PullReqReviewReminder) error
+
+		// List lists all reminder entries of the specified PR.
+		List(ctx context.Context, prID int64) ([]*types.PullReqReviewReminder, error)
+	}
+
+	// NotificationPreferenceStore stores the notification settings of users.
+	NotificationPreferenceStore interface {
+		// List lists the notification preferences of the user.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationPreference, error)
+
+		// Upsert creates or updates the user's delivery of a notification trigger.
+		Upsert(ctx context.Context, preference *types.NotificationPreference) error
+
+		// MapDeliveries returns the deliveries of the notification trigger chosen by the provided users.
+		// Users that haven't chosen a delivery of the trigger are not in the returned map.
+		MapDeliveries(
+			ctx context.Context,
+			trigger enum.NotificationTrigger,
+			principalIDs []int64,
+		) (map[int64]enum.NotificationDelivery, error)
+
+		// ListRepos lists the repositories the user explicitly watches or ignores.
+		ListRepos(ctx context.Context, principalID int64) ([]*types.RepoNotificationPreference, error)
+
+		// UpsertRepo creates or updates the user's notification mode of a repository.
+		UpsertRepo(ctx context.Context, preference *types.RepoNotificationPreference) error
+
+		// MapRepoModes returns the notification modes of the repository chosen by the provided users.
+		// Users that haven't chosen a mode for the repository are not in the returned map.
+		MapRepoModes(
+			ctx context.Context,
+			repoID int64,
+			principalIDs []int64,
+		) (map[int64]enum.RepoNotificationMode, error)
+	}
+
+	// NotificationDigestStore stores the notifications queued for the daily digest emails.
+	NotificationDigestStore interface {
+		// Create queues a new digest item.
+		Create(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListPrincipalIDs returns the IDs of all users with queued digest items.
+		ListPrincipalIDs(ctx context.Context) ([]int64, error)
+
+		// List lists the queued digest items of the user, oldest first.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationDigestItem, error)
+
+		// Delete deletes the queued digest items of the user up to and including the provided item ID.
+		Delete(ctx context.Context, principalID int64, maxID int64) error
+	}
+
 	// RuleStore defines database interface for protection rules.
 	RuleStore interface {
 		// Find finds a protection rule by ID.
@@ -901,6 +1051,20 @@ type (
 
 		// CountInSpace counts the number of executions in a given space.
 		CountInSpace(ctx context.Context, spaceID int64, filter types.ListExecutionsFilter) (int64, error)
+
+		// ListDeployments lists the executions that deployed to the given environment.
+		ListDeployments(
+			ctx context.Context,
+			environmentID int64,
+			pagination types.Pagination,
+		) ([]*types.Execution, error)
+
+		// CountDeployments counts the executions that deployed to the given environment.
+		CountDeployments(ctx context.Context, environmentID int64) (int64, error)
+
+		// FindLatestDeployment returns the most recent successful execution
+		// that deployed to the given environment.
+		FindLatestDeployment(ctx context.Context, environmentID int64) (*types.Execution, error)
 	}
 
 	StageStore interface {
@@ -930,6 +1094,9 @@ type (
 	}
 
 	StepStore interface {
+		// Find returns a step from the datastore by ID.
+		Find(ctx context.Context, stepID int64) (*types.Step, error)
+
 		// FindByNumber returns a step from the datastore by number.
 		FindByNumber(ctx context.Context, stageID int64, stepNum int) (*types.Step, error)
 
@@ -939,6 +1106,157 @@ type (
 		// Update tries to update a step and returns an optimistic locking error if it was
 		// unable to do so.
 		Update(ctx context.Context, e *types.Step) error
+
+		// DeleteByStageID deletes all the steps of a stage.
+		DeleteByStageID(ctx context.Context, stageID int64) error
+	}
+
+	ApprovalStore interface {
+		// FindByStageID returns the approval of a stage.
+		FindByStageID(ctx context.Context, stageID int64) (*types.Approval, error)
+
+		// ListExpired returns the undecided approvals of the stages
+		// waiting on approval for longer than the approval timeout.
+		ListExpired(ctx context.Context, now int64) ([]*types.Approval, error)
+
+		// Create creates a new approval.
+		Create(ctx context.Context, approval *types.Approval) error
+
+		// Update tries to update the decision of an approval and returns
+		// an optimistic locking error if it was unable to do so.
+		Update(ctx context.Context, approval *types.Approval) error
+	}
+
+	RunnerStore interface {
+		// Find returns a runner given an ID.
+		Find(ctx context.Context, id int64) (*types.Runner, error)
+
+		// FindByIdentifier returns a runner given an identifier.
+		FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error)
+
+		// FindByTokenHash returns the runner with the given registration token hash.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error)
+
+		// Create creates a new runner.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// Count returns the number of runners matching the filter.
+		Count(ctx context.Context, filter types.ListQueryFilter) (int64, error)
+
+		// List returns the runners matching the filter.
+		List(ctx context.Context, filter types.ListQueryFilter) ([]*types.Runner, error)
+
+		// ListSeenBefore returns the runners that were seen at least once,
+		// but not since the provided time.
+		ListSeenBefore(ctx context.Context, seenBefore int64) ([]*types.Runner, error)
+
+		// UpdateLastSeen records the last time the runner called the server.
+		UpdateLastSeen(ctx context.Context, id int64, lastSeen int64) error
+
+		// UpdatePlatform records the platform the runner requested stages for.
+		UpdatePlatform(ctx context.Context, id int64, platform types.RunnerPlatform) error
+
+		// Delete deletes a runner given an ID.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	PipelineArtifactStore interface {
+		// FindByKey returns an artifact given a pipeline ID, an execution ID and a key.
+		// Caches are looked up with a zero execution ID.
+		FindByKey(ctx context.Context, pipelineID, executionID int64, key string) (*types.PipelineArtifact, error)
+
+		// Create creates a new artifact.
+		Create(ctx context.Context, artifact *types.PipelineArtifact) error
+
+		// Update updates the size and the expiry of an artifact.
+		Update(ctx context.Context, artifact *types.PipelineArtifact) error
+
+		// Count returns the number of artifacts of an execution (or caches of a pipeline) matching the filter.
+		Count(ctx context.Context, pipelineID, executionID int64, filter types.ListQueryFilter) (int64, error)
+
+		// List returns the artifacts of an execution (or caches of a pipeline) matching the filter.
+		List(
+			ctx context.Context,
+			pipelineID, executionID int64,
+			filter types.ListQueryFilter,
+		) ([]*types.PipelineArtifact, error)
+
+		// ListExpired returns up to limit artifacts that expired before the provided time.
+		ListExpired(ctx context.Context, now int64, limit int) ([]*types.PipelineArtifact, error)
+
+		// Delete deletes an artifact given an ID.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	EnvironmentStore interface {
+		// Find returns an environment given an ID.
+		Find(ctx context.Context, id int64) (*types.Environment, error)
+
+		// FindByIdentifier returns an environment given a repo ID and an identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.Environment, error)
+
+		// Create creates a new environment.
+		Create(ctx context.Context, env *types.Environment) error
+
+		// Update tries to update an environment.
+		Update(ctx context.Context, env *types.Environment) error
+
+		// UpdateOptLock updates the environment using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context, env *types.Environment,
+			mutateFn func(env *types.Environment) error,
+		) (*types.Environment, error)
+
+		// Delete deletes an environment given an ID.
+		Delete(ctx context.Context, id int64) error
+
+		// Count the number of environments in a repo matching the given filter.
+		Count(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error)
+
+		// List lists the environments in a given repo.
+		List(ctx context.Context, repoID int64, filter types.ListQueryFilter) ([]*types.Environment, error)
+	}
+
+	GitspacePrebuildStore interface {
+		// Find returns a gitspace prebuild given an ID.
+		Find(ctx context.Context, id int64) (*types.GitspacePrebuild, error)
+
+		// FindByIdentifier returns a gitspace prebuild given a repo ID and an identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.GitspacePrebuild, error)
+
+		// FindByBranch returns the gitspace prebuild of a repository branch.
+		FindByBranch(ctx context.Context, repoID int64, branch string) (*types.GitspacePrebuild, error)
+
+		// Create creates a new gitspace prebuild.
+		Create(ctx context.Context, prebuild *types.GitspacePrebuild) error
+
+		// Update tries to update a gitspace prebuild.
+		Update(ctx context.Context, prebuild *types.GitspacePrebuild) error
+
+		// UpdateOptLock updates the gitspace prebuild using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context, prebuild *types.GitspacePrebuild,
+			mutateFn func(prebuild *types.GitspacePrebuild) error,
+		) (*types.GitspacePrebuild, error)
+
+		// UpdateLogs replaces the logs of the latest build of a gitspace prebuild.
+		UpdateLogs(ctx context.Context, id int64, logs string) error
+
+		// FindLogs returns the logs of the latest build of a gitspace prebuild.
+		FindLogs(ctx context.Context, id int64) (*types.GitspacePrebuildLogs, error)
+
+		// Delete deletes a gitspace prebuild given an ID.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the gitspace prebuilds of a repo.
+		List(ctx context.Context, repoID int64) ([]*types.GitspacePrebuild, error)
+
+		// ListByState lists the gitspace prebuilds in the given state which weren't updated since the provided time.
+		ListByState(
+			ctx context.Context,
+			state enum.GitspacePrebuildState,
+			updatedBefore int64,
+		) ([]*types.GitspacePrebuild, error)
 	}
 
 	ConnectorStore interface {
@@ -0,0 +1,286 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.ApprovalStore = (*approvalStore)(nil)
+
+const (
+	approvalColumns = `
+		 approval_id
+		,approval_execution_id
+		,approval_stage_id
+		,approval_message
+		,approval_users
+		,approval_user_groups
+		,approval_timeout
+		,approval_default_decision
+		,approval_decision
+		,approval_decided_by
+		,approval_comment
+		,approval_decided
+		,approval_created
+		,approval_updated
+		,approval_version`
+
+	approvalSelectBase = `
+		SELECT` + approvalColumns + `
+		FROM approvals`
+)
+
+type approval struct {
+	ID              int64                 `db:"approval_id"`
+	ExecutionID     int64                 `db:"approval_execution_id"`
+	StageID         int64                 `db:"approval_stage_id"`
+	Message         string                `db:"approval_message"`
+	Users           sqlxtypes.JSONText    `db:"approval_users"`
+	UserGroups      sqlxtypes.JSONText    `db:"approval_user_groups"`
+	Timeout         int64                 `db:"approval_timeout"`
+	DefaultDecision enum.ApprovalDecision `db:"approval_default_decision"`
+	Decision        enum.ApprovalDecision `db:"approval_decision"`
+	DecidedBy       null.Int              `db:"approval_decided_by"`
+	Comment         string                `db:"approval_comment"`
+	Decided         int64                 `db:"approval_decided"`
+	Created         int64                 `db:"approval_created"`
+	Updated         int64                 `db:"approval_updated"`
+	Version         int64                 `db:"approval_version"`
+}
+
+// NewApprovalStore returns a new ApprovalStore.
+func NewApprovalStore(db *sqlx.DB) store.ApprovalStore {
+	return &approvalStore{
+		db: db,
+	}
+}
+
+type approvalStore struct {
+	db *sqlx.DB
+}
+
+// FindByStageID returns the approval of a stage.
+func (s *approvalStore) FindByStageID(ctx context.Context, stageID int64) (*types.Approval, error) {
+	const findQueryStmt = approvalSelectBase + `
+		WHERE approval_stage_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(approval)
+	if err := db.GetContext(ctx, dst, findQueryStmt, stageID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find approval")
+	}
+	return mapInternalToApproval(dst)
+}
+
+// ListExpired returns the undecided approvals of the stages waiting on approval
+// for longer than the approval timeout.
+func (s *approvalStore) ListExpired(ctx context.Context, now int64) ([]*types.Approval, error) {
+	const listQueryStmt = `
+		SELECT` + approvalColumns + `
+		FROM approvals
+		INNER JOIN stages ON stage_id = approval_stage_id
+		WHERE approval_decision = ''
+			AND approval_timeout > 0
+			AND stage_status = $1
+			AND stage_started + approval_timeout <= $2
+		ORDER BY approval_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*approval{}
+	if err := db.SelectContext(ctx, &dst, listQueryStmt, enum.CIStatusWaitingOnApproval, now); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list expired approvals")
+	}
+
+	approvals := make([]*types.Approval, len(dst))
+	for i, a := range dst {
+		m, err := mapInternalToApproval(a)
+		if err != nil {
+			return nil, err
+		}
+		approvals[i] = m
+	}
+	return approvals, nil
+}
+
+// Create creates a new approval.
+func (s *approvalStore) Create(ctx context.Context, a *types.Approval) error {
+	const approvalInsertStmt = `
+	INSERT INTO approvals (
+		 approval_execution_id
+		,approval_stage_id
+		,approval_message
+		,approval_users
+		,approval_user_groups
+		,approval_timeout
+		,approval_default_decision
+		,approval_decision
+		,approval_decided_by
+		,approval_comment
+		,approval_decided
+		,approval_created
+		,approval_updated
+		,approval_version
+	) VALUES (
+		 :approval_execution_id
+		,:approval_stage_id
+		,:approval_message
+		,:approval_users
+		,:approval_user_groups
+		,:approval_timeout
+		,:approval_default_decision
+		,:approval_decision
+		,:approval_decided_by
+		,:approval_comment
+		,:approval_decided
+		,:approval_created
+		,:approval_updated
+		,:approval_version
+	) RETURNING approval_id`
+
+	dbApproval, err := mapApprovalToInternal(a)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(approvalInsertStmt, dbApproval)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind approval object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&a.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Approval query failed")
+	}
+
+	return nil
+}
+
+// Update tries to update an approval and returns an optimistic locking error
+// if it was unable to do so.
+func (s *approvalStore) Update(ctx context.Context, a *types.Approval) error {
+	const approvalUpdateStmt = `
+	UPDATE approvals
+	SET
+		 approval_decision = :approval_decision
+		,approval_decided_by = :approval_decided_by
+		,approval_comment = :approval_comment
+		,approval_decided = :approval_decided
+		,approval_updated = :approval_updated
+		,approval_version = :approval_version
+	WHERE approval_id = :approval_id AND approval_version = :approval_version - 1`
+
+	dbApproval, err := mapApprovalToInternal(a)
+	if err != nil {
+		return err
+	}
+
+	dbApproval.Version++
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(approvalUpdateStmt, dbApproval)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind approval object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update approval")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	a.Version = dbApproval.Version
+	return nil
+}
+
+func mapApprovalToInternal(in *types.Approval) (*approval, error) {
+	users, err := json.Marshal(in.Users)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal approval users: %w", err)
+	}
+	userGroups, err := json.Marshal(in.UserGroups)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal approval user groups: %w", err)
+	}
+	return &approval{
+		ID:              in.ID,
+		ExecutionID:     in.ExecutionID,
+		StageID:         in.StageID,
+		Message:         in.Message,
+		Users:           users,
+		UserGroups:      userGroups,
+		Timeout:         in.Timeout,
+		DefaultDecision: in.DefaultDecision,
+		Decision:        in.Decision,
+		DecidedBy:       null.IntFromPtr(in.DecidedBy),
+		Comment:         in.Comment,
+		Decided:         in.Decided,
+		Created:         in.Created,
+		Updated:         in.Updated,
+		Version:         in.Version,
+	}, nil
+}
+
+func mapInternalToApproval(in *approval) (*types.Approval, error) {
+	var users, userGroups []string
+	if err := json.Unmarshal(in.Users, &users); err != nil {
+		return nil, fmt.Errorf("could not unmarshal approval users: %w", err)
+	}
+	if err := json.Unmarshal(in.UserGroups, &userGroups); err != nil {
+		return nil, fmt.Errorf("could not unmarshal approval user groups: %w", err)
+	}
+	return &types.Approval{
+		ID:              in.ID,
+		ExecutionID:     in.ExecutionID,
+		StageID:         in.StageID,
+		Message:         in.Message,
+		Users:           users,
+		UserGroups:      userGroups,
+		Timeout:         in.Timeout,
+		DefaultDecision: in.DefaultDecision,
+		Decision:        in.Decision,
+		DecidedBy:       in.DecidedBy.Ptr(),
+		Comment:         in.Comment,
+		Decided:         in.Decided,
+		Created:         in.Created,
+		Updated:         in.Updated,
+		Version:         in.Version,
+	}, nil
+}
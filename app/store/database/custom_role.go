@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.CustomRoleStore = (*customRoleStore)(nil)
+
+const (
+	customRoleColumns = `
+		 custom_role_id
+		,custom_role_space_id
+		,custom_role_identifier
+		,custom_role_description
+		,custom_role_permissions
+		,custom_role_created_by
+		,custom_role_created
+		,custom_role_updated
+		,custom_role_version`
+
+	customRoleSelectBase = `
+		SELECT` + customRoleColumns + `
+		FROM custom_roles`
+)
+
+type customRole struct {
+	ID          int64              `db:"custom_role_id"`
+	SpaceID     int64              `db:"custom_role_space_id"`
+	Identifier  string             `db:"custom_role_identifier"`
+	Description string             `db:"custom_role_description"`
+	Permissions sqlxtypes.JSONText `db:"custom_role_permissions"`
+	CreatedBy   int64              `db:"custom_role_created_by"`
+	Created     int64              `db:"custom_role_created"`
+	Updated     int64              `db:"custom_role_updated"`
+	Version     int64              `db:"custom_role_version"`
+}
+
+// NewCustomRoleStore returns a new CustomRoleStore.
+func NewCustomRoleStore(db *sqlx.DB) store.CustomRoleStore {
+	return &customRoleStore{
+		db: db,
+	}
+}
+
+type customRoleStore struct {
+	db *sqlx.DB
+}
+
+// Find returns a custom role given an ID.
+func (s *customRoleStore) Find(ctx context.Context, id int64) (*types.CustomRole, error) {
+	const findQueryStmt = customRoleSelectBase + `
+		WHERE custom_role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(customRole)
+	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find custom role")
+	}
+	return mapInternalToCustomRole(dst)
+}
+
+// FindByIdentifier returns a custom role of a space given its identifier.
+func (s *customRoleStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.CustomRole, error) {
+	const findQueryStmt = customRoleSelectBase + `
+		WHERE custom_role_space_id = $1 AND LOWER(custom_role_identifier) = LOWER($2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(customRole)
+	if err := db.GetContext(ctx, dst, findQueryStmt, spaceID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find custom role")
+	}
+	return mapInternalToCustomRole(dst)
+}
+
+// Create creates a new custom role.
+func (s *customRoleStore) Create(ctx context.Context, role *types.CustomRole) error {
+	const customRoleInsertStmt = `
+	INSERT INTO custom_roles (
+		 custom_role_space_id
+		,custom_role_identifier
+		,custom_role_description
+		,custom_role_permissions
+		,custom_role_created_by
+		,custom_role_created
+		,custom_role_updated
+		,custom_role_version
+	) VALUES (
+		 :custom_role_space_id
+		,:custom_role_identifier
+		,:custom_role_description
+		,:custom_role_permissions
+		,:custom_role_created_by
+		,:custom_role_created
+		,:custom_role_updated
+		,:custom_role_version
+	) RETURNING custom_role_id`
+
+	dbRole, err := mapCustomRoleToInternal(role)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(customRoleInsertStmt, dbRole)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind custom role object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&role.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Custom role query failed")
+	}
+
+	return nil
+}
+
+// Update tries to update a custom role and returns an optimistic locking error
+// if it was unable to do so.
+func (s *customRoleStore) Update(ctx context.Context, role *types.CustomRole) error {
+	const customRoleUpdateStmt = `
+	UPDATE custom_roles
+	SET
+		 custom_role_identifier = :custom_role_identifier
+		,custom_role_description = :custom_role_description
+		,custom_role_permissions = :custom_role_permissions
+		,custom_role_updated = :custom_role_updated
+		,custom_role_version = :custom_role_version
+	WHERE custom_role_id = :custom_role_id AND custom_role_version = :custom_role_version - 1`
+
+	dbRole, err := mapCustomRoleToInternal(role)
+	if err != nil {
+		return err
+	}
+
+	dbRole.Version++
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(customRoleUpdateStmt, dbRole)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind custom role object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update custom role")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	role.Version = dbRole.Version
+	return nil
+}
+
+// UpdateOptLock updates the custom role using the optimistic locking mechanism.
+func (s *customRoleStore) UpdateOptLock(
+	ctx context.Context,
+	role *types.CustomRole,
+	mutateFn func(role *types.CustomRole) error,
+) (*types.CustomRole, error) {
+	for {
+		dup := *role
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		role, err = s.Find(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes a custom role given an ID.
+func (s *customRoleStore) Delete(ctx context.Context, id int64) error {
+	const customRoleDeleteStmt = `
+		DELETE FROM custom_roles
+		WHERE custom_role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, customRoleDeleteStmt, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete custom role")
+	}
+	return nil
+}
+
+// List returns the custom roles defined in any of the provided spaces.
+func (s *customRoleStore) List(ctx context.Context, spaceIDs []int64) ([]*types.CustomRole, error) {
+	stmt := database.Builder.
+		Select(customRoleColumns).
+		From("custom_roles").
+		Where(squirrel.Eq{"custom_role_space_id": spaceIDs}).
+		OrderBy("custom_role_identifier")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*customRole{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	roles := make([]*types.CustomRole, len(dst))
+	for i, r := range dst {
+		role, err := mapInternalToCustomRole(r)
+		if err != nil {
+			return nil, err
+		}
+		roles[i] = role
+	}
+	return roles, nil
+}
+
+// CountMemberships returns the number of memberships the custom role is assigned to.
+func (s *customRoleStore) CountMemberships(ctx context.Context, id int64) (int64, error) {
+	const countStmt = `
+		SELECT count(*)
+		FROM memberships
+		WHERE membership_custom_role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, countStmt, id).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+	return count, nil
+}
+
+func mapCustomRoleToInternal(in *types.CustomRole) (*customRole, error) {
+	permissions, err := json.Marshal(in.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal custom role permissions: %w", err)
+	}
+	return &customRole{
+		ID:          in.ID,
+		SpaceID:     in.SpaceID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Permissions: permissions,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}, nil
+}
+
+func mapInternalToCustomRole(in *customRole) (*types.CustomRole, error) {
+	role := &types.CustomRole{
+		ID:          in.ID,
+		SpaceID:     in.SpaceID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}
+	if err := json.Unmarshal(in.Permissions, &role.Permissions); err != nil {
+		return nil, fmt.Errorf("could not unmarshal custom role permissions: %w", err)
+	}
+	return role, nil
+}
@@ -0,0 +1,337 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.EnvironmentStore = (*environmentStore)(nil)
+
+const (
+	environmentColumns = `
+		 environment_id
+		,environment_repo_id
+		,environment_identifier
+		,environment_description
+		,environment_protection
+		,environment_created_by
+		,environment_created
+		,environment_updated
+		,environment_version`
+
+	environmentSelectBase = `
+		SELECT` + environmentColumns + `
+		FROM environments`
+)
+
+type environment struct {
+	ID          int64              `db:"environment_id"`
+	RepoID      int64              `db:"environment_repo_id"`
+	Identifier  string             `db:"environment_identifier"`
+	Description string             `db:"environment_description"`
+	Protection  sqlxtypes.JSONText `db:"environment_protection"`
+	CreatedBy   int64              `db:"environment_created_by"`
+	Created     int64              `db:"environment_created"`
+	Updated     int64              `db:"environment_updated"`
+	Version     int64              `db:"environment_version"`
+}
+
+// NewEnvironmentStore returns a new EnvironmentStore.
+func NewEnvironmentStore(db *sqlx.DB) store.EnvironmentStore {
+	return &environmentStore{
+		db: db,
+	}
+}
+
+type environmentStore struct {
+	db *sqlx.DB
+}
+
+// Find returns an environment given an ID.
+func (s *environmentStore) Find(ctx context.Context, id int64) (*types.Environment, error) {
+	const findQueryStmt = environmentSelectBase + `
+		WHERE environment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(environment)
+	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find environment")
+	}
+	return mapInternalToEnvironment(dst)
+}
+
+// FindByIdentifier returns an environment of a repository given its identifier.
+func (s *environmentStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.Environment, error) {
+	const findQueryStmt = environmentSelectBase + `
+		WHERE environment_repo_id = $1 AND LOWER(environment_identifier) = LOWER($2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(environment)
+	if err := db.GetContext(ctx, dst, findQueryStmt, repoID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find environment")
+	}
+	return mapInternalToEnvironment(dst)
+}
+
+// Create creates a new environment.
+func (s *environmentStore) Create(ctx context.Context, env *types.Environment) error {
+	const environmentInsertStmt = `
+	INSERT INTO environments (
+		 environment_repo_id
+		,environment_identifier
+		,environment_description
+		,environment_protection
+		,environment_created_by
+		,environment_created
+		,environment_updated
+		,environment_version
+	) VALUES (
+		 :environment_repo_id
+		,:environment_identifier
+		,:environment_description
+		,:environment_protection
+		,:environment_created_by
+		,:environment_created
+		,:environment_updated
+		,:environment_version
+	) RETURNING environment_id`
+
+	dbEnv, err := mapEnvironmentToInternal(env)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(environmentInsertStmt, dbEnv)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind environment object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&env.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Environment query failed")
+	}
+
+	return nil
+}
+
+// Update tries to update an environment and returns an optimistic locking error
+// if it was unable to do so.
+func (s *environmentStore) Update(ctx context.Context, env *types.Environment) error {
+	const environmentUpdateStmt = `
+	UPDATE environments
+	SET
+		 environment_identifier = :environment_identifier
+		,environment_description = :environment_description
+		,environment_protection = :environment_protection
+		,environment_updated = :environment_updated
+		,environment_version = :environment_version
+	WHERE environment_id = :environment_id AND environment_version = :environment_version - 1`
+
+	dbEnv, err := mapEnvironmentToInternal(env)
+	if err != nil {
+		return err
+	}
+
+	dbEnv.Version++
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(environmentUpdateStmt, dbEnv)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind environment object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update environment")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	env.Version = dbEnv.Version
+	return nil
+}
+
+// UpdateOptLock updates the environment using the optimistic locking mechanism.
+func (s *environmentStore) UpdateOptLock(
+	ctx context.Context,
+	env *types.Environment,
+	mutateFn func(env *types.Environment) error,
+) (*types.Environment, error) {
+	for {
+		dup := *env
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		env, err = s.Find(ctx, env.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes an environment given an ID.
+func (s *environmentStore) Delete(ctx context.Context, id int64) error {
+	const environmentDeleteStmt = `
+		DELETE FROM environments
+		WHERE environment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, environmentDeleteStmt, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete environment")
+	}
+	return nil
+}
+
+// Count returns the number of environments of a repository matching the filter.
+func (s *environmentStore) Count(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("environments").
+		Where("environment_repo_id = ?", repoID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("environment_identifier", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+	return count, nil
+}
+
+// List returns the environments of a repository matching the filter.
+func (s *environmentStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Environment, error) {
+	stmt := database.Builder.
+		Select(environmentColumns).
+		From("environments").
+		Where("environment_repo_id = ?", repoID).
+		OrderBy("environment_identifier")
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("environment_identifier", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*environment{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	envs := make([]*types.Environment, len(dst))
+	for i, e := range dst {
+		m, err := mapInternalToEnvironment(e)
+		if err != nil {
+			return nil, err
+		}
+		envs[i] = m
+	}
+	return envs, nil
+}
+
+func mapEnvironmentToInternal(in *types.Environment) (*environment, error) {
+	protection, err := json.Marshal(in.Protection)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal environment protection: %w", err)
+	}
+	return &environment{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Protection:  protection,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}, nil
+}
+
+func mapInternalToEnvironment(in *environment) (*types.Environment, error) {
+	env := &types.Environment{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Version:     in.Version,
+	}
+	if err := json.Unmarshal(in.Protection, &env.Protection); err != nil {
+		return nil, fmt.Errorf("could not unmarshal environment protection: %w", err)
+	}
+	return env, nil
+}
@@ -466,6 +466,84 @@ func (s *executionStore) Count(ctx context.Context, pipelineID int64) (int64, er
 	return count, nil
 }
 
+// ListDeployments lists the executions that deployed to the given environment.
+// It orders them in descending order of execution id.
+func (s *executionStore) ListDeployments(
+	ctx context.Context,
+	environmentID int64,
+	pagination types.Pagination,
+) ([]*types.Execution, error) {
+	stmt := database.Builder.
+		Select(executionColumns).
+		From("executions").
+		Where("execution_deploy_id = ?", environmentID).
+		OrderBy("execution_id " + enum.OrderDesc.String())
+
+	stmt = stmt.Limit(database.Limit(pagination.Size))
+	stmt = stmt.Offset(database.Offset(pagination.Page, pagination.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*execution{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list deployments")
+	}
+
+	return mapInternalToExecutionList(dst)
+}
+
+// CountDeployments counts the executions that deployed to the given environment.
+func (s *executionStore) CountDeployments(ctx context.Context, environmentID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("executions").
+		Where("execution_deploy_id = ?", environmentID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count deployments")
+	}
+	return count, nil
+}
+
+// FindLatestDeployment returns the most recent successful execution
+// that deployed to the given environment.
+func (s *executionStore) FindLatestDeployment(ctx context.Context, environmentID int64) (*types.Execution, error) {
+	stmt := database.Builder.
+		Select(executionColumns).
+		From("executions").
+		Where("execution_deploy_id = ?", environmentID).
+		Where("execution_status = ?", enum.CIStatusSuccess).
+		OrderBy("execution_finished " + enum.OrderDesc.String()).
+		Limit(1)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(execution)
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find latest deployment")
+	}
+	return mapInternalToExecution(dst)
+}
+
 // CountInSpace counts the number of executions in a given space.
 func (s *executionStore) CountInSpace(
 	ctx context.Context,
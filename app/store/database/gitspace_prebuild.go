@@ -0,0 +1,396 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.GitspacePrebuildStore = (*gitspacePrebuildStore)(nil)
+
+const (
+	// gitspacePrebuildColumns doesn't contain the logs as they are only read using FindLogs.
+	gitspacePrebuildColumns = `
+		 gitspace_prebuild_id
+		,gitspace_prebuild_repo_id
+		,gitspace_prebuild_identifier
+		,gitspace_prebuild_branch
+		,gitspace_prebuild_disabled
+		,gitspace_prebuild_state
+		,gitspace_prebuild_commit_sha
+		,gitspace_prebuild_image
+		,gitspace_prebuild_error_message
+		,gitspace_prebuild_build_started
+		,gitspace_prebuild_build_finished
+		,gitspace_prebuild_created_by
+		,gitspace_prebuild_created
+		,gitspace_prebuild_updated
+		,gitspace_prebuild_version`
+
+	gitspacePrebuildSelectBase = `
+		SELECT` + gitspacePrebuildColumns + `
+		FROM gitspace_prebuilds`
+)
+
+type gitspacePrebuild struct {
+	ID            int64                      `db:"gitspace_prebuild_id"`
+	RepoID        int64                      `db:"gitspace_prebuild_repo_id"`
+	Identifier    string                     `db:"gitspace_prebuild_identifier"`
+	Branch        string                     `db:"gitspace_prebuild_branch"`
+	Disabled      bool                       `db:"gitspace_prebuild_disabled"`
+	State         enum.GitspacePrebuildState `db:"gitspace_prebuild_state"`
+	CommitSHA     string                     `db:"gitspace_prebuild_commit_sha"`
+	Image         string                     `db:"gitspace_prebuild_image"`
+	ErrorMessage  *string                    `db:"gitspace_prebuild_error_message"`
+	BuildStarted  int64                      `db:"gitspace_prebuild_build_started"`
+	BuildFinished int64                      `db:"gitspace_prebuild_build_finished"`
+	CreatedBy     int64                      `db:"gitspace_prebuild_created_by"`
+	Created       int64                      `db:"gitspace_prebuild_created"`
+	Updated       int64                      `db:"gitspace_prebuild_updated"`
+	Version       int64                      `db:"gitspace_prebuild_version"`
+}
+
+// NewGitspacePrebuildStore returns a new GitspacePrebuildStore.
+func NewGitspacePrebuildStore(db *sqlx.DB) store.GitspacePrebuildStore {
+	return &gitspacePrebuildStore{
+		db: db,
+	}
+}
+
+type gitspacePrebuildStore struct {
+	db *sqlx.DB
+}
+
+// Find returns a gitspace prebuild given an ID.
+func (s *gitspacePrebuildStore) Find(ctx context.Context, id int64) (*types.GitspacePrebuild, error) {
+	const findQueryStmt = gitspacePrebuildSelectBase + `
+		WHERE gitspace_prebuild_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(gitspacePrebuild)
+	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find gitspace prebuild")
+	}
+	return mapInternalToGitspacePrebuild(dst), nil
+}
+
+// FindByIdentifier returns a gitspace prebuild of a repository given its identifier.
+func (s *gitspacePrebuildStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.GitspacePrebuild, error) {
+	const findQueryStmt = gitspacePrebuildSelectBase + `
+		WHERE gitspace_prebuild_repo_id = $1 AND LOWER(gitspace_prebuild_identifier) = LOWER($2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(gitspacePrebuild)
+	if err := db.GetContext(ctx, dst, findQueryStmt, repoID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find gitspace prebuild")
+	}
+	return mapInternalToGitspacePrebuild(dst), nil
+}
+
+// FindByBranch returns the gitspace prebuild of a repository branch.
+func (s *gitspacePrebuildStore) FindByBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) (*types.GitspacePrebuild, error) {
+	const findQueryStmt = gitspacePrebuildSelectBase + `
+		WHERE gitspace_prebuild_repo_id = $1 AND gitspace_prebuild_branch = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(gitspacePrebuild)
+	if err := db.GetContext(ctx, dst, findQueryStmt, repoID, branch); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find gitspace prebuild")
+	}
+	return mapInternalToGitspacePrebuild(dst), nil
+}
+
+// Create creates a new gitspace prebuild.
+func (s *gitspacePrebuildStore) Create(ctx context.Context, prebuild *types.GitspacePrebuild) error {
+	const gitspacePrebuildInsertStmt = `
+	INSERT INTO gitspace_prebuilds (
+		 gitspace_prebuild_repo_id
+		,gitspace_prebuild_identifier
+		,gitspace_prebuild_branch
+		,gitspace_prebuild_disabled
+		,gitspace_prebuild_state
+		,gitspace_prebuild_commit_sha
+		,gitspace_prebuild_image
+		,gitspace_prebuild_error_message
+		,gitspace_prebuild_build_started
+		,gitspace_prebuild_build_finished
+		,gitspace_prebuild_created_by
+		,gitspace_prebuild_created
+		,gitspace_prebuild_updated
+		,gitspace_prebuild_version
+	) VALUES (
+		 :gitspace_prebuild_repo_id
+		,:gitspace_prebuild_identifier
+		,:gitspace_prebuild_branch
+		,:gitspace_prebuild_disabled
+		,:gitspace_prebuild_state
+		,:gitspace_prebuild_commit_sha
+		,:gitspace_prebuild_image
+		,:gitspace_prebuild_error_message
+		,:gitspace_prebuild_build_started
+		,:gitspace_prebuild_build_finished
+		,:gitspace_prebuild_created_by
+		,:gitspace_prebuild_created
+		,:gitspace_prebuild_updated
+		,:gitspace_prebuild_version
+	) RETURNING gitspace_prebuild_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(gitspacePrebuildInsertStmt, mapGitspacePrebuildToInternal(prebuild))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind gitspace prebuild object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&prebuild.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Gitspace prebuild query failed")
+	}
+
+	return nil
+}
+
+// Update tries to update a gitspace prebuild and returns an optimistic locking error
+// if it was unable to do so.
+func (s *gitspacePrebuildStore) Update(ctx context.Context, prebuild *types.GitspacePrebuild) error {
+	const gitspacePrebuildUpdateStmt = `
+	UPDATE gitspace_prebuilds
+	SET
+		 gitspace_prebuild_identifier = :gitspace_prebuild_identifier
+		,gitspace_prebuild_branch = :gitspace_prebuild_branch
+		,gitspace_prebuild_disabled = :gitspace_prebuild_disabled
+		,gitspace_prebuild_state = :gitspace_prebuild_state
+		,gitspace_prebuild_commit_sha = :gitspace_prebuild_commit_sha
+		,gitspace_prebuild_image = :gitspace_prebuild_image
+		,gitspace_prebuild_error_message = :gitspace_prebuild_error_message
+		,gitspace_prebuild_build_started = :gitspace_prebuild_build_started
+		,gitspace_prebuild_build_finished = :gitspace_prebuild_build_finished
+		,gitspace_prebuild_updated = :gitspace_prebuild_updated
+		,gitspace_prebuild_version = :gitspace_prebuild_version
+	WHERE gitspace_prebuild_id = :gitspace_prebuild_id AND gitspace_prebuild_version = :gitspace_prebuild_version - 1`
+
+	dbPrebuild := mapGitspacePrebuildToInternal(prebuild)
+	dbPrebuild.Version++
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(gitspacePrebuildUpdateStmt, dbPrebuild)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind gitspace prebuild object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update gitspace prebuild")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	prebuild.Version = dbPrebuild.Version
+	return nil
+}
+
+// UpdateOptLock updates the gitspace prebuild using the optimistic locking mechanism.
+func (s *gitspacePrebuildStore) UpdateOptLock(
+	ctx context.Context,
+	prebuild *types.GitspacePrebuild,
+	mutateFn func(prebuild *types.GitspacePrebuild) error,
+) (*types.GitspacePrebuild, error) {
+	for {
+		dup := *prebuild
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		prebuild, err = s.Find(ctx, prebuild.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// UpdateLogs replaces the logs of the latest build of a gitspace prebuild.
+func (s *gitspacePrebuildStore) UpdateLogs(ctx context.Context, id int64, logs string) error {
+	const gitspacePrebuildUpdateLogsStmt = `
+		UPDATE gitspace_prebuilds
+		SET gitspace_prebuild_logs = $1
+		WHERE gitspace_prebuild_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, gitspacePrebuildUpdateLogsStmt, logs, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update gitspace prebuild logs")
+	}
+	return nil
+}
+
+// FindLogs returns the logs of the latest build of a gitspace prebuild.
+func (s *gitspacePrebuildStore) FindLogs(ctx context.Context, id int64) (*types.GitspacePrebuildLogs, error) {
+	const findLogsQueryStmt = `
+		SELECT gitspace_prebuild_commit_sha, gitspace_prebuild_logs
+		FROM gitspace_prebuilds
+		WHERE gitspace_prebuild_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.GitspacePrebuildLogs{}
+	if err := db.QueryRowContext(ctx, findLogsQueryStmt, id).Scan(&dst.CommitSHA, &dst.Logs); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find gitspace prebuild logs")
+	}
+	return dst, nil
+}
+
+// Delete deletes a gitspace prebuild given an ID.
+func (s *gitspacePrebuildStore) Delete(ctx context.Context, id int64) error {
+	const gitspacePrebuildDeleteStmt = `
+		DELETE FROM gitspace_prebuilds
+		WHERE gitspace_prebuild_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, gitspacePrebuildDeleteStmt, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete gitspace prebuild")
+	}
+	return nil
+}
+
+// List returns the gitspace prebuilds of a repository.
+func (s *gitspacePrebuildStore) List(ctx context.Context, repoID int64) ([]*types.GitspacePrebuild, error) {
+	stmt := database.Builder.
+		Select(gitspacePrebuildColumns).
+		From("gitspace_prebuilds").
+		Where("gitspace_prebuild_repo_id = ?", repoID).
+		OrderBy("gitspace_prebuild_identifier")
+
+	return s.list(ctx, stmt)
+}
+
+// ListByState returns the gitspace prebuilds in the given state which weren't updated since the provided time.
+func (s *gitspacePrebuildStore) ListByState(
+	ctx context.Context,
+	state enum.GitspacePrebuildState,
+	updatedBefore int64,
+) ([]*types.GitspacePrebuild, error) {
+	stmt := database.Builder.
+		Select(gitspacePrebuildColumns).
+		From("gitspace_prebuilds").
+		Where("gitspace_prebuild_state = ?", state).
+		Where("gitspace_prebuild_updated < ?", updatedBefore).
+		OrderBy("gitspace_prebuild_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *gitspacePrebuildStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.GitspacePrebuild, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*gitspacePrebuild{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	prebuilds := make([]*types.GitspacePrebuild, len(dst))
+	for i, p := range dst {
+		prebuilds[i] = mapInternalToGitspacePrebuild(p)
+	}
+	return prebuilds, nil
+}
+
+func mapGitspacePrebuildToInternal(in *types.GitspacePrebuild) *gitspacePrebuild {
+	return &gitspacePrebuild{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		Identifier:    in.Identifier,
+		Branch:        in.Branch,
+		Disabled:      in.Disabled,
+		State:         in.State,
+		CommitSHA:     in.CommitSHA,
+		Image:         in.Image,
+		ErrorMessage:  in.ErrorMessage,
+		BuildStarted:  in.BuildStarted,
+		BuildFinished: in.BuildFinished,
+		CreatedBy:     in.CreatedBy,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		Version:       in.Version,
+	}
+}
+
+func mapInternalToGitspacePrebuild(in *gitspacePrebuild) *types.GitspacePrebuild {
+	return &types.GitspacePrebuild{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		Identifier:    in.Identifier,
+		Branch:        in.Branch,
+		Disabled:      in.Disabled,
+		State:         in.State,
+		CommitSHA:     in.CommitSHA,
+		Image:         in.Image,
+		ErrorMessage:  in.ErrorMessage,
+		BuildStarted:  in.BuildStarted,
+		BuildFinished: in.BuildFinished,
+		CreatedBy:     in.CreatedBy,
+		Created:       in.Created,
+		Updated:       in.Updated,
+		Version:       in.Version,
+	}
+}
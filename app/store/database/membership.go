@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/gotidy/ptr"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -63,6 +64,9 @@ type membership struct {
 	Updated   int64 `db:"membership_updated"`
 
 	Role enum.MembershipRole `db:"membership_role"`
+
+	CustomRoleID *int64  `db:"membership_custom_role_id"`
+	CustomRole   *string `db:"membership_custom_role"`
 }
 
 type membershipPrincipal struct {
@@ -82,7 +86,10 @@ const (
 		,membership_created_by
 		,membership_created
 		,membership_updated
-		,membership_role`
+		,membership_role
+		,membership_custom_role_id
+		,(SELECT custom_role_identifier FROM custom_roles
+			WHERE custom_role_id = membership_custom_role_id) AS membership_custom_role`
 
 	membershipSelectBase = `
 	SELECT` + membershipColumns + `
@@ -130,6 +137,7 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,membership_created
 		,membership_updated
 		,membership_role
+		,membership_custom_role_id
 	) values (
 		 :membership_space_id
 		,:membership_principal_id
@@ -137,6 +145,7 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,:membership_created
 		,:membership_updated
 		,:membership_role
+		,:membership_custom_role_id
 	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -160,6 +169,7 @@ func (s *MembershipStore) Update(ctx context.Context, membership *types.Membersh
 	SET
 		 membership_updated = :membership_updated
 		,membership_role = :membership_role
+		,membership_custom_role_id = :membership_custom_role_id
 	WHERE membership_space_id = :membership_space_id AND
 	      membership_principal_id = :membership_principal_id`
 
@@ -381,21 +391,24 @@ func mapToMembership(m *membership) types.Membership {
 			SpaceID:     m.SpaceID,
 			PrincipalID: m.PrincipalID,
 		},
-		CreatedBy: m.CreatedBy,
-		Created:   m.Created,
-		Updated:   m.Updated,
-		Role:      m.Role,
+		CreatedBy:    m.CreatedBy,
+		Created:      m.Created,
+		Updated:      m.Updated,
+		Role:         m.Role,
+		CustomRoleID: m.CustomRoleID,
+		CustomRole:   ptr.ToString(m.CustomRole),
 	}
 }
 
 func mapToInternalMembership(m *types.Membership) membership {
 	return membership{
-		SpaceID:     m.SpaceID,
-		PrincipalID: m.PrincipalID,
-		CreatedBy:   m.CreatedBy,
-		Created:     m.Created,
-		Updated:     m.Updated,
-		Role:        m.Role,
+		SpaceID:      m.SpaceID,
+		PrincipalID:  m.PrincipalID,
+		CreatedBy:    m.CreatedBy,
+		Created:      m.Created,
+		Updated:      m.Updated,
+		Role:         m.Role,
+		CustomRoleID: m.CustomRoleID,
 	}
 }
 
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS package_tags
+(
+    package_tag_id         SERIAL PRIMARY KEY,
+    package_tag_name       TEXT NOT NULL,
+    package_tag_image_id   INTEGER NOT NULL
+        CONSTRAINT fk_images_image_id
+            REFERENCES images(image_id) ON DELETE CASCADE,
+    package_tag_version    TEXT NOT NULL,
+    package_tag_created_at BIGINT NOT NULL,
+    package_tag_updated_at BIGINT NOT NULL,
+    package_tag_created_by INTEGER NOT NULL,
+    package_tag_updated_by INTEGER NOT NULL,
+    CONSTRAINT unique_package_tag_image_id_and_name UNIQUE (package_tag_image_id, package_tag_name)
+);
@@ -0,0 +1,49 @@
+DROP TRIGGER IF EXISTS gc_track_assigned_subject_trigger ON manifests;
+DROP FUNCTION IF EXISTS gc_track_assigned_subjects();
+
+CREATE OR REPLACE FUNCTION gc_track_manifest_uploads()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+    VALUES (NEW.manifest_registry_id, NEW.manifest_id, gc_review_after('manifest_upload'), 'manifest_upload');
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION gc_track_deleted_tags()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1
+               FROM manifests
+               WHERE manifest_registry_id = OLD.tag_registry_id
+                 AND manifest_id = OLD.tag_registry_id) THEN
+        INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+        VALUES (OLD.tag_registry_id, OLD.tag_manifest_id, gc_review_after('tag_delete'), 'tag_delete')
+        ON CONFLICT (registry_id, manifest_id)
+            DO UPDATE SET review_after = gc_review_after('tag_delete'),
+                          event        = 'tag_delete';
+    END IF;
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION gc_track_switched_tags()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+    VALUES (OLD.tag_registry_id, OLD.tag_manifest_id, gc_review_after('tag_switch'), 'tag_switch')
+    ON CONFLICT (registry_id, manifest_id)
+        DO UPDATE SET review_after = gc_review_after('tag_switch'),
+                      event        = 'tag_switch';
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
@@ -0,0 +1,87 @@
+-- referrers (signatures, attestations, SBOMs) are usually never tagged, they must
+-- be kept as long as their subject exists and are removed along with it through
+-- the manifest_subject_id foreign key.
+CREATE OR REPLACE FUNCTION gc_track_manifest_uploads()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    IF NEW.manifest_subject_id IS NULL THEN
+        INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+        VALUES (NEW.manifest_registry_id, NEW.manifest_id, gc_review_after('manifest_upload'), 'manifest_upload');
+    END IF;
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION gc_track_deleted_tags()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1
+               FROM manifests
+               WHERE manifest_registry_id = OLD.tag_registry_id
+                 AND manifest_id = OLD.tag_manifest_id
+                 AND manifest_subject_id IS NULL) THEN
+        INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+        VALUES (OLD.tag_registry_id, OLD.tag_manifest_id, gc_review_after('tag_delete'), 'tag_delete')
+        ON CONFLICT (registry_id, manifest_id)
+            DO UPDATE SET review_after = gc_review_after('tag_delete'),
+                          event        = 'tag_delete';
+    END IF;
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION gc_track_switched_tags()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1
+               FROM manifests
+               WHERE manifest_registry_id = OLD.tag_registry_id
+                 AND manifest_id = OLD.tag_manifest_id
+                 AND manifest_subject_id IS NULL) THEN
+        INSERT INTO gc_manifest_review_queue (registry_id, manifest_id, review_after, event)
+        VALUES (OLD.tag_registry_id, OLD.tag_manifest_id, gc_review_after('tag_switch'), 'tag_switch')
+        ON CONFLICT (registry_id, manifest_id)
+            DO UPDATE SET review_after = gc_review_after('tag_switch'),
+                          event        = 'tag_switch';
+    END IF;
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+-- referrers pushed before their subject get linked to it once it's pushed.
+CREATE OR REPLACE FUNCTION gc_track_assigned_subjects()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    DELETE
+    FROM gc_manifest_review_queue
+    WHERE registry_id = NEW.manifest_registry_id
+      AND manifest_id = NEW.manifest_id;
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+CREATE TRIGGER gc_track_assigned_subject_trigger
+    AFTER UPDATE OF manifest_subject_id
+    ON manifests
+    FOR EACH ROW
+    WHEN (OLD.manifest_subject_id IS NULL AND NEW.manifest_subject_id IS NOT NULL)
+EXECUTE PROCEDURE gc_track_assigned_subjects();
+
+DELETE
+FROM gc_manifest_review_queue q
+    USING manifests m
+WHERE q.registry_id = m.manifest_registry_id
+  AND q.manifest_id = m.manifest_id
+  AND m.manifest_subject_id IS NOT NULL;
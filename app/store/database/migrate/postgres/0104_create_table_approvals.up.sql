@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS approvals
+(
+    approval_id               SERIAL PRIMARY KEY,
+    approval_execution_id     INTEGER NOT NULL,
+    approval_stage_id         INTEGER NOT NULL,
+    approval_message          TEXT    NOT NULL,
+    approval_users            TEXT    NOT NULL,
+    approval_user_groups      TEXT    NOT NULL,
+    approval_timeout          BIGINT  NOT NULL,
+    approval_default_decision TEXT    NOT NULL,
+    approval_decision         TEXT    NOT NULL,
+    approval_decided_by       INTEGER,
+    approval_comment          TEXT    NOT NULL,
+    approval_decided          BIGINT  NOT NULL,
+    approval_created          BIGINT  NOT NULL,
+    approval_updated          BIGINT  NOT NULL,
+    approval_version          INTEGER NOT NULL,
+    CONSTRAINT unique_approval_stage_id UNIQUE (approval_stage_id),
+    CONSTRAINT fk_approvals_execution_id FOREIGN KEY (approval_execution_id)
+        REFERENCES executions (execution_id) ON DELETE CASCADE,
+    CONSTRAINT fk_approvals_stage_id FOREIGN KEY (approval_stage_id)
+        REFERENCES stages (stage_id) ON DELETE CASCADE,
+    CONSTRAINT fk_approvals_decided_by FOREIGN KEY (approval_decided_by)
+        REFERENCES principals (principal_id) ON DELETE SET NULL
+);
+
+CREATE INDEX approvals_execution_id ON approvals (approval_execution_id);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS gitspace_prebuilds
+(
+    gitspace_prebuild_id             SERIAL PRIMARY KEY,
+    gitspace_prebuild_repo_id        INTEGER NOT NULL,
+    gitspace_prebuild_identifier     TEXT    NOT NULL,
+    gitspace_prebuild_branch         TEXT    NOT NULL,
+    gitspace_prebuild_disabled       BOOLEAN NOT NULL,
+    gitspace_prebuild_state          TEXT    NOT NULL,
+    gitspace_prebuild_commit_sha     TEXT    NOT NULL,
+    gitspace_prebuild_image          TEXT    NOT NULL,
+    gitspace_prebuild_error_message  TEXT,
+    gitspace_prebuild_logs           TEXT    NOT NULL DEFAULT '',
+    gitspace_prebuild_build_started  BIGINT  NOT NULL,
+    gitspace_prebuild_build_finished BIGINT  NOT NULL,
+    gitspace_prebuild_created_by     INTEGER NOT NULL,
+    gitspace_prebuild_created        BIGINT  NOT NULL,
+    gitspace_prebuild_updated        BIGINT  NOT NULL,
+    gitspace_prebuild_version        INTEGER NOT NULL,
+    CONSTRAINT fk_gitspace_prebuilds_repo_id FOREIGN KEY (gitspace_prebuild_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX gitspace_prebuilds_repo_id_identifier
+    ON gitspace_prebuilds (gitspace_prebuild_repo_id, LOWER(gitspace_prebuild_identifier));
+
+CREATE UNIQUE INDEX gitspace_prebuilds_repo_id_branch
+    ON gitspace_prebuilds (gitspace_prebuild_repo_id, gitspace_prebuild_branch);
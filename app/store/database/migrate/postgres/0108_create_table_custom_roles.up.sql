@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS custom_roles
+(
+    custom_role_id          SERIAL PRIMARY KEY,
+    custom_role_space_id    INTEGER NOT NULL,
+    custom_role_identifier  TEXT    NOT NULL,
+    custom_role_description TEXT    NOT NULL,
+    custom_role_permissions TEXT    NOT NULL,
+    custom_role_created_by  INTEGER NOT NULL,
+    custom_role_created     BIGINT  NOT NULL,
+    custom_role_updated     BIGINT  NOT NULL,
+    custom_role_version     INTEGER NOT NULL,
+    CONSTRAINT fk_custom_roles_space_id FOREIGN KEY (custom_role_space_id)
+        REFERENCES spaces (space_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX custom_roles_space_id_identifier
+    ON custom_roles (custom_role_space_id, LOWER(custom_role_identifier));
+
+ALTER TABLE memberships ADD COLUMN membership_custom_role_id INTEGER;
+
+CREATE INDEX memberships_custom_role_id ON memberships (membership_custom_role_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pullreq_review_reminders;
@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS notification_preferences
+(
+    notification_preference_principal_id INTEGER NOT NULL,
+    notification_preference_trigger      TEXT    NOT NULL,
+    notification_preference_delivery     TEXT    NOT NULL,
+    notification_preference_updated      BIGINT  NOT NULL,
+    CONSTRAINT pk_notification_preferences PRIMARY KEY (
+        notification_preference_principal_id,
+        notification_preference_trigger
+    ),
+    CONSTRAINT fk_notification_preferences_principal_id FOREIGN KEY (notification_preference_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS repo_notification_preferences
+(
+    repo_notification_preference_principal_id INTEGER NOT NULL,
+    repo_notification_preference_repo_id      INTEGER NOT NULL,
+    repo_notification_preference_mode         TEXT    NOT NULL,
+    repo_notification_preference_updated      BIGINT  NOT NULL,
+    CONSTRAINT pk_repo_notification_preferences PRIMARY KEY (
+        repo_notification_preference_principal_id,
+        repo_notification_preference_repo_id
+    ),
+    CONSTRAINT fk_repo_notification_preferences_principal_id FOREIGN KEY (repo_notification_preference_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE,
+    CONSTRAINT fk_repo_notification_preferences_repo_id FOREIGN KEY (repo_notification_preference_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE
+);
+
+CREATE INDEX repo_notification_preferences_repo_id
+    ON repo_notification_preferences(repo_notification_preference_repo_id);
+
+CREATE TABLE IF NOT EXISTS notification_digest_items
+(
+    notification_digest_item_id           SERIAL PRIMARY KEY,
+    notification_digest_item_principal_id INTEGER NOT NULL,
+    notification_digest_item_repo_id      INTEGER NOT NULL,
+    notification_digest_item_trigger      TEXT    NOT NULL,
+    notification_digest_item_title        TEXT    NOT NULL,
+    notification_digest_item_url          TEXT    NOT NULL,
+    notification_digest_item_created      BIGINT  NOT NULL,
+    CONSTRAINT fk_notification_digest_items_principal_id FOREIGN KEY (notification_digest_item_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE,
+    CONSTRAINT fk_notification_digest_items_repo_id FOREIGN KEY (notification_digest_item_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_items_principal_id
+    ON notification_digest_items(notification_digest_item_principal_id);
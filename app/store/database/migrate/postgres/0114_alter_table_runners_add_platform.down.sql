@@ -0,0 +1 @@
+ALTER TABLE runners DROP COLUMN runner_platform;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS runners
+(
+    runner_id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    runner_identifier  TEXT    NOT NULL,
+    runner_description TEXT    NOT NULL,
+    runner_labels      TEXT    NOT NULL,
+    runner_token_hash  TEXT    NOT NULL,
+    runner_last_seen   INTEGER NOT NULL,
+    runner_created_by  INTEGER NOT NULL,
+    runner_created     INTEGER NOT NULL,
+    runner_updated     INTEGER NOT NULL,
+    CONSTRAINT unique_runner_identifier UNIQUE (runner_identifier),
+    CONSTRAINT unique_runner_token_hash UNIQUE (runner_token_hash)
+);
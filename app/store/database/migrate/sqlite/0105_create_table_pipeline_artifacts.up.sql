@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS pipeline_artifacts
+(
+    artifact_id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    artifact_repo_id      INTEGER NOT NULL,
+    artifact_pipeline_id  INTEGER NOT NULL,
+    artifact_execution_id INTEGER,
+    artifact_kind         TEXT    NOT NULL,
+    artifact_key          TEXT    NOT NULL,
+    artifact_size         INTEGER NOT NULL,
+    artifact_created_by   INTEGER NOT NULL,
+    artifact_created      INTEGER NOT NULL,
+    artifact_updated      INTEGER NOT NULL,
+    artifact_expires      INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX pipeline_artifacts_execution_id_key
+    ON pipeline_artifacts (artifact_execution_id, artifact_key)
+    WHERE artifact_execution_id IS NOT NULL;
+
+CREATE UNIQUE INDEX pipeline_artifacts_pipeline_id_key
+    ON pipeline_artifacts (artifact_pipeline_id, artifact_key)
+    WHERE artifact_execution_id IS NULL;
+
+CREATE INDEX pipeline_artifacts_expires ON pipeline_artifacts (artifact_expires);
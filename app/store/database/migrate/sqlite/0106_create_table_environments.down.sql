@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS executions_deploy_id;
+DROP TABLE IF EXISTS environments;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS environments
+(
+    environment_id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    environment_repo_id     INTEGER NOT NULL,
+    environment_identifier  TEXT    NOT NULL,
+    environment_description TEXT    NOT NULL,
+    environment_protection  TEXT    NOT NULL,
+    environment_created_by  INTEGER NOT NULL,
+    environment_created     INTEGER NOT NULL,
+    environment_updated     INTEGER NOT NULL,
+    environment_version     INTEGER NOT NULL,
+    CONSTRAINT fk_environments_repo_id FOREIGN KEY (environment_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX environments_repo_id_identifier
+    ON environments (environment_repo_id, LOWER(environment_identifier));
+
+CREATE INDEX executions_deploy_id ON executions (execution_deploy_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gitspace_prebuilds;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS memberships_custom_role_id;
+ALTER TABLE memberships DROP COLUMN membership_custom_role_id;
+DROP TABLE IF EXISTS custom_roles;
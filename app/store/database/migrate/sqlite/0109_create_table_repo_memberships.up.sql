@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS repo_memberships
+(
+    repo_membership_id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_membership_repo_id       INTEGER NOT NULL,
+    repo_membership_principal_id  INTEGER,
+    repo_membership_usergroup_id  INTEGER,
+    repo_membership_role          TEXT    NOT NULL,
+    repo_membership_created_by    INTEGER NOT NULL,
+    repo_membership_created       INTEGER NOT NULL,
+    repo_membership_updated       INTEGER NOT NULL,
+    CONSTRAINT fk_repo_memberships_repo_id FOREIGN KEY (repo_membership_repo_id)
+        REFERENCES repositories (repo_id) ON DELETE CASCADE,
+    CONSTRAINT fk_repo_memberships_principal_id FOREIGN KEY (repo_membership_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE,
+    CONSTRAINT fk_repo_memberships_usergroup_id FOREIGN KEY (repo_membership_usergroup_id)
+        REFERENCES usergroups (usergroup_id) ON DELETE CASCADE,
+    CONSTRAINT chk_repo_memberships_member CHECK (
+        (repo_membership_principal_id IS NULL) <> (repo_membership_usergroup_id IS NULL)
+    )
+);
+
+CREATE UNIQUE INDEX repo_memberships_repo_id_principal_id
+    ON repo_memberships (repo_membership_repo_id, repo_membership_principal_id)
+    WHERE repo_membership_principal_id IS NOT NULL;
+
+CREATE UNIQUE INDEX repo_memberships_repo_id_usergroup_id
+    ON repo_memberships (repo_membership_repo_id, repo_membership_usergroup_id)
+    WHERE repo_membership_usergroup_id IS NOT NULL;
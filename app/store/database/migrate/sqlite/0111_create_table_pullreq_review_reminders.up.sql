@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS pullreq_review_reminders
+(
+    pullreq_review_reminder_pullreq_id   INTEGER NOT NULL,
+    pullreq_review_reminder_principal_id INTEGER NOT NULL,
+    pullreq_review_reminder_type         TEXT    NOT NULL,
+    pullreq_review_reminder_count        INTEGER NOT NULL,
+    pullreq_review_reminder_created      INTEGER NOT NULL,
+    pullreq_review_reminder_updated      INTEGER NOT NULL,
+    CONSTRAINT pk_pullreq_review_reminders PRIMARY KEY (
+        pullreq_review_reminder_pullreq_id,
+        pullreq_review_reminder_principal_id,
+        pullreq_review_reminder_type
+    ),
+    CONSTRAINT fk_pullreq_review_reminders_pullreq_id FOREIGN KEY (pullreq_review_reminder_pullreq_id)
+        REFERENCES pullreqs (pullreq_id) ON DELETE CASCADE,
+    CONSTRAINT fk_pullreq_review_reminders_principal_id FOREIGN KEY (pullreq_review_reminder_principal_id)
+        REFERENCES principals (principal_id) ON DELETE CASCADE
+);
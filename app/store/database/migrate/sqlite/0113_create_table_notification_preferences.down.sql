@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_digest_items;
+DROP TABLE IF EXISTS repo_notification_preferences;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1 @@
+ALTER TABLE runners ADD COLUMN runner_platform TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,440 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationChannelStore = (*NotificationChannelStore)(nil)
+
+// NewNotificationChannelStore returns a new NotificationChannelStore.
+func NewNotificationChannelStore(db *sqlx.DB) *NotificationChannelStore {
+	return &NotificationChannelStore{
+		db: db,
+	}
+}
+
+// NotificationChannelStore implements store.NotificationChannelStore backed by a relational database.
+type NotificationChannelStore struct {
+	db *sqlx.DB
+}
+
+// notificationChannel is an internal representation used to store notification channel data in the database.
+type notificationChannel struct {
+	ID          int64                        `db:"notification_channel_id"`
+	Version     int64                        `db:"notification_channel_version"`
+	SpaceID     null.Int                     `db:"notification_channel_space_id"`
+	RepoID      null.Int                     `db:"notification_channel_repo_id"`
+	Identifier  string                       `db:"notification_channel_uid"`
+	Description string                       `db:"notification_channel_description"`
+	Type        enum.NotificationChannelType `db:"notification_channel_type"`
+	URL         string                       `db:"notification_channel_url"`
+	Enabled     bool                         `db:"notification_channel_enabled"`
+	Triggers    string                       `db:"notification_channel_triggers"`
+	CreatedBy   int64                        `db:"notification_channel_created_by"`
+	Created     int64                        `db:"notification_channel_created"`
+	Updated     int64                        `db:"notification_channel_updated"`
+}
+
+const (
+	notificationChannelColumns = `
+		 notification_channel_id
+		,notification_channel_version
+		,notification_channel_space_id
+		,notification_channel_repo_id
+		,notification_channel_uid
+		,notification_channel_description
+		,notification_channel_type
+		,notification_channel_url
+		,notification_channel_enabled
+		,notification_channel_triggers
+		,notification_channel_created_by
+		,notification_channel_created
+		,notification_channel_updated`
+)
+
+// Find finds the notification channel by id.
+func (s *NotificationChannelStore) Find(ctx context.Context, id int64) (*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("notification_channel_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByIdentifier finds the notification channel with the given identifier for the given parent.
+func (s *NotificationChannelStore) FindByIdentifier(
+	ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("LOWER(notification_channel_uid) = ?", strings.ToLower(identifier))
+
+	stmt, err := applyNotificationChannelParent(stmt, parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.find(ctx, stmt)
+}
+
+func (s *NotificationChannelStore) find(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) (*types.NotificationChannel, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToNotificationChannel(dst)
+}
+
+// Create creates a new notification channel.
+func (s *NotificationChannelStore) Create(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		INSERT INTO notification_channels (
+			 notification_channel_version
+			,notification_channel_space_id
+			,notification_channel_repo_id
+			,notification_channel_uid
+			,notification_channel_description
+			,notification_channel_type
+			,notification_channel_url
+			,notification_channel_enabled
+			,notification_channel_triggers
+			,notification_channel_created_by
+			,notification_channel_created
+			,notification_channel_updated
+		) values (
+			 :notification_channel_version
+			,:notification_channel_space_id
+			,:notification_channel_repo_id
+			,:notification_channel_uid
+			,:notification_channel_description
+			,:notification_channel_type
+			,:notification_channel_url
+			,:notification_channel_enabled
+			,:notification_channel_triggers
+			,:notification_channel_created_by
+			,:notification_channel_created
+			,:notification_channel_updated
+		) RETURNING notification_channel_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbChannel, err := mapToInternalNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&channel.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing notification channel.
+func (s *NotificationChannelStore) Update(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		UPDATE notification_channels
+		SET
+			 notification_channel_version = :notification_channel_version
+			,notification_channel_updated = :notification_channel_updated
+			,notification_channel_uid = :notification_channel_uid
+			,notification_channel_description = :notification_channel_description
+			,notification_channel_type = :notification_channel_type
+			,notification_channel_url = :notification_channel_url
+			,notification_channel_enabled = :notification_channel_enabled
+			,notification_channel_triggers = :notification_channel_triggers
+		WHERE notification_channel_id = :notification_channel_id AND
+			notification_channel_version = :notification_channel_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbChannel, err := mapToInternalNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	// update Version (used for optimistic locking) and Updated time
+	dbChannel.Version++
+	dbChannel.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification channel")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	channel.Version = dbChannel.Version
+	channel.Updated = dbChannel.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the notification channel using the optimistic locking mechanism.
+func (s *NotificationChannelStore) UpdateOptLock(
+	ctx context.Context,
+	channel *types.NotificationChannel,
+	mutateFn func(channel *types.NotificationChannel) error,
+) (*types.NotificationChannel, error) {
+	for {
+		dup := *channel
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		channel, err = s.Find(ctx, channel.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the notification channel with the given id.
+func (s *NotificationChannelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM notification_channels
+		WHERE notification_channel_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists the notification channels of the given parent.
+func (s *NotificationChannelStore) List(
+	ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+) ([]*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		OrderBy("LOWER(notification_channel_uid)")
+
+	stmt, err := applyNotificationChannelParent(stmt, parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, stmt)
+}
+
+// ListEnabled lists the enabled notification channels of the repo and of the provided spaces.
+func (s *NotificationChannelStore) ListEnabled(
+	ctx context.Context,
+	repoID int64,
+	spaceIDs []int64,
+) ([]*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("notification_channel_enabled = ?", true).
+		Where(squirrel.Or{
+			squirrel.Eq{"notification_channel_repo_id": repoID},
+			squirrel.Eq{"notification_channel_space_id": spaceIDs},
+		}).
+		OrderBy("notification_channel_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *NotificationChannelStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.NotificationChannel, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationChannel
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	channels := make([]*types.NotificationChannel, len(dst))
+	for i, channel := range dst {
+		channels[i], err = mapToNotificationChannel(channel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return channels, nil
+}
+
+func applyNotificationChannelParent(
+	stmt squirrel.SelectBuilder,
+	parentType enum.ParentResourceType,
+	parentID int64,
+) (squirrel.SelectBuilder, error) {
+	switch parentType {
+	case enum.ParentResourceTypeRepo:
+		return stmt.Where("notification_channel_repo_id = ?", parentID), nil
+	case enum.ParentResourceTypeSpace:
+		return stmt.Where("notification_channel_space_id = ?", parentID), nil
+	default:
+		return stmt, fmt.Errorf("notification channel parent type %q is not supported", parentType)
+	}
+}
+
+func mapToNotificationChannel(channel *notificationChannel) (*types.NotificationChannel, error) {
+	res := &types.NotificationChannel{
+		ID:          channel.ID,
+		Version:     channel.Version,
+		CreatedBy:   channel.CreatedBy,
+		Created:     channel.Created,
+		Updated:     channel.Updated,
+		Identifier:  channel.Identifier,
+		Description: channel.Description,
+		Type:        channel.Type,
+		URL:         channel.URL,
+		Enabled:     channel.Enabled,
+		Triggers:    notificationTriggersFromString(channel.Triggers),
+	}
+
+	switch {
+	case channel.RepoID.Valid && channel.SpaceID.Valid:
+		return nil, fmt.Errorf("both repoID and spaceID are set for notification channel %d", channel.ID)
+	case channel.RepoID.Valid:
+		res.ParentType = enum.ParentResourceTypeRepo
+		res.ParentID = channel.RepoID.Int64
+	case channel.SpaceID.Valid:
+		res.ParentType = enum.ParentResourceTypeSpace
+		res.ParentID = channel.SpaceID.Int64
+	default:
+		return nil, fmt.Errorf("neither repoID nor spaceID are set for notification channel %d", channel.ID)
+	}
+
+	return res, nil
+}
+
+func mapToInternalNotificationChannel(channel *types.NotificationChannel) (*notificationChannel, error) {
+	res := &notificationChannel{
+		ID:          channel.ID,
+		Version:     channel.Version,
+		CreatedBy:   channel.CreatedBy,
+		Created:     channel.Created,
+		Updated:     channel.Updated,
+		Identifier:  channel.Identifier,
+		Description: channel.Description,
+		Type:        channel.Type,
+		URL:         channel.URL,
+		Enabled:     channel.Enabled,
+		Triggers:    notificationTriggersToString(channel.Triggers),
+	}
+
+	switch channel.ParentType {
+	case enum.ParentResourceTypeRepo:
+		res.RepoID = null.IntFrom(channel.ParentID)
+	case enum.ParentResourceTypeSpace:
+		res.SpaceID = null.IntFrom(channel.ParentID)
+	default:
+		return nil, fmt.Errorf("notification channel parent type %q is not supported", channel.ParentType)
+	}
+
+	return res, nil
+}
+
+func notificationTriggersFromString(triggersString string) []enum.NotificationTrigger {
+	if triggersString == "" {
+		return []enum.NotificationTrigger{}
+	}
+
+	rawTriggers := strings.Split(triggersString, triggersSeparator)
+
+	triggers := make([]enum.NotificationTrigger, len(rawTriggers))
+	for i, rawTrigger := range rawTriggers {
+		// ASSUMPTION: trigger is valid value (as we wrote it to DB)
+		triggers[i] = enum.NotificationTrigger(rawTrigger)
+	}
+
+	return triggers
+}
+
+func notificationTriggersToString(triggers []enum.NotificationTrigger) string {
+	rawTriggers := make([]string, len(triggers))
+	for i := range triggers {
+		rawTriggers[i] = string(triggers[i])
+	}
+
+	return strings.Join(rawTriggers, triggersSeparator)
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationDigestStore = (*NotificationDigestStore)(nil)
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) *NotificationDigestStore {
+	return &NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigestItem struct {
+	ID          int64                    `db:"notification_digest_item_id"`
+	PrincipalID int64                    `db:"notification_digest_item_principal_id"`
+	RepoID      int64                    `db:"notification_digest_item_repo_id"`
+	EventID     string                   `db:"notification_digest_item_event_id"`
+	Trigger     enum.NotificationTrigger `db:"notification_digest_item_trigger"`
+	Title       string                   `db:"notification_digest_item_title"`
+	URL         string                   `db:"notification_digest_item_url"`
+	Created     int64                    `db:"notification_digest_item_created"`
+}
+
+const (
+	notificationDigestItemColumns = `
+		 notification_digest_item_id
+		,notification_digest_item_principal_id
+		,notification_digest_item_repo_id
+		,notification_digest_item_event_id
+		,notification_digest_item_trigger
+		,notification_digest_item_title
+		,notification_digest_item_url
+		,notification_digest_item_created`
+)
+
+// Create queues a new digest item. An item of an event already queued for the user is ignored.
+func (s *NotificationDigestStore) Create(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+	INSERT INTO notification_digest_items (
+		 notification_digest_item_principal_id
+		,notification_digest_item_repo_id
+		,notification_digest_item_event_id
+		,notification_digest_item_trigger
+		,notification_digest_item_title
+		,notification_digest_item_url
+		,notification_digest_item_created
+	) VALUES (
+		 :notification_digest_item_principal_id
+		,:notification_digest_item_repo_id
+		,:notification_digest_item_event_id
+		,:notification_digest_item_trigger
+		,:notification_digest_item_title
+		,:notification_digest_item_url
+		,:notification_digest_item_created
+	)
+	ON CONFLICT (
+		 notification_digest_item_principal_id
+		,notification_digest_item_event_id
+		,notification_digest_item_trigger
+	) WHERE notification_digest_item_event_id <> '' DO NOTHING
+	RETURNING notification_digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &notificationDigestItem{
+		PrincipalID: item.PrincipalID,
+		RepoID:      item.RepoID,
+		EventID:     item.EventID,
+		Trigger:     item.Trigger,
+		Title:       item.Title,
+		URL:         item.URL,
+		Created:     item.Created,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest item object")
+	}
+
+	err = db.QueryRowContext(ctx, query, arg...).Scan(&item.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the item of the event is already queued.
+		return nil
+	}
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// ListPrincipalIDs returns the IDs of all users with queued digest items.
+func (s *NotificationDigestStore) ListPrincipalIDs(ctx context.Context) ([]int64, error) {
+	stmt := database.Builder.
+		Select("DISTINCT notification_digest_item_principal_id").
+		From("notification_digest_items").
+		OrderBy("notification_digest_item_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var principalIDs []int64
+	if err = db.SelectContext(ctx, &principalIDs, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list principal IDs query")
+	}
+
+	return principalIDs, nil
+}
+
+// List lists the queued digest items of the user, oldest first.
+func (s *NotificationDigestStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationDigestItem, error) {
+	stmt := database.Builder.
+		Select(notificationDigestItemColumns).
+		From("notification_digest_items").
+		Where("notification_digest_item_principal_id = ?", principalID).
+		OrderBy("notification_digest_item_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationDigestItem
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list query")
+	}
+
+	result := make([]*types.NotificationDigestItem, len(dst))
+	for i, item := range dst {
+		result[i] = &types.NotificationDigestItem{
+			ID:          item.ID,
+			PrincipalID: item.PrincipalID,
+			RepoID:      item.RepoID,
+			EventID:     item.EventID,
+			Trigger:     item.Trigger,
+			Title:       item.Title,
+			URL:         item.URL,
+			Created:     item.Created,
+		}
+	}
+
+	return result, nil
+}
+
+// Delete deletes the queued digest items of the user up to and including the provided item ID.
+func (s *NotificationDigestStore) Delete(ctx context.Context, principalID int64, maxID int64) error {
+	stmt := database.Builder.
+		Delete("notification_digest_items").
+		Where("notification_digest_item_principal_id = ?", principalID).
+		Where("notification_digest_item_id <= ?", maxID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to execute delete query")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDigestStore_CreateIsIdempotent(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	digestStore := database.NewNotificationDigestStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	newItem := func(eventID string, trigger enum.NotificationTrigger) *types.NotificationDigestItem {
+		return &types.NotificationDigestItem{
+			PrincipalID: userID,
+			RepoID:      1,
+			EventID:     eventID,
+			Trigger:     trigger,
+			Title:       "title",
+			URL:         "http://localhost",
+			Created:     1,
+		}
+	}
+
+	require.NoError(t, digestStore.Create(ctx, newItem("event-1", enum.NotificationTriggerCommentMentions)))
+	require.NoError(t, digestStore.Create(ctx, newItem("event-1", enum.NotificationTriggerCommentMentions)))
+	// a single event notifies a user for multiple triggers.
+	require.NoError(t, digestStore.Create(ctx, newItem("event-1", enum.NotificationTriggerCommentPRAuthor)))
+	require.NoError(t, digestStore.Create(ctx, newItem("event-2", enum.NotificationTriggerCommentMentions)))
+	// items without an event aren't deduplicated.
+	require.NoError(t, digestStore.Create(ctx, newItem("", enum.NotificationTriggerCommentMentions)))
+	require.NoError(t, digestStore.Create(ctx, newItem("", enum.NotificationTriggerCommentMentions)))
+
+	items, err := digestStore.List(ctx, userID)
+	require.NoError(t, err)
+
+	eventIDs := make([]string, len(items))
+	for i, item := range items {
+		eventIDs[i] = item.EventID
+	}
+	assert.Equal(t, []string{"event-1", "event-1", "event-2", "", ""}, eventIDs)
+}
@@ -0,0 +1,278 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationPreferenceStore = (*NotificationPreferenceStore)(nil)
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+type notificationPreference struct {
+	PrincipalID int64                     `db:"notification_preference_principal_id"`
+	Trigger     enum.NotificationTrigger  `db:"notification_preference_trigger"`
+	Delivery    enum.NotificationDelivery `db:"notification_preference_delivery"`
+	Updated     int64                     `db:"notification_preference_updated"`
+}
+
+type repoNotificationPreference struct {
+	PrincipalID int64                     `db:"repo_notification_preference_principal_id"`
+	RepoID      int64                     `db:"repo_notification_preference_repo_id"`
+	Mode        enum.RepoNotificationMode `db:"repo_notification_preference_mode"`
+	Updated     int64                     `db:"repo_notification_preference_updated"`
+}
+
+const (
+	notificationPreferenceColumns = `
+		 notification_preference_principal_id
+		,notification_preference_trigger
+		,notification_preference_delivery
+		,notification_preference_updated`
+
+	repoNotificationPreferenceColumns = `
+		 repo_notification_preference_principal_id
+		,repo_notification_preference_repo_id
+		,repo_notification_preference_mode
+		,repo_notification_preference_updated`
+)
+
+// List lists the notification preferences of the user.
+func (s *NotificationPreferenceStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationPreference, error) {
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where("notification_preference_principal_id = ?", principalID).
+		OrderBy("notification_preference_trigger")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list query")
+	}
+
+	result := make([]*types.NotificationPreference, len(dst))
+	for i, p := range dst {
+		result[i] = &types.NotificationPreference{
+			PrincipalID: p.PrincipalID,
+			Trigger:     p.Trigger,
+			Delivery:    p.Delivery,
+			Updated:     p.Updated,
+		}
+	}
+
+	return result, nil
+}
+
+// Upsert creates or updates the user's delivery of a notification trigger.
+func (s *NotificationPreferenceStore) Upsert(ctx context.Context, preference *types.NotificationPreference) error {
+	const sqlQuery = `
+	INSERT INTO notification_preferences (` + notificationPreferenceColumns + `
+	) VALUES (
+		 :notification_preference_principal_id
+		,:notification_preference_trigger
+		,:notification_preference_delivery
+		,:notification_preference_updated
+	)
+	ON CONFLICT (notification_preference_principal_id, notification_preference_trigger) DO
+	UPDATE SET
+		 notification_preference_delivery = :notification_preference_delivery
+		,notification_preference_updated = :notification_preference_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &notificationPreference{
+		PrincipalID: preference.PrincipalID,
+		Trigger:     preference.Trigger,
+		Delivery:    preference.Delivery,
+		Updated:     preference.Updated,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification preference object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// MapDeliveries returns the deliveries of the notification trigger chosen by the provided users.
+func (s *NotificationPreferenceStore) MapDeliveries(
+	ctx context.Context,
+	trigger enum.NotificationTrigger,
+	principalIDs []int64,
+) (map[int64]enum.NotificationDelivery, error) {
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where("notification_preference_trigger = ?", trigger).
+		Where(squirrel.Eq{"notification_preference_principal_id": principalIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute map deliveries query")
+	}
+
+	result := make(map[int64]enum.NotificationDelivery, len(dst))
+	for _, p := range dst {
+		result[p.PrincipalID] = p.Delivery
+	}
+
+	return result, nil
+}
+
+// ListRepos lists the repositories the user explicitly watches or ignores.
+func (s *NotificationPreferenceStore) ListRepos(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.RepoNotificationPreference, error) {
+	stmt := database.Builder.
+		Select(repoNotificationPreferenceColumns).
+		From("repo_notification_preferences").
+		Where("repo_notification_preference_principal_id = ?", principalID).
+		OrderBy("repo_notification_preference_repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoNotificationPreference
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list repos query")
+	}
+
+	result := make([]*types.RepoNotificationPreference, len(dst))
+	for i, p := range dst {
+		result[i] = &types.RepoNotificationPreference{
+			PrincipalID: p.PrincipalID,
+			RepoID:      p.RepoID,
+			Mode:        p.Mode,
+			Updated:     p.Updated,
+		}
+	}
+
+	return result, nil
+}
+
+// UpsertRepo creates or updates the user's notification mode of a repository.
+func (s *NotificationPreferenceStore) UpsertRepo(
+	ctx context.Context,
+	preference *types.RepoNotificationPreference,
+) error {
+	const sqlQuery = `
+	INSERT INTO repo_notification_preferences (` + repoNotificationPreferenceColumns + `
+	) VALUES (
+		 :repo_notification_preference_principal_id
+		,:repo_notification_preference_repo_id
+		,:repo_notification_preference_mode
+		,:repo_notification_preference_updated
+	)
+	ON CONFLICT (repo_notification_preference_principal_id, repo_notification_preference_repo_id) DO
+	UPDATE SET
+		 repo_notification_preference_mode = :repo_notification_preference_mode
+		,repo_notification_preference_updated = :repo_notification_preference_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &repoNotificationPreference{
+		PrincipalID: preference.PrincipalID,
+		RepoID:      preference.RepoID,
+		Mode:        preference.Mode,
+		Updated:     preference.Updated,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo notification preference object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// MapRepoModes returns the notification modes of the repository chosen by the provided users.
+func (s *NotificationPreferenceStore) MapRepoModes(
+	ctx context.Context,
+	repoID int64,
+	principalIDs []int64,
+) (map[int64]enum.RepoNotificationMode, error) {
+	stmt := database.Builder.
+		Select(repoNotificationPreferenceColumns).
+		From("repo_notification_preferences").
+		Where("repo_notification_preference_repo_id = ?", repoID).
+		Where(squirrel.Eq{"repo_notification_preference_principal_id": principalIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoNotificationPreference
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute map repo modes query")
+	}
+
+	result := make(map[int64]enum.RepoNotificationMode, len(dst))
+	for _, p := range dst {
+		result[p.PrincipalID] = p.Mode
+	}
+
+	return result, nil
+}
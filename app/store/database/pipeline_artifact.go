@@ -0,0 +1,331 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineArtifactStore = (*pipelineArtifactStore)(nil)
+
+const (
+	pipelineArtifactColumns = `
+		 artifact_id
+		,artifact_repo_id
+		,artifact_pipeline_id
+		,artifact_execution_id
+		,artifact_kind
+		,artifact_key
+		,artifact_size
+		,artifact_created_by
+		,artifact_created
+		,artifact_updated
+		,artifact_expires`
+
+	pipelineArtifactSelectBase = `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts`
+)
+
+type pipelineArtifact struct {
+	ID          int64                     `db:"artifact_id"`
+	RepoID      int64                     `db:"artifact_repo_id"`
+	PipelineID  int64                     `db:"artifact_pipeline_id"`
+	ExecutionID null.Int                  `db:"artifact_execution_id"`
+	Kind        enum.PipelineArtifactKind `db:"artifact_kind"`
+	Key         string                    `db:"artifact_key"`
+	Size        int64                     `db:"artifact_size"`
+	CreatedBy   int64                     `db:"artifact_created_by"`
+	Created     int64                     `db:"artifact_created"`
+	Updated     int64                     `db:"artifact_updated"`
+	Expires     int64                     `db:"artifact_expires"`
+}
+
+// NewPipelineArtifactStore returns a new PipelineArtifactStore.
+func NewPipelineArtifactStore(db *sqlx.DB) store.PipelineArtifactStore {
+	return &pipelineArtifactStore{
+		db: db,
+	}
+}
+
+type pipelineArtifactStore struct {
+	db *sqlx.DB
+}
+
+// FindByKey returns an artifact given a pipeline ID, an execution ID and a key.
+// Caches are looked up with a zero execution ID.
+func (s *pipelineArtifactStore) FindByKey(
+	ctx context.Context,
+	pipelineID int64,
+	executionID int64,
+	key string,
+) (*types.PipelineArtifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("artifact_key = ?", key)
+
+	stmt = filterPipelineArtifactScope(stmt, pipelineID, executionID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(pipelineArtifact)
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline artifact")
+	}
+
+	return mapInternalToPipelineArtifact(dst), nil
+}
+
+// Create creates a new artifact.
+func (s *pipelineArtifactStore) Create(ctx context.Context, artifact *types.PipelineArtifact) error {
+	const artifactInsertStmt = `
+	INSERT INTO pipeline_artifacts (
+		 artifact_repo_id
+		,artifact_pipeline_id
+		,artifact_execution_id
+		,artifact_kind
+		,artifact_key
+		,artifact_size
+		,artifact_created_by
+		,artifact_created
+		,artifact_updated
+		,artifact_expires
+	) VALUES (
+		 :artifact_repo_id
+		,:artifact_pipeline_id
+		,:artifact_execution_id
+		,:artifact_kind
+		,:artifact_key
+		,:artifact_size
+		,:artifact_created_by
+		,:artifact_created
+		,:artifact_updated
+		,:artifact_expires
+	) RETURNING artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(artifactInsertStmt, mapPipelineArtifactToInternal(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Pipeline artifact query failed")
+	}
+
+	return nil
+}
+
+// Update updates the size and the expiry of an artifact.
+func (s *pipelineArtifactStore) Update(ctx context.Context, artifact *types.PipelineArtifact) error {
+	const artifactUpdateStmt = `
+	UPDATE pipeline_artifacts
+	SET
+		 artifact_size = :artifact_size
+		,artifact_updated = :artifact_updated
+		,artifact_expires = :artifact_expires
+	WHERE artifact_id = :artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(artifactUpdateStmt, mapPipelineArtifactToInternal(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline artifact object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update pipeline artifact")
+	}
+
+	return nil
+}
+
+// Count returns the number of artifacts of an execution (or caches of a pipeline) matching the filter.
+func (s *pipelineArtifactStore) Count(
+	ctx context.Context,
+	pipelineID int64,
+	executionID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("pipeline_artifacts")
+
+	stmt = filterPipelineArtifactScope(stmt, pipelineID, executionID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("artifact_key", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+	return count, nil
+}
+
+// List returns the artifacts of an execution (or caches of a pipeline) matching the filter.
+func (s *pipelineArtifactStore) List(
+	ctx context.Context,
+	pipelineID int64,
+	executionID int64,
+	filter types.ListQueryFilter,
+) ([]*types.PipelineArtifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		OrderBy("artifact_key")
+
+	stmt = filterPipelineArtifactScope(stmt, pipelineID, executionID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("artifact_key", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pipelineArtifact{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return mapInternalToPipelineArtifacts(dst), nil
+}
+
+// ListExpired returns up to limit artifacts that expired before the provided time.
+func (s *pipelineArtifactStore) ListExpired(
+	ctx context.Context,
+	now int64,
+	limit int,
+) ([]*types.PipelineArtifact, error) {
+	const listQueryStmt = pipelineArtifactSelectBase + `
+		WHERE artifact_expires <= $1
+		ORDER BY artifact_expires
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pipelineArtifact{}
+	if err := db.SelectContext(ctx, &dst, listQueryStmt, now, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list expired pipeline artifacts")
+	}
+
+	return mapInternalToPipelineArtifacts(dst), nil
+}
+
+// Delete deletes an artifact given an ID.
+func (s *pipelineArtifactStore) Delete(ctx context.Context, id int64) error {
+	const artifactDeleteStmt = `
+		DELETE FROM pipeline_artifacts
+		WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, artifactDeleteStmt, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pipeline artifact")
+	}
+	return nil
+}
+
+// filterPipelineArtifactScope limits the query to the artifacts of an execution,
+// or to the caches of the pipeline if the execution ID is zero.
+func filterPipelineArtifactScope(
+	stmt squirrel.SelectBuilder,
+	pipelineID int64,
+	executionID int64,
+) squirrel.SelectBuilder {
+	stmt = stmt.Where("artifact_pipeline_id = ?", pipelineID)
+	if executionID == 0 {
+		return stmt.Where("artifact_execution_id IS NULL")
+	}
+	return stmt.Where("artifact_execution_id = ?", executionID)
+}
+
+func mapPipelineArtifactToInternal(in *types.PipelineArtifact) *pipelineArtifact {
+	executionID := null.Int{}
+	if in.ExecutionID != 0 {
+		executionID = null.IntFrom(in.ExecutionID)
+	}
+	return &pipelineArtifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		PipelineID:  in.PipelineID,
+		ExecutionID: executionID,
+		Kind:        in.Kind,
+		Key:         in.Key,
+		Size:        in.Size,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Expires:     in.Expires,
+	}
+}
+
+func mapInternalToPipelineArtifact(in *pipelineArtifact) *types.PipelineArtifact {
+	return &types.PipelineArtifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		PipelineID:  in.PipelineID,
+		ExecutionID: in.ExecutionID.Int64,
+		Kind:        in.Kind,
+		Key:         in.Key,
+		Size:        in.Size,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		Expires:     in.Expires,
+	}
+}
+
+func mapInternalToPipelineArtifacts(in []*pipelineArtifact) []*types.PipelineArtifact {
+	artifacts := make([]*types.PipelineArtifact, len(in))
+	for i, a := range in {
+		artifacts[i] = mapInternalToPipelineArtifact(a)
+	}
+	return artifacts
+}
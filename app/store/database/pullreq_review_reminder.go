@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqReviewReminderStore = (*PullReqReviewReminderStore)(nil)
+
+// NewPullReqReviewReminderStore returns a new PullReqReviewReminderStore.
+func NewPullReqReviewReminderStore(db *sqlx.DB) *PullReqReviewReminderStore {
+	return &PullReqReviewReminderStore{
+		db: db,
+	}
+}
+
+// PullReqReviewReminderStore implements store.PullReqReviewReminderStore backed by a relational database.
+type PullReqReviewReminderStore struct {
+	db *sqlx.DB
+}
+
+type pullReqReviewReminder struct {
+	PullReqID   int64                          `db:"pullreq_review_reminder_pullreq_id"`
+	PrincipalID int64                          `db:"pullreq_review_reminder_principal_id"`
+	Type        enum.PullReqReviewReminderType `db:"pullreq_review_reminder_type"`
+	Count       int64                          `db:"pullreq_review_reminder_count"`
+	Created     int64                          `db:"pullreq_review_reminder_created"`
+	Updated     int64                          `db:"pullreq_review_reminder_updated"`
+}
+
+const (
+	pullReqReviewReminderColumns = `
+		 pullreq_review_reminder_pullreq_id
+		,pullreq_review_reminder_principal_id
+		,pullreq_review_reminder_type
+		,pullreq_review_reminder_count
+		,pullreq_review_reminder_created
+		,pullreq_review_reminder_updated`
+)
+
+// Upsert inserts a reminder entry or, if it already exists, increases its count and updates its time.
+func (s *PullReqReviewReminderStore) Upsert(ctx context.Context, reminder *types.PullReqReviewReminder) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_review_reminders (
+		 pullreq_review_reminder_pullreq_id
+		,pullreq_review_reminder_principal_id
+		,pullreq_review_reminder_type
+		,pullreq_review_reminder_count
+		,pullreq_review_reminder_created
+		,pullreq_review_reminder_updated
+	) VALUES (
+		 :pullreq_review_reminder_pullreq_id
+		,:pullreq_review_reminder_principal_id
+		,:pullreq_review_reminder_type
+		,1
+		,:pullreq_review_reminder_created
+		,:pullreq_review_reminder_updated
+	)
+	ON CONFLICT (
+		 pullreq_review_reminder_pullreq_id
+		,pullreq_review_reminder_principal_id
+		,pullreq_review_reminder_type
+	) DO
+	UPDATE SET
+		 pullreq_review_reminder_count = pullreq_review_reminders.pullreq_review_reminder_count + 1
+		,pullreq_review_reminder_updated = :pullreq_review_reminder_updated
+	RETURNING pullreq_review_reminder_count, pullreq_review_reminder_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqReviewReminder(reminder))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request review reminder object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&reminder.Count, &reminder.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// List lists all reminder entries of the specified PR.
+func (s *PullReqReviewReminderStore) List(ctx context.Context, prID int64) ([]*types.PullReqReviewReminder, error) {
+	stmt := database.Builder.
+		Select(pullReqReviewReminderColumns).
+		From("pullreq_review_reminders").
+		Where("pullreq_review_reminder_pullreq_id = ?", prID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqReviewReminder
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list query")
+	}
+
+	return mapToPullReqReviewReminders(dst), nil
+}
+
+func mapToInternalPullReqReviewReminder(reminder *types.PullReqReviewReminder) *pullReqReviewReminder {
+	return &pullReqReviewReminder{
+		PullReqID:   reminder.PullReqID,
+		PrincipalID: reminder.PrincipalID,
+		Type:        reminder.Type,
+		Count:       reminder.Count,
+		Created:     reminder.Created,
+		Updated:     reminder.Updated,
+	}
+}
+
+func mapToPullReqReviewReminder(reminder *pullReqReviewReminder) *types.PullReqReviewReminder {
+	return &types.PullReqReviewReminder{
+		PullReqID:   reminder.PullReqID,
+		PrincipalID: reminder.PrincipalID,
+		Type:        reminder.Type,
+		Count:       reminder.Count,
+		Created:     reminder.Created,
+		Updated:     reminder.Updated,
+	}
+}
+
+func mapToPullReqReviewReminders(reminders []*pullReqReviewReminder) []*types.PullReqReviewReminder {
+	m := make([]*types.PullReqReviewReminder, len(reminders))
+	for i, reminder := range reminders {
+		m[i] = mapToPullReqReviewReminder(reminder)
+	}
+	return m
+}
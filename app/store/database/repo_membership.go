@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoMembershipStore = (*repoMembershipStore)(nil)
+
+const (
+	repoMembershipColumns = `
+		 repo_membership_id
+		,repo_membership_repo_id
+		,repo_membership_principal_id
+		,repo_membership_usergroup_id
+		,repo_membership_role
+		,repo_membership_created_by
+		,repo_membership_created
+		,repo_membership_updated`
+
+	repoMembershipSelectBase = `
+		SELECT` + repoMembershipColumns + `
+		FROM repo_memberships`
+)
+
+type repoMembership struct {
+	ID          int64               `db:"repo_membership_id"`
+	RepoID      int64               `db:"repo_membership_repo_id"`
+	PrincipalID *int64              `db:"repo_membership_principal_id"`
+	UserGroupID *int64              `db:"repo_membership_usergroup_id"`
+	Role        enum.MembershipRole `db:"repo_membership_role"`
+	CreatedBy   int64               `db:"repo_membership_created_by"`
+	Created     int64               `db:"repo_membership_created"`
+	Updated     int64               `db:"repo_membership_updated"`
+}
+
+// NewRepoMembershipStore returns a new RepoMembershipStore.
+func NewRepoMembershipStore(db *sqlx.DB) store.RepoMembershipStore {
+	return &repoMembershipStore{
+		db: db,
+	}
+}
+
+type repoMembershipStore struct {
+	db *sqlx.DB
+}
+
+// Find returns a repository membership given its ID.
+func (s *repoMembershipStore) Find(ctx context.Context, id int64) (*types.RepoMembership, error) {
+	const findQueryStmt = repoMembershipSelectBase + `
+		WHERE repo_membership_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(repoMembership)
+	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo membership")
+	}
+	return mapInternalToRepoMembership(dst), nil
+}
+
+// Create creates a new repository membership.
+func (s *repoMembershipStore) Create(ctx context.Context, membership *types.RepoMembership) error {
+	const repoMembershipInsertStmt = `
+	INSERT INTO repo_memberships (
+		 repo_membership_repo_id
+		,repo_membership_principal_id
+		,repo_membership_usergroup_id
+		,repo_membership_role
+		,repo_membership_created_by
+		,repo_membership_created
+		,repo_membership_updated
+	) VALUES (
+		 :repo_membership_repo_id
+		,:repo_membership_principal_id
+		,:repo_membership_usergroup_id
+		,:repo_membership_role
+		,:repo_membership_created_by
+		,:repo_membership_created
+		,:repo_membership_updated
+	) RETURNING repo_membership_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(repoMembershipInsertStmt, mapRepoMembershipToInternal(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo membership object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&membership.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repo membership")
+	}
+
+	return nil
+}
+
+// Update updates the role of a repository membership.
+func (s *repoMembershipStore) Update(ctx context.Context, membership *types.RepoMembership) error {
+	const repoMembershipUpdateStmt = `
+	UPDATE repo_memberships
+	SET
+		 repo_membership_role = :repo_membership_role
+		,repo_membership_updated = :repo_membership_updated
+	WHERE repo_membership_id = :repo_membership_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(repoMembershipUpdateStmt, mapRepoMembershipToInternal(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repo membership")
+	}
+
+	return nil
+}
+
+// Delete deletes a repository membership given its ID.
+func (s *repoMembershipStore) Delete(ctx context.Context, id int64) error {
+	const repoMembershipDeleteStmt = `
+		DELETE FROM repo_memberships
+		WHERE repo_membership_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, repoMembershipDeleteStmt, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repo membership")
+	}
+	return nil
+}
+
+// List returns all memberships of a repository.
+func (s *repoMembershipStore) List(ctx context.Context, repoID int64) ([]*types.RepoMembership, error) {
+	const listQueryStmt = repoMembershipSelectBase + `
+		WHERE repo_membership_repo_id = $1
+		ORDER BY repo_membership_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repoMembership{}
+	if err := db.SelectContext(ctx, &dst, listQueryStmt, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing repo membership list query")
+	}
+
+	memberships := make([]*types.RepoMembership, len(dst))
+	for i, m := range dst {
+		memberships[i] = mapInternalToRepoMembership(m)
+	}
+	return memberships, nil
+}
+
+func mapRepoMembershipToInternal(in *types.RepoMembership) *repoMembership {
+	return &repoMembership{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		PrincipalID: in.PrincipalID,
+		UserGroupID: in.UserGroupID,
+		Role:        in.Role,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapInternalToRepoMembership(in *repoMembership) *types.RepoMembership {
+	return &types.RepoMembership{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		PrincipalID: in.PrincipalID,
+		UserGroupID: in.UserGroupID,
+		Role:        in.Role,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
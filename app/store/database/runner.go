@@ -0,0 +1,325 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.RunnerStore = (*runnerStore)(nil)
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_identifier
+		,runner_description
+		,runner_labels
+		,runner_platform
+		,runner_token_hash
+		,runner_last_seen
+		,runner_created_by
+		,runner_created
+		,runner_updated`
+
+	runnerSelectBase = `
+		SELECT` + runnerColumns + `
+		FROM runners`
+)
+
+type runner struct {
+	ID          int64              `db:"runner_id"`
+	Identifier  string             `db:"runner_identifier"`
+	Description string             `db:"runner_description"`
+	Labels      sqlxtypes.JSONText `db:"runner_labels"`
+	Platform    sqlxtypes.JSONText `db:"runner_platform"`
+	TokenHash   string             `db:"runner_token_hash"`
+	LastSeen    int64              `db:"runner_last_seen"`
+	CreatedBy   int64              `db:"runner_created_by"`
+	Created     int64              `db:"runner_created"`
+	Updated     int64              `db:"runner_updated"`
+}
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return &runnerStore{
+		db: db,
+	}
+}
+
+type runnerStore struct {
+	db *sqlx.DB
+}
+
+// Find returns a runner given an ID.
+func (s *runnerStore) Find(ctx context.Context, id int64) (*types.Runner, error) {
+	const findQueryStmt = runnerSelectBase + `
+		WHERE runner_id = $1`
+	return s.find(ctx, findQueryStmt, id)
+}
+
+// FindByIdentifier returns a runner given an identifier.
+func (s *runnerStore) FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error) {
+	const findQueryStmt = runnerSelectBase + `
+		WHERE runner_identifier = $1`
+	return s.find(ctx, findQueryStmt, identifier)
+}
+
+// FindByTokenHash returns the runner with the given registration token hash.
+func (s *runnerStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error) {
+	const findQueryStmt = runnerSelectBase + `
+		WHERE runner_token_hash = $1`
+	return s.find(ctx, findQueryStmt, tokenHash)
+}
+
+func (s *runnerStore) find(ctx context.Context, query string, arg any) (*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(runner)
+	if err := db.GetContext(ctx, dst, query, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner")
+	}
+	return mapInternalToRunner(dst)
+}
+
+// Create creates a new runner.
+func (s *runnerStore) Create(ctx context.Context, r *types.Runner) error {
+	const runnerInsertStmt = `
+	INSERT INTO runners (
+		 runner_identifier
+		,runner_description
+		,runner_labels
+		,runner_platform
+		,runner_token_hash
+		,runner_last_seen
+		,runner_created_by
+		,runner_created
+		,runner_updated
+	) VALUES (
+		 :runner_identifier
+		,:runner_description
+		,:runner_labels
+		,:runner_platform
+		,:runner_token_hash
+		,:runner_last_seen
+		,:runner_created_by
+		,:runner_created
+		,:runner_updated
+	) RETURNING runner_id`
+
+	dbRunner, err := mapRunnerToInternal(r)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(runnerInsertStmt, dbRunner)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&r.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Runner query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of runners matching the filter.
+func (s *runnerStore) Count(ctx context.Context, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("runners")
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("runner_identifier", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+	return count, nil
+}
+
+// List returns the runners matching the filter.
+func (s *runnerStore) List(ctx context.Context, filter types.ListQueryFilter) ([]*types.Runner, error) {
+	stmt := database.Builder.
+		Select(runnerColumns).
+		From("runners").
+		OrderBy("runner_identifier")
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("runner_identifier", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	return s.list(ctx, sql, args...)
+}
+
+// ListSeenBefore returns the runners that were seen at least once, but not since the provided time.
+func (s *runnerStore) ListSeenBefore(ctx context.Context, seenBefore int64) ([]*types.Runner, error) {
+	const listQueryStmt = runnerSelectBase + `
+		WHERE runner_last_seen > 0 AND runner_last_seen < $1`
+	return s.list(ctx, listQueryStmt, seenBefore)
+}
+
+func (s *runnerStore) list(ctx context.Context, query string, args ...any) ([]*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*runner{}
+	if err := db.SelectContext(ctx, &dst, query, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list runners")
+	}
+
+	runners := make([]*types.Runner, len(dst))
+	for i, r := range dst {
+		m, err := mapInternalToRunner(r)
+		if err != nil {
+			return nil, err
+		}
+		runners[i] = m
+	}
+	return runners, nil
+}
+
+// UpdateLastSeen records the last time the runner called the server.
+func (s *runnerStore) UpdateLastSeen(ctx context.Context, id int64, lastSeen int64) error {
+	const runnerUpdateStmt = `
+		UPDATE runners
+		SET runner_last_seen = $1
+		WHERE runner_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, runnerUpdateStmt, lastSeen, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner last seen")
+	}
+	return nil
+}
+
+// UpdatePlatform records the platform the runner requested stages for.
+func (s *runnerStore) UpdatePlatform(ctx context.Context, id int64, platform types.RunnerPlatform) error {
+	const runnerUpdateStmt = `
+		UPDATE runners
+		SET runner_platform = $1
+		WHERE runner_id = $2`
+
+	data, err := json.Marshal(platform)
+	if err != nil {
+		return fmt.Errorf("could not marshal runner platform: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, runnerUpdateStmt, sqlxtypes.JSONText(data), id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner platform")
+	}
+	return nil
+}
+
+// Delete deletes a runner given an ID.
+func (s *runnerStore) Delete(ctx context.Context, id int64) error {
+	const runnerDeleteStmt = `
+		DELETE FROM runners
+		WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, runnerDeleteStmt, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete runner")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+	return nil
+}
+
+func mapRunnerToInternal(in *types.Runner) (*runner, error) {
+	labels, err := json.Marshal(in.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal runner labels: %w", err)
+	}
+	platform, err := json.Marshal(in.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal runner platform: %w", err)
+	}
+	return &runner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Labels:      labels,
+		Platform:    platform,
+		TokenHash:   in.TokenHash,
+		LastSeen:    in.LastSeen,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}, nil
+}
+
+func mapInternalToRunner(in *runner) (*types.Runner, error) {
+	var labels map[string]string
+	if err := json.Unmarshal(in.Labels, &labels); err != nil {
+		return nil, fmt.Errorf("could not unmarshal runner labels: %w", err)
+	}
+	var platform types.RunnerPlatform
+	if err := json.Unmarshal(in.Platform, &platform); err != nil {
+		return nil, fmt.Errorf("could not unmarshal runner platform: %w", err)
+	}
+	return &types.Runner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Labels:      labels,
+		Platform:    platform,
+		TokenHash:   in.TokenHash,
+		LastSeen:    in.LastSeen,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}, nil
+}
@@ -205,3 +205,42 @@ func (s *SettingsStore) Upsert(ctx context.Context,
 
 	return nil
 }
+
+func (s *SettingsStore) ListScopeIDs(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	key string,
+) ([]int64, error) {
+	var column string
+	switch scope {
+	case enum.SettingsScopeSpace:
+		column = "setting_space_id"
+	case enum.SettingsScopeRepo:
+		column = "setting_repo_id"
+	case enum.SettingsScopeSystem:
+		return nil, fmt.Errorf("setting scope %q doesn't have scope IDs", scope)
+	default:
+		return nil, fmt.Errorf("setting scope %q is not supported", scope)
+	}
+
+	stmt := database.Builder.
+		Select(column).
+		From("settings").
+		Where("LOWER(setting_key) = ?", strings.ToLower(key)).
+		Where(column + " IS NOT NULL").
+		OrderBy(column)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []int64
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return dst, nil
+}
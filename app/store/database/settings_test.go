@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsStore_ListScopeIDs(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	settingsStore := database.NewSettingsStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	for id := int64(1); id <= 3; id++ {
+		createRepo(ctx, t, repoStore, id, 1, 0)
+	}
+
+	const key = "review_reminder_sla_in_hours"
+	value := json.RawMessage("24")
+
+	require.NoError(t, settingsStore.Upsert(ctx, enum.SettingsScopeRepo, 3, key, value))
+	require.NoError(t, settingsStore.Upsert(ctx, enum.SettingsScopeRepo, 1, key, value))
+	require.NoError(t, settingsStore.Upsert(ctx, enum.SettingsScopeRepo, 2, "file_size_limit", value))
+	require.NoError(t, settingsStore.Upsert(ctx, enum.SettingsScopeSpace, 1, key, value))
+
+	ids, err := settingsStore.ListScopeIDs(ctx, enum.SettingsScopeRepo, key)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 3}, ids)
+
+	ids, err = settingsStore.ListScopeIDs(ctx, enum.SettingsScopeSpace, key)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+
+	_, err = settingsStore.ListScopeIDs(ctx, enum.SettingsScopeSystem, key)
+	assert.Error(t, err)
+}
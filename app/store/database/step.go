@@ -81,6 +81,21 @@ type stepStore struct {
 	db *sqlx.DB
 }
 
+// Find returns a step given a step ID.
+func (s *stepStore) Find(ctx context.Context, id int64) (*types.Step, error) {
+	const findQueryStmt = `
+		SELECT` + stepColumns + `
+		FROM steps
+		WHERE step_id = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(step)
+	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find step")
+	}
+	return mapInternalToStep(dst)
+}
+
 // FindByNumber returns a step given a stage ID and a step number.
 func (s *stepStore) FindByNumber(ctx context.Context, stageID int64, stepNum int) (*types.Step, error) {
 	const findQueryStmt = `
@@ -198,3 +213,17 @@ func (s *stepStore) Update(ctx context.Context, e *types.Step) error {
 	e.Version = step.Version
 	return nil
 }
+
+// DeleteByStageID deletes all the steps of a stage.
+func (s *stepStore) DeleteByStageID(ctx context.Context, stageID int64) error {
+	const stepDeleteStmt = `
+		DELETE FROM steps
+		WHERE step_stage_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, stepDeleteStmt, stageID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete steps")
+	}
+	return nil
+}
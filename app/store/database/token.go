@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
 
 var _ store.TokenStore = (*TokenStore)(nil)
@@ -42,23 +44,35 @@ type TokenStore struct {
 	db *sqlx.DB
 }
 
+type token struct {
+	types.Token
+	Scope sqlxtypes.NullJSONText `db:"token_scope"`
+}
+
+// tokenScope is the stored form of the token scope, resources are referenced by their IDs.
+type tokenScope struct {
+	Permissions []enum.Permission `json:"permissions,omitempty"`
+	SpaceIDs    []int64           `json:"space_ids,omitempty"`
+	RepoIDs     []int64           `json:"repo_ids,omitempty"`
+}
+
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapInternalToToken(dst)
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,14 +83,19 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapInternalToToken(dst)
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
+	dbToken, err := mapTokenToInternal(token)
+	if err != nil {
+		return err
+	}
+
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	query, arg, err := db.BindNamed(tokenInsert, dbToken)
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
 	}
@@ -153,7 +172,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,7 +180,56 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	tokens := make([]*types.Token, len(dst))
+	for i, t := range dst {
+		tokens[i], err = mapInternalToToken(t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tokens, nil
+}
+
+func mapTokenToInternal(in *types.Token) (*token, error) {
+	out := &token{Token: *in}
+	if in.Scope == nil {
+		return out, nil
+	}
+
+	scope, err := json.Marshal(tokenScope{
+		Permissions: in.Scope.Permissions,
+		SpaceIDs:    in.Scope.SpaceIDs,
+		RepoIDs:     in.Scope.RepoIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token scope: %w", err)
+	}
+
+	out.Scope = sqlxtypes.NullJSONText{JSONText: scope, Valid: true}
+
+	return out, nil
+}
+
+func mapInternalToToken(in *token) (*types.Token, error) {
+	out := in.Token
+	if !in.Scope.Valid {
+		return &out, nil
+	}
+
+	var scope tokenScope
+	if err := json.Unmarshal(in.Scope.JSONText, &scope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token scope: %w", err)
+	}
+
+	out.Scope = &types.TokenScope{
+		Permissions: scope.Permissions,
+		SpaceIDs:    scope.SpaceIDs,
+		RepoIDs:     scope.RepoIDs,
+	}
+
+	return &out, nil
 }
 
 const tokenSelectBase = `
@@ -173,6 +241,7 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_scope
 FROM tokens
 ` //#nosec G101
 
@@ -208,6 +277,7 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_scope
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +285,6 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_scope
 ) RETURNING token_id
 `
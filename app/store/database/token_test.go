@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenStore_Scope(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	tokenStore := database.NewTokenStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	scoped := &types.Token{
+		Type:        enum.TokenTypePAT,
+		Identifier:  "ci",
+		PrincipalID: userID,
+		CreatedBy:   userID,
+		Scope: &types.TokenScope{
+			Permissions: []enum.Permission{enum.PermissionRepoView},
+			RepoIDs:     []int64{42},
+		},
+	}
+	require.NoError(t, tokenStore.Create(ctx, scoped))
+
+	unscoped := &types.Token{
+		Type:        enum.TokenTypePAT,
+		Identifier:  "full",
+		PrincipalID: userID,
+		CreatedBy:   userID,
+	}
+	require.NoError(t, tokenStore.Create(ctx, unscoped))
+
+	found, err := tokenStore.Find(ctx, scoped.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.Scope)
+	assert.Equal(t, []enum.Permission{enum.PermissionRepoView}, found.Scope.Permissions)
+	assert.Equal(t, []int64{42}, found.Scope.RepoIDs)
+	assert.Empty(t, found.Scope.SpaceIDs)
+
+	found, err = tokenStore.FindByIdentifier(ctx, userID, "full")
+	require.NoError(t, err)
+	assert.Nil(t, found.Scope)
+
+	tokens, err := tokenStore.List(ctx, userID, enum.TokenTypePAT)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+}
@@ -43,6 +43,9 @@ var WireSet = wire.NewSet(
 	ProvidePipelineStore,
 	ProvideStageStore,
 	ProvideStepStore,
+	ProvideRunnerStore,
+	ProvidePipelineArtifactStore,
+	ProvideApprovalStore,
 	ProvideSecretStore,
 	ProvideMembershipStore,
 	ProvideTokenStore,
@@ -52,11 +55,19 @@ var WireSet = wire.NewSet(
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
 	ProvidePullReqFileViewStore,
+	ProvidePullReqReviewReminderStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationDigestStore,
+	ProvideNotificationChannelStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
 	ProvideSettingsStore,
 	ProvidePublicAccessStore,
 	ProvideCheckStore,
+	ProvideEnvironmentStore,
+	ProvideCustomRoleStore,
+	ProvideRepoMembershipStore,
+	ProvideGitspacePrebuildStore,
 	ProvideConnectorStore,
 	ProvideTemplateStore,
 	ProvideTriggerStore,
@@ -200,11 +211,46 @@ func ProvideStepStore(db *sqlx.DB) store.StepStore {
 	return NewStepStore(db)
 }
 
+// ProvideApprovalStore provides an approval store.
+func ProvideApprovalStore(db *sqlx.DB) store.ApprovalStore {
+	return NewApprovalStore(db)
+}
+
+// ProvideRunnerStore provides a runner store.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvidePipelineArtifactStore provides a pipeline artifact store.
+func ProvidePipelineArtifactStore(db *sqlx.DB) store.PipelineArtifactStore {
+	return NewPipelineArtifactStore(db)
+}
+
 // ProvideSecretStore provides a secret store.
 func ProvideSecretStore(db *sqlx.DB) store.SecretStore {
 	return NewSecretStore(db)
 }
 
+// ProvideGitspacePrebuildStore provides a gitspace prebuild store.
+func ProvideGitspacePrebuildStore(db *sqlx.DB) store.GitspacePrebuildStore {
+	return NewGitspacePrebuildStore(db)
+}
+
+// ProvideEnvironmentStore provides an environment store.
+func ProvideEnvironmentStore(db *sqlx.DB) store.EnvironmentStore {
+	return NewEnvironmentStore(db)
+}
+
+// ProvideCustomRoleStore provides a custom role store.
+func ProvideCustomRoleStore(db *sqlx.DB) store.CustomRoleStore {
+	return NewCustomRoleStore(db)
+}
+
+// ProvideRepoMembershipStore provides a repository membership store.
+func ProvideRepoMembershipStore(db *sqlx.DB) store.RepoMembershipStore {
+	return NewRepoMembershipStore(db)
+}
+
 // ProvideConnectorStore provides a connector store.
 func ProvideConnectorStore(db *sqlx.DB, secretStore store.SecretStore) store.ConnectorStore {
 	return NewConnectorStore(db, secretStore)
@@ -278,6 +324,26 @@ func ProvidePullReqReviewerStore(
 	return NewPullReqReviewerStore(db, principalInfoCache)
 }
 
+// ProvideNotificationChannelStore provides a notification channel store.
+func ProvideNotificationChannelStore(db *sqlx.DB) store.NotificationChannelStore {
+	return NewNotificationChannelStore(db)
+}
+
+// ProvidePullReqReviewReminderStore provides a pull request review reminder store.
+func ProvidePullReqReviewReminderStore(db *sqlx.DB) store.PullReqReviewReminderStore {
+	return NewPullReqReviewReminderStore(db)
+}
+
+// ProvideNotificationPreferenceStore provides a notification preference store.
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+// ProvideNotificationDigestStore provides a notification digest store.
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
 // ProvidePullReqFileViewStore provides a pull request file view store.
 func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
@@ -48,6 +48,11 @@ type Provider interface {
 	// interact with Harness and clone a repo.
 	GenerateContainerGITCloneURL(ctx context.Context, repoPath string) string
 
+	// GenerateContainerAPIURL generates a URL that can be used by CI container builds to
+	// call the api of Harness, the provided path segments are appended to the api base path.
+	// NOTE: url is guaranteed to not have any trailing '/'.
+	GenerateContainerAPIURL(ctx context.Context, segments ...string) string
+
 	// GenerateGITCloneURL generates the public git clone URL for the provided repo path.
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneURL(ctx context.Context, repoPath string) string
@@ -191,6 +196,10 @@ func (p *provider) GenerateContainerGITCloneURL(_ context.Context, repoPath stri
 	return p.containerURL.JoinPath(GITMount, repoPath).String()
 }
 
+func (p *provider) GenerateContainerAPIURL(_ context.Context, segments ...string) string {
+	return p.containerURL.JoinPath(append([]string{APIMount, "v1"}, segments...)...).String()
+}
+
 func (p *provider) GenerateGITCloneURL(_ context.Context, repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
@@ -247,7 +256,8 @@ func (p *provider) RegistryURL(_ context.Context, params ...string) string {
 
 	segments := []string{u.Path}
 	if len(params) > 0 {
-		if len(params) > 1 && (params[1] == "generic" || params[1] == "maven") {
+		if len(params) > 1 && (params[1] == "generic" || params[1] == "maven" || params[1] == "npm" ||
+			params[1] == "python" || params[1] == "go") {
 			params[0], params[1] = params[1], params[0]
 		} else {
 			params[0] = strings.ToLower(params[0])
@@ -75,6 +75,7 @@ const (
 	ResourceTypeBranch                ResourceType = "branch"
 	ResourceTypePullRequest           ResourceType = "pull_request"
 	ResourceTypeRepositorySettings    ResourceType = "repository_settings"
+	ResourceTypeSpaceSettings         ResourceType = "space_settings"
 	ResourceTypeRegistry              ResourceType = "registry"
 	ResourceTypeRegistryUpstreamProxy ResourceType = "registry_upstream_proxy"
 	ResourceTypeRegistryArtifact      ResourceType = "registry_artifact"
@@ -87,6 +88,7 @@ func (a ResourceType) Validate() error {
 		ResourceTypeBranch,
 		ResourceTypePullRequest,
 		ResourceTypeRepositorySettings,
+		ResourceTypeSpaceSettings,
 		ResourceTypeRegistry,
 		ResourceTypeRegistryUpstreamProxy,
 		ResourceTypeRegistryArtifact:
@@ -20,14 +20,19 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
 	"github.com/harness/gitness/app/gitspace/orchestrator/ide"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/gitspaceevent"
+	"github.com/harness/gitness/app/services/gitspaceidle"
+	"github.com/harness/gitness/app/services/gitspaceprebuild"
+	"github.com/harness/gitness/app/services/gomodule"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/trigger"
@@ -37,6 +42,7 @@ import (
 	gittypes "github.com/harness/gitness/git/types"
 	"github.com/harness/gitness/infraprovider"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/store/database"
@@ -334,11 +340,22 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 	}
 }
 
+// ProvideNotificationChannelConfig loads the notification channel controller config from the main config.
+func ProvideNotificationChannelConfig(config *types.Config) notificationchannel.Config {
+	return notificationchannel.Config{
+		AllowLoopback:       config.Webhook.AllowLoopback,
+		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
+	}
+}
+
 func ProvideNotificationConfig(config *types.Config) notification.Config {
 	return notification.Config{
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.Notification.Concurrency,
 		MaxRetries:      config.Notification.MaxRetries,
+
+		ChatAllowLoopback:       config.Webhook.AllowLoopback,
+		ChatAllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 	}
 }
 
@@ -377,6 +394,16 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	}
 }
 
+// ProvideLogStreamConfig loads the livelog config from the main config.
+func ProvideLogStreamConfig(config *types.Config) livelog.Config {
+	return livelog.Config{
+		Provider:   config.LogStream.Provider,
+		Namespace:  config.LogStream.Namespace,
+		BufferSize: config.LogStream.BufferSize,
+		Expiry:     config.LogStream.Expiry,
+	}
+}
+
 // ProvideCleanupConfig loads the cleanup service config from the main config.
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
@@ -401,6 +428,17 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	}
 }
 
+// ProvideGoModuleConfig loads the go module publishing service config from the main config.
+func ProvideGoModuleConfig(config *types.Config) gomodule.Config {
+	return gomodule.Config{
+		Enabled:            config.Registry.Enable && config.Registry.GoModules.PublishTags,
+		RegistryIdentifier: config.Registry.GoModules.Registry,
+		EventReaderName:    config.InstanceID,
+		Concurrency:        config.Registry.GoModules.Concurrency,
+		MaxRetries:         config.Registry.GoModules.MaxRetries,
+	}
+}
+
 func ProvideJobsConfig(config *types.Config) job.Config {
 	return job.Config{
 		InstanceID:                  config.InstanceID,
@@ -470,6 +508,26 @@ func ProvideGitspaceInfraProvisionerConfig(config *types.Config) *infrastructure
 	}
 }
 
+// ProvideGitspaceIdleConfig loads the gitspace idle service config from the main config.
+func ProvideGitspaceIdleConfig(config *types.Config) gitspaceidle.Config {
+	return gitspaceidle.Config{
+		Enabled:     config.Gitspace.Enable,
+		IdleTimeout: time.Duration(config.Gitspace.IdleTimeoutInMins) * time.Minute,
+	}
+}
+
+// ProvideGitspacePrebuildConfig loads the gitspace prebuild service config from the main config.
+func ProvideGitspacePrebuildConfig(config *types.Config) gitspaceprebuild.Config {
+	return gitspaceprebuild.Config{
+		Enabled:         config.Gitspace.Enable && config.Gitspace.Prebuilds.Enable,
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Gitspace.Prebuilds.Concurrency,
+		MaxRetries:      config.Gitspace.Prebuilds.MaxRetries,
+		BuildTimeout:    config.Gitspace.Prebuilds.BuildTimeout,
+		Retention:       config.Gitspace.Prebuilds.Retention,
+	}
+}
+
 // ProvideGitspaceEventConfig loads the gitspace event service config from the main config.
 func ProvideGitspaceEventConfig(config *types.Config) *gitspaceevent.Config {
 	return &gitspaceevent.Config{
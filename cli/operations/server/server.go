@@ -105,6 +105,31 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.StageReclaimer.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register stage reclaimer")
+			return err
+		}
+
+		if err := system.services.Approval.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register approval service")
+			return err
+		}
+
+		if err := system.services.Notification.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register notification service")
+			return err
+		}
+
+		if err := system.services.GitspaceService.GitspaceIdle.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register gitspace idle service")
+			return err
+		}
+
+		if err := system.services.GitspaceService.GitspacePrebuild.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register gitspace prebuild service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -23,6 +23,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/drone/funcmap"
 	"github.com/gotidy/ptr"
@@ -33,12 +34,20 @@ const tokenTmpl = `
 principalID: {{ .Token.PrincipalID }}
 identifier:  {{ .Token.Identifier }}
 expiresAt:   {{ .Token.ExpiresAt }}
+{{- with .Token.Scope }}
+permissions: {{ .Permissions }}
+spaces:      {{ .Spaces }}
+repos:       {{ .Repos }}
+{{- end }}
 token:       {{ .AccessToken }}
 ` //#nosec G101
 
 type createPATCommand struct {
 	identifier  string
 	lifetimeInS int64
+	permissions []string
+	spaces      []string
+	repos       []string
 
 	json bool
 	tmpl string
@@ -53,9 +62,17 @@ func (c *createPATCommand) run(*kingpin.ParseContext) error {
 		lifeTime = ptr.Duration(time.Duration(int64(time.Second) * c.lifetimeInS))
 	}
 
+	permissions := make([]enum.Permission, len(c.permissions))
+	for i, permission := range c.permissions {
+		permissions[i] = enum.Permission(permission)
+	}
+
 	in := user.CreateTokenInput{
-		Identifier: c.identifier,
-		Lifetime:   lifeTime,
+		Identifier:  c.identifier,
+		Lifetime:    lifeTime,
+		Permissions: permissions,
+		Spaces:      c.spaces,
+		Repos:       c.repos,
 	}
 
 	tokenResp, err := provide.Client().UserCreatePAT(ctx, in)
@@ -87,6 +104,15 @@ func registerCreatePAT(app *kingpin.CmdClause) {
 	cmd.Arg("lifetime", "the lifetime of the token in seconds").
 		Int64Var(&c.lifetimeInS)
 
+	cmd.Flag("permission", "restrict the token to the permission, can be repeated").
+		StringsVar(&c.permissions)
+
+	cmd.Flag("space", "restrict the token to the space and its subspaces, can be repeated").
+		StringsVar(&c.spaces)
+
+	cmd.Flag("repo", "restrict the token to the repository, can be repeated").
+		StringsVar(&c.repos)
+
 	cmd.Flag("json", "json encode the output").
 		BoolVar(&c.json)
 
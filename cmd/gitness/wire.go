@@ -24,24 +24,29 @@ import (
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	"github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	gitspaceCtrl "github.com/harness/gitness/app/api/controller/gitspace"
+	gitspaceprebuildCtrl "github.com/harness/gitness/app/api/controller/gitspaceprebuild"
 	infraproviderCtrl "github.com/harness/gitness/app/api/controller/infraprovider"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/controller/spacesettings"
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/controller/template"
 	controllertrigger "github.com/harness/gitness/app/api/controller/trigger"
@@ -69,11 +74,13 @@ import (
 	"github.com/harness/gitness/app/gitspace/platformconnector"
 	"github.com/harness/gitness/app/gitspace/scm"
 	gitspacesecret "github.com/harness/gitness/app/gitspace/secret"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/reclaimer"
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
@@ -82,6 +89,7 @@ import (
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	aiagentservice "github.com/harness/gitness/app/services/aiagent"
+	approvalservice "github.com/harness/gitness/app/services/approval"
 	capabilitiesservice "github.com/harness/gitness/app/services/capabilities"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
@@ -89,6 +97,7 @@ import (
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/gitspaceservice"
+	"github.com/harness/gitness/app/services/gomodule"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
@@ -103,6 +112,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	reposervice "github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/rules"
@@ -168,6 +178,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		pullreq.WireSet,
 		controllerwebhook.WireSet,
 		controllerwebhook.ProvidePreprocessor,
+		notificationchannel.WireSet,
 		svclabel.WireSet,
 		serviceaccount.WireSet,
 		user.WireSet,
@@ -198,6 +209,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		events.WireSet,
 		cliserver.ProvideWebhookConfig,
 		cliserver.ProvideNotificationConfig,
+		cliserver.ProvideNotificationChannelConfig,
 		webhook.WireSet,
 		cliserver.ProvideTriggerConfig,
 		trigger.WireSet,
@@ -216,8 +228,12 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		protection.WireSet,
 		checkcontroller.WireSet,
 		execution.WireSet,
+		environment.WireSet,
+		gitspaceprebuildCtrl.WireSet,
+		spacesettings.WireSet,
 		pipeline.WireSet,
 		logs.WireSet,
+		cliserver.ProvideLogStreamConfig,
 		livelog.WireSet,
 		controllerlogs.WireSet,
 		secret.WireSet,
@@ -238,14 +254,21 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		importer.WireSet,
 		migrateservice.WireSet,
 		canceler.WireSet,
+		controllerrunner.WireSet,
+		reclaimer.WireSet,
+		artifact.WireSet,
+		approvalservice.WireSet,
 		exporter.WireSet,
 		metric.WireSet,
 		reposervice.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
+		pullreqtemplate.WireSet,
 		gitspaceevent.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
+		cliserver.ProvideGoModuleConfig,
+		gomodule.WireSet,
 		rules.WireSet,
 		controllerkeywordsearch.WireSet,
 		settings.WireSet,
@@ -264,6 +287,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideIDEVSCodeWebConfig,
 		cliserver.ProvideDockerConfig,
 		cliserver.ProvideGitspaceEventConfig,
+		cliserver.ProvideGitspaceIdleConfig,
+		cliserver.ProvideGitspacePrebuildConfig,
 		logutil.WireSet,
 		cliserver.ProvideGitspaceOrchestratorConfig,
 		ide.WireSet,
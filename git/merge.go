@@ -67,6 +67,11 @@ type MergeParams struct {
 	DeleteHeadBranch bool
 
 	Method enum.MergeMethod
+
+	// RebaseUpstreamSHA optionally limits the commits replayed by the rebase method to the ones
+	// that aren't reachable from it, as the upstream argument of `git rebase --onto` does.
+	// Used when the head branch was based on a branch that got squashed or rebased into the base branch.
+	RebaseUpstreamSHA sha.SHA
 }
 
 type RefUpdate struct {
@@ -202,6 +207,11 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, errors.InvalidArgument("head branch doesn't contain any new commits.")
 	}
 
+	rebaseFromSHA := mergeBaseCommitSHA
+	if mergeMethod == enum.MergeMethodRebase && !params.RebaseUpstreamSHA.IsEmpty() {
+		rebaseFromSHA = params.RebaseUpstreamSHA
+	}
+
 	// find short stat and number of commits
 
 	shortStat, err := s.git.DiffShortStat(
@@ -272,7 +282,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 				Author:       &author,
 				Committer:    &committer,
 				Message:      message,
-				MergeBaseSHA: mergeBaseCommitSHA,
+				MergeBaseSHA: rebaseFromSHA,
 				TargetSHA:    baseCommitSHA,
 				SourceSHA:    headCommitSHA,
 			})
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
 
 	"github.com/harness/gitness/types"
@@ -50,6 +51,28 @@ func (d *DockerClientFactory) NewDockerClient(
 	return dockerClient, nil
 }
 
+// CLIEnv returns the environment variables required by the docker CLI to reach the same docker host
+// as the clients created by the factory. Values not configured in gitness are inherited from the process env.
+func (d *DockerClientFactory) CLIEnv(infra types.Infrastructure) ([]string, error) {
+	if infra.ProviderType != enum.InfraProviderTypeDocker {
+		return nil, fmt.Errorf("infra provider type %s not supported", infra.ProviderType)
+	}
+	env := os.Environ()
+	if d.config.DockerHost != "" {
+		env = append(env, "DOCKER_HOST="+d.config.DockerHost)
+	}
+	if d.config.DockerAPIVersion != "" {
+		env = append(env, "DOCKER_API_VERSION="+d.config.DockerAPIVersion)
+	}
+	if d.config.DockerCertPath != "" {
+		env = append(env, "DOCKER_CERT_PATH="+d.config.DockerCertPath)
+	}
+	if d.config.DockerTLSVerify != "" {
+		env = append(env, "DOCKER_TLS_VERIFY="+d.config.DockerTLSVerify)
+	}
+	return env, nil
+}
+
 func (d *DockerClientFactory) getClient(_ []types.InfraProviderParameter) (*client.Client, error) {
 	overrides, err := d.dockerOpts(d.config)
 	if err != nil {
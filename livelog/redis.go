@@ -0,0 +1,353 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	redisDefaultExpiry = 24 * time.Hour
+
+	// redisClosed is published on the channel of a stream once it's deleted.
+	// Every other message published on the channel is a json encoded line.
+	redisClosed = ""
+)
+
+// redisCreateScript resets the stream. Subscribers of a previous stream with
+// the same ID are notified, as the line numbers start over.
+// KEYS: open, lines. ARGV: channel, expiry (ms).
+var redisCreateScript = redis.NewScript(`
+if redis.call('DEL', KEYS[1], KEYS[2]) > 0 then
+	redis.call('PUBLISH', ARGV[1], '')
+end
+redis.call('SET', KEYS[1], '1', 'PX', ARGV[2])
+return 1
+`)
+
+// redisWriteScript appends the line to the capped history and publishes it,
+// only if the stream exists.
+// KEYS: open, lines. ARGV: channel, line, buffer size, expiry (ms).
+var redisWriteScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('RPUSH', KEYS[2], ARGV[2])
+redis.call('LTRIM', KEYS[2], -tonumber(ARGV[3]), -1)
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+redis.call('PEXPIRE', KEYS[2], ARGV[4])
+redis.call('PUBLISH', ARGV[1], ARGV[2])
+return 1
+`)
+
+// redisDeleteScript removes the stream and notifies all subscribers.
+// KEYS: open, lines. ARGV: channel.
+var redisDeleteScript = redis.NewScript(`
+if redis.call('DEL', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('DEL', KEYS[2])
+redis.call('PUBLISH', ARGV[1], '')
+return 1
+`)
+
+// redisStreamer is a LogStream that shares the streams between all instances
+// of the server using redis. The history of a stream is kept in a capped redis list,
+// the new lines are published on a redis channel. Each instance subscribes once
+// per tailed stream and fans the lines out to its local subscribers.
+type redisStreamer struct {
+	sync.Mutex
+
+	client     redis.UniversalClient
+	namespace  string
+	bufferSize int
+	expiry     time.Duration
+
+	hubs map[int64]*redisHub
+}
+
+// redisHub distributes the lines received from redis to the local subscribers of a stream.
+type redisHub struct {
+	stream *stream
+	pubsub *redis.PubSub
+	refs   int
+	closed bool
+
+	// last is the number of the last line sent to the subscribers,
+	// used to skip the lines both loaded from the history and received from the channel.
+	last int
+}
+
+// NewRedis returns a new redis log streamer.
+func NewRedis(client redis.UniversalClient, config Config) LogStream {
+	if config.BufferSize <= 0 {
+		config.BufferSize = bufferSize
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = redisDefaultExpiry
+	}
+
+	return &redisStreamer{
+		client:     client,
+		namespace:  config.Namespace,
+		bufferSize: config.BufferSize,
+		expiry:     config.Expiry,
+		hubs:       make(map[int64]*redisHub),
+	}
+}
+
+func (r *redisStreamer) Create(ctx context.Context, id int64) error {
+	open, lines, channel := r.keys(id)
+
+	err := redisCreateScript.Run(ctx, r.client, []string{open, lines},
+		channel, r.expiry.Milliseconds()).Err()
+	if err != nil {
+		return fmt.Errorf("failed to create log stream %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *redisStreamer) Delete(ctx context.Context, id int64) error {
+	open, lines, channel := r.keys(id)
+
+	deleted, err := redisDeleteScript.Run(ctx, r.client, []string{open, lines}, channel).Int()
+	if err != nil {
+		return fmt.Errorf("failed to delete log stream %d: %w", id, err)
+	}
+	if deleted == 0 {
+		return ErrStreamNotFound
+	}
+
+	return nil
+}
+
+func (r *redisStreamer) Write(ctx context.Context, id int64, line *Line) error {
+	open, lines, channel := r.keys(id)
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log line: %w", err)
+	}
+
+	written, err := redisWriteScript.Run(ctx, r.client, []string{open, lines},
+		channel, data, r.bufferSize, r.expiry.Milliseconds()).Int()
+	if err != nil {
+		return fmt.Errorf("failed to write to log stream %d: %w", id, err)
+	}
+	if written == 0 {
+		return ErrStreamNotFound
+	}
+
+	return nil
+}
+
+func (r *redisStreamer) Tail(ctx context.Context, id int64) (<-chan *Line, <-chan error) {
+	r.Lock()
+	hub, ok := r.hubs[id]
+	if !ok {
+		var err error
+		hub, err = r.newHub(ctx, id)
+		if err != nil {
+			r.Unlock()
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to tail log stream %d", id)
+			return nil, nil
+		}
+		if hub == nil {
+			r.Unlock()
+			return nil, nil
+		}
+
+		r.hubs[id] = hub
+		go r.dispatch(id, hub)
+	}
+	hub.refs++
+	// subscribe while holding the lock, so the hub can't be shut down in between.
+	linec, errc := hub.stream.subscribe(ctx)
+	r.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.release(id, hub)
+	}()
+
+	return linec, errc
+}
+
+func (r *redisStreamer) Info(ctx context.Context) *LogStreamInfo {
+	info := &LogStreamInfo{
+		Streams: map[int64]int{},
+	}
+
+	pattern := r.namespace + ":livelog:{*}:open"
+	iter := r.client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		key := strings.TrimPrefix(iter.Val(), r.namespace+":livelog:{")
+		id, err := strconv.ParseInt(strings.TrimSuffix(key, "}:open"), 10, 64)
+		if err != nil {
+			continue
+		}
+		info.Streams[id] = 0
+	}
+	if err := iter.Err(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list log streams")
+	}
+
+	// the subscriber count only includes the subscribers of this instance.
+	r.Lock()
+	defer r.Unlock()
+	for id, hub := range r.hubs {
+		hub.stream.Lock()
+		info.Streams[id] = len(hub.stream.list)
+		hub.stream.Unlock()
+	}
+
+	return info
+}
+
+// newHub subscribes to the channel of the stream and loads its history.
+// It returns nil if the stream doesn't exist.
+func (r *redisStreamer) newHub(ctx context.Context, id int64) (*redisHub, error) {
+	open, lines, channel := r.keys(id)
+
+	// the subscription has to be confirmed before the history is loaded, to not miss any lines.
+	pubsub := r.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	var exists *redis.IntCmd
+	var history *redis.StringSliceCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		exists = pipe.Exists(ctx, open)
+		history = pipe.LRange(ctx, lines, 0, -1)
+		return nil
+	})
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to load history: %w", err)
+	}
+
+	if exists.Val() == 0 {
+		_ = pubsub.Close()
+		return nil, nil
+	}
+
+	hub := &redisHub{
+		stream: newStream(),
+		pubsub: pubsub,
+		last:   -1,
+	}
+	for _, data := range history.Val() {
+		line := new(Line)
+		if err := json.Unmarshal([]byte(data), line); err != nil {
+			continue
+		}
+		hub.stream.hist = append(hub.stream.hist, line)
+		hub.last = line.Number
+	}
+
+	return hub, nil
+}
+
+// dispatch forwards the messages received from redis to the local subscribers,
+// until the stream is deleted or the hub released.
+func (r *redisStreamer) dispatch(id int64, hub *redisHub) {
+	for msg := range hub.pubsub.Channel() {
+		if msg.Payload == redisClosed {
+			r.close(id, hub)
+			return
+		}
+
+		line := new(Line)
+		if err := json.Unmarshal([]byte(msg.Payload), line); err != nil {
+			log.Warn().Err(err).Msgf("failed to unmarshal line of log stream %d", id)
+			continue
+		}
+		if line.Number <= hub.last {
+			continue
+		}
+		hub.last = line.Number
+
+		_ = hub.stream.write(line)
+	}
+}
+
+// release unsubscribes from redis once the last local subscriber of the stream is gone.
+func (r *redisStreamer) release(id int64, hub *redisHub) {
+	if r.unref(id, hub) {
+		hub.shutdown()
+	}
+}
+
+// unref removes a local subscriber from the hub and detaches the hub in the same critical section
+// once the last subscriber is gone, so a concurrent Tail can't join a hub that is about to be shut down.
+// It returns true if the hub was detached and has to be shut down.
+func (r *redisStreamer) unref(id int64, hub *redisHub) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	hub.refs--
+	return hub.refs <= 0 && r.detach(id, hub)
+}
+
+// close closes all local subscribers of a deleted stream.
+func (r *redisStreamer) close(id int64, hub *redisHub) {
+	r.Lock()
+	detached := r.detach(id, hub)
+	r.Unlock()
+
+	if detached {
+		hub.shutdown()
+	}
+}
+
+// detach marks the hub as closed and removes it, so no new subscriber can join it.
+// It returns false if the hub was closed already. The caller must hold the lock.
+func (r *redisStreamer) detach(id int64, hub *redisHub) bool {
+	if hub.closed {
+		return false
+	}
+
+	hub.closed = true
+	if r.hubs[id] == hub {
+		delete(r.hubs, id)
+	}
+
+	return true
+}
+
+// shutdown unsubscribes from redis and closes the local subscribers of a detached hub.
+func (h *redisHub) shutdown() {
+	_ = h.pubsub.Close()
+	_ = h.stream.close()
+}
+
+// keys returns the redis keys and channel of a stream. The ID is used as hash tag
+// to keep all keys of a stream in the same slot of a redis cluster.
+func (r *redisStreamer) keys(id int64) (open, lines, channel string) {
+	channel = r.namespace + ":livelog:{" + strconv.FormatInt(id, 10) + "}"
+	return channel + ":open", channel + ":lines", channel
+}
@@ -0,0 +1,339 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTimeout = 5 * time.Second
+
+func newTestRedis(t *testing.T) (*redisStreamer, redis.UniversalClient) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	streamer, ok := NewRedis(client, Config{Namespace: "test", BufferSize: 3}).(*redisStreamer)
+	require.True(t, ok)
+
+	return streamer, client
+}
+
+func receive(t *testing.T, linec <-chan *Line) *Line {
+	t.Helper()
+
+	select {
+	case line, ok := <-linec:
+		require.True(t, ok, "the subscriber channel was closed")
+		return line
+	case <-time.After(testTimeout):
+		require.FailNow(t, "no line received")
+		return nil
+	}
+}
+
+func requireClosed(t *testing.T, linec <-chan *Line) {
+	t.Helper()
+
+	timeout := time.After(testTimeout)
+	for {
+		select {
+		case _, ok := <-linec:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			require.FailNow(t, "the subscriber channel wasn't closed")
+		}
+	}
+}
+
+func hubCount(r *redisStreamer) int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.hubs)
+}
+
+func TestRedis_WriteAndTail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, _ := newTestRedis(t)
+
+	require.NoError(t, r.Create(ctx, 1))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, r.Write(ctx, 1, &Line{Number: i, Message: "line"}))
+	}
+
+	linec, errc := r.Tail(ctx, 1)
+	require.NotNil(t, linec)
+	require.NotNil(t, errc)
+
+	// only the capped history is replayed.
+	assert.Equal(t, 2, receive(t, linec).Number)
+	assert.Equal(t, 3, receive(t, linec).Number)
+	assert.Equal(t, 4, receive(t, linec).Number)
+
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 5, Message: "live"}))
+	line := receive(t, linec)
+	assert.Equal(t, 5, line.Number)
+	assert.Equal(t, "live", line.Message)
+}
+
+func TestRedis_TailSkipsReplayedLines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, client := newTestRedis(t)
+	_, _, channel := r.keys(1)
+
+	require.NoError(t, r.Create(ctx, 1))
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 0}))
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 1}))
+
+	linec, _ := r.Tail(ctx, 1)
+	assert.Equal(t, 0, receive(t, linec).Number)
+	assert.Equal(t, 1, receive(t, linec).Number)
+
+	// a line published while the history was loaded is received twice, once in the history.
+	data, err := json.Marshal(&Line{Number: 1})
+	require.NoError(t, err)
+	require.NoError(t, client.Publish(ctx, channel, data).Err())
+
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 2}))
+	assert.Equal(t, 2, receive(t, linec).Number)
+}
+
+func TestRedis_TailShareHub(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRedis(t)
+
+	require.NoError(t, r.Create(ctx, 1))
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(ctx)
+	defer cancel2()
+
+	linec1, _ := r.Tail(ctx1, 1)
+	linec2, _ := r.Tail(ctx2, 1)
+	assert.Equal(t, 1, hubCount(r))
+
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 0}))
+	assert.Equal(t, 0, receive(t, linec1).Number)
+	assert.Equal(t, 0, receive(t, linec2).Number)
+
+	cancel1()
+	requireClosed(t, linec1)
+
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 1}))
+	assert.Equal(t, 1, receive(t, linec2).Number)
+
+	// the hub is released with its last subscriber.
+	cancel2()
+	requireClosed(t, linec2)
+	require.Eventually(t, func() bool { return hubCount(r) == 0 }, testTimeout, 10*time.Millisecond)
+}
+
+// tailLive subscribes to the stream and checks the subscriber receives the next written line.
+func tailLive(ctx context.Context, t *testing.T, r *redisStreamer, number int) <-chan *Line {
+	t.Helper()
+
+	linec, _ := r.Tail(ctx, 1)
+	require.NotNil(t, linec)
+
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: number}))
+	// lines replayed from the history of a new hub come first.
+	line := receive(t, linec)
+	for line.Number != number {
+		line = receive(t, linec)
+	}
+
+	return linec
+}
+
+func TestRedis_TailDuringRelease(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRedis(t)
+
+	require.NoError(t, r.Create(ctx, 1))
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	defer cancel1()
+	_, _ = r.Tail(ctx1, 1)
+
+	r.Lock()
+	hub := r.hubs[1]
+	hub.refs++ // keeps the hub referenced when the first subscriber is canceled.
+	r.Unlock()
+
+	// the last subscriber is gone, but the hub isn't shut down yet.
+	cancel1()
+	require.Eventually(t, func() bool {
+		r.Lock()
+		defer r.Unlock()
+		return hub.refs == 1
+	}, testTimeout, 10*time.Millisecond)
+	require.True(t, r.unref(1, hub))
+	assert.Zero(t, hubCount(r), "the hub is detached together with its last subscriber")
+
+	ctx2, cancel2 := context.WithCancel(ctx)
+	defer cancel2()
+	linec2 := tailLive(ctx2, t, r, 0)
+
+	hub.shutdown()
+
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 1}))
+	assert.Equal(t, 1, receive(t, linec2).Number, "a new subscriber doesn't join a released hub")
+}
+
+func TestRedis_TailConcurrentWithRelease(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRedis(t)
+
+	require.NoError(t, r.Create(ctx, 1))
+
+	for i := range 100 {
+		ctx1, cancel1 := context.WithCancel(ctx)
+		_, _ = r.Tail(ctx1, 1)
+
+		ctx2, cancel2 := context.WithCancel(ctx)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cancel1()
+		}()
+		linec2 := tailLive(ctx2, t, r, i)
+		wg.Wait()
+
+		cancel2()
+		requireClosed(t, linec2)
+	}
+
+	require.Eventually(t, func() bool { return hubCount(r) == 0 }, testTimeout, 10*time.Millisecond)
+}
+
+func TestRedis_TailMissingStream(t *testing.T) {
+	r, _ := newTestRedis(t)
+
+	linec, errc := r.Tail(context.Background(), 1)
+	assert.Nil(t, linec)
+	assert.Nil(t, errc)
+	assert.Zero(t, hubCount(r))
+}
+
+func TestRedis_Delete(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRedis(t)
+
+	require.ErrorIs(t, r.Delete(ctx, 1), ErrStreamNotFound)
+	require.ErrorIs(t, r.Write(ctx, 1, &Line{}), ErrStreamNotFound)
+
+	require.NoError(t, r.Create(ctx, 1))
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 0}))
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	linec, _ := r.Tail(subCtx, 1)
+	assert.Equal(t, 0, receive(t, linec).Number)
+
+	require.NoError(t, r.Delete(ctx, 1))
+	requireClosed(t, linec)
+	require.Eventually(t, func() bool { return hubCount(r) == 0 }, testTimeout, 10*time.Millisecond)
+
+	// the subscriber leaving after the deletion doesn't shut the hub down again.
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	require.ErrorIs(t, r.Delete(ctx, 1), ErrStreamNotFound)
+	require.ErrorIs(t, r.Write(ctx, 1, &Line{}), ErrStreamNotFound)
+	assert.Empty(t, r.Info(ctx).Streams)
+}
+
+func TestRedis_CreateResetsStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, _ := newTestRedis(t)
+
+	require.NoError(t, r.Create(ctx, 1))
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 0}))
+
+	linec, _ := r.Tail(ctx, 1)
+	assert.Equal(t, 0, receive(t, linec).Number)
+
+	// the subscribers of the previous stream are closed, as the line numbers start over.
+	require.NoError(t, r.Create(ctx, 1))
+	requireClosed(t, linec)
+
+	require.NoError(t, r.Write(ctx, 1, &Line{Number: 0, Message: "again"}))
+
+	linec, _ = r.Tail(ctx, 1)
+	line := receive(t, linec)
+	assert.Equal(t, 0, line.Number)
+	assert.Equal(t, "again", line.Message)
+}
+
+func TestRedis_Info(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, _ := newTestRedis(t)
+
+	require.NoError(t, r.Create(ctx, 1))
+	require.NoError(t, r.Create(ctx, 2))
+
+	_, _ = r.Tail(ctx, 2)
+	_, _ = r.Tail(ctx, 2)
+
+	assert.Equal(t, map[int64]int{1: 0, 2: 2}, r.Info(ctx).Streams)
+}
+
+func TestRedis_ShutdownIsIdempotent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, _ := newTestRedis(t)
+
+	require.NoError(t, r.Create(ctx, 1))
+	linec, _ := r.Tail(ctx, 1)
+
+	r.Lock()
+	hub := r.hubs[1]
+	r.Unlock()
+	require.NotNil(t, hub)
+
+	assert.NotPanics(t, func() {
+		r.close(1, hub)
+		r.close(1, hub)
+		r.release(1, hub)
+	})
+	requireClosed(t, linec)
+	assert.Zero(t, hubCount(r))
+}
@@ -42,9 +42,7 @@ func (s *stream) write(line *Line) error {
 	s.Lock()
 	s.hist = append(s.hist, line)
 	for l := range s.list {
-		if !l.closed {
-			l.publish(line)
-		}
+		l.publish(line)
 	}
 	// the history should not be unbounded. The history
 	// slice is capped and items are removed in a FIFO
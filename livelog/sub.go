@@ -39,6 +39,10 @@ func (s *subscriber) publish(line *Line) {
 	s.Lock()
 	defer s.Unlock()
 
+	if s.closed {
+		return
+	}
+
 	select {
 	case <-s.closec:
 	case s.handler <- line:
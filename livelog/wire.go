@@ -15,6 +15,7 @@
 package livelog
 
 import (
+	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
 )
 
@@ -24,6 +25,13 @@ var WireSet = wire.NewSet(
 )
 
 // ProvideLogStream provides an implementation of a logs streamer.
-func ProvideLogStream() LogStream {
-	return NewMemory()
+func ProvideLogStream(config Config, client redis.UniversalClient) LogStream {
+	switch config.Provider {
+	case ProviderRedis:
+		return NewRedis(client, config)
+	case ProviderMemory:
+		fallthrough
+	default:
+		return NewMemory()
+	}
 }
@@ -16,6 +16,7 @@ package metadata
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -23,6 +24,9 @@ import (
 
 	"github.com/harness/gitness/app/url"
 	artifactapi "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/docker"
+	"github.com/harness/gitness/registry/app/pkg/helm"
+	npmutils "github.com/harness/gitness/registry/app/pkg/npm/utils"
 	"github.com/harness/gitness/registry/app/store/database"
 	"github.com/harness/gitness/registry/types"
 
@@ -119,6 +123,12 @@ func toPackageType(packageTypeStr string) (artifactapi.PackageType, error) {
 		return artifactapi.PackageTypeHELM, nil
 	case string(artifactapi.PackageTypeMAVEN):
 		return artifactapi.PackageTypeMAVEN, nil
+	case string(artifactapi.PackageTypeNPM):
+		return artifactapi.PackageTypeNPM, nil
+	case string(artifactapi.PackageTypePYTHON):
+		return artifactapi.PackageTypePYTHON, nil
+	case string(artifactapi.PackageTypeGO):
+		return artifactapi.PackageTypeGO, nil
 	default:
 		return "", errors.New("invalid package type")
 	}
@@ -235,6 +245,12 @@ func GetArtifactFilesMetadata(metadata *[]types.FileNodeMetadata,
 			filePathPrefix = "/" + artifactName + "/" + version + "/"
 			filename = strings.Replace(file.Path, filePathPrefix, "", 1)
 			downloadCommand = GetMavenArtifactFileDownloadCommand(registryURL, artifactName, version, filename)
+		} else if artifactapi.PackageTypeNPM == packageType {
+			downloadCommand = GetNpmArtifactFileDownloadCommand(registryURL, artifactName, filename)
+		} else if artifactapi.PackageTypePYTHON == packageType {
+			downloadCommand = GetPythonArtifactFileDownloadCommand(registryURL, artifactName, version, filename)
+		} else if artifactapi.PackageTypeGO == packageType {
+			downloadCommand = GetGoArtifactFileDownloadCommand(registryURL, artifactName, filename)
 		}
 		files = append(files, artifactapi.FileDetail{
 			Checksums:       getCheckSums(file),
@@ -454,6 +470,7 @@ func GetHelmArtifactDetails(
 		Size:            &size,
 		DownloadsCount:  &downloadCount,
 	}
+	setHelmChartMetadata(artifactDetail, manifest)
 
 	response := &artifactapi.HelmArtifactDetailResponseJSONResponse{
 		Data:   *artifactDetail,
@@ -462,6 +479,32 @@ func GetHelmArtifactDetails(
 	return response
 }
 
+// setHelmChartMetadata fills the details which come from the Chart.yaml of a
+// chart, helm stores it as the configuration of the manifest.
+func setHelmChartMetadata(artifactDetail *artifactapi.HelmArtifactDetail, manifest *types.Manifest) {
+	if manifest.Configuration == nil || manifest.Configuration.MediaType != docker.HelmConfigMediaType ||
+		len(manifest.Configuration.Payload) == 0 {
+		return
+	}
+	var chart helm.Metadata
+	if err := json.Unmarshal(manifest.Configuration.Payload, &chart); err != nil {
+		log.Warn().Err(err).Msgf("failed to parse helm chart configuration of manifest %s", manifest.Digest)
+		return
+	}
+	if chart.Description != "" {
+		artifactDetail.Description = &chart.Description
+	}
+	if chart.AppVersion != "" {
+		artifactDetail.AppVersion = &chart.AppVersion
+	}
+	if chart.Home != "" {
+		artifactDetail.Home = &chart.Home
+	}
+	if len(chart.Keywords) > 0 {
+		artifactDetail.Keywords = &chart.Keywords
+	}
+}
+
 func GetGenericArtifactDetail(image *types.Image, artifact *types.Artifact,
 	metadata database.GenericMetadata) artifactapi.ArtifactDetail {
 	createdAt := GetTimeInMs(artifact.CreatedAt)
@@ -481,6 +524,105 @@ func GetGenericArtifactDetail(image *types.Image, artifact *types.Artifact,
 	return *artifactDetail
 }
 
+func GetNpmArtifactDetail(image *types.Image, artifact *types.Artifact,
+	metadata database.NpmMetadata, registryURL string) artifactapi.ArtifactDetail {
+	createdAt := GetTimeInMs(artifact.CreatedAt)
+	modifiedAt := GetTimeInMs(artifact.UpdatedAt)
+	var size int64
+	for _, file := range metadata.Files {
+		size += file.Size
+	}
+	sizeVal := GetSize(size)
+	artifactDetail := &artifactapi.ArtifactDetail{
+		CreatedAt:  &createdAt,
+		ModifiedAt: &modifiedAt,
+		Name:       &image.Name,
+		Version:    artifact.Version,
+		Size:       &sizeVal,
+	}
+	installCommand := GetNpmInstallCommand(image.Name, artifact.Version, registryURL)
+	config := artifactapi.NpmArtifactDetailConfig{
+		InstallCommand: &installCommand,
+	}
+	if version, err := npmutils.ParseVersion(metadata.Package); err == nil {
+		if version.Description != "" {
+			config.Description = &version.Description
+		}
+		if version.Deprecated != "" {
+			config.Deprecated = &version.Deprecated
+		}
+	}
+	if err := artifactDetail.FromNpmArtifactDetailConfig(config); err != nil {
+		return artifactapi.ArtifactDetail{}
+	}
+	return *artifactDetail
+}
+
+func GetPythonArtifactDetail(image *types.Image, artifact *types.Artifact,
+	metadata database.PythonMetadata, registryURL string) artifactapi.ArtifactDetail {
+	createdAt := GetTimeInMs(artifact.CreatedAt)
+	modifiedAt := GetTimeInMs(artifact.UpdatedAt)
+	var size int64
+	for _, file := range metadata.Files {
+		size += file.Size
+	}
+	sizeVal := GetSize(size)
+	artifactDetail := &artifactapi.ArtifactDetail{
+		CreatedAt:  &createdAt,
+		ModifiedAt: &modifiedAt,
+		Name:       &image.Name,
+		Version:    artifact.Version,
+		Size:       &sizeVal,
+	}
+	installCommand := GetPythonInstallCommand(image.Name, artifact.Version, registryURL)
+	config := artifactapi.PythonArtifactDetailConfig{
+		InstallCommand: &installCommand,
+	}
+	if metadata.Summary != "" {
+		config.Summary = &metadata.Summary
+	}
+	if metadata.RequiresPython != "" {
+		config.RequiresPython = &metadata.RequiresPython
+	}
+	if err := artifactDetail.FromPythonArtifactDetailConfig(config); err != nil {
+		return artifactapi.ArtifactDetail{}
+	}
+	return *artifactDetail
+}
+
+func GetGoArtifactDetail(image *types.Image, artifact *types.Artifact,
+	metadata database.GoMetadata, registryURL string) artifactapi.ArtifactDetail {
+	createdAt := GetTimeInMs(artifact.CreatedAt)
+	modifiedAt := GetTimeInMs(artifact.UpdatedAt)
+	var size int64
+	for _, file := range metadata.Files {
+		size += file.Size
+	}
+	sizeVal := GetSize(size)
+	artifactDetail := &artifactapi.ArtifactDetail{
+		CreatedAt:  &createdAt,
+		ModifiedAt: &modifiedAt,
+		Name:       &image.Name,
+		Version:    artifact.Version,
+		Size:       &sizeVal,
+	}
+	installCommand := GetGoInstallCommand(image.Name, artifact.Version, registryURL)
+	config := artifactapi.GoArtifactDetailConfig{
+		InstallCommand: &installCommand,
+	}
+	if metadata.GoVersion != "" {
+		config.GoVersion = &metadata.GoVersion
+	}
+	if metadata.Origin != nil {
+		config.Repository = &metadata.Origin.URL
+		config.Commit = &metadata.Origin.Hash
+	}
+	if err := artifactDetail.FromGoArtifactDetailConfig(config); err != nil {
+		return artifactapi.ArtifactDetail{}
+	}
+	return *artifactDetail
+}
+
 func GetArtifactSummary(artifact types.ArtifactMetadata) *artifactapi.ArtifactSummaryResponseJSONResponse {
 	createdAt := GetTimeInMs(artifact.CreatedAt)
 	modifiedAt := GetTimeInMs(artifact.ModifiedAt)
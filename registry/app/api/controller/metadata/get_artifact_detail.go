@@ -118,6 +118,42 @@ func (c *APIController) GetArtifactDetails(
 			}, nil
 		}
 		artifactDetails = GetGenericArtifactDetail(img, art, metadata)
+	} else if artifact.PackageTypeNPM == registry.PackageType {
+		var metadata database.NpmMetadata
+		err := json.Unmarshal(art.Metadata, &metadata)
+		if err != nil {
+			return artifact.GetArtifactDetails500JSONResponse{
+				InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+					*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+				),
+			}, nil
+		}
+		registryURL := c.URLProvider.RegistryURL(ctx, regInfo.RootIdentifier, "npm", regInfo.RegistryIdentifier)
+		artifactDetails = GetNpmArtifactDetail(img, art, metadata, registryURL)
+	} else if artifact.PackageTypePYTHON == registry.PackageType {
+		var metadata database.PythonMetadata
+		err := json.Unmarshal(art.Metadata, &metadata)
+		if err != nil {
+			return artifact.GetArtifactDetails500JSONResponse{
+				InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+					*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+				),
+			}, nil
+		}
+		registryURL := c.URLProvider.RegistryURL(ctx, regInfo.RootIdentifier, "python", regInfo.RegistryIdentifier)
+		artifactDetails = GetPythonArtifactDetail(img, art, metadata, registryURL)
+	} else if artifact.PackageTypeGO == registry.PackageType {
+		var metadata database.GoMetadata
+		err := json.Unmarshal(art.Metadata, &metadata)
+		if err != nil {
+			return artifact.GetArtifactDetails500JSONResponse{
+				InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+					*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+				),
+			}, nil
+		}
+		registryURL := c.URLProvider.RegistryURL(ctx, regInfo.RootIdentifier, "go", regInfo.RegistryIdentifier)
+		artifactDetails = GetGoArtifactDetail(img, art, metadata, registryURL)
 	}
 	return artifact.GetArtifactDetails200JSONResponse{
 		ArtifactDetailResponseJSONResponse: artifact.ArtifactDetailResponseJSONResponse{
@@ -0,0 +1,176 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/docker"
+	store2 "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func (c *APIController) GetDockerArtifactReferrers(
+	ctx context.Context,
+	r artifact.GetDockerArtifactReferrersRequestObject,
+) (artifact.GetDockerArtifactReferrersResponseObject, error) {
+	regInfo, err := c.GetRegistryRequestBaseInfo(ctx, "", string(r.RegistryRef))
+	if err != nil {
+		return artifactReferrersBadRequestRs(err), nil
+	}
+
+	space, err := c.SpaceFinder.FindByRef(ctx, regInfo.ParentRef)
+	if err != nil {
+		return artifactReferrersBadRequestRs(err), nil
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	permissionChecks := GetPermissionChecks(space, regInfo.RegistryIdentifier, enum.PermissionRegistryView)
+	if err = apiauth.CheckRegistry(
+		ctx,
+		c.Authorizer,
+		session,
+		permissionChecks...,
+	); err != nil {
+		return artifact.GetDockerArtifactReferrers403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(
+				*GetErrorResponse(http.StatusForbidden, err.Error()),
+			),
+		}, nil
+	}
+
+	image := string(r.Artifact)
+	version := string(r.Version)
+
+	registry, err := c.RegistryRepository.GetByParentIDAndName(ctx, regInfo.parentID, regInfo.RegistryIdentifier)
+	if err != nil {
+		return artifactReferrersErrorRs(err), nil
+	}
+
+	// the referrers of a platform manifest of a multi-arch image can be requested
+	// through its digest, the manifest of the version is used otherwise.
+	var subject digest.Digest
+	if r.Params.Digest != nil && *r.Params.Digest != "" {
+		subject, err = digest.Parse(string(*r.Params.Digest))
+		if err != nil {
+			return artifactReferrersBadRequestRs(err), nil
+		}
+	} else {
+		m, err := c.ManifestStore.FindManifestByTagName(ctx, registry.ID, image, version)
+		if err != nil {
+			return artifactReferrersErrorRs(err), nil
+		}
+		subject = m.Digest
+	}
+
+	artifactType := ""
+	if r.Params.ArtifactType != nil {
+		artifactType = string(*r.Params.ArtifactType)
+	}
+	descriptors, err := docker.ListReferrerDescriptors(
+		ctx, c.ManifestStore, registry.ID, image, subject, artifactType,
+	)
+	if err != nil {
+		return artifactReferrersErrorRs(err), nil
+	}
+
+	referrers := make([]artifact.DockerReferrer, 0, len(descriptors))
+	for _, d := range descriptors {
+		referrers = append(referrers, getReferrerDetails(d))
+	}
+
+	return artifact.GetDockerArtifactReferrers200JSONResponse{
+		DockerReferrersResponseJSONResponse: artifact.DockerReferrersResponseJSONResponse{
+			Data: artifact.DockerReferrers{
+				ImageName: image,
+				Version:   version,
+				Digest:    subject.String(),
+				Referrers: referrers,
+			},
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
+}
+
+func getReferrerDetails(d v1.Descriptor) artifact.DockerReferrer {
+	size := GetSize(d.Size)
+	referrer := artifact.DockerReferrer{
+		Digest:    d.Digest.String(),
+		MediaType: d.MediaType,
+		Size:      &size,
+		Category:  GetReferrerCategory(d.ArtifactType),
+	}
+	if d.ArtifactType != "" {
+		referrer.ArtifactType = &d.ArtifactType
+	}
+	if len(d.Annotations) > 0 {
+		annotations := d.Annotations
+		referrer.Annotations = &annotations
+	}
+	return referrer
+}
+
+// GetReferrerCategory classifies a referrer by its artifact type, so signatures,
+// attestations and SBOMs can be told apart without knowing every tool's media type.
+func GetReferrerCategory(artifactType string) artifact.DockerReferrerCategory {
+	t := strings.ToLower(artifactType)
+	switch {
+	case t == "":
+		return artifact.DockerReferrerCategoryOTHER
+	case strings.Contains(t, "spdx"), strings.Contains(t, "cyclonedx"), strings.Contains(t, "syft"),
+		strings.Contains(t, "sbom"):
+		return artifact.DockerReferrerCategorySBOM
+	case strings.Contains(t, "in-toto"), strings.Contains(t, "dsse"), strings.Contains(t, "attestation"),
+		strings.Contains(t, "provenance"), strings.Contains(t, "vex"):
+		return artifact.DockerReferrerCategoryATTESTATION
+	case strings.Contains(t, "signature"), strings.Contains(t, "cosign"), strings.Contains(t, "sigstore"),
+		strings.Contains(t, "notary"), strings.HasSuffix(t, ".sig"):
+		return artifact.DockerReferrerCategorySIGNATURE
+	default:
+		return artifact.DockerReferrerCategoryOTHER
+	}
+}
+
+func artifactReferrersBadRequestRs(err error) artifact.GetDockerArtifactReferrersResponseObject {
+	return artifact.GetDockerArtifactReferrers400JSONResponse{
+		BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+			*GetErrorResponse(http.StatusBadRequest, err.Error()),
+		),
+	}
+}
+
+func artifactReferrersErrorRs(err error) artifact.GetDockerArtifactReferrersResponseObject {
+	if errors.Is(err, store2.ErrResourceNotFound) {
+		return artifact.GetDockerArtifactReferrers404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, err.Error()),
+			),
+		}
+	}
+	return artifact.GetDockerArtifactReferrers500JSONResponse{
+		InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+			*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+		),
+	}
+}
@@ -0,0 +1,40 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReferrerCategory(t *testing.T) {
+	tests := map[string]artifact.DockerReferrerCategory{
+		"": artifact.DockerReferrerCategoryOTHER,
+		"application/vnd.dev.cosign.artifact.sig.v1+json": artifact.DockerReferrerCategorySIGNATURE,
+		"application/vnd.cncf.notary.signature":           artifact.DockerReferrerCategorySIGNATURE,
+		"application/vnd.dev.sigstore.bundle.v0.3+json":   artifact.DockerReferrerCategorySIGNATURE,
+		"application/vnd.in-toto+json":                    artifact.DockerReferrerCategoryATTESTATION,
+		"application/vnd.dev.cosign.attestation.v1+json":  artifact.DockerReferrerCategoryATTESTATION,
+		"application/spdx+json":                           artifact.DockerReferrerCategorySBOM,
+		"application/vnd.cyclonedx+json":                  artifact.DockerReferrerCategorySBOM,
+		"application/vnd.oci.image.config.v1+json":        artifact.DockerReferrerCategoryOTHER,
+	}
+	for artifactType, expected := range tests {
+		assert.Equal(t, expected, GetReferrerCategory(artifactType), artifactType)
+	}
+}
@@ -131,6 +131,12 @@ func (c *APIController) GenerateClientSetupDetails(
 			loginPasswordLabel, username, registryRef, image, tag)
 	case string(artifact.PackageTypeGENERIC):
 		return c.generateGenericClientSetupDetail(ctx, blankString, registryRef, image, tag)
+	case string(artifact.PackageTypeNPM):
+		return c.generateNpmClientSetupDetail(ctx, blankString, username, registryRef, image, tag)
+	case string(artifact.PackageTypePYTHON):
+		return c.generatePythonClientSetupDetail(ctx, blankString, username, registryRef, image, tag)
+	case string(artifact.PackageTypeGO):
+		return c.generateGoClientSetupDetail(ctx, blankString, username, registryRef, image, tag)
 	}
 	header1 := "Login to Docker"
 	section1step1Header := "Run this Docker command in your terminal to authenticate the client."
@@ -383,9 +389,366 @@ func (c *APIController) generateHelmClientSetupDetail(ctx context.Context, blank
 	_ = section3.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
 		Steps: &section3Steps,
 	})
+	rootSpace, _, _ := paths.DisectRoot(registryRef)
+	_, registryName, _ := paths.DisectLeaf(registryRef)
+	repoURL := c.URLProvider.RegistryURL(ctx) + "/helm/" + strings.ToLower(rootSpace) + "/" + registryName
+
+	header4 := "Use as a Helm chart repository"
+	section4step1Header := "Run this Helm command in your terminal to add the registry as a chart repository."
+	helmRepoAddValue := "helm repo add <REGISTRY_NAME> " + repoURL + " --username <USERNAME> --password <TOKEN>"
+	section4step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &helmRepoAddValue},
+	}
+	section4step2Header := "Run this command in your terminal to upload a packaged chart to the repository."
+	helmUploadValue := "curl -u <USERNAME>:<TOKEN> --data-binary \"@<CHART_TGZ_FILE>\" " + repoURL + "/api/charts"
+	section4step2Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &helmUploadValue},
+	}
+	section4step3Header := "Run this Helm command in your terminal to install a specific chart version."
+	helmInstallValue := "helm repo update && helm install <RELEASE_NAME> <REGISTRY_NAME>/<IMAGE_NAME> --version <TAG>"
+	section4step3Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &helmInstallValue},
+	}
+	section4stepType := artifact.ClientSetupStepTypeStatic
+	section4Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section4step1Header,
+			Commands: &section4step1Commands,
+			Type:     &section4stepType,
+		},
+		{
+			Header:   &section4step2Header,
+			Commands: &section4step2Commands,
+			Type:     &section4stepType,
+		},
+		{
+			Header:   &section4step3Header,
+			Commands: &section4step3Commands,
+			Type:     &section4stepType,
+		},
+	}
+	section4 := artifact.ClientSetupSection{
+		Header: &header4,
+	}
+	_ = section4.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section4Steps,
+	})
+
 	clientSetupDetails := artifact.ClientSetupDetails{
 		MainHeader: "Helm Client Setup",
 		SecHeader:  "Follow these instructions to install/use Helm artifacts or compatible packages.",
+		Sections: []artifact.ClientSetupSection{
+			section1,
+			section2,
+			section3,
+			section4,
+		},
+	}
+
+	c.replacePlaceholders(ctx, &clientSetupDetails.Sections, username, registryRef, image, tag, "", "", "")
+
+	return &artifact.ClientSetupDetailsResponseJSONResponse{
+		Data:   clientSetupDetails,
+		Status: artifact.StatusSUCCESS,
+	}
+}
+
+func (c *APIController) generateNpmClientSetupDetail(ctx context.Context, blankString string, username string,
+	registryRef string, image *artifact.ArtifactParam, tag *artifact.VersionParam,
+) *artifact.ClientSetupDetailsResponseJSONResponse {
+	rootSpace, _, _ := paths.DisectRoot(registryRef)
+	_, registryName, _ := paths.DisectLeaf(registryRef)
+	registryURL := c.URLProvider.RegistryURL(ctx, rootSpace, "npm", registryName) + "/"
+
+	header1 := "Configure npm"
+	section1step1Header := "Add the following lines to the .npmrc file of your project or of your user."
+	npmrcValue := "registry=" + registryURL + "\n" +
+		"//" + common.TrimURLScheme(registryURL) + ":_authToken=<TOKEN>\n" +
+		"always-auth=true"
+	section1step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &npmrcValue},
+	}
+	section1step1Type := artifact.ClientSetupStepTypeStatic
+	section1step2Header := "For the <TOKEN> above, generate an identity token"
+	section1step2Type := artifact.ClientSetupStepTypeGenerateToken
+	section1Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section1step1Header,
+			Commands: &section1step1Commands,
+			Type:     &section1step1Type,
+		},
+		{
+			Header: &section1step2Header,
+			Type:   &section1step2Type,
+		},
+	}
+	section1 := artifact.ClientSetupSection{
+		Header: &header1,
+	}
+	_ = section1.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section1Steps,
+	})
+
+	header2 := "Publish a package"
+	section2step1Header := "Run this command from the directory of your package to publish it."
+	npmPublishValue := "npm publish --registry " + registryURL
+	section2step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &npmPublishValue},
+	}
+	section2step1Type := artifact.ClientSetupStepTypeStatic
+	section2Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section2step1Header,
+			Commands: &section2step1Commands,
+			Type:     &section2step1Type,
+		},
+	}
+	section2 := artifact.ClientSetupSection{
+		Header: &header2,
+	}
+	_ = section2.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section2Steps,
+	})
+
+	header3 := "Install a package"
+	section3step1Header := "Run this command in your terminal to install a specific version of the package."
+	npmInstallValue := "npm install <IMAGE_NAME>@<TAG> --registry " + registryURL
+	section3step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &npmInstallValue},
+	}
+	section3step1Type := artifact.ClientSetupStepTypeStatic
+	section3Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section3step1Header,
+			Commands: &section3step1Commands,
+			Type:     &section3step1Type,
+		},
+	}
+	section3 := artifact.ClientSetupSection{
+		Header: &header3,
+	}
+	_ = section3.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section3Steps,
+	})
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "npm Client Setup",
+		SecHeader:  "Follow these instructions to install/use npm packages from this registry.",
+		Sections: []artifact.ClientSetupSection{
+			section1,
+			section2,
+			section3,
+		},
+	}
+
+	c.replacePlaceholders(ctx, &clientSetupDetails.Sections, username, registryRef, image, tag, "", "", "")
+
+	return &artifact.ClientSetupDetailsResponseJSONResponse{
+		Data:   clientSetupDetails,
+		Status: artifact.StatusSUCCESS,
+	}
+}
+
+func (c *APIController) generatePythonClientSetupDetail(ctx context.Context, blankString string, username string,
+	registryRef string, image *artifact.ArtifactParam, tag *artifact.VersionParam,
+) *artifact.ClientSetupDetailsResponseJSONResponse {
+	rootSpace, _, _ := paths.DisectRoot(registryRef)
+	_, registryName, _ := paths.DisectLeaf(registryRef)
+	registryURL := c.URLProvider.RegistryURL(ctx, rootSpace, "python", registryName)
+
+	header1 := "Configure twine"
+	section1step1Header := "Add the following lines to the .pypirc file in your home directory."
+	pypircValue := "[distutils]\n" +
+		"index-servers = <REGISTRY_NAME>\n\n" +
+		"[<REGISTRY_NAME>]\n" +
+		"repository = " + registryURL + "/\n" +
+		"username = <USERNAME>\n" +
+		"password = <TOKEN>"
+	section1step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &pypircValue},
+	}
+	section1step1Type := artifact.ClientSetupStepTypeStatic
+	section1step2Header := "For the <TOKEN> above, generate an identity token"
+	section1step2Type := artifact.ClientSetupStepTypeGenerateToken
+	section1Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section1step1Header,
+			Commands: &section1step1Commands,
+			Type:     &section1step1Type,
+		},
+		{
+			Header: &section1step2Header,
+			Type:   &section1step2Type,
+		},
+	}
+	section1 := artifact.ClientSetupSection{
+		Header: &header1,
+	}
+	_ = section1.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section1Steps,
+	})
+
+	header2 := "Publish a package"
+	section2step1Header := "Run this command from the directory of your project to upload the built distributions."
+	twineUploadValue := "twine upload --repository <REGISTRY_NAME> dist/*"
+	section2step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &twineUploadValue},
+	}
+	section2step1Type := artifact.ClientSetupStepTypeStatic
+	section2Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section2step1Header,
+			Commands: &section2step1Commands,
+			Type:     &section2step1Type,
+		},
+	}
+	section2 := artifact.ClientSetupSection{
+		Header: &header2,
+	}
+	_ = section2.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section2Steps,
+	})
+
+	header3 := "Install a package"
+	section3step1Header := "Run this command in your terminal to install a specific version of the package. " +
+		"pip asks for your username and the identity token."
+	pipInstallValue := "pip install <IMAGE_NAME>==<TAG> --index-url " + registryURL + "/simple/"
+	section3step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &pipInstallValue},
+	}
+	section3step1Type := artifact.ClientSetupStepTypeStatic
+	section3Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section3step1Header,
+			Commands: &section3step1Commands,
+			Type:     &section3step1Type,
+		},
+	}
+	section3 := artifact.ClientSetupSection{
+		Header: &header3,
+	}
+	_ = section3.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section3Steps,
+	})
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Python Client Setup",
+		SecHeader:  "Follow these instructions to install/use Python packages from this registry.",
+		Sections: []artifact.ClientSetupSection{
+			section1,
+			section2,
+			section3,
+		},
+	}
+
+	c.replacePlaceholders(ctx, &clientSetupDetails.Sections, username, registryRef, image, tag, "", "", "")
+
+	return &artifact.ClientSetupDetailsResponseJSONResponse{
+		Data:   clientSetupDetails,
+		Status: artifact.StatusSUCCESS,
+	}
+}
+
+func (c *APIController) generateGoClientSetupDetail(ctx context.Context, blankString string, username string,
+	registryRef string, image *artifact.ArtifactParam, tag *artifact.VersionParam,
+) *artifact.ClientSetupDetailsResponseJSONResponse {
+	rootSpace, _, _ := paths.DisectRoot(registryRef)
+	_, registryName, _ := paths.DisectLeaf(registryRef)
+	registryURL := c.URLProvider.RegistryURL(ctx, rootSpace, "go", registryName)
+	host := GetRepoURLWithoutProtocol(registryURL)
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+
+	header1 := "Configure the go command"
+	section1step1Header := "Add the following lines to the .netrc file in your home directory."
+	netrcValue := "machine " + host + "\n" +
+		"login <USERNAME>\n" +
+		"password <TOKEN>"
+	section1step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &netrcValue},
+	}
+	section1step1Type := artifact.ClientSetupStepTypeStatic
+	section1step2Header := "For the <TOKEN> above, generate an identity token"
+	section1step2Type := artifact.ClientSetupStepTypeGenerateToken
+	section1step3Header := "Run these commands in your terminal to fetch modules from this registry. " +
+		"The modules of the registry aren't known to the public checksum database."
+	goEnvValue := "go env -w GOPROXY=" + registryURL + ",https://proxy.golang.org,direct\n" +
+		"go env -w GONOSUMDB=<IMAGE_NAME>"
+	section1step3Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &goEnvValue},
+	}
+	section1step3Type := artifact.ClientSetupStepTypeStatic
+	section1Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section1step1Header,
+			Commands: &section1step1Commands,
+			Type:     &section1step1Type,
+		},
+		{
+			Header: &section1step2Header,
+			Type:   &section1step2Type,
+		},
+		{
+			Header:   &section1step3Header,
+			Commands: &section1step3Commands,
+			Type:     &section1step3Type,
+		},
+	}
+	section1 := artifact.ClientSetupSection{
+		Header: &header1,
+	}
+	_ = section1.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section1Steps,
+	})
+
+	header2 := "Publish a module"
+	section2step1Header := "Upload the zip of a module version, as created by golang.org/x/mod/zip. " +
+		"Versions can also be published by pushing a tag to a repository of this server."
+	uploadValue := "curl --request PUT --upload-file <TAG>.zip --header 'Authorization: Bearer <TOKEN>' '" +
+		registryURL + "/<IMAGE_NAME>/@v/<TAG>.zip'"
+	section2step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &uploadValue},
+	}
+	section2step1Type := artifact.ClientSetupStepTypeStatic
+	section2Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section2step1Header,
+			Commands: &section2step1Commands,
+			Type:     &section2step1Type,
+		},
+	}
+	section2 := artifact.ClientSetupSection{
+		Header: &header2,
+	}
+	_ = section2.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section2Steps,
+	})
+
+	header3 := "Install a module"
+	section3step1Header := "Run this command in your terminal to add a specific version of the module to your module."
+	goGetValue := "go get <IMAGE_NAME>@<TAG>"
+	section3step1Commands := []artifact.ClientSetupStepCommand{
+		{Label: &blankString, Value: &goGetValue},
+	}
+	section3step1Type := artifact.ClientSetupStepTypeStatic
+	section3Steps := []artifact.ClientSetupStep{
+		{
+			Header:   &section3step1Header,
+			Commands: &section3step1Commands,
+			Type:     &section3step1Type,
+		},
+	}
+	section3 := artifact.ClientSetupSection{
+		Header: &header3,
+	}
+	_ = section3.FromClientSetupStepConfig(artifact.ClientSetupStepConfig{
+		Steps: &section3Steps,
+	})
+
+	clientSetupDetails := artifact.ClientSetupDetails{
+		MainHeader: "Go Client Setup",
+		SecHeader:  "Follow these instructions to install/use Go modules from this registry.",
 		Sections: []artifact.ClientSetupSection{
 			section1,
 			section2,
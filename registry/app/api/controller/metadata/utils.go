@@ -26,6 +26,7 @@ import (
 
 	a "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
 	"github.com/harness/gitness/registry/app/pkg/commons"
+	goutils "github.com/harness/gitness/registry/app/pkg/gomodule/utils"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -116,6 +117,9 @@ var validPackageTypes = []string{
 	string(a.PackageTypeHELM),
 	string(a.PackageTypeGENERIC),
 	string(a.PackageTypeMAVEN),
+	string(a.PackageTypeNPM),
+	string(a.PackageTypePYTHON),
+	string(a.PackageTypeGO),
 }
 
 var validUpstreamSources = []string{
@@ -123,6 +127,8 @@ var validUpstreamSources = []string{
 	string(a.UpstreamConfigSourceDockerhub),
 	string(a.UpstreamConfigSourceAwsEcr),
 	string(a.UpstreamConfigSourceMavenCentral),
+	string(a.UpstreamConfigSourceNpmjs),
+	string(a.UpstreamConfigSourcePyPi),
 }
 
 func ValidatePackageTypes(packageTypes []string) error {
@@ -179,7 +185,9 @@ func ValidateUpstream(config *a.RegistryConfig) error {
 	}
 	if !commons.IsEmpty(config.Type) && config.Type == a.RegistryTypeUPSTREAM &&
 		*upstreamConfig.Source != a.UpstreamConfigSourceDockerhub &&
-		*upstreamConfig.Source != a.UpstreamConfigSourceMavenCentral {
+		*upstreamConfig.Source != a.UpstreamConfigSourceMavenCentral &&
+		*upstreamConfig.Source != a.UpstreamConfigSourceNpmjs &&
+		*upstreamConfig.Source != a.UpstreamConfigSourcePyPi {
 		if commons.IsEmpty(upstreamConfig.Url) {
 			return errors.New("URL is required for upstream repository")
 		}
@@ -367,6 +375,12 @@ func GetPullCommand(
 		return GetHelmPullCommand(image, tag, registryURL)
 	case string(a.PackageTypeGENERIC):
 		return GetGenericArtifactFileDownloadCommand(registryURL, image, tag, "<FILENAME>")
+	case string(a.PackageTypeNPM):
+		return GetNpmInstallCommand(image, tag, registryURL)
+	case string(a.PackageTypePYTHON):
+		return GetPythonInstallCommand(image, tag, registryURL)
+	case string(a.PackageTypeGO):
+		return GetGoInstallCommand(image, tag, registryURL)
 	default:
 		return ""
 	}
@@ -383,6 +397,35 @@ func GetHelmPullCommand(image string, tag string, registryURL string) string {
 	return "helm pull oci://" + GetRepoURLWithoutProtocol(registryURL) + "/" + image + ":" + tag
 }
 
+func GetNpmInstallCommand(image string, version string, registryURL string) string {
+	return "npm install " + image + "@" + version + " --registry " + registryURL + "/"
+}
+
+func GetNpmArtifactFileDownloadCommand(regURL, artifact, filename string) string {
+	return "curl --location '" + regURL + "/" + artifact + "/-/" + filename + "'" +
+		" --header 'Authorization: Bearer <TOKEN>' -O"
+}
+
+func GetPythonInstallCommand(image string, version string, registryURL string) string {
+	return "pip install " + image + "==" + version + " --index-url " + registryURL + "/simple/"
+}
+
+func GetPythonArtifactFileDownloadCommand(regURL, artifact, version, filename string) string {
+	return "curl --location '" + regURL + "/files/" + artifact + "/" + version + "/" + filename + "'" +
+		" --header 'Authorization: Bearer <TOKEN>' -O"
+}
+
+// GetGoInstallCommand skips the checksum database for the module, it can't
+// know about modules that are only published to the registry.
+func GetGoInstallCommand(image string, version string, registryURL string) string {
+	return "GOPROXY=" + registryURL + " GONOSUMDB=" + image + " go get " + image + "@" + version
+}
+
+func GetGoArtifactFileDownloadCommand(regURL, artifact, filename string) string {
+	return "curl --location '" + goutils.FileURL(regURL, artifact, filename) + "'" +
+		" --header 'Authorization: Bearer <TOKEN>' -O"
+}
+
 func GetGenericArtifactFileDownloadCommand(regURL, artifact, version, filename string) string {
 	downloadCommand := "curl --location '<HOSTNAME>/<ARTIFACT>:<VERSION>:<FILENAME>' --header 'x-api-key: <API_KEY>'" +
 		" -J -O"
@@ -0,0 +1,177 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/auth/authz"
+	corestore "github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/api/handler/utils"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/gomodule"
+	goutils "github.com/harness/gitness/registry/app/pkg/gomodule/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	pathPrefix = "/go/"
+	// maxUploadSize is the maximum size of a module zip accepted by the go
+	// command.
+	maxUploadSize = 500 << 20
+)
+
+type Handler struct {
+	Controller    *gomodule.Controller
+	SpaceStore    corestore.SpaceStore
+	Authenticator authn.Authenticator
+	Authorizer    authz.Authorizer
+	URLProvider   urlprovider.Provider
+}
+
+func NewHandler(
+	controller *gomodule.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, urlProvider urlprovider.Provider,
+) *Handler {
+	return &Handler{
+		Controller:    controller,
+		SpaceStore:    spaceStore,
+		Authenticator: authenticator,
+		Authorizer:    authorizer,
+		URLProvider:   urlProvider,
+	}
+}
+
+// GetArtifactInfo resolves the registry addressed by the request and parses
+// the module request that follows it.
+func (h *Handler) GetArtifactInfo(r *http.Request) (pkg.GoModuleArtifactInfo, goutils.Request, errcode.Error) {
+	ctx := r.Context()
+	request, err := goutils.ParseRequestPath(chi.URLParam(r, "*"))
+	if err != nil {
+		return pkg.GoModuleArtifactInfo{}, goutils.Request{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	rootIdentifier, registryIdentifier, err := ExtractPathVars(r.URL.Path)
+	if err != nil {
+		return pkg.GoModuleArtifactInfo{}, goutils.Request{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+	if err := metadata.ValidateIdentifier(registryIdentifier); err != nil {
+		return pkg.GoModuleArtifactInfo{}, goutils.Request{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	rootSpace, err := h.SpaceStore.FindByRefCaseInsensitive(ctx, rootIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Root space not found: %s", rootIdentifier)
+		return pkg.GoModuleArtifactInfo{}, goutils.Request{}, errcode.ErrCodeRootNotFound.WithDetail(err)
+	}
+
+	registry, err := h.Controller.DBStore.RegistryDao.GetByRootParentIDAndName(ctx, rootSpace.ID, registryIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s not found for root: %s. Reason: %s", registryIdentifier, rootSpace.Identifier, err,
+		)
+		return pkg.GoModuleArtifactInfo{}, goutils.Request{}, errcode.ErrCodeRegNotFound.WithDetail(err)
+	}
+
+	if registry.PackageType != artifact.PackageTypeGO {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s is not a go registry for root: %s", registryIdentifier, rootSpace.Identifier,
+		)
+		return pkg.GoModuleArtifactInfo{}, goutils.Request{}, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("registry %s is not a go registry", registryIdentifier),
+		)
+	}
+
+	flag, err := utils.MatchArtifactFilter(registry.AllowedPattern, registry.BlockedPattern, request.ModulePath)
+	if !flag || err != nil {
+		return pkg.GoModuleArtifactInfo{}, goutils.Request{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	info := pkg.GoModuleArtifactInfo{
+		ArtifactInfo: &pkg.ArtifactInfo{
+			BaseInfo: &pkg.BaseInfo{
+				PathRoot:       rootIdentifier,
+				RootIdentifier: rootIdentifier,
+				RootParentID:   rootSpace.ID,
+				ParentID:       registry.ParentID,
+			},
+			RegIdentifier: registryIdentifier,
+			Image:         request.ModulePath,
+		},
+		RegistryID:  registry.ID,
+		Version:     request.Version,
+		RegistryURL: h.URLProvider.RegistryURL(ctx, rootIdentifier, "go", registryIdentifier),
+	}
+
+	log.Ctx(ctx).Info().Msgf("Dispatch: URI: %s", r.URL.Path)
+	return info, request, errcode.Error{}
+}
+
+// ExtractPathVars extracts the root space and the registry from the path.
+// Path format: /go/:rootSpace/:registry/:module/@v/... (for ex:
+// /go/myRootSpace/reg1/example.com/mod/@v/list).
+func ExtractPathVars(path string) (rootIdentifier, registry string, err error) {
+	if !strings.HasPrefix(path, pathPrefix) {
+		return "", "", fmt.Errorf("invalid path: must start with %s", pathPrefix)
+	}
+
+	segments := strings.SplitN(strings.TrimPrefix(path, pathPrefix), "/", 3)
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("invalid path format: missing rootIdentifier or registry")
+	}
+	return segments[0], segments[1], nil
+}
+
+// handleErrors writes the first error as plain text, which the go command
+// prints when a request fails. Requests for modules or versions the registry
+// doesn't have must get a 404 for the go command to fall back to the next
+// proxy of GOPROXY.
+func handleErrors(ctx context.Context, errs []error, w http.ResponseWriter) {
+	if commons.IsEmpty(errs) {
+		return
+	}
+	log.Ctx(ctx).Error().Errs("errs occurred during go module operation: ", errs).Msgf("Error occurred")
+
+	status := http.StatusInternalServerError
+	message := errs[0].Error()
+	var commonsErr *commons.Error
+	var coder errcode.ErrorCoder
+	switch {
+	case errors.As(errs[0], &commonsErr):
+		status = commonsErr.Status
+		message = commonsErr.Message
+	case errors.As(errs[0], &coder):
+		status = coder.ErrorCode().Descriptor().HTTPStatusCode
+	}
+	http.Error(w, message, status)
+}
+
+func handleError(ctx context.Context, err errcode.Error, w http.ResponseWriter) {
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, []error{err}, w)
+	}
+}
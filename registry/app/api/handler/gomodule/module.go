@@ -0,0 +1,150 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	goutils "github.com/harness/gitness/registry/app/pkg/gomodule/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// GetModule serves the GOPROXY protocol: the version list (GET /:module/@v/list),
+// the latest version (GET /:module/@latest) and the .info, .mod and .zip files
+// of a version (GET /:module/@v/:version.:ext).
+func (h *Handler) GetModule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	// The go command asks for arbitrary queries, like branch names, it gets
+	// a 404 for the ones that aren't versions so it moves on to the next proxy.
+	if _, err := goutils.ParseRequestPath(chi.URLParam(r, "*")); errors.Is(err, goutils.ErrInvalidVersion) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	info, request, e := h.GetArtifactInfo(r)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+
+	switch request.Action {
+	case goutils.ActionList:
+		versions, errs := h.Controller.ListVersions(ctx, info)
+		if !commons.IsEmpty(errs) {
+			handleErrors(ctx, errs, w)
+			return
+		}
+		w.Header().Set(commons.HeaderContentType, goutils.ContentTypeText)
+		w.WriteHeader(http.StatusOK)
+		if len(versions) > 0 {
+			if _, err := io.WriteString(w, strings.Join(versions, "\n")+"\n"); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("failed to write version list")
+			}
+		}
+	case goutils.ActionLatest, goutils.ActionInfo:
+		getInfo := h.Controller.GetInfo
+		if request.Action == goutils.ActionLatest {
+			getInfo = h.Controller.GetLatest
+		}
+		moduleInfo, errs := getInfo(ctx, info)
+		if !commons.IsEmpty(errs) {
+			handleErrors(ctx, errs, w)
+			return
+		}
+		w.Header().Set(commons.HeaderContentType, goutils.ContentTypeJSON)
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(moduleInfo); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to encode module info")
+		}
+	case goutils.ActionMod, goutils.ActionZip:
+		extension := goutils.ModExtension
+		if request.Action == goutils.ActionZip {
+			extension = goutils.ZipExtension
+		}
+		headers, body, redirectURL, errs := h.Controller.DownloadFile(ctx, info, extension)
+		if body != nil {
+			defer func() {
+				if err := body.Close(); err != nil {
+					log.Ctx(ctx).Error().Msgf("Failed to close body: %v", err)
+				}
+			}()
+		}
+		if !commons.IsEmpty(errs) {
+			handleErrors(ctx, errs, w)
+			return
+		}
+		if !commons.IsEmpty(redirectURL) {
+			http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+			return
+		}
+		headers.WriteHeadersToResponse(w)
+		http.ServeContent(w, r, goutils.FileName(info.Version, extension), time.Time{}, body)
+	}
+}
+
+// UploadModule publishes a module version (PUT /:module/@v/:version.zip). The
+// body is the module zip, the go.mod file is read from it.
+func (h *Handler) UploadModule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, request, e := h.GetArtifactInfo(r)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+	if request.Action != goutils.ActionZip {
+		handleError(ctx, errcode.ErrCodeUnsupported.WithDetail(
+			fmt.Errorf("modules are uploaded as %s files", goutils.ZipExtension)), w)
+		return
+	}
+
+	// Validating a module zip needs random access, so the body is spooled to
+	// disk first.
+	tmp, err := os.CreateTemp("", "gomodule-*.zip")
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(err), w)
+		return
+	}
+	defer func() {
+		tmp.Close()
+		if err := os.Remove(tmp.Name()); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove %s", tmp.Name())
+		}
+	}()
+	if _, err := io.Copy(tmp, http.MaxBytesReader(w, r.Body, maxUploadSize)); err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("failed to read module zip: %w", err)), w)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(err), w)
+		return
+	}
+
+	if errs := h.Controller.UploadModule(ctx, info, tmp.Name()); !commons.IsEmpty(errs) {
+		handleErrors(ctx, errs, w)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
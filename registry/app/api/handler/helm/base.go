@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/auth/authz"
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/api/handler/utils"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/helm"
+
+	v2 "github.com/distribution/distribution/v3/registry/api/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	pathPrefix   = "/helm/"
+	chartsPrefix = "charts/"
+	// maxChartSize limits the size of an uploaded chart package.
+	maxChartSize = 20 << 20
+)
+
+type Handler struct {
+	Controller     *helm.Controller
+	SpaceStore     corestore.SpaceStore
+	Authenticator  authn.Authenticator
+	Authorizer     authz.Authorizer
+	OCIRelativeURL bool
+}
+
+func NewHandler(
+	controller *helm.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, ociRelativeURL bool,
+) *Handler {
+	return &Handler{
+		Controller:     controller,
+		SpaceStore:     spaceStore,
+		Authenticator:  authenticator,
+		Authorizer:     authorizer,
+		OCIRelativeURL: ociRelativeURL,
+	}
+}
+
+func (h *Handler) GetArtifactInfo(r *http.Request) (pkg.HelmArtifactInfo, errcode.Error) {
+	ctx := r.Context()
+	rootIdentifier, registryIdentifier, filePath, err := ExtractPathVars(r.URL.Path)
+	if err != nil {
+		return pkg.HelmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	if err := metadata.ValidateIdentifier(registryIdentifier); err != nil {
+		return pkg.HelmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	rootSpace, err := h.SpaceStore.FindByRefCaseInsensitive(ctx, rootIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Root space not found: %s", rootIdentifier)
+		return pkg.HelmArtifactInfo{}, errcode.ErrCodeRootNotFound.WithDetail(err)
+	}
+
+	registry, err := h.Controller.DBStore.RegistryDao.GetByRootParentIDAndName(ctx, rootSpace.ID, registryIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s not found for root: %s. Reason: %s", registryIdentifier, rootSpace.Identifier, err,
+		)
+		return pkg.HelmArtifactInfo{}, errcode.ErrCodeRegNotFound.WithDetail(err)
+	}
+
+	if registry.PackageType != artifact.PackageTypeHELM {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s is not a helm registry for root: %s", registryIdentifier, rootSpace.Identifier,
+		)
+		return pkg.HelmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("registry %s is not a helm registry", registryIdentifier),
+		)
+	}
+
+	_, err = h.SpaceStore.Find(ctx, registry.ParentID)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Parent space not found: %d", registry.ParentID)
+		return pkg.HelmArtifactInfo{}, errcode.ErrCodeParentNotFound.WithDetail(err)
+	}
+
+	// OCI clients always address the registry with a lowercase root, charts
+	// uploaded here must end up in the same place.
+	rootIdentifier = strings.ToLower(rootIdentifier)
+	info := pkg.HelmArtifactInfo{
+		ArtifactInfo: &pkg.ArtifactInfo{
+			BaseInfo: &pkg.BaseInfo{
+				PathRoot:       rootIdentifier,
+				RootIdentifier: rootIdentifier,
+				RootParentID:   rootSpace.ID,
+				ParentID:       registry.ParentID,
+			},
+			RegIdentifier: registryIdentifier,
+		},
+		RegistryID: registry.ID,
+		URLBuilder: v2.NewURLBuilderFromRequest(r, h.OCIRelativeURL),
+	}
+
+	if strings.HasPrefix(filePath, chartsPrefix) {
+		name, version, _, err := helm.ParseChartFileName(strings.TrimPrefix(filePath, chartsPrefix))
+		if err != nil {
+			return pkg.HelmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+		}
+		info.Image = name
+		info.Version = version
+		info.FileName = filePath[strings.LastIndex(filePath, "/")+1:]
+
+		flag, err := utils.MatchArtifactFilter(registry.AllowedPattern, registry.BlockedPattern,
+			info.Image+":"+info.Version)
+		if !flag || err != nil {
+			return pkg.HelmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+		}
+	}
+
+	log.Ctx(ctx).Info().Msgf("Dispatch: URI: %s", r.URL.Path)
+	return info, errcode.Error{}
+}
+
+// ExtractPathVars extracts the root space, registry and the path of the
+// requested file inside the repository.
+// Path format: /helm/:rootSpace/:registry/:file (for ex:
+// /helm/myRootSpace/reg1/index.yaml).
+func ExtractPathVars(path string) (rootIdentifier, registry, filePath string, err error) {
+	if !strings.HasPrefix(path, pathPrefix) {
+		return "", "", "", fmt.Errorf("invalid path: must start with %s", pathPrefix)
+	}
+
+	segments := strings.SplitN(strings.TrimPrefix(path, pathPrefix), "/", 3)
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", "", fmt.Errorf("invalid path format: missing rootIdentifier or registry")
+	}
+	rootIdentifier = segments[0]
+	registry = segments[1]
+	if len(segments) == 3 {
+		filePath = strings.Trim(segments[2], "/")
+	}
+	return rootIdentifier, registry, filePath, nil
+}
+
+func handleErrors(ctx context.Context, err errcode.Error, w http.ResponseWriter) {
+	if !commons.IsEmptyError(err) {
+		w.WriteHeader(err.Code.Descriptor().HTTPStatusCode)
+		_ = errcode.ServeJSON(w, err)
+		log.Ctx(ctx).Error().Msgf("Error occurred while performing helm chart action: %s", err.Message)
+	}
+}
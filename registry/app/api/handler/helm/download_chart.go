@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/app/pkg/commons"
+)
+
+func (h *Handler) DownloadChart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, err := h.GetArtifactInfo(r)
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, err, w)
+		return
+	}
+
+	prov := strings.HasSuffix(info.FileName, ".prov")
+	fileReader, _, redirectURL, err := h.Controller.DownloadChart(ctx, info, prov)
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, err, w)
+		return
+	}
+	if redirectURL != "" {
+		http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+	defer fileReader.Close()
+
+	w.Header().Set("Content-Disposition", "attachment; filename="+info.FileName)
+	http.ServeContent(w, r, info.FileName, time.Time{}, fileReader)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+
+	"gopkg.in/yaml.v3"
+)
+
+func (h *Handler) GetIndex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, err := h.GetArtifactInfo(r)
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, err, w)
+		return
+	}
+
+	index, err := h.Controller.GetIndex(ctx, info)
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, err, w)
+		return
+	}
+
+	out, marshalErr := yaml.Marshal(index)
+	if marshalErr != nil {
+		handleErrors(ctx, errcode.ErrCodeUnknown.WithDetail(marshalErr), w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
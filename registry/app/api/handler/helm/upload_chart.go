@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+)
+
+const (
+	formFieldChart = "chart"
+	formFieldProv  = "prov"
+)
+
+// UploadChart accepts a chart package the way ChartMuseum does: either as the
+// raw request body or as a multipart form with a "chart" and an optional
+// "prov" file.
+func (h *Handler) UploadChart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, err := h.GetArtifactInfo(r)
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, err, w)
+		return
+	}
+
+	chart, prov, readErr := readChartUpload(r)
+	if readErr != nil {
+		handleErrors(ctx, errcode.ErrCodeInvalidRequest.WithDetail(readErr), w)
+		return
+	}
+
+	chartMetadata, err := h.Controller.UploadChart(ctx, info, chart, prov)
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"saved":   true,
+		"name":    chartMetadata.Name,
+		"version": chartMetadata.Version,
+	})
+}
+
+func readChartUpload(r *http.Request) ([]byte, []byte, error) {
+	r.Body = http.MaxBytesReader(nil, r.Body, maxChartSize)
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		chart, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read chart: %w", err)
+		}
+		if len(chart) == 0 {
+			return nil, nil, fmt.Errorf("chart package missing in request body")
+		}
+		return chart, nil, nil
+	}
+
+	if err := r.ParseMultipartForm(maxChartSize); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+	chart, err := readFormFile(r, formFieldChart)
+	if err != nil {
+		return nil, nil, err
+	}
+	if chart == nil {
+		return nil, nil, fmt.Errorf("form field %q missing", formFieldChart)
+	}
+	prov, err := readFormFile(r, formFieldProv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return chart, prov, nil
+}
+
+func readFormFile(r *http.Request, field string) ([]byte, error) {
+	file, _, err := r.FormFile(field)
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read form field %q: %w", field, err)
+	}
+	defer func(file multipart.File) {
+		_ = file.Close()
+	}(file)
+	return io.ReadAll(file)
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/auth/authz"
+	corestore "github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/api/handler/utils"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	npmutils "github.com/harness/gitness/registry/app/pkg/npm/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	pathPrefix = "/npm/"
+	// packagePathPrefix prefixes the dist-tag endpoints: /-/package/:package/dist-tags.
+	packagePathPrefix = "-/package/"
+	distTagsSegment   = "dist-tags"
+	revSegment        = "-rev"
+	tarballSegment    = "-"
+	// maxPublishSize limits the size of a publish request, tarballs are sent base64 encoded.
+	maxPublishSize = 256 << 20
+)
+
+type Handler struct {
+	Controller    *npm.Controller
+	SpaceStore    corestore.SpaceStore
+	Authenticator authn.Authenticator
+	Authorizer    authz.Authorizer
+	URLProvider   urlprovider.Provider
+}
+
+func NewHandler(
+	controller *npm.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, urlProvider urlprovider.Provider,
+) *Handler {
+	return &Handler{
+		Controller:    controller,
+		SpaceStore:    spaceStore,
+		Authenticator: authenticator,
+		Authorizer:    authorizer,
+		URLProvider:   urlProvider,
+	}
+}
+
+// GetArtifactInfo resolves the registry addressed by the request and validates
+// the name of the package the request is about.
+func (h *Handler) GetArtifactInfo(r *http.Request, packageName string) (pkg.NpmArtifactInfo, errcode.Error) {
+	ctx := r.Context()
+	rootIdentifier, registryIdentifier, _, err := ExtractPathVars(r.URL.Path)
+	if err != nil {
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	if err := metadata.ValidateIdentifier(registryIdentifier); err != nil {
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	if err := npmutils.ValidatePackageName(packageName); err != nil {
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	rootSpace, err := h.SpaceStore.FindByRefCaseInsensitive(ctx, rootIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Root space not found: %s", rootIdentifier)
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeRootNotFound.WithDetail(err)
+	}
+
+	registry, err := h.Controller.DBStore.RegistryDao.GetByRootParentIDAndName(ctx, rootSpace.ID, registryIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s not found for root: %s. Reason: %s", registryIdentifier, rootSpace.Identifier, err,
+		)
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeRegNotFound.WithDetail(err)
+	}
+
+	if registry.PackageType != artifact.PackageTypeNPM {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s is not a npm registry for root: %s", registryIdentifier, rootSpace.Identifier,
+		)
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("registry %s is not a npm registry", registryIdentifier),
+		)
+	}
+
+	_, err = h.SpaceStore.Find(ctx, registry.ParentID)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Parent space not found: %d", registry.ParentID)
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeParentNotFound.WithDetail(err)
+	}
+
+	flag, err := utils.MatchArtifactFilter(registry.AllowedPattern, registry.BlockedPattern, packageName)
+	if !flag || err != nil {
+		return pkg.NpmArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	info := pkg.NpmArtifactInfo{
+		ArtifactInfo: &pkg.ArtifactInfo{
+			BaseInfo: &pkg.BaseInfo{
+				PathRoot:       rootIdentifier,
+				RootIdentifier: rootIdentifier,
+				RootParentID:   rootSpace.ID,
+				ParentID:       registry.ParentID,
+			},
+			RegIdentifier: registryIdentifier,
+			Image:         packageName,
+		},
+		RegistryID:  registry.ID,
+		RegistryURL: h.URLProvider.RegistryURL(ctx, rootIdentifier, "npm", registryIdentifier),
+	}
+
+	log.Ctx(ctx).Info().Msgf("Dispatch: URI: %s", r.URL.Path)
+	return info, errcode.Error{}
+}
+
+// ExtractPathVars extracts the root space, registry and the path requested
+// inside the registry.
+// Path format: /npm/:rootSpace/:registry/:path (for ex:
+// /npm/myRootSpace/reg1/@scope%2fpkg).
+func ExtractPathVars(path string) (rootIdentifier, registry, filePath string, err error) {
+	if !strings.HasPrefix(path, pathPrefix) {
+		return "", "", "", fmt.Errorf("invalid path: must start with %s", pathPrefix)
+	}
+
+	segments := strings.SplitN(strings.TrimPrefix(path, pathPrefix), "/", 3)
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", "", fmt.Errorf("invalid path format: missing rootIdentifier or registry")
+	}
+	rootIdentifier = segments[0]
+	registry = segments[1]
+	if len(segments) == 3 {
+		filePath = strings.Trim(segments[2], "/")
+	}
+	return rootIdentifier, registry, filePath, nil
+}
+
+// SplitPackagePath splits a path into the package name and the segments that
+// follow it. Clients escape the slash of scoped packages (@scope%2fname), but
+// as the path is already unescaped both forms end up as @scope/name here.
+func SplitPackagePath(path string) (name string, rest []string, err error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if segments[0] == "" {
+		return "", nil, fmt.Errorf("%w: package name is missing", npmutils.ErrInvalidPackageName)
+	}
+	if !strings.HasPrefix(segments[0], "@") {
+		return segments[0], segments[1:], nil
+	}
+	if len(segments) < 2 || segments[1] == "" {
+		return "", nil, fmt.Errorf("%w: %s", npmutils.ErrInvalidPackageName, segments[0])
+	}
+	return segments[0] + "/" + segments[1], segments[2:], nil
+}
+
+// packagePath returns the path of the request relative to the registry.
+func packagePath(r *http.Request) (string, error) {
+	_, _, filePath, err := ExtractPathVars(r.URL.Path)
+	return filePath, err
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", npmutils.ContentTypeJSON)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to encode npm response")
+	}
+}
+
+func writeOK(ctx context.Context, w http.ResponseWriter, status int) {
+	writeJSON(ctx, w, status, map[string]bool{"ok": true})
+}
+
+// handleErrors writes the first error in the format npm clients print,
+// {"error": "..."}.
+func handleErrors(ctx context.Context, errs []error, w http.ResponseWriter) {
+	if commons.IsEmpty(errs) {
+		return
+	}
+	log.Ctx(ctx).Error().Errs("errs occurred during npm operation: ", errs).Msgf("Error occurred")
+
+	status := http.StatusInternalServerError
+	message := errs[0].Error()
+	var commonsErr *commons.Error
+	var coder errcode.ErrorCoder
+	switch {
+	case errors.As(errs[0], &commonsErr):
+		status = commonsErr.Status
+		message = commonsErr.Message
+	case errors.As(errs[0], &coder):
+		status = coder.ErrorCode().Descriptor().HTTPStatusCode
+	}
+	writeJSON(ctx, w, status, map[string]string{"error": message})
+}
+
+func handleError(ctx context.Context, err errcode.Error, w http.ResponseWriter) {
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, []error{err}, w)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	npmutils "github.com/harness/gitness/registry/app/pkg/npm/utils"
+)
+
+// DeletePackage handles `npm unpublish`. The whole package is removed with
+// DELETE /:package/-rev/:rev, a single tarball with
+// DELETE /:package/-/:file/-rev/:rev once npm has removed the version from
+// the packument.
+func (h *Handler) DeletePackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	path, err := packagePath(r)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	name, rest, err := SplitPackagePath(path)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	info, e := h.GetArtifactInfo(r, name)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+
+	var result npm.Response
+	switch {
+	case len(rest) == 2 && rest[0] == revSegment:
+		result = h.Controller.UnpublishPackage(ctx, info)
+	case len(rest) == 4 && rest[0] == tarballSegment && rest[2] == revSegment:
+		version, err := npmutils.ParseTarballFileName(name, rest[1])
+		if err != nil {
+			handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+			return
+		}
+		info.Version = version
+		info.FileName = rest[1]
+		result = h.Controller.DeleteTarball(ctx, info)
+	default:
+		handleError(ctx, errcode.ErrCodeNameUnknown.WithDetail(fmt.Errorf("unknown path %s", path)), w)
+		return
+	}
+	if !commons.IsEmpty(result.GetErrors()) {
+		handleErrors(ctx, result.GetErrors(), w)
+		return
+	}
+	writeOK(ctx, w, http.StatusOK)
+}
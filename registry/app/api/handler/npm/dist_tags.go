@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+)
+
+// maxDistTagBodySize bounds the body of a dist-tag update, a JSON string holding a version.
+const maxDistTagBodySize = 1 << 10
+
+// DistTags handles `npm dist-tag`:
+// GET /-/package/:package/dist-tags, PUT and DELETE /-/package/:package/dist-tags/:tag.
+func (h *Handler) DistTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, ok := h.distTagArtifactInfo(w, r)
+	if !ok {
+		return
+	}
+
+	var result npm.Response
+	switch r.Method {
+	case http.MethodGet:
+		if info.DistTag != "" {
+			handleError(ctx, errcode.ErrCodeUnsupported.WithDetail(
+				fmt.Errorf("reading a single dist-tag is not supported"),
+			), w)
+			return
+		}
+		result = h.Controller.ListDistTags(ctx, info)
+	case http.MethodPut:
+		version, err := readDistTagVersion(r)
+		if err != nil {
+			handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+			return
+		}
+		result = h.Controller.PutDistTag(ctx, info, version)
+	case http.MethodDelete:
+		result = h.Controller.DeleteDistTag(ctx, info)
+	default:
+		handleError(ctx, errcode.ErrCodeUnsupported.WithDetail(
+			fmt.Errorf("method %s is not supported", r.Method),
+		), w)
+		return
+	}
+
+	response, ok := result.(*npm.DistTagsResponse)
+	if !ok {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(
+			fmt.Errorf("failed to get dist-tags of %s", info.Image),
+		), w)
+		return
+	}
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	writeJSON(ctx, w, http.StatusOK, response.DistTags)
+}
+
+func (h *Handler) distTagArtifactInfo(w http.ResponseWriter, r *http.Request) (pkg.NpmArtifactInfo, bool) {
+	ctx := r.Context()
+	path, err := packagePath(r)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return pkg.NpmArtifactInfo{}, false
+	}
+	name, rest, err := SplitPackagePath(strings.TrimPrefix(path, packagePathPrefix))
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return pkg.NpmArtifactInfo{}, false
+	}
+	if len(rest) == 0 || len(rest) > 2 || rest[0] != distTagsSegment {
+		handleError(ctx, errcode.ErrCodeNameUnknown.WithDetail(fmt.Errorf("unknown path %s", path)), w)
+		return pkg.NpmArtifactInfo{}, false
+	}
+	info, e := h.GetArtifactInfo(r, name)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return pkg.NpmArtifactInfo{}, false
+	}
+	if len(rest) == 2 {
+		info.DistTag = rest[1]
+	} else if r.Method != http.MethodGet {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("dist-tag is missing")), w)
+		return pkg.NpmArtifactInfo{}, false
+	}
+	return info, true
+}
+
+// readDistTagVersion reads the version npm sends as a JSON string.
+func readDistTagVersion(r *http.Request) (string, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxDistTagBodySize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	var version string
+	if err := json.Unmarshal(body, &version); err != nil {
+		return "", fmt.Errorf("request body must be a JSON string holding a version: %w", err)
+	}
+	return version, nil
+}
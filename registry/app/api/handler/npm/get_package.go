@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	npmutils "github.com/harness/gitness/registry/app/pkg/npm/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetPackage serves the packument of a package (GET /:package), a single
+// version of it (GET /:package/:version|:tag) or one of its tarballs
+// (GET /:package/-/:file).
+func (h *Handler) GetPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	path, err := packagePath(r)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	name, rest, err := SplitPackagePath(path)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	info, e := h.GetArtifactInfo(r, name)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+
+	switch {
+	case len(rest) == 0:
+		h.getPackument(w, r, info)
+	case len(rest) == 1:
+		h.getVersion(w, r, info, rest[0])
+	case len(rest) == 2 && rest[0] == tarballSegment:
+		h.getTarball(w, r, info, rest[1])
+	default:
+		handleError(ctx, errcode.ErrCodeNameUnknown.WithDetail(fmt.Errorf("unknown path %s", path)), w)
+	}
+}
+
+func (h *Handler) getPackument(w http.ResponseWriter, r *http.Request, info pkg.NpmArtifactInfo) {
+	ctx := r.Context()
+	response, ok := h.Controller.GetPackument(ctx, info).(*npm.GetPackumentResponse)
+	if !ok {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to get package %s", info.Image)), w)
+		return
+	}
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	writeJSON(ctx, w, http.StatusOK, response.Packument)
+}
+
+// getVersion serves the version document of a version, or of the version a
+// dist-tag points at.
+func (h *Handler) getVersion(w http.ResponseWriter, r *http.Request, info pkg.NpmArtifactInfo, version string) {
+	ctx := r.Context()
+	response, ok := h.Controller.GetPackument(ctx, info).(*npm.GetPackumentResponse)
+	if !ok {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to get package %s", info.Image)), w)
+		return
+	}
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	if tagged, ok := response.Packument.DistTags[version]; ok {
+		version = tagged
+	}
+	raw, ok := response.Packument.Versions[version]
+	if !ok {
+		handleErrors(ctx, []error{commons.NotFoundError(
+			fmt.Sprintf("version not found: %s@%s", info.Image, version), nil,
+		)}, w)
+		return
+	}
+	w.Header().Set("Content-Type", npmutils.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(raw); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to write npm version")
+	}
+}
+
+func (h *Handler) getTarball(w http.ResponseWriter, r *http.Request, info pkg.NpmArtifactInfo, fileName string) {
+	ctx := r.Context()
+	version, err := npmutils.ParseTarballFileName(info.Image, fileName)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	info.Version = version
+	info.FileName = fileName
+
+	response, ok := h.Controller.GetTarball(ctx, info).(*npm.GetTarballResponse)
+	if !ok {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to get tarball %s", fileName)), w)
+		return
+	}
+	defer func() {
+		if response.Body != nil {
+			if err := response.Body.Close(); err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close body: %v", err)
+			}
+		}
+		if response.ReadCloser != nil {
+			if err := response.ReadCloser.Close(); err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close readCloser: %v", err)
+			}
+		}
+	}()
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	if !commons.IsEmpty(response.RedirectURL) {
+		http.Redirect(w, r, response.RedirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+	response.ResponseHeaders.WriteHeadersToResponse(w)
+	if response.Body != nil {
+		http.ServeContent(w, r, fileName, time.Time{}, response.Body)
+		return
+	}
+	if _, err := io.Copy(w, response.ReadCloser); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error copying tarball to response")
+	}
+}
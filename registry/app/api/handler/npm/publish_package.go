@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	npmutils "github.com/harness/gitness/registry/app/pkg/npm/utils"
+)
+
+// PutPackage handles `npm publish` (PUT /:package), and the packument updates
+// npm sends to deprecate or unpublish versions (PUT /:package/-rev/:rev).
+func (h *Handler) PutPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	path, err := packagePath(r)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	name, rest, err := SplitPackagePath(path)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	update := len(rest) == 2 && rest[0] == revSegment
+	if len(rest) != 0 && !update {
+		handleError(ctx, errcode.ErrCodeNameUnknown.WithDetail(fmt.Errorf("unknown path %s", path)), w)
+		return
+	}
+	info, e := h.GetArtifactInfo(r, name)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+
+	packument := &npmutils.Packument{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPublishSize)).Decode(packument); err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("failed to parse package document: %w", err),
+		), w)
+		return
+	}
+	if packument.Name != name {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("package name %q does not match the path", packument.Name),
+		), w)
+		return
+	}
+
+	var result npm.Response
+	status := http.StatusCreated
+	if update {
+		result = h.Controller.UpdatePackage(ctx, info, packument)
+		status = http.StatusOK
+	} else {
+		result = h.Controller.PublishPackage(ctx, info, packument)
+	}
+	if !commons.IsEmpty(result.GetErrors()) {
+		handleErrors(ctx, result.GetErrors(), w)
+		return
+	}
+	writeOK(ctx, w, status)
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+)
+
+// WhoAmI answers `npm whoami` with the principal the token belongs to.
+func (h *Handler) WhoAmI(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session, _ := request.AuthSessionFrom(ctx)
+	writeJSON(ctx, w, http.StatusOK, map[string]string{"username": session.Principal.UID})
+}
+
+// Ping answers `npm ping`.
+func (h *Handler) Ping(w http.ResponseWriter, r *http.Request) {
+	writeJSON(r.Context(), w, http.StatusOK, map[string]any{})
+}
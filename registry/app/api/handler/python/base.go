@@ -0,0 +1,204 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/auth/authz"
+	corestore "github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/api/handler/utils"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/python"
+	pythonutils "github.com/harness/gitness/registry/app/pkg/python/utils"
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	pathPrefix = "/python/"
+	// maxUploadSize limits the size of an upload request.
+	maxUploadSize = 1 << 30
+	// maxUploadMemory is the part of an upload kept in memory, the rest is
+	// spooled to disk.
+	maxUploadMemory = 32 << 20
+)
+
+type Handler struct {
+	Controller    *python.Controller
+	SpaceStore    corestore.SpaceStore
+	Authenticator authn.Authenticator
+	Authorizer    authz.Authorizer
+	URLProvider   urlprovider.Provider
+}
+
+func NewHandler(
+	controller *python.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, urlProvider urlprovider.Provider,
+) *Handler {
+	return &Handler{
+		Controller:    controller,
+		SpaceStore:    spaceStore,
+		Authenticator: authenticator,
+		Authorizer:    authorizer,
+		URLProvider:   urlProvider,
+	}
+}
+
+// GetArtifactInfo resolves the registry addressed by the request and validates
+// the name of the project the request is about. The name is normalized.
+func (h *Handler) GetArtifactInfo(r *http.Request, projectName string) (pkg.PythonArtifactInfo, errcode.Error) {
+	if err := pythonutils.ValidateName(projectName); err != nil {
+		return pkg.PythonArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+	projectName = pythonutils.NormalizeName(projectName)
+
+	info, registry, e := h.getRegistryInfo(r)
+	if !commons.IsEmptyError(e) {
+		return pkg.PythonArtifactInfo{}, e
+	}
+
+	flag, err := utils.MatchArtifactFilter(registry.AllowedPattern, registry.BlockedPattern, projectName)
+	if !flag || err != nil {
+		return pkg.PythonArtifactInfo{}, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	info.Image = projectName
+	return info, errcode.Error{}
+}
+
+// getRegistryInfo resolves the registry addressed by the request.
+func (h *Handler) getRegistryInfo(r *http.Request) (
+	pkg.PythonArtifactInfo, *types.Registry, errcode.Error,
+) {
+	ctx := r.Context()
+	rootIdentifier, registryIdentifier, err := ExtractPathVars(r.URL.Path)
+	if err != nil {
+		return pkg.PythonArtifactInfo{}, nil, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	if err := metadata.ValidateIdentifier(registryIdentifier); err != nil {
+		return pkg.PythonArtifactInfo{}, nil, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	rootSpace, err := h.SpaceStore.FindByRefCaseInsensitive(ctx, rootIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Root space not found: %s", rootIdentifier)
+		return pkg.PythonArtifactInfo{}, nil, errcode.ErrCodeRootNotFound.WithDetail(err)
+	}
+
+	registry, err := h.Controller.DBStore.RegistryDao.GetByRootParentIDAndName(ctx, rootSpace.ID, registryIdentifier)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s not found for root: %s. Reason: %s", registryIdentifier, rootSpace.Identifier, err,
+		)
+		return pkg.PythonArtifactInfo{}, nil, errcode.ErrCodeRegNotFound.WithDetail(err)
+	}
+
+	if registry.PackageType != artifact.PackageTypePYTHON {
+		log.Ctx(ctx).Error().Msgf(
+			"registry %s is not a python registry for root: %s", registryIdentifier, rootSpace.Identifier,
+		)
+		return pkg.PythonArtifactInfo{}, nil, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("registry %s is not a python registry", registryIdentifier),
+		)
+	}
+
+	_, err = h.SpaceStore.Find(ctx, registry.ParentID)
+	if err != nil {
+		log.Ctx(ctx).Error().Msgf("Parent space not found: %d", registry.ParentID)
+		return pkg.PythonArtifactInfo{}, nil, errcode.ErrCodeParentNotFound.WithDetail(err)
+	}
+
+	info := pkg.PythonArtifactInfo{
+		ArtifactInfo: &pkg.ArtifactInfo{
+			BaseInfo: &pkg.BaseInfo{
+				PathRoot:       rootIdentifier,
+				RootIdentifier: rootIdentifier,
+				RootParentID:   rootSpace.ID,
+				ParentID:       registry.ParentID,
+			},
+			RegIdentifier: registryIdentifier,
+		},
+		RegistryID:  registry.ID,
+		RegistryURL: h.URLProvider.RegistryURL(ctx, rootIdentifier, "python", registryIdentifier),
+	}
+
+	log.Ctx(ctx).Info().Msgf("Dispatch: URI: %s", r.URL.Path)
+	return info, registry, errcode.Error{}
+}
+
+// ExtractPathVars extracts the root space and the registry from the path.
+// Path format: /python/:rootSpace/:registry/... (for ex:
+// /python/myRootSpace/reg1/simple/requests/).
+func ExtractPathVars(path string) (rootIdentifier, registry string, err error) {
+	if !strings.HasPrefix(path, pathPrefix) {
+		return "", "", fmt.Errorf("invalid path: must start with %s", pathPrefix)
+	}
+
+	segments := strings.SplitN(strings.TrimPrefix(path, pathPrefix), "/", 3)
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("invalid path format: missing rootIdentifier or registry")
+	}
+	return segments[0], segments[1], nil
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, contentType string, body any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to encode python response")
+	}
+}
+
+// handleErrors writes the first error as plain text, which is what twine and
+// pip print when a request fails.
+func handleErrors(ctx context.Context, errs []error, w http.ResponseWriter) {
+	if commons.IsEmpty(errs) {
+		return
+	}
+	log.Ctx(ctx).Error().Errs("errs occurred during python operation: ", errs).Msgf("Error occurred")
+
+	status := http.StatusInternalServerError
+	message := errs[0].Error()
+	var commonsErr *commons.Error
+	var coder errcode.ErrorCoder
+	switch {
+	case errors.As(errs[0], &commonsErr):
+		status = commonsErr.Status
+		message = commonsErr.Message
+	case errors.As(errs[0], &coder):
+		status = coder.ErrorCode().Descriptor().HTTPStatusCode
+	}
+	http.Error(w, message, status)
+}
+
+func handleError(ctx context.Context, err errcode.Error, w http.ResponseWriter) {
+	if !commons.IsEmptyError(err) {
+		handleErrors(ctx, []error{err}, w)
+	}
+}
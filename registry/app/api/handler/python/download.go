@@ -0,0 +1,89 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/python"
+	pythonutils "github.com/harness/gitness/registry/app/pkg/python/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// DownloadFile serves a distribution file (GET /files/:project/:version/:file).
+func (h *Handler) DownloadFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	fileName := chi.URLParam(r, "filename")
+	name, version, _, err := pythonutils.ParseFileName(fileName)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(err), w)
+		return
+	}
+	info, e := h.GetArtifactInfo(r, chi.URLParam(r, "project"))
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+	if name != info.Image || !strings.EqualFold(version, chi.URLParam(r, "version")) {
+		handleError(ctx, errcode.ErrCodeNameUnknown.WithDetail(
+			fmt.Errorf("file %s does not belong to %s", fileName, info.Image)), w)
+		return
+	}
+	info.Version = version
+	info.FileName = fileName
+
+	response, ok := h.Controller.DownloadFile(ctx, info).(*python.GetFileResponse)
+	if !ok {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to get file %s", fileName)), w)
+		return
+	}
+	defer func() {
+		if response.Body != nil {
+			if err := response.Body.Close(); err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close body: %v", err)
+			}
+		}
+		if response.ReadCloser != nil {
+			if err := response.ReadCloser.Close(); err != nil {
+				log.Ctx(ctx).Error().Msgf("Failed to close readCloser: %v", err)
+			}
+		}
+	}()
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	if !commons.IsEmpty(response.RedirectURL) {
+		http.Redirect(w, r, response.RedirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+	response.ResponseHeaders.WriteHeadersToResponse(w)
+	if response.Body != nil {
+		http.ServeContent(w, r, fileName, time.Time{}, response.Body)
+		return
+	}
+	if _, err := io.Copy(w, response.ReadCloser); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error copying file to response")
+	}
+}
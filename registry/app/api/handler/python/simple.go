@@ -0,0 +1,108 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/python"
+	pythonutils "github.com/harness/gitness/registry/app/pkg/python/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ListProjects serves the root page of the simple index (GET /simple/).
+func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	info, _, e := h.getRegistryInfo(r)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+
+	response, ok := h.Controller.ListProjects(ctx, info).(*python.ProjectListResponse)
+	if !ok {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to list projects")), w)
+		return
+	}
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	contentType := negotiateContentType(w, r)
+	if contentType == pythonutils.ContentTypeSimpleJSON {
+		writeJSON(ctx, w, contentType, response.ProjectList)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	if err := pythonutils.RenderProjectListHTML(w, response.ProjectList); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to write python project list")
+	}
+}
+
+// GetProject serves the page of a project in the simple index
+// (GET /simple/:project/). Requests for a name which isn't normalized are
+// redirected to the normalized one.
+func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := chi.URLParam(r, "project")
+	info, e := h.GetArtifactInfo(r, name)
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+	if name != info.Image || !strings.HasSuffix(r.URL.Path, "/") {
+		target := strings.TrimRight(info.RegistryURL, "/") + "/simple/" + info.Image + "/"
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return
+	}
+
+	response, ok := h.Controller.GetProject(ctx, info).(*python.GetProjectResponse)
+	if !ok {
+		handleError(ctx, errcode.ErrCodeUnknown.WithDetail(fmt.Errorf("failed to get project %s", info.Image)), w)
+		return
+	}
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+
+	contentType := negotiateContentType(w, r)
+	if contentType == pythonutils.ContentTypeSimpleJSON {
+		writeJSON(ctx, w, contentType, response.Project)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	if err := pythonutils.RenderProjectHTML(w, response.Project); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to write python project")
+	}
+}
+
+// negotiateContentType picks the format of a simple index page (PEP 691).
+func negotiateContentType(w http.ResponseWriter, r *http.Request) string {
+	w.Header().Add("Vary", "Accept")
+	return pythonutils.NegotiateContentType(
+		r.Header.Get("Accept"), r.URL.Query().Get(pythonutils.FormatQueryParam),
+	)
+}
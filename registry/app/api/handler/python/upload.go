@@ -0,0 +1,86 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	pythonutils "github.com/harness/gitness/registry/app/pkg/python/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	actionField     = ":action"
+	actionUpload    = "file_upload"
+	nameField       = "name"
+	versionField    = "version"
+	fileTypeField   = "filetype"
+	sha256Field     = "sha256_digest"
+	contentFormFile = "content"
+)
+
+// UploadPackage handles the legacy upload API used by twine (POST / or
+// POST /legacy/), a multipart form with the file and its core metadata.
+func (h *Handler) UploadPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("failed to parse upload form: %w", err)), w)
+		return
+	}
+	defer func() {
+		if err := r.MultipartForm.RemoveAll(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to remove the files of the upload form")
+		}
+	}()
+
+	if action := r.FormValue(actionField); action != actionUpload {
+		handleError(ctx, errcode.ErrCodeUnsupported.WithDetail(fmt.Errorf("unsupported action %q", action)), w)
+		return
+	}
+	fileType := pythonutils.FileType(r.FormValue(fileTypeField))
+	if fileType != pythonutils.FileTypeWheel && fileType != pythonutils.FileTypeSdist {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("unsupported file type %q", fileType)), w)
+		return
+	}
+	file, header, err := r.FormFile(contentFormFile)
+	if err != nil {
+		handleError(ctx, errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf("file is missing: %w", err)), w)
+		return
+	}
+	defer file.Close()
+
+	info, e := h.GetArtifactInfo(r, r.FormValue(nameField))
+	if !commons.IsEmptyError(e) {
+		handleError(ctx, e, w)
+		return
+	}
+	info.Version = r.FormValue(versionField)
+	info.FileName = path.Base(header.Filename)
+
+	response := h.Controller.UploadPackage(ctx, info, file, header.Size, r.FormValue(sha256Field))
+	if !commons.IsEmpty(response.GetErrors()) {
+		handleErrors(ctx, response.GetErrors(), w)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -126,6 +126,16 @@ func CheckMavenAuthHeader() func(http.Handler) http.Handler {
 	}
 }
 
+// CheckHelmAuth rejects anonymous requests with a basic auth challenge. Helm
+// clients authenticate with basic auth, the same way maven clients do.
+func CheckHelmAuth() func(http.Handler) http.Handler {
+	return CheckMavenAuth()
+}
+
+func CheckHelmAuthHeader() func(http.Handler) http.Handler {
+	return CheckMavenAuthHeader()
+}
+
 func setMavenHeaders(w http.ResponseWriter) {
 	w.Header().Set("WWW-Authenticate", "Basic realm=\"Harness Registry\"")
 }
@@ -71,6 +71,9 @@ type ServerInterface interface {
 	// Describe Docker Artifact Manifests
 	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/docker/manifests)
 	GetDockerArtifactManifests(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam)
+	// List Docker Artifact Referrers
+	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/docker/referrers)
+	GetDockerArtifactReferrers(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetDockerArtifactReferrersParams)
 	// Describe Artifact files
 	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/files)
 	GetArtifactFiles(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetArtifactFilesParams)
@@ -227,6 +230,12 @@ func (_ Unimplemented) GetDockerArtifactManifests(w http.ResponseWriter, r *http
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List Docker Artifact Referrers
+// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/docker/referrers)
+func (_ Unimplemented) GetDockerArtifactReferrers(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetDockerArtifactReferrersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Describe Artifact files
 // (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/files)
 func (_ Unimplemented) GetArtifactFiles(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetArtifactFilesParams) {
@@ -1036,6 +1045,69 @@ func (siw *ServerInterfaceWrapper) GetDockerArtifactManifests(w http.ResponseWri
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetDockerArtifactReferrers operation middleware
+func (siw *ServerInterfaceWrapper) GetDockerArtifactReferrers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "registry_ref" -------------
+	var registryRef RegistryRefPathParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "registry_ref", runtime.ParamLocationPath, chi.URLParam(r, "registry_ref"), &registryRef)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registry_ref", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "artifact" -------------
+	var artifact ArtifactPathParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "artifact", runtime.ParamLocationPath, chi.URLParam(r, "artifact"), &artifact)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "artifact", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version VersionPathParam
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDockerArtifactReferrersParams
+
+	// ------------- Optional query parameter "digest" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "digest", r.URL.Query(), &params.Digest)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "digest", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "artifact_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "artifact_type", r.URL.Query(), &params.ArtifactType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "artifact_type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDockerArtifactReferrers(w, r, registryRef, artifact, version, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetArtifactFiles operation middleware
 func (siw *ServerInterfaceWrapper) GetArtifactFiles(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -2189,6 +2261,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/artifact/{artifact}/version/{version}/docker/manifests", wrapper.GetDockerArtifactManifests)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/registry/{registry_ref}/artifact/{artifact}/version/{version}/docker/referrers", wrapper.GetDockerArtifactReferrers)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/artifact/{artifact}/version/{version}/files", wrapper.GetArtifactFiles)
 	})
@@ -2329,6 +2404,14 @@ type DockerManifestsResponseJSONResponse struct {
 	Status Status `json:"status"`
 }
 
+type DockerReferrersResponseJSONResponse struct {
+	// Data Harness Referrers
+	Data DockerReferrers `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 type FileDetailResponseJSONResponse struct {
 	// Files A list of Harness Artifact Files
 	Files []FileDetail `json:"files"`
@@ -3519,6 +3602,75 @@ func (response GetDockerArtifactManifests500JSONResponse) VisitGetDockerArtifact
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetDockerArtifactReferrersRequestObject struct {
+	RegistryRef RegistryRefPathParam `json:"registry_ref"`
+	Artifact    ArtifactPathParam    `json:"artifact"`
+	Version     VersionPathParam     `json:"version"`
+	Params      GetDockerArtifactReferrersParams
+}
+
+type GetDockerArtifactReferrersResponseObject interface {
+	VisitGetDockerArtifactReferrersResponse(w http.ResponseWriter) error
+}
+
+type GetDockerArtifactReferrers200JSONResponse struct {
+	DockerReferrersResponseJSONResponse
+}
+
+func (response GetDockerArtifactReferrers200JSONResponse) VisitGetDockerArtifactReferrersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDockerArtifactReferrers400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetDockerArtifactReferrers400JSONResponse) VisitGetDockerArtifactReferrersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDockerArtifactReferrers401JSONResponse struct{ UnauthenticatedJSONResponse }
+
+func (response GetDockerArtifactReferrers401JSONResponse) VisitGetDockerArtifactReferrersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDockerArtifactReferrers403JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetDockerArtifactReferrers403JSONResponse) VisitGetDockerArtifactReferrersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDockerArtifactReferrers404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetDockerArtifactReferrers404JSONResponse) VisitGetDockerArtifactReferrersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDockerArtifactReferrers500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetDockerArtifactReferrers500JSONResponse) VisitGetDockerArtifactReferrersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetArtifactFilesRequestObject struct {
 	RegistryRef RegistryRefPathParam `json:"registry_ref"`
 	Artifact    ArtifactPathParam    `json:"artifact"`
@@ -4712,6 +4864,9 @@ type StrictServerInterface interface {
 	// Describe Docker Artifact Manifests
 	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/docker/manifests)
 	GetDockerArtifactManifests(ctx context.Context, request GetDockerArtifactManifestsRequestObject) (GetDockerArtifactManifestsResponseObject, error)
+	// List Docker Artifact Referrers
+	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/docker/referrers)
+	GetDockerArtifactReferrers(ctx context.Context, request GetDockerArtifactReferrersRequestObject) (GetDockerArtifactReferrersResponseObject, error)
 	// Describe Artifact files
 	// (GET /registry/{registry_ref}/artifact/{artifact}/version/{version}/files)
 	GetArtifactFiles(ctx context.Context, request GetArtifactFilesRequestObject) (GetArtifactFilesResponseObject, error)
@@ -5257,6 +5412,35 @@ func (sh *strictHandler) GetDockerArtifactManifests(w http.ResponseWriter, r *ht
 	}
 }
 
+// GetDockerArtifactReferrers operation middleware
+func (sh *strictHandler) GetDockerArtifactReferrers(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetDockerArtifactReferrersParams) {
+	var request GetDockerArtifactReferrersRequestObject
+
+	request.RegistryRef = registryRef
+	request.Artifact = artifact
+	request.Version = version
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDockerArtifactReferrers(ctx, request.(GetDockerArtifactReferrersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDockerArtifactReferrers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDockerArtifactReferrersResponseObject); ok {
+		if err := validResponse.VisitGetDockerArtifactReferrersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetArtifactFiles operation middleware
 func (sh *strictHandler) GetArtifactFiles(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, artifact ArtifactPathParam, version VersionPathParam, params GetArtifactFilesParams) {
 	var request GetArtifactFilesRequestObject
@@ -5768,96 +5952,104 @@ func (sh *strictHandler) GetAllRegistries(w http.ResponseWriter, r *http.Request
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xdW3PbuJL+KyzuPjKW55zsPvhNkeWJauzEK9s5NTXlcsEkJPGEIjkAaEcnpf++hRsJ",
-	"kgAJ6u6YT3FEXBqNrxsNoLvx0/WTZZrEMCbYvfjppgCBJSQQsf9dg2cY4Vv6G/1vALGPwpSESexe8I9n",
-	"rueG9H9/ZxCtXM+NwRK6F25EP7qei/0FXAJaOSRwyRolq5SWwASF8dxde/IHgBBYueu1507hPMQErSYB",
-	"jEk4CyEykCALOkVJAz0Izp9CtdBWhN2vUthGEi1jIIbwTwUJMM6W7sVf7rfJ9P5heO167sPt3f10PLxx",
-	"H70qXWvPBYiEM+ATAw1D9pkYepeVSxQ09UEWhn6+gCV0kpkji+ZgSAFZaDtE8O8sRDBwLwjKYDMB/iKM",
-	"gm8Q4TCJDQSMaBHnhZdxwtgHmBF0mfjfIcrpwiaUql20sCMI5xCbGH7JPpp64VU7jn6GkuUlICaY0U9n",
-	"zlWCloA4H5ybm8Hl5eDPP//800ADba5lhBEgEBPJDY2408+O+O5chRGByCz+tPDTi5m1z0kSQRCznlPg",
-	"fwdzaCNVt7xok3SJ1p5qUtZB0FMwh1+y5TNEGtBlCMGYOLSME/NCJkrmZQoCOANZRNyL3zx3xubOvXDD",
-	"mPzvRzcnIowJnEOUk3EX/gdqRI/1S7HORuWkEDmiOx0lmDaipeQf53akIOhnCIcvphn61wKSBUQOSZwo",
-	"xMRBfMZCiJ28arQ6M6pnUURP5AxEGHo66IhuVlM4a1BUD3H4dwYlTSuH6ieDspJlnhCcdRRZDAHyF/cQ",
-	"aSjg3xz60cQDXuSJ0PotHSWIXIUwCjT95J8MnSSIPM1EgbY+vqJAJwDFp4Y+ElGgsY8U+NBq5ljJpmlj",
-	"BTaZM0HC/9Eh2NJgGrdCQ1OfJNmhYidJS28vjStosfjpGn+xWhrzHloNhaFYkOUqYpjMotsuU/kKnxdJ",
-	"8n38A/oZ7XcStONK1HGgrOQURqKBOFHlKa/yFAabUaqat7aEWpNXMnbtiVvzwhCTT0kQQrZcylljBv+U",
-	"f6W/+0lMYMz+BGkahT6gNA/+jbn5UHTy31QmLtz/GhR7jQH/igfaxhkdZT4Iquj6kqUBIDC37hy218Cu",
-	"Yp/vmshquw30zRLk+AgyAuNA0ipXFUrkv/gM7ZrGSrOdSRTAEasqTpMYl6f/EhIQRlPxqRPdKUpSiIjA",
-	"UwCINSx4p5RtmACS4bZ6d7yUxDEH/V+yssf7LvZUyfO/oW9gFh8nBdwckgJtAaOIwa2C3YMy5i5bLgEH",
-	"1KlwhsmhIz+rDKJ940MziPZ5SuyhTWE9e/hc9gjCBUmSSmEpHIdF5c5PgFNB+WAjP/pQGPcJBLteWsYI",
-	"JUhH3icQOEguOJ47ikIYkztIspTr7UPJfL3jY84VW18ZRQ6mJKlLBj+ZOsqSquv6BCEd5ISVCb4BcTiD",
-	"mByFW7LzE+TXUiGNE30NVhDhg/KJd3mSNgklrOCNnMjDsifv9TRZcxVGcGeqaBZGgj3lOwl+LJjMnM8A",
-	"xRDj4jDgitXwivPZJr4UtNYPbnkToyTjVJcJuF9QFhAQiTPb/OzU9Vz4AyzTCNqdy/Jj2Q690OLlXs7P",
-	"rfuZxAH8oe/HVw6i1ebtG9efLdO2Y/P5ssqserM7RLcnsLQVynkTa8/9DKPlUdbdescnoAUWMFrq1lyV",
-	"2AOvuLquT45T6mo7iQlEMYjuIHqBiBvJeze5ZacOZr06kBf03OsQk2McSNT6PbbpzdYZzeGgSugReHNS",
-	"bKnyQ2x0j8AWeQFxCtwRu2ms+jRITskj4CMgqNr1SSKpOCI/OF9Ogh/qCT8lThzH4/w66oCMqfV9jH0H",
-	"44q4VMDFBVv5BFSl9ggMOgnkvCrEfEnIVZLFwf7tCGrk4xT64SyEAZ2TJEM+dF4BduKEODNGRelK7SCz",
-	"cyoyze/HPL6X0N/j3WW+DzHegiG7GKDNyASlzlSRvIcYZGQBY0KJhQcAXLXDnIYEhf85HAGit+Ie9tAK",
-	"utrtEZBe93pQdXJ+kXxIdpyovKuX4oIAdiXO5OkPuLqDPoLkD7iqDx7IMlqPQ1BuQfFftih9lwIfTgKl",
-	"qHIAoyt7C8hC2zCW9LcQkJdr7LpcytBpdd40FDyuPXcYJ/FqmTA8KNeB4gDF4PLsE0cU8NwgpN+XYQwI",
-	"35cvQZpSCi5+updfR3+Mp10uSkZJPAvnruf+Pv4ynk5Gprq/wxii0DdU/jy+vrE/Jsqr3Qy/jb+Y6t2A",
-	"FxhrK649CcnVl5JrLHOeXXtuEsOvM/fir+73RnkPXY++LCs2sbGtbgNDHr2KkHKxD4ZEi3rx9ZNehIPk",
-	"NY4SEOQnwRaHrsskYAaXoUPuw6X5oE5ci368Lc8xFke8tSZfCkfvZgkNl2AOGYC8kmcet4RuufdZhqjM",
-	"qWTWVbBn9CQrT4o4sunmsa1SLBpoouAGEiCXJ4MmyYtUQSMnHneZ+e6DonUwuRGIORRe0iyKRslyCWJ9",
-	"l6gWm9NYzLjyWMMv5sjT9FsNWaj02jT93DmoNve1qylezgSALvMv68grF4sq7B7pjiRIuamxqJalnfpZ",
-	"N7FJXOhaMEqU7KZgN5KkQh/pmtxEzlq08qbC1KBHbRWlgLaFtpJxOWatxaKQckabEdppMmZhBDtNHq6F",
-	"GVWjOn5tvWdYjw+h9CrebPXQA+5ZUoOUSbabBTHE1+0zvfl8bWm+tApgRhaSqorEFacYlDkiykxGcD5g",
-	"iG4Bxq8JClxPt1FUtzb14E7PHVHeZOltEoW+ZpLEZ4d/Z3vXmjKe5rFXtTmDP9IQwUuwwnol0CZZtwjO",
-	"wh/d1KuMD+lcVbc0aVz9NDxizneskCNLVTmxBGH8GYLAvONt/srP1dXRWHoo3vG6rVasQqBKjtL5YzN/",
-	"ZEfN/JGlmnfMky/Xky9jm9ERmOYb1/vhpztTnXvwXK1Q37CSTjtVPRlt+0UdIbWN4mJTpBAL1SamQGs1",
-	"EJOGqgy2bZZpkZpxxte7zVDMuMXXS43ML7bjSKWjnDNtXFBW8BZmOLKop9t96rcsIMoMa3c7XQxXG8wR",
-	"JjDdeII6q9Sc2QZKS4Wqax/dLIW+67ns9AYQeJ98h7F2kdP6IrcaJPnZ3pH3GFamzZ42FfaWalcTlB/i",
-	"nMpRUcOpYx2b7HdmC+m9yeurfzMT160E5Q5qraDNS9YNkKKJZrbmJc2MYg7Y45hYbda5t7ZpOdhi2yJb",
-	"aKETt54r8GLGnYfIqKHf/69E8horhVnjnmYtS/AQ+RZXGYIq8+AlFIyGq/VMNWs8M3c2UobG8dvCIk+A",
-	"EsnhiCbbWdXApKLIHjamS7X/DkiqTrF5O7SZNtVxLPc8rYpzYHDuXhCScsdRhxVSfLrdj+cKCBTgmCA7",
-	"DIKQ/gkiqWsd8JxkxCELKJxTNSQvIcZgbiAPQYDpzpr9KUKWQRjBwPVa9Q8bjWxdy6wfBIHCXq8kMREX",
-	"46yQk2+4ynz9brjS7WIeKlEMNSLoN6Ops4D+d5wtOx5w2llITUaF8Vyg03mWSENTjKLeuUqsbv6abgab",
-	"DIM5r9duGZRasJpMjYN/XWnBaNlqyOY+lQ2XkTu1cns7du92rPEKvAmsuuiMXdiw2hCLFqzu234t+cg3",
-	"hI3JMtgoNtimum2cWe2iuI82O7loswrMChy04exanvFYxyjyTJh1S/MgCNjkTrNHjSVqGpxFdHEqFiom",
-	"jyMxaqpvskDH1jpprurdca/A3r4Cy93ju+iuhuvAHgDHjpcu8mRuPqdWakFCx6wPKmhUKGuD4wnab1XS",
-	"ejX4C6nBPH7LQmQKSSkirXo1eGpq8NViRvUzaaUNlCiTRp2Xt9uGPCW0cjMMKhGRGicdm8ZbG+3CmVI4",
-	"Uq8fT1o/KpOsg6k5/mEn56OywER/ajdHSZZObA+kbsunh9UArRlE2CGJIw7ZFF8DEcEjY2OKuBwRZKNz",
-	"OjAbzybzqn7zCKIoeYXBLSAEorjblvw5SvzvG9b1q16Blu4gai1dszkwbGypwk2r5TC68Qjdc8NmH9oT",
-	"cuE2nSXrrzdKOZeFc4zdKbHRWn3T4SkmV++9OnLvxlF7n/7YFXGqTfFd9iwUswjQ95mG/hYikoHISZDz",
-	"kGKCIFiqeqrJezN/TcbAQtle7rgpH6IxlBek7Mhts9pac+kKrXVXTRv/QvUhH3uXy9oW2n4hMYtrvtvp",
-	"FE3UomE3uw7cvVpuVRFbXB+argWl/N2Zrge7A8RyGRAqXx1TZVGgzTQhyxgT2dsdx7IstkEo3UhMafnW",
-	"C2eN5WBrM7QZz7Qi3XLkr9+EZ9B5KRaTTChU3Rpi0Oud3ivzXNW/vYHQUs5kEeQgfYy7UibiFUQIgpao",
-	"PNtENcFewDKUYCeclXyQXgF2MM+kMssY5+KEqO7PD6PR+O7O9dyr4eT6YUp7H0+nX6fa7tWoA81mETwL",
-	"p3CscwpfHD4ypQY/TdhEyzAcX5oXlQUbPNuTW+KbHaEonM913l6KiIgixWQOp/eTq+Ho/mk0HQ/vJ1/p",
-	"FjP/7ebr5eRqMqr9fjm+HrPfdBNesVsMe/EM8bAybVyXbOIWJT90tzkg40uindlVClVrs7qKmLXWkvWQ",
-	"t/Xj2mPE2SjRPPKOPUKVIR+qLyRyL8tF9ux67ijDhL1qN3zFY5/OHjv6GMGYIBBpJ8FqGc8prWldz/3x",
-	"oaSJPginv0L/0ZlWGVtP+mST8AW353nBFuldMgyRwQ+2Mua8JJ2qskHeAalyi2Jz9ZhVwLxlXKA84TWa",
-	"5FM4F6elsugWyUB2YKLDGDxHJatYcbqDhZeqvWpUXVt1p7jNkAtjDP0MQT1BoUg7awrYpltgNZMWe7bP",
-	"+uRZVNgiQcohpUqsFh3WLLG8aCbFwlXR7nVGgy3Jj5/E8YWEnDLZj2ZR4hOT70fapOrz/f2tFC1H1quK",
-	"2HMS6J2nFwXW7ZV2M+VF+rKOpIuKO6G9yGlm+DQSXvo2aTnqEtNg09SSvGlN1en4fjoZfroeP3FTlRqv",
-	"98PrJ7PhWrswste4zlihRat7bXWrWHwsi0MZIKHZL1o2gQpBsNZp+bs4SMGivUbME/KhzdUpgkJZfZ1Z",
-	"D1TUoKpCr+1FARtjTtF8+aOHG2elqT+c18Vz/62tuO9kqasuXpInpdXKsKLpszyG8SyRSSuFT5J4G9N8",
-	"OvvBCeALjCiasOjjwl0QkuKLweD19fVswauehQkbWkii5gaHtxMlduDC/e3s/OycHYmnMAZp6F64/2Q/",
-	"8YNMxtcBUi4o00S37I7Es415R2cua5KrQzrTooh6gak86W/YEhZFBpoHcdeP6qOgKxMESu+G1p/MrLwq",
-	"+Y/z38wNiXKDWvLhted+PD9vr6g8gsaqWPSlyU/78fyftvWKtLL/Y0Of7ukIlmNUBsbKmVbnmYA5nUJX",
-	"2VQ90ko5bgY/1der1xw+ESQaI+iS/a4AyQl5xB3w/SSL+SOh9P/z8AXGzneWuKYMNN7ExkDTvtzNoVaC",
-	"iQU3ZSbmN4COj+cf2yvlWcB3B6fafJvw5LlzSHQP8JMMxbiAiwhw7Q6b3yE5Bcy8RdVyLPCYJt+MoTTT",
-	"YOiB5VTGWykddpm42geAdr6+9SDcKQjr6NlgSRzI2/ZBcRWo1XfXISbV6K+6rVWLKcM7QqTXWi8Fc/iF",
-	"uRPalmb34RZlMQTIX9xDtKlqNb9E1cPbCG8d4BSAF9ETlvjGMsOtFt6/Q1JJcnumW6hL6XKvErRjvduO",
-	"xRlKlpeAQOsKJFGKb4Re/ZvlPXKNyK1jaRvc/pR/2WxfZOtnhs2JEjR0GLxK4vsdzaF2NMoU7wBzilnQ",
-	"YMK2Gwa83JFMAxMIO1q42mT9621Uam8MdLJ1d2kOKBDfvWVwTGT3NkRvQzSBvcg0aAF3XrgZ8EVKwjdl",
-	"UVTo70HZFZT5vO8CluJiaPBT/NHF2JV5+duM3m9KsvuTVc4yN3xvLx/qBiCuAWlfmB4oSSPblW9xpmzU",
-	"vUoq/7eE6PY6/iKMgm+y4vZKnjOq1/E2UkEB+Qx1ONyTUDAPZivZ0Oc/14qILl32LygoPJPwNiKiY1Qv",
-	"KB0ExZiUX4pLpcBOpabI7m0tNHkK7RaZKVJt9yKjExnOn15UthCVHGKHEBU1Pau1sCjJXlvERU0L2wtM",
-	"0xojOdWLzhaio8DtkMKDN5IebC8++F1szytvOvSSsANJ2Ps6MgsjaLl350Ubdu5XosAvtlTs0QknQeQr",
-	"CuwapoWvQhgFB3HvKd6u6OV4kwMGKSz7OV5YwGhpdbige5FCK8P1Fwzex6JVH3eP9w54N7x4IlFf+rxD",
-	"6Ftte4wvXDSC/61uebZGf7+D2Rr/mv3LHiSg02135XnvxlvvytPh70EA9EPvRaDjvXn9Efkd2j3N/vvY",
-	"AVHE4kmq1Bh8mqJoWH2g46SR/i63H5pHWXqh7BpfoOB7U3HsKnuYRXcpEQRN8oc/rQ4ea8CdLHvhaxO+",
-	"aq7iXvo6Sl9NEjpHsfFMiR9YpsQPbZt9Gb05up44pffmZQjvM8AwcJJYplmXORdrAqqkCjzeQUBXK3Bz",
-	"C7A+3B7q9sHCJrhtgnf1dZBGjF+LtzFkwiHTsVbpEZlfIGTztFcMyel3mMajAjSJ/PwnFiyvTfUiId0G",
-	"ZZ4oRMlpeKSA+Ep2po3yveRtvNN0L8UsaoBioyAHP8VfT0XOJLs8MEXXOp/y3cKrXe3kycLkIHoH8QM5",
-	"iDdCsCU5TJuq+h2SNw+k96uiSrOnX8iyLcDBYx5PDh/9KnhAiFUxsMtVcFB+Vc9KkeX5SvO9MrXnmnYT",
-	"49KrfkeH8P42JVtvBtRs0e94V1ACzJ7wXnzPf3sKg/XmYtCwspdS/L4B/L9WyJ4EO7IQ3jO+9XA4LLoH",
-	"eSbjJpzzEtoE1WWET6FIbdvjvMd5cdZpBoUB7Sy/Lh78ZP8eImcXS/C8cRrgPtPGe8q0wbBigdTOd79t",
-	"/hb4MACd1t7vfDeH9+2ly++YWg0yf6BuGxFWHTp6Ce56l9xBelFx3WYnvsX9nEl+y+877V+A65CzF/pO",
-	"lX59cUfQzxAOX7aW3T6JcUfZLQlNXXjZ0xy0AS5G1S1L7jXCH6kYgDQcvPzG5k+0VXsy/HbC39dkd0ye",
-	"k7FTNs+JKDFIJUa8k6EQSIGkb20OiWgCKLpItFCop8YGHOG74iQzh0de6hqrRbdZt7mA0VLXYsX12tye",
-	"lmWvxX2uaC+38deP6/8PAAD//4C6fVFS4wAA",
+	"H4sIAAAAAAAC/+x9TXPbuLL2X2HxfZeMlTln7l14p8hyohp/HVnJqdRUygWTkMQJRXIA0I4mpf9+C18k",
+	"SAIkKMmSHHOVWGwAjcbTDaCBbvx0/WSVJjGMCXbPf7opQGAFCUTsryvwCCN8R3+jfwYQ+yhMSZjE7jn/",
+	"eOZ6bkj/+juDaO16bgxW0D13I/rR9VzsL+EK0MIhgStWKVmnlAITFMYLd+PJHwBCYO1uNp47hYsQE7Se",
+	"BDAm4TyEyMCCJHQKSgM/CC4eQpVoJ8Zm6xS2sURpDMwQ/qlgAcbZyj3/0/0ymc4+D69cz/18dz+bjofX",
+	"7jevytfGcwEi4Rz4xMDDkH0mhtZl4RIHTW2QpaGdG7CCTjJ3JGkOhhSQpbZBBP/OQgQD95ygDNox0CDs",
+	"oSBxaGnKCVlCB8E5RAgifNYigIfaONSZ8JdhFHyBCIdJbGBiREmcJ07jhLEPMOPlIvG/Q5QLx8iO2kQL",
+	"N0G4gNg06hfso6kVXrTjEMxRsroAxCR++unMuUzQChDnnXN9Pbi4GHz9+vWrgQdaXUsPI0AgJlIaGptD",
+	"Pzviu3MZRgQisw2ixA9PZtE+JkkEQcxaToH/HSygjWrfcdImFRe11SHWwdqkYAFvstUjRBrQZQjBmDiU",
+	"xok5kYmTRZmDAM5BFhH3/DfPnbOxc8/dMCb/+7ubMxHGBC4gytm4D/+BGv1n7VKss145KUSOaE7HCQ7/",
+	"MXDyr/d2rCDoZwiHT6YR+u8SkiVEDkmcKMTEQXzEQoidvGi0PjPOEYJEz+QcRBh6OuiIZtZTOG+wlp/j",
+	"8O8MSp7WDjWSBospaR4QnHdUWQwB8pcziDQc8G8O/WiSASd5ILR8S0MJIpchjAJNO/knQyMJIg9zQdDW",
+	"xi0KdApQfGpoIxEEjW2kwIdWI8com4aNEWwzZoKF/9Au2PJg6rfCQ2Ob2eNf0CcX7TOKnFdFCWcF4nAO",
+	"MfEcoRqY6hulkF9kCWF5W6ekJj5JsscJiCQtrT01zvTFJK2r/MlqCs9baF1V5YubLxUxlkFXNNsFcs/w",
+	"cZkk38c/oJ/RdidBO/5FGQfKQk6xojYwJ4o85EUewmA7TtW9gC2j1uyVdgb2zG04McTkQxKEkE3rctTY",
+	"7mjKv9Lf/SQmMGb/BWkahT6gPA/+wnyZUzTy/6nunrv/b1BszAb8Kx5oK2d8lOUguKJ6maUBIDBfhTps",
+	"Y4ZdZTOzbyar9TbwN0+Q4yPIGIwDyauc/SiT/+UjtG8eK9V2ZlEAR8z+OE1iXB7+C0hAGE3Fp058pyhJ",
+	"ISICTwEg1rDgjVKxYQJIhtvK3XOqzUYF/Z+ysMfbLjagCbP/emHxflLALSAp0BYwjhjcKtg9qGDus9UK",
+	"cECdimSYHjrysyog2jY+tIBom6ckHloV1ouHj2WPIFywJLkUK4XjiKjc+AlIKig7YHIXjSK4DyDY99Qy",
+	"RihBOvY+gMBBcsLx3FEUwpjcQ5Kl3G4fSufrDR9zrNj8yjhyMGVJnTK4B+0oU6qu6ROEdJAzVmb4WuzE",
+	"jiIt2fgJymulsMaZvgJriPBB5cSbPMk1CWWskI0cyMOKJ2/1lEUzlYcMBxVN3uopiQapTF2GEdyboZ6H",
+	"kZBQ+dSHO3eTufMJoBhiXLhKLlkJr/CyN4mm4LXufudVjJIsJnUGZksqBQIi4XnPPeCu58IfYJVG0M67",
+	"zp3rHVqh5OVW3r+3bmcSB/CHvh1fOU5Qq7evXH9CQOuOzacEqrDq1e4R4J7A0k5A51VsPPcTjFZHWZXU",
+	"Gz4BQ7CE0Uq3IlGZPfB6RNf0yUlKXYtMYgJRDKJ7iJ4g4luIF9+QyEYdzFp1ICf03KsQk2O4a2rtHntj",
+	"wuYZjetUZfQIsjkpsVTlIdwARxCLaPkkpCN8DVi9HiMlJR3kR0BQtemTRFJxgHBwuZyEPNTzD8qcOKzA",
+	"+WHdAQVTa/sYWw8mFXHkgovjx7J/WOX2CAI6CeQ8K8zcJOQyyeLg5dcRdJGPU+iH8xBSFydOMuRD5xlg",
+	"J07oCRrlonTgeJDRORWd5qeHHt9L6E857zPfhxjvIJB9dNCmZ4JTZ6po3ucYZGQJY0KZhQcAXLXBnIcE",
+	"hf8cjgHRWnFKfWgDXW32CEiv3wlRbXJ+zH5IcZyovqtXBgQD7MIA06c/4Poe+giSP+C63nkgabT3RkG5",
+	"BuUqvAX1fQp8OAkUUsUBo6Olt2+0FWPJfwsDOV1j02UqQ6PVcdNw8I2ejsZJvF4lDA/KYalwoJgvlQsC",
+	"zw1C+n0VxoDwffkKpCnl4Pyne3E7+mM87XKMNEriebhwPffj+GY8nYxMZT/CGKLQNxW+NZZLDEU+ja+u",
+	"7T1LebHr4ZfxjancNXiCsaHgzZ2xuZvU1Nrd19mnW2Nzd2uyTPTtbTypNeub0h1sdkt747lJDG/n7vmf",
+	"3Q/+8ha6eucsCzaNdFtZ8wC0lTSNQVu5xjFo6agemptvXsXicRsaDInWhIivH/T2MEie4ygBQe5Wt/Bg",
+	"r5KArV4NDfLrgpoPKsRaJpu7Mhpx+I++yqci9qHZ3IUrGh9AOfNKl0D5svKOX3TMUOSW2azPZ57x0mJ5",
+	"UIT/q1sQg8qxqKCJg2tIgJzrDWY5J6mCRg487jLy3TtFy2ByLRBzKLykWRSNktUKxPomUS1mrpHMOI1b",
+	"wy/myNO0W43iqbTaNPz8Hlpt7GvnfJzOBIAu4y/LyPMriyLsUO6eJEg59rIolqWd2tk0iUncHbAQlKDs",
+	"ZmC30qTCHumq3EbPWqzytsrUYEdtDaWAtoW1EpQNVotFQeSCNiO002DQs8pOg4drkXfVQKdf2+4Z5uND",
+	"GL3Kxcl6lAu/xFSDlEm3mxUxxFftI739eO24fGlVwIwsJVcVjStcQlQ4rKSXR1Z/xjRwBOPnBAWup9t1",
+	"q/vEetA1va8JQZyld0kU+ppBEp8d/p05AmrGeJqHI9bGDP5IQwQvwBrrjUCbZt0hOA9/dDOvMhSpc1Hd",
+	"1KS5VaqREaVxGJEjqaqSWIEw/gRBYHYfNH/lhxRqbywvw97zsq2rWIVBlR2l8W/N8pENNctHUjW7HyY3",
+	"V5ObsU3vCEzzTfZs+OHeVGYGHqsF6ltr0mlPrWejbbOoY6S2UVxuixRiYdrEEGhXDcRkoSqdbRtlSlJb",
+	"nPH5bjsUM2mx8jqdX+4mkUpDuWTapKDM4C3CcCSpp9t96rcsIMoMc3c7XwxXW4wRJjDdeoA6m9Rc2AZO",
+	"S0TVuY9ulkKf+gxhDBEgcJZ8h7F2ktNee29dkOSO0iPvMayWNi+0qbBfqXZdgnInzqm4ihr8o3Vsst/Z",
+	"WkgfuFCf/ZuFuGllKL/t1wranLK+ACmqaBZrTmkWFLvrP46J1WadEWPTdLDDtkXW0MInbvUrcDLjzkNE",
+	"9Ov3/2uI7A1mTXqauSzBQ+RbnAsJrsydl1AwLlytR6rZ4pmls5UxNPbfFhZ5AoZIdkdU2S6qBiEVJC+w",
+	"MV2p7XdAUnWIzduh7aypWWIyZqPBR8QjKMJ4QS8KA5k4amUyUCCOEwLyfQ4IgpD+AaK7EplpE1cwqGbY",
+	"0pbwAYGLBK3tZCx7OpKlmjG/gkEIjE13RHBRmcJ1+6CMlA6WB+ePMA7Uy5vtg5QvtyYfb4azz9MxdSnM",
+	"ZuP72XA2ub1xPff+w+2167m3s0/jacPyq4jyMepYQdLBBDerH1Jb7aBZOb73rVJeMboFb7ohza/LV6fN",
+	"wBCRsiQk5bfdHUakBKK4v79XjK1ioE1TwzDXPrmmccBjkhGWAIe14WpYXkGMwcLAHoIAUw8W+6/IQgHC",
+	"CAau1zrPs97I2rXC+kEQKPbFlfxZ4jYPI3Jyx0ZZrt/heudtmBJ6VWOCfjNuKZbQ/46zVceDBLudSNPi",
+	"3eh/6+Q3ZsSe0ot64yqzuvFruivQtABf8HLtK/BSDVaDaTjVb+QmcVZJkEWwnR+6dg31A7dIvhgNjOeG",
+	"MSagbUuWJjgkYgKw6KomAKtuo2mUT9veGKSpMcmh+CCzaCnX5ByxOwucxzX75i8BIjp7JcXadH/CpA5l",
+	"AFR2UcVfDk3nyLgYUS7O1mAVSZ6NfG210F0muywZv8M19fqfxtnjW3ETGO9CNVkFXSThPlwE2nDAFr19",
+	"afdAKZ6rIcRZ0tTlAPIvFsVtY6Jr93D6yOiTi4yuwKzAQRvOrqQL3TqenpXQbOQPgoBtroz0qLFETcNd",
+	"PF1MpYWJyWMejZbqiyToWFsny1W9mtMbsNdvwPJQri62q+G2RQ+AY+f2KDJzbz+mVmZBQsdsDypoVDhr",
+	"g+MJrt+qrPVm8Bcyg3mssYXKFJpSRAX3ZvDUzOCzxYjqR9LKGigRkY02L6+3DXlKGoDtMKhE72vuQNpU",
+	"3lppF8mUQmd7+3jS9lEZZB1MzYFwe3GLS4KJ3mu3QEmWTmwdUqbQuyZO43Rl47xPEcyj8Ntcu91d5rrO",
+	"3JVdodXI6DlE7IEI4TFUDkpF6KyMMC0CYkWoKo8gzUNCWcyr7tS0ISCxSaApK9YuU6tzBAZTzDnRkuDi",
+	"ao2FTM0bHtOSuH4ZB0RR8gzpuw4EoribG+Uxome725X1qxflLW9IqqV01eYjarP+LW4udzrsqH0Pm8NK",
+	"TiiqyeT/159Ell68YCVsPfvGHcarjtg0RT+9aGzTfmKXXjJEqaJOtSG+zx75J5kAyGem9UuISAYiJ0HO",
+	"5xQTBMFKtVNNAQ35w4cGEcr68lgG+WaigV6wsqdIhmptzdQVXuvRCzZX7tU3J+2jEGpuD/uJxKyu+Q61",
+	"U4Bti4Xd7h74/s1yq4nY4cjXdJQr9e/edKTbHSCW04Aw+WqfKpMCraYJWcY0Af2641gri10QimBMpnCu",
+	"aaeCHN3KwXbN0LZHkM+45m8khmfQeSomk0wYVN0cYrDrnZ7W9Vw15KuB0dKLFSLuT4bddOVMhPCJqDwt",
+	"U3k2q2oC34BlQMNOOC9dF6Sp9zDP1DbPmOTipHRF9fNoNL6/dz33cji54ldVx9Pprf5WqhqIp9ngg0cR",
+	"J4V1cVLLwwdr1uCniSRs6Ybjy+VFZcIGj/bsluRmxygKFwuImpBHBEkxmMPpbHI5HM0eRtOxvGmc/3Z9",
+	"ezG5nIxqv1+Mr8bsN92AV9Ythk10hvidNG2os6ziDiU/dCdwNH8e/ddu2VWK3m5bdRVh3K2U9SjwDU0Y",
+	"BpQg88byko6CmSW9VB/z5tejl9mj67mjDBP29vHwGY995Ap31QjGBAG667pJV39RKd2t70LtmFjN6jnj",
+	"NSPsuT/elQzTO3FdtzCHdOBVOdcmdmyTXw63p5XDFtnkMgyR4ap6pc85JR258vq8A3BFQavT46yC7R0j",
+	"56WTvuGy/0I4vCXpDumy9rBihzF4jGCgv20Ji/vl9pZSvZSuc8Q3Qy6MMfQzBPUMhSLLvSmlCYGYqIk7",
+	"2VvP1ocHosAOKcQOqVVi8ugwhfECukGxuG1q96S3YWnJvVHCmyEhpwz2N7Mq8YHJtydtWvVpNruTquXI",
+	"clUVe0wCfdjDssC6vdFu5rzIltqRdVFwL7wXKVQNn0YivsYmcVVdYxqWOLWcstqV63Q8m06GH67GD3zl",
+	"Steys+HVg3kdWzvzs7e4zljhRWt7bW2rmHwsyaEMbdJsHy2rQIUiWNu0/JFCpGDRunSR/xdtb04RFMbq",
+	"dm7dUVGCmgq9tRcENms7xfLlL1Bvnbet/opxl5ib1zbjvpGprjp5SZmUZivDjKZPKh3G80TmyBbXyrgs",
+	"G5y175wAPsGIogmLNs5dGuCIzweD5+fnsyUvehYmrGshiZorHN5NlPCPc/e3s/dn72nRJIUxSEP33P03",
+	"+4n7NZlcB0g5r0wT3bQ7Em9o5w3RJ9kp14A/P5+TqOeZAIEVJGwUDTvEgmSAKR6mcP6fDKI1fyB+842P",
+	"EX+h3RjGXHrEvf5+eeWJ73+9/81ckaAb1N462Hju7+/ftxdUXqRlRSza0qTD//39v23LFVns/8eGP91L",
+	"VRv1fFuOtDrOBCzoELrKpuobLZTjZvBT/u8BwfmGwyeCBOoi0CJYApIT8lhZ4Pv0BIFt6+jfi/AJxg6N",
+	"Vq0CjVexNdBQPrZzan5UqJVgYiFN+fDDK0AHDYxuLZQ/OrI/ONXG24Qnz11AjeGZQpKhGBdwESkgusPm",
+	"IySngJnXaFqOBR7T4JsxlGYaDH1mTzjgnYwOO1tcvwSA9j6/9SDcKwjr6NliShzIw/dBcTKotXf0um41",
+	"gK++1qqFBeI9IdJrLZfSIGN2I9SWmh2PW9BiCJC/nEG0rWk1P3zZw9sIbx3gFIAPi7AGO3xjmQNeC++P",
+	"kFTSwJ/pJupSQvnLBO3Z7rZjkSZHuAAEWhcgiUK+FXpLfe6R247cOpZ2we1P+T+b7Yus/cywOVHivg6D",
+	"V8l8v6M51I5GGeI9YE5ZFjQsYdsXBpzuSEsDEwg7rnC1z9lsdjGp/WKg01p3n8sBBeL7XxkcE9n9GqJf",
+	"QzSBvQgYsoA7J24GfJG091WtKCr896DsCsp83PcBS3EwNPgp/tNlsStfrmlb9H5RnoM5WeMsX0/p18uH",
+	"OgGIa0B6KUwPlHSv7ca38CkbbW9B8qoQ3V7GX4ZR8EUW3N3Ic0H1Nt5GKyggH6EOhy+kFOxCs5Vu6F8I",
+	"0aqI7kGJX1BReC7rXVREJ6heUTooivHZGqkuFYK9ak3x/oW10uSPTLToTE7Xq4xWZbh8elXZQVVyiB1C",
+	"VdQMu9bKouTrbVEXhbJXmMY5RkqqV50dVEeB2yGVB2+lPdheffCb2J5XXj3qNWEPmnCoeaT0oo35rgy9",
+	"toXDRQxIhiD2HEAIxOJ9JQfQV4DIUnm6DhteAlKO81o0qPR4z681A+GMXWe/UCcie/ZoXMbuypqLt1dW",
+	"y8s7VUVVAfqiijoPI2jpZOOkDS62S0Hwi2nUC96WSxC5RQFEtsSXIYyCg9zDK56H6nV4G0+gVJaX8QPS",
+	"Z1qsvIC6l5C0Olx/LeZtrC7r/e7x3gHvhpe2JOpLn/cIfSv/hPE1oUbwv1bfxM7o710NO+Nf42h4AQ3o",
+	"dC1FvmRncz1F0J7ALZWDKYC+670KdLzgUkHZftc9zc4D7IAoYh6EKjeGy4dRNKw+hnTSSH+T2w/NA1i9",
+	"UnYNBFLwva06dtU9zMIwlVCfJv3DH9YHDwrit6F75WtTvmqO8V77OmpfTRM6h5vyDKfvWIbTd22bfRlm",
+	"PbqaODxJp8ilKWPtHwGGgVM8YCxzpdYUVEnxeTxHQNdV4PYrwHp3e6jbR/Wb4LYN3tWXmBoxfiXeIZKZ",
+	"wUxurdKDXb9AbPVpzxhS0m8w304FaBL5+U8sq0WCGyDdBmWe0UdJPnqkzBWVNGpbJWbK63ijeZmKUdQA",
+	"xcZADn6K/z0Uyc3sEjYVTeuCP/YLr3azk2f1k53oIzkOFMnRCMGWLE5tpuojJK8eSG/XRJVGTz+RZTuA",
+	"gwcnnxw++lnwgBCrYmCfs+Cg/IKplSHLEwvne2W6nmvaTYxLL6geHcIvtynZeTOgpnV/w7uCEmBeCO/F",
+	"9/y3hzDYbK8GDTN7KRf3K8D/c4XtSbCnFcJbxrceDodF9yBPOd6Ec06hzSRfRvgUihzUPc57nBe+TjMo",
+	"DGhnibDx4Cf79xDJ9Vgm9q3zdfcpcd5SShyGFQukdj77bbtvgQ8D0Gnt3d0347xvpy6/P2zVyfxhyV1U",
+	"WL3Q0Wtw17PkDtqLiuM2O/UtzudM+lt+iO3lFbgOOXul71To11d3BP0M4fBpZ93ts4131N2S0tSVlxZg",
+	"FXA1qm5Z8lsj/DWZAUjDwdNvbPxEXdUyw7sJfxeXnTF5Tsa8bJ4TUWaQyox40EZhcOOZaltAIqoAii0S",
+	"NRTmqbECR9xdoUf2PC5UV1ktus26ThoRoKuxcvV643US2XNxnivqy9f4m2+b/xsANr1nj7XxAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
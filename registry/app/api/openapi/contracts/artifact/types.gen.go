@@ -24,12 +24,23 @@ const (
 	ClientSetupStepTypeStatic        ClientSetupStepType = "Static"
 )
 
+// Defines values for DockerReferrerCategory.
+const (
+	DockerReferrerCategoryATTESTATION DockerReferrerCategory = "ATTESTATION"
+	DockerReferrerCategoryOTHER       DockerReferrerCategory = "OTHER"
+	DockerReferrerCategorySBOM        DockerReferrerCategory = "SBOM"
+	DockerReferrerCategorySIGNATURE   DockerReferrerCategory = "SIGNATURE"
+)
+
 // Defines values for PackageType.
 const (
 	PackageTypeDOCKER  PackageType = "DOCKER"
 	PackageTypeGENERIC PackageType = "GENERIC"
+	PackageTypeGO      PackageType = "GO"
 	PackageTypeHELM    PackageType = "HELM"
 	PackageTypeMAVEN   PackageType = "MAVEN"
+	PackageTypeNPM     PackageType = "NPM"
+	PackageTypePYTHON  PackageType = "PYTHON"
 )
 
 // Defines values for RegistryType.
@@ -64,6 +75,8 @@ const (
 	UpstreamConfigSourceCustom       UpstreamConfigSource = "Custom"
 	UpstreamConfigSourceDockerhub    UpstreamConfigSource = "Dockerhub"
 	UpstreamConfigSourceMavenCentral UpstreamConfigSource = "MavenCentral"
+	UpstreamConfigSourceNpmjs        UpstreamConfigSource = "Npmjs"
+	UpstreamConfigSourcePyPi         UpstreamConfigSource = "PyPi"
 )
 
 // Defines values for WebhookExecResult.
@@ -289,6 +302,29 @@ type DockerManifests struct {
 	Version         string                   `json:"version"`
 }
 
+// DockerReferrer Artifact referring to a Docker manifest
+type DockerReferrer struct {
+	Annotations  *map[string]string `json:"annotations,omitempty"`
+	ArtifactType *string            `json:"artifactType,omitempty"`
+
+	// Category Kind of artifact referring to a Docker manifest
+	Category  DockerReferrerCategory `json:"category"`
+	Digest    string                 `json:"digest"`
+	MediaType string                 `json:"mediaType"`
+	Size      *string                `json:"size,omitempty"`
+}
+
+// DockerReferrerCategory Kind of artifact referring to a Docker manifest
+type DockerReferrerCategory string
+
+// DockerReferrers Harness Referrers
+type DockerReferrers struct {
+	Digest    string           `json:"digest"`
+	ImageName string           `json:"imageName"`
+	Referrers []DockerReferrer `json:"referrers"`
+	Version   string           `json:"version"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Code The http error code
@@ -321,13 +357,28 @@ type GenericArtifactDetailConfig struct {
 	Description *string `json:"description,omitempty"`
 }
 
+// GoArtifactDetailConfig Config for go module artifact details
+type GoArtifactDetailConfig struct {
+	Commit         *string `json:"commit,omitempty"`
+	GoVersion      *string `json:"goVersion,omitempty"`
+	InstallCommand *string `json:"installCommand,omitempty"`
+	Repository     *string `json:"repository,omitempty"`
+}
+
 // HelmArtifactDetail Helm Artifact Detail
 type HelmArtifactDetail struct {
-	Artifact        *string `json:"artifact,omitempty"`
-	CreatedAt       *string `json:"createdAt,omitempty"`
-	DownloadsCount  *int64  `json:"downloadsCount,omitempty"`
-	IsLatestVersion *bool   `json:"isLatestVersion,omitempty"`
-	ModifiedAt      *string `json:"modifiedAt,omitempty"`
+	// AppVersion Version of the application packaged by the chart
+	AppVersion *string `json:"appVersion,omitempty"`
+	Artifact   *string `json:"artifact,omitempty"`
+	CreatedAt  *string `json:"createdAt,omitempty"`
+
+	// Description Description from the Chart.yaml of the chart
+	Description     *string   `json:"description,omitempty"`
+	DownloadsCount  *int64    `json:"downloadsCount,omitempty"`
+	Home            *string   `json:"home,omitempty"`
+	IsLatestVersion *bool     `json:"isLatestVersion,omitempty"`
+	Keywords        *[]string `json:"keywords,omitempty"`
+	ModifiedAt      *string   `json:"modifiedAt,omitempty"`
 
 	// PackageType refers to package
 	PackageType  PackageType `json:"packageType"`
@@ -478,9 +529,23 @@ type MavenArtifactDetailConfig struct {
 	GroupId    *string `json:"groupId,omitempty"`
 }
 
+// NpmArtifactDetailConfig Config for npm artifact details
+type NpmArtifactDetailConfig struct {
+	Deprecated     *string `json:"deprecated,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	InstallCommand *string `json:"installCommand,omitempty"`
+}
+
 // PackageType refers to package
 type PackageType string
 
+// PythonArtifactDetailConfig Config for python artifact details
+type PythonArtifactDetailConfig struct {
+	InstallCommand *string `json:"installCommand,omitempty"`
+	RequiresPython *string `json:"requiresPython,omitempty"`
+	Summary        *string `json:"summary,omitempty"`
+}
+
 // Registry Harness Artifact Registry
 type Registry struct {
 	AllowedPattern *[]string        `json:"allowedPattern,omitempty"`
@@ -705,6 +770,9 @@ type ArtifactParam string
 // ArtifactPathParam defines model for artifactPathParam.
 type ArtifactPathParam string
 
+// ArtifactTypeParam defines model for artifactTypeParam.
+type ArtifactTypeParam string
+
 // ChildVersionParam defines model for childVersionParam.
 type ChildVersionParam string
 
@@ -747,6 +815,9 @@ type SpaceRefPathParam string
 // SpaceRefQueryParam defines model for spaceRefQueryParam.
 type SpaceRefQueryParam string
 
+// SubjectDigestParam defines model for subjectDigestParam.
+type SubjectDigestParam string
+
 // ToDateParam defines model for toDateParam.
 type ToDateParam string
 
@@ -855,6 +926,15 @@ type DockerManifestsResponse struct {
 	Status Status `json:"status"`
 }
 
+// DockerReferrersResponse defines model for DockerReferrersResponse.
+type DockerReferrersResponse struct {
+	// Data Harness Referrers
+	Data DockerReferrers `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 // FileDetailResponse defines model for FileDetailResponse.
 type FileDetailResponse struct {
 	// Files A list of Harness Artifact Files
@@ -1062,6 +1142,15 @@ type GetDockerArtifactManifestParams struct {
 	Digest DigestParam `form:"digest" json:"digest"`
 }
 
+// GetDockerArtifactReferrersParams defines parameters for GetDockerArtifactReferrers.
+type GetDockerArtifactReferrersParams struct {
+	// Digest Digest of the subject manifest, defaults to the manifest of the version.
+	Digest *SubjectDigestParam `form:"digest,omitempty" json:"digest,omitempty"`
+
+	// ArtifactType Artifact type of the referrers.
+	ArtifactType *ArtifactTypeParam `form:"artifact_type,omitempty" json:"artifact_type,omitempty"`
+}
+
 // GetArtifactFilesParams defines parameters for GetArtifactFiles.
 type GetArtifactFilesParams struct {
 	// Page Current page number
@@ -1356,6 +1445,96 @@ func (t *ArtifactDetail) MergeMavenArtifactDetailConfig(v MavenArtifactDetailCon
 	return err
 }
 
+// AsNpmArtifactDetailConfig returns the union data inside the ArtifactDetail as a NpmArtifactDetailConfig
+func (t ArtifactDetail) AsNpmArtifactDetailConfig() (NpmArtifactDetailConfig, error) {
+	var body NpmArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromNpmArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided NpmArtifactDetailConfig
+func (t *ArtifactDetail) FromNpmArtifactDetailConfig(v NpmArtifactDetailConfig) error {
+	t.PackageType = "NPM"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeNpmArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided NpmArtifactDetailConfig
+func (t *ArtifactDetail) MergeNpmArtifactDetailConfig(v NpmArtifactDetailConfig) error {
+	t.PackageType = "NPM"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsPythonArtifactDetailConfig returns the union data inside the ArtifactDetail as a PythonArtifactDetailConfig
+func (t ArtifactDetail) AsPythonArtifactDetailConfig() (PythonArtifactDetailConfig, error) {
+	var body PythonArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromPythonArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided PythonArtifactDetailConfig
+func (t *ArtifactDetail) FromPythonArtifactDetailConfig(v PythonArtifactDetailConfig) error {
+	t.PackageType = "PYTHON"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergePythonArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided PythonArtifactDetailConfig
+func (t *ArtifactDetail) MergePythonArtifactDetailConfig(v PythonArtifactDetailConfig) error {
+	t.PackageType = "PYTHON"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsGoArtifactDetailConfig returns the union data inside the ArtifactDetail as a GoArtifactDetailConfig
+func (t ArtifactDetail) AsGoArtifactDetailConfig() (GoArtifactDetailConfig, error) {
+	var body GoArtifactDetailConfig
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGoArtifactDetailConfig overwrites any union data inside the ArtifactDetail as the provided GoArtifactDetailConfig
+func (t *ArtifactDetail) FromGoArtifactDetailConfig(v GoArtifactDetailConfig) error {
+	t.PackageType = "GO"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGoArtifactDetailConfig performs a merge with any union data inside the ArtifactDetail, using the provided GoArtifactDetailConfig
+func (t *ArtifactDetail) MergeGoArtifactDetailConfig(v GoArtifactDetailConfig) error {
+	t.PackageType = "GO"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t ArtifactDetail) Discriminator() (string, error) {
 	var discriminator struct {
 		Discriminator string `json:"packageType"`
@@ -1374,10 +1553,16 @@ func (t ArtifactDetail) ValueByDiscriminator() (interface{}, error) {
 		return t.AsDockerArtifactDetailConfig()
 	case "GENERIC":
 		return t.AsGenericArtifactDetailConfig()
+	case "GO":
+		return t.AsGoArtifactDetailConfig()
 	case "HELM":
 		return t.AsHelmArtifactDetailConfig()
 	case "MAVEN":
 		return t.AsMavenArtifactDetailConfig()
+	case "NPM":
+		return t.AsNpmArtifactDetailConfig()
+	case "PYTHON":
+		return t.AsPythonArtifactDetailConfig()
 	default:
 		return nil, errors.New("unknown discriminator value: " + discriminator)
 	}
@@ -0,0 +1,43 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"net/http"
+
+	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	"github.com/harness/gitness/registry/app/api/handler/gomodule"
+	"github.com/harness/gitness/registry/app/api/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	http.Handler
+}
+
+func NewGoModuleHandler(handler *gomodule.Handler) Handler {
+	r := chi.NewRouter()
+
+	r.Route("/go/{rootIdentifier}/{registryIdentifier}", func(r chi.Router) {
+		r.Use(middlewareauthn.Attempt(handler.Authenticator))
+		r.Use(middleware.CheckAuth())
+
+		r.Get("/*", handler.GetModule)
+		r.Put("/*", handler.UploadModule)
+	})
+
+	return r
+}
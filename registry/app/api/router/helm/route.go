@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"net/http"
+
+	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	"github.com/harness/gitness/registry/app/api/handler/helm"
+	"github.com/harness/gitness/registry/app/api/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler interface {
+	http.Handler
+}
+
+func NewHelmHandler(handler *helm.Handler) Handler {
+	r := chi.NewRouter()
+
+	r.Route("/helm/{rootIdentifier}/{registryIdentifier}", func(r chi.Router) {
+		r.Use(middleware.CheckHelmAuthHeader())
+		r.Use(middlewareauthn.Attempt(handler.Authenticator))
+		r.Use(middleware.CheckHelmAuth())
+
+		r.Get("/index.yaml", handler.GetIndex)
+		r.Get("/charts/*", handler.DownloadChart)
+		r.Post("/api/charts", handler.UploadChart)
+	})
+
+	return r
+}
@@ -22,9 +22,13 @@ import (
 	"github.com/harness/gitness/app/api/middleware/logging"
 	"github.com/harness/gitness/registry/app/api/handler/swagger"
 	generic2 "github.com/harness/gitness/registry/app/api/router/generic"
+	"github.com/harness/gitness/registry/app/api/router/gomodule"
 	"github.com/harness/gitness/registry/app/api/router/harness"
+	"github.com/harness/gitness/registry/app/api/router/helm"
 	"github.com/harness/gitness/registry/app/api/router/maven"
+	"github.com/harness/gitness/registry/app/api/router/npm"
 	"github.com/harness/gitness/registry/app/api/router/oci"
+	"github.com/harness/gitness/registry/app/api/router/python"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog/hlog"
@@ -40,6 +44,10 @@ func GetAppRouter(
 	baseURL string,
 	mavenHandler maven.Handler,
 	genericHandler generic2.Handler,
+	helmHandler helm.Handler,
+	npmHandler npm.Handler,
+	pythonHandler python.Handler,
+	goModuleHandler gomodule.Handler,
 ) AppRouter {
 	r := chi.NewRouter()
 	r.Use(hlog.URLHandler("http.url"))
@@ -53,6 +61,10 @@ func GetAppRouter(
 		r.Handle("/v2/*", ociHandler)
 		r.Handle("/maven/*", mavenHandler)
 		r.Handle("/generic/*", genericHandler)
+		r.Handle("/helm/*", helmHandler)
+		r.Handle("/npm/*", npmHandler)
+		r.Handle("/python/*", pythonHandler)
+		r.Handle("/go/*", goModuleHandler)
 
 		r.Handle("/registry/swagger*", swagger.GetSwaggerHandler("/registry"))
 	})
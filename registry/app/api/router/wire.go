@@ -23,12 +23,20 @@ import (
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
+	hgomodule "github.com/harness/gitness/registry/app/api/handler/gomodule"
+	hhelm "github.com/harness/gitness/registry/app/api/handler/helm"
 	"github.com/harness/gitness/registry/app/api/handler/maven"
+	hnpm "github.com/harness/gitness/registry/app/api/handler/npm"
 	hoci "github.com/harness/gitness/registry/app/api/handler/oci"
+	hpython "github.com/harness/gitness/registry/app/api/handler/python"
 	generic2 "github.com/harness/gitness/registry/app/api/router/generic"
+	goModuleRouter "github.com/harness/gitness/registry/app/api/router/gomodule"
 	"github.com/harness/gitness/registry/app/api/router/harness"
+	helmRouter "github.com/harness/gitness/registry/app/api/router/helm"
 	mavenRouter "github.com/harness/gitness/registry/app/api/router/maven"
+	npmRouter "github.com/harness/gitness/registry/app/api/router/npm"
 	"github.com/harness/gitness/registry/app/api/router/oci"
+	pythonRouter "github.com/harness/gitness/registry/app/api/router/python"
 	storagedriver "github.com/harness/gitness/registry/app/driver"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	"github.com/harness/gitness/registry/app/store"
@@ -42,8 +50,15 @@ func AppRouterProvider(
 	appHandler harness.APIHandler,
 	mavenHandler mavenRouter.Handler,
 	genericHandler generic2.Handler,
+	helmHandler helmRouter.Handler,
+	npmHandler npmRouter.Handler,
+	pythonHandler pythonRouter.Handler,
+	goModuleHandler goModuleRouter.Handler,
 ) AppRouter {
-	return GetAppRouter(ocir, appHandler, config.APIURL, mavenHandler, genericHandler)
+	return GetAppRouter(
+		ocir, appHandler, config.APIURL, mavenHandler, genericHandler, helmHandler, npmHandler, pythonHandler,
+		goModuleHandler,
+	)
 }
 
 func APIHandlerProvider(
@@ -99,5 +114,22 @@ func GenericHandlerProvider(handler *generic.Handler) generic2.Handler {
 	return generic2.NewGenericArtifactHandler(handler)
 }
 
+func HelmHandlerProvider(handler *hhelm.Handler) helmRouter.Handler {
+	return helmRouter.NewHelmHandler(handler)
+}
+
+func NpmHandlerProvider(handler *hnpm.Handler) npmRouter.Handler {
+	return npmRouter.NewNpmHandler(handler)
+}
+
+func PythonHandlerProvider(handler *hpython.Handler) pythonRouter.Handler {
+	return pythonRouter.NewPythonHandler(handler)
+}
+
+func GoModuleHandlerProvider(handler *hgomodule.Handler) goModuleRouter.Handler {
+	return goModuleRouter.NewGoModuleHandler(handler)
+}
+
 var WireSet = wire.NewSet(APIHandlerProvider, OCIHandlerProvider, AppRouterProvider,
-	MavenHandlerProvider, GenericHandlerProvider)
+	MavenHandlerProvider, GenericHandlerProvider, HelmHandlerProvider, NpmHandlerProvider,
+	PythonHandlerProvider, GoModuleHandlerProvider)
@@ -21,8 +21,12 @@ import (
 	corestore "github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/registry/app/api/handler/generic"
+	gomodulehandler "github.com/harness/gitness/registry/app/api/handler/gomodule"
+	helmhandler "github.com/harness/gitness/registry/app/api/handler/helm"
 	mavenhandler "github.com/harness/gitness/registry/app/api/handler/maven"
+	npmhandler "github.com/harness/gitness/registry/app/api/handler/npm"
 	ocihandler "github.com/harness/gitness/registry/app/api/handler/oci"
+	pythonhandler "github.com/harness/gitness/registry/app/api/handler/python"
 	"github.com/harness/gitness/registry/app/api/router"
 	storagedriver "github.com/harness/gitness/registry/app/driver"
 	"github.com/harness/gitness/registry/app/driver/factory"
@@ -32,7 +36,11 @@ import (
 	"github.com/harness/gitness/registry/app/pkg/docker"
 	"github.com/harness/gitness/registry/app/pkg/filemanager"
 	generic2 "github.com/harness/gitness/registry/app/pkg/generic"
+	"github.com/harness/gitness/registry/app/pkg/gomodule"
+	"github.com/harness/gitness/registry/app/pkg/helm"
 	"github.com/harness/gitness/registry/app/pkg/maven"
+	"github.com/harness/gitness/registry/app/pkg/npm"
+	"github.com/harness/gitness/registry/app/pkg/python"
 	"github.com/harness/gitness/registry/app/store/database"
 	"github.com/harness/gitness/registry/config"
 	"github.com/harness/gitness/registry/gc"
@@ -118,11 +126,67 @@ func NewGenericHandlerProvider(
 	)
 }
 
+func NewHelmHandlerProvider(
+	controller *helm.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, config *types.Config,
+) *helmhandler.Handler {
+	return helmhandler.NewHandler(
+		controller,
+		spaceStore,
+		authenticator,
+		authorizer,
+		config.Registry.HTTP.RelativeURL,
+	)
+}
+
+func NewNpmHandlerProvider(
+	controller *npm.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, urlProvider urlprovider.Provider,
+) *npmhandler.Handler {
+	return npmhandler.NewHandler(
+		controller,
+		spaceStore,
+		authenticator,
+		authorizer,
+		urlProvider,
+	)
+}
+
+func NewPythonHandlerProvider(
+	controller *python.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, urlProvider urlprovider.Provider,
+) *pythonhandler.Handler {
+	return pythonhandler.NewHandler(
+		controller,
+		spaceStore,
+		authenticator,
+		authorizer,
+		urlProvider,
+	)
+}
+
+func NewGoModuleHandlerProvider(
+	controller *gomodule.Controller, spaceStore corestore.SpaceStore, authenticator authn.Authenticator,
+	authorizer authz.Authorizer, urlProvider urlprovider.Provider,
+) *gomodulehandler.Handler {
+	return gomodulehandler.NewHandler(
+		controller,
+		spaceStore,
+		authenticator,
+		authorizer,
+		urlProvider,
+	)
+}
+
 var WireSet = wire.NewSet(
 	BlobStorageProvider,
 	NewHandlerProvider,
 	NewMavenHandlerProvider,
 	NewGenericHandlerProvider,
+	NewHelmHandlerProvider,
+	NewNpmHandlerProvider,
+	NewPythonHandlerProvider,
+	NewGoModuleHandlerProvider,
 	database.WireSet,
 	pkg.WireSet,
 	docker.WireSet,
@@ -131,6 +195,10 @@ var WireSet = wire.NewSet(
 	router.WireSet,
 	gc.WireSet,
 	generic2.WireSet,
+	helm.WireSet,
+	npm.WireSet,
+	python.WireSet,
+	gomodule.WireSet,
 )
 
 func Wire(_ *types.Config) (RegistryApp, error) {
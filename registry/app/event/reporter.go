@@ -36,6 +36,9 @@ const (
 	PackageTypeGENERIC
 	PackageTypeHELM
 	PackageTypeMAVEN
+	PackageTypeNPM
+	PackageTypePYTHON
+	PackageTypeGO
 )
 
 var PackageTypeValue = map[string]PackageType{
@@ -43,6 +46,9 @@ var PackageTypeValue = map[string]PackageType{
 	string(artifact.PackageTypeGENERIC): PackageTypeGENERIC,
 	string(artifact.PackageTypeHELM):    PackageTypeHELM,
 	string(artifact.PackageTypeMAVEN):   PackageTypeMAVEN,
+	string(artifact.PackageTypeNPM):     PackageTypeNPM,
+	string(artifact.PackageTypePYTHON):  PackageTypePYTHON,
+	string(artifact.PackageTypeGO):      PackageTypeGO,
 }
 
 // GetPackageTypeFromString returns the PackageType constant corresponding to the given string value.
@@ -79,6 +79,40 @@ type GenericArtifactInfo struct {
 	Description string
 }
 
+type HelmArtifactInfo struct {
+	*ArtifactInfo
+	RegistryID int64
+	Version    string
+	FileName   string
+	URLBuilder *v2.URLBuilder
+}
+
+type NpmArtifactInfo struct {
+	*ArtifactInfo
+	RegistryID  int64
+	Version     string
+	FileName    string
+	DistTag     string
+	RegistryURL string
+}
+
+type PythonArtifactInfo struct {
+	*ArtifactInfo
+	RegistryID  int64
+	Version     string
+	FileName    string
+	RegistryURL string
+}
+
+// GoModuleArtifactInfo describes a request to a Go module registry. The image
+// is the module path, as written in go.mod.
+type GoModuleArtifactInfo struct {
+	*ArtifactInfo
+	RegistryID  int64
+	Version     string
+	RegistryURL string
+}
+
 func (a *MavenArtifactInfo) SetMavenRepoKey(key string) {
 	a.RegIdentifier = key
 }
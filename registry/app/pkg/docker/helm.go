@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/manifest"
+	"github.com/harness/gitness/registry/app/manifest/ocischema"
+	"github.com/harness/gitness/registry/app/pkg"
+)
+
+const (
+	// HelmConfigMediaType is the media type of the configuration of a Helm chart stored as an OCI artifact.
+	HelmConfigMediaType = "application/vnd.cncf.helm.config.v1+json"
+	// HelmChartLayerMediaType is the media type of the chart archive layer.
+	HelmChartLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	// HelmProvenanceLayerMediaType is the media type of the optional chart provenance layer.
+	HelmProvenanceLayerMediaType = "application/vnd.cncf.helm.chart.provenance.v1.prov"
+)
+
+// PushBlobContent stores a small blob in the registry blob store and links it
+// to the image referenced by artInfo. It is meant for content which is already
+// fully in memory, like a chart archive received by the Helm repository API.
+func (r *LocalRegistry) PushBlobContent(
+	ctx context.Context,
+	artInfo pkg.RegistryInfo,
+	mediaType string,
+	content []byte,
+) (manifest.Descriptor, error) {
+	blobCtx := r.App.GetBlobsContext(ctx, artInfo)
+	desc, err := blobCtx.OciBlobStore.Put(ctx, artInfo.RootIdentifier, content)
+	if err != nil {
+		return manifest.Descriptor{}, fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	err = r.dbPutBlobUploadComplete(
+		ctx,
+		artInfo.RegIdentifier,
+		mediaType,
+		desc.Digest.String(),
+		int(desc.Size),
+		artInfo,
+	)
+	if err != nil {
+		return manifest.Descriptor{}, err
+	}
+
+	desc.MediaType = mediaType
+	return desc, nil
+}
+
+// validateHelmManifest makes sure a Helm registry only stores Helm charts and
+// artifacts, like signatures, which refer to them.
+func (r *LocalRegistry) validateHelmManifest(
+	ctx context.Context,
+	artInfo pkg.RegistryInfo,
+	mfst manifest.Manifest,
+) error {
+	registry, err := r.registryDao.GetByParentIDAndName(ctx, artInfo.ParentID, artInfo.RegIdentifier)
+	if err != nil {
+		return errcode.ErrCodeRegNotFound.WithDetail(err)
+	}
+	if registry.PackageType != artifact.PackageTypeHELM {
+		return nil
+	}
+
+	ociManifest, ok := mfst.(*ocischema.DeserializedManifest)
+	if !ok {
+		return errcode.ErrCodeManifestInvalid.WithDetail("helm registries only accept OCI image manifests")
+	}
+	if ociManifest.Manifest.Subject != nil {
+		return nil
+	}
+	if ociManifest.Manifest.Config.MediaType != HelmConfigMediaType {
+		return errcode.ErrCodeManifestInvalid.WithDetail(
+			fmt.Sprintf("helm registries only accept manifests with config media type %s", HelmConfigMediaType),
+		)
+	}
+	return nil
+}
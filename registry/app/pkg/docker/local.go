@@ -715,6 +715,11 @@ func (r *LocalRegistry) PutManifest(
 		return responseHeaders, errs
 	}
 
+	if err = r.validateHelmManifest(ctx, artInfo, unmarshalManifest); err != nil {
+		errs = append(errs, err)
+		return responseHeaders, errs
+	}
+
 	if d != "" {
 		if desc.Digest != d {
 			log.Ctx(ctx).Error().Stack().Err(err).Msgf("payload digest does not match: %q != %q", desc.Digest, d)
@@ -1272,7 +1277,6 @@ func (r *LocalRegistry) ListReferrers(
 	artInfo pkg.RegistryInfo,
 	artifactType string,
 ) (index *v1.Index, responseHeaders *commons.ResponseHeaders, err error) {
-	mfs := make([]v1.Descriptor, 0)
 	rsHeaders := &commons.ResponseHeaders{
 		Headers: map[string]string{"Content-Type": ReferrersMediaType},
 		Code:    0,
@@ -1288,42 +1292,13 @@ func (r *LocalRegistry) ListReferrers(
 		err := errcode.ErrCodeNameUnknown.WithDetail(artInfo.RegIdentifier)
 		return nil, rsHeaders, err
 	}
-	subjectDigest, err := types.NewDigest(digest.Digest(artInfo.Digest))
-	if err != nil {
-		return nil, rsHeaders, err
-	}
-	manifests, err := r.manifestDao.ListManifestsBySubjectDigest(
-		ctx, registry.ID, subjectDigest,
+	mfs, err := ListReferrerDescriptors(
+		ctx, r.manifestDao, registry.ID, artInfo.Image, digest.Digest(artInfo.Digest), artifactType,
 	)
-	if err != nil && !errors.Is(err, store2.ErrResourceNotFound) {
+	if err != nil {
 		return nil, rsHeaders, err
 	}
 
-	for _, m := range manifests {
-		mf := v1.Descriptor{
-			MediaType:   m.MediaType,
-			Size:        m.TotalSize,
-			Digest:      m.Digest,
-			Annotations: m.Annotations,
-		}
-
-		if m.ArtifactType.Valid {
-			mf.ArtifactType = m.ArtifactType.String
-		} else {
-			mf.ArtifactType = m.Configuration.MediaType
-		}
-
-		// filter by the artifactType since the artifactType is
-		// actually the config media type of the artifact.
-		if artifactType != "" {
-			if mf.ArtifactType == artifactType {
-				mfs = append(mfs, mf)
-			}
-		} else {
-			mfs = append(mfs, mf)
-		}
-	}
-
 	// Populate index manifest
 	result := &v1.Index{}
 	result.SchemaVersion = ReferrersSchemaVersion
@@ -539,6 +539,9 @@ func (l *manifestService) dbPutManifestV2(
 	if err := l.manifestDao.CreateOrFind(ctx, m); err != nil {
 		return err
 	}
+	if err := l.linkReferrers(ctx, m); err != nil {
+		return err
+	}
 
 	dbManifest = m
 
@@ -666,6 +669,16 @@ func (l *manifestService) handleSubject(
 	return nil
 }
 
+// linkReferrers assigns the manifest as subject of the referrers that were pushed
+// before it, so they are listed by the referrers API and kept alongside it.
+func (l *manifestService) linkReferrers(ctx context.Context, m *types.Manifest) error {
+	dgst, err := types.NewDigest(m.Digest)
+	if err != nil {
+		return err
+	}
+	return l.manifestDao.AssignSubject(ctx, m.RegistryID, m.ImageName, dgst, m.ID)
+}
+
 func (l *manifestService) dbPutManifestOCI(
 	ctx context.Context,
 	manifest *ocischema.DeserializedManifest,
@@ -757,6 +770,9 @@ func (l *manifestService) dbPutManifestList(
 			if err := l.manifestDao.CreateOrFind(ctx, ml); err != nil {
 				return err
 			}
+			if err := l.linkReferrers(ctx, ml); err != nil {
+				return err
+			}
 
 			// Associate manifests to the manifest list.
 			for _, m := range mm {
@@ -956,6 +972,9 @@ func (l *manifestService) dbPutImageIndex(
 			if err := l.manifestDao.CreateOrFind(ctx, mi); err != nil {
 				return err
 			}
+			if err := l.linkReferrers(ctx, mi); err != nil {
+				return err
+			}
 
 			// Associate manifests to the manifest list.
 			for _, m := range mm {
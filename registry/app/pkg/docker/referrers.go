@@ -0,0 +1,128 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	store2 "github.com/harness/gitness/store"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// maxTagLength is the maximum length of a tag as defined by the distribution spec.
+const maxTagLength = 128
+
+// ReferrersTag returns the tag under which clients following the referrers tag
+// schema push the referrers index of the subject with the given digest.
+func ReferrersTag(dgst digest.Digest) string {
+	tag := dgst.Algorithm().String() + "-" + dgst.Encoded()
+	if len(tag) > maxTagLength {
+		tag = tag[:maxTagLength]
+	}
+	return tag
+}
+
+// ListReferrerDescriptors lists the descriptors of the manifests referring to the
+// subject with the given digest. Referrers tracked through the subject field are
+// merged with the ones of the referrers tag schema index, if one was pushed.
+// An empty artifactType doesn't filter the referrers.
+func ListReferrerDescriptors(
+	ctx context.Context,
+	manifestDao store.ManifestRepository,
+	registryID int64,
+	imageName string,
+	subject digest.Digest,
+	artifactType string,
+) ([]v1.Descriptor, error) {
+	subjectDigest, err := types.NewDigest(subject)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := manifestDao.ListManifestsBySubjectDigest(ctx, registryID, imageName, subjectDigest)
+	if err != nil && !errors.Is(err, store2.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	descriptors := make([]v1.Descriptor, 0, len(manifests))
+	seen := make(map[digest.Digest]struct{}, len(manifests))
+	add := func(d v1.Descriptor) {
+		if _, ok := seen[d.Digest]; ok {
+			return
+		}
+		seen[d.Digest] = struct{}{}
+		if artifactType == "" || d.ArtifactType == artifactType {
+			descriptors = append(descriptors, d)
+		}
+	}
+
+	for _, m := range manifests {
+		d := v1.Descriptor{
+			MediaType:   m.MediaType,
+			Size:        m.TotalSize,
+			Digest:      m.Digest,
+			Annotations: m.Annotations,
+		}
+		if m.ArtifactType.Valid {
+			d.ArtifactType = m.ArtifactType.String
+		} else if m.Configuration != nil {
+			// the artifactType of a manifest without one is its config media type.
+			d.ArtifactType = m.Configuration.MediaType
+		}
+		add(d)
+	}
+
+	fallback, err := listTagSchemaReferrers(ctx, manifestDao, registryID, imageName, subject)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range fallback {
+		add(d)
+	}
+	return descriptors, nil
+}
+
+// listTagSchemaReferrers returns the entries of the referrers index pushed under
+// the referrers tag of the subject by clients not using the referrers API.
+func listTagSchemaReferrers(
+	ctx context.Context,
+	manifestDao store.ManifestRepository,
+	registryID int64,
+	imageName string,
+	subject digest.Digest,
+) ([]v1.Descriptor, error) {
+	m, err := manifestDao.FindManifestByTagName(ctx, registryID, imageName, ReferrersTag(subject))
+	if errors.Is(err, store2.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.MediaType != v1.MediaTypeImageIndex {
+		return nil, nil
+	}
+
+	var index v1.Index
+	if err := json.Unmarshal(m.Payload, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse referrers index %s: %w", m.Digest, err)
+	}
+	return index.Manifests, nil
+}
@@ -0,0 +1,101 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	store2 "github.com/harness/gitness/store"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type referrersManifestDao struct {
+	store.ManifestRepository
+	referrers types.Manifests
+	tags      map[string]*types.Manifest
+}
+
+func (d *referrersManifestDao) ListManifestsBySubjectDigest(
+	_ context.Context, _ int64, _ string, _ types.Digest,
+) (types.Manifests, error) {
+	return d.referrers, nil
+}
+
+func (d *referrersManifestDao) FindManifestByTagName(
+	_ context.Context, _ int64, _ string, tag string,
+) (*types.Manifest, error) {
+	m, ok := d.tags[tag]
+	if !ok {
+		return nil, store2.ErrResourceNotFound
+	}
+	return m, nil
+}
+
+func TestReferrersTag(t *testing.T) {
+	dgst := digest.FromString("subject")
+	assert.Equal(t, "sha256-"+dgst.Encoded(), ReferrersTag(dgst))
+	assert.Len(t, ReferrersTag(digest.SHA512.FromString("subject")), maxTagLength)
+}
+
+func TestListReferrerDescriptors(t *testing.T) {
+	subject := digest.FromString("subject")
+	signature := digest.FromString("signature")
+	sbom := digest.FromString("sbom")
+
+	index, err := json.Marshal(v1.Index{
+		MediaType: v1.MediaTypeImageIndex,
+		Manifests: []v1.Descriptor{
+			{MediaType: v1.MediaTypeImageManifest, Digest: signature, ArtifactType: "application/vnd.dev.cosign.sig"},
+			{MediaType: v1.MediaTypeImageManifest, Digest: sbom, ArtifactType: "application/spdx+json"},
+		},
+	})
+	require.NoError(t, err)
+
+	dao := &referrersManifestDao{
+		referrers: types.Manifests{{
+			MediaType:    v1.MediaTypeImageManifest,
+			Digest:       signature,
+			ArtifactType: sql.NullString{String: "application/vnd.dev.cosign.sig", Valid: true},
+		}},
+		tags: map[string]*types.Manifest{
+			ReferrersTag(subject): {MediaType: v1.MediaTypeImageIndex, Payload: index},
+		},
+	}
+
+	descriptors, err := ListReferrerDescriptors(context.Background(), dao, 1, "image", subject, "")
+	require.NoError(t, err)
+	require.Len(t, descriptors, 2)
+	assert.Equal(t, signature, descriptors[0].Digest)
+	assert.Equal(t, sbom, descriptors[1].Digest)
+
+	descriptors, err = ListReferrerDescriptors(context.Background(), dao, 1, "image", subject, "application/spdx+json")
+	require.NoError(t, err)
+	require.Len(t, descriptors, 1)
+	assert.Equal(t, sbom, descriptors[0].Digest)
+
+	delete(dao.tags, ReferrersTag(subject))
+	descriptors, err = ListReferrerDescriptors(context.Background(), dao, 1, "image", subject, "")
+	require.NoError(t, err)
+	assert.Len(t, descriptors, 1)
+}
@@ -0,0 +1,113 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth/authz"
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/gomodule/utils"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Controller serves the module proxy protocol of the Go registries. Modules
+// are only served from the registry itself, there is no upstream proxy.
+type Controller struct {
+	local      *LocalRegistry
+	authorizer authz.Authorizer
+	DBStore    *DBStore
+}
+
+type DBStore struct {
+	RegistryDao store.RegistryRepository
+	ImageDao    store.ImageRepository
+	ArtifactDao store.ArtifactRepository
+	SpaceStore  corestore.SpaceStore
+}
+
+func NewController(
+	local *LocalRegistry,
+	authorizer authz.Authorizer,
+	dBStore *DBStore,
+) *Controller {
+	return &Controller{
+		local:      local,
+		authorizer: authorizer,
+		DBStore:    dBStore,
+	}
+}
+
+func NewDBStore(
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	spaceStore corestore.SpaceStore,
+) *DBStore {
+	return &DBStore{
+		RegistryDao: registryDao,
+		ImageDao:    imageDao,
+		ArtifactDao: artifactDao,
+		SpaceStore:  spaceStore,
+	}
+}
+
+func (c *Controller) ListVersions(ctx context.Context, info pkg.GoModuleArtifactInfo) ([]string, []error) {
+	if err := c.checkAccess(ctx, info, enum.PermissionArtifactsDownload); err != nil {
+		return nil, []error{errcode.ErrCodeDenied.WithDetail(err)}
+	}
+	return c.local.ListVersions(ctx, info)
+}
+
+func (c *Controller) GetLatest(ctx context.Context, info pkg.GoModuleArtifactInfo) (*utils.Info, []error) {
+	if err := c.checkAccess(ctx, info, enum.PermissionArtifactsDownload); err != nil {
+		return nil, []error{errcode.ErrCodeDenied.WithDetail(err)}
+	}
+	return c.local.GetLatest(ctx, info)
+}
+
+func (c *Controller) GetInfo(ctx context.Context, info pkg.GoModuleArtifactInfo) (*utils.Info, []error) {
+	if err := c.checkAccess(ctx, info, enum.PermissionArtifactsDownload); err != nil {
+		return nil, []error{errcode.ErrCodeDenied.WithDetail(err)}
+	}
+	return c.local.GetInfo(ctx, info)
+}
+
+func (c *Controller) DownloadFile(ctx context.Context, info pkg.GoModuleArtifactInfo, extension string) (
+	*commons.ResponseHeaders, *storage.FileReader, string, []error) {
+	if err := c.checkAccess(ctx, info, enum.PermissionArtifactsDownload); err != nil {
+		return nil, nil, "", []error{errcode.ErrCodeDenied.WithDetail(err)}
+	}
+	return c.local.DownloadFile(ctx, info, extension)
+}
+
+func (c *Controller) UploadModule(ctx context.Context, info pkg.GoModuleArtifactInfo, zipFile string) []error {
+	if err := c.checkAccess(ctx, info, enum.PermissionArtifactsUpload); err != nil {
+		return []error{errcode.ErrCodeDenied.WithDetail(err)}
+	}
+	return c.local.UploadModule(ctx, info, zipFile)
+}
+
+func (c *Controller) checkAccess(ctx context.Context, info pkg.GoModuleArtifactInfo, permission enum.Permission) error {
+	return pkg.GetRegistryCheckAccess(
+		ctx, c.DBStore.RegistryDao, c.authorizer, c.DBStore.SpaceStore, info.RegIdentifier, info.ParentID,
+		permission,
+	)
+}
@@ -0,0 +1,283 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/gomodule/utils"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store/database"
+	"github.com/harness/gitness/registry/types"
+	store2 "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"golang.org/x/mod/module"
+)
+
+func NewLocalRegistry(dBStore *DBStore, tx dbtx.Transactor,
+	fileManager filemanager.FileManager,
+) *LocalRegistry {
+	return &LocalRegistry{
+		DBStore:     dBStore,
+		tx:          tx,
+		fileManager: fileManager,
+	}
+}
+
+type LocalRegistry struct {
+	DBStore     *DBStore
+	tx          dbtx.Transactor
+	fileManager filemanager.FileManager
+}
+
+// Module is a module version ready to be published: its validated zip, its
+// go.mod file and where it comes from.
+type Module struct {
+	ZipFile string
+	Mod     []byte
+	Time    time.Time
+	Origin  *database.GoOrigin
+}
+
+// ListVersions returns the released and prereleased versions of a module.
+func (r *LocalRegistry) ListVersions(ctx context.Context, info pkg.GoModuleArtifactInfo) ([]string, []error) {
+	versions, err := r.versions(ctx, info)
+	if err != nil {
+		return nil, processError(err)
+	}
+	return utils.ListVersions(versions), nil
+}
+
+// GetLatest returns the info of the version the @latest query resolves to.
+func (r *LocalRegistry) GetLatest(ctx context.Context, info pkg.GoModuleArtifactInfo) (*utils.Info, []error) {
+	versions, err := r.versions(ctx, info)
+	if err != nil {
+		return nil, processError(err)
+	}
+	info.Version = utils.LatestVersion(versions)
+	if info.Version == "" {
+		return nil, []error{commons.NotFoundError(fmt.Sprintf("module %s has no versions", info.Image), nil)}
+	}
+	return r.GetInfo(ctx, info)
+}
+
+// GetInfo returns the info of a module version.
+func (r *LocalRegistry) GetInfo(ctx context.Context, info pkg.GoModuleArtifactInfo) (*utils.Info, []error) {
+	metadata, err := r.metadata(ctx, info)
+	if err != nil {
+		return nil, processError(err)
+	}
+	moduleInfo := &utils.Info{
+		Version: info.Version,
+		Time:    metadata.Time,
+	}
+	if metadata.Origin != nil {
+		moduleInfo.Origin = &utils.Origin{
+			VCS:  metadata.Origin.VCS,
+			URL:  metadata.Origin.URL,
+			Ref:  metadata.Origin.Ref,
+			Hash: metadata.Origin.Hash,
+		}
+	}
+	return moduleInfo, nil
+}
+
+// DownloadFile serves the go.mod file or the zip of a module version.
+func (r *LocalRegistry) DownloadFile(ctx context.Context, info pkg.GoModuleArtifactInfo, extension string) (
+	responseHeaders *commons.ResponseHeaders, body *storage.FileReader, redirectURL string, errs []error) {
+	if _, err := r.metadata(ctx, info); err != nil {
+		return nil, nil, "", processError(err)
+	}
+
+	fileName := utils.FileName(info.Version, extension)
+	filePath := utils.GetFilePath(info.Image, info.Version, fileName)
+	fileReader, size, redirectURL, err := r.fileManager.DownloadFile(ctx, filePath, types.Registry{
+		ID:   info.RegistryID,
+		Name: info.RootIdentifier,
+	}, info.RootIdentifier)
+	if err != nil {
+		return nil, nil, "", processError(err)
+	}
+
+	contentType := utils.ContentTypeText
+	if extension == utils.ZipExtension {
+		contentType = utils.ContentTypeZip
+	}
+	responseHeaders = &commons.ResponseHeaders{
+		Headers: map[string]string{
+			commons.HeaderContentType: contentType,
+		},
+		Code: http.StatusOK,
+	}
+	if redirectURL == "" {
+		responseHeaders.Headers[commons.HeaderContentLength] = strconv.FormatInt(size, 10)
+	}
+	return responseHeaders, fileReader, redirectURL, nil
+}
+
+// UploadModule publishes a module version from its zip, as created by
+// `go mod download` or golang.org/x/mod/zip. The zip is validated the way the
+// go command does before using it.
+func (r *LocalRegistry) UploadModule(ctx context.Context, info pkg.GoModuleArtifactInfo, zipFile string) []error {
+	mod, err := utils.ReadZip(module.Version{Path: info.Image, Version: info.Version}, zipFile)
+	if err != nil {
+		return []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+	}
+	return r.PublishModule(ctx, info, Module{
+		ZipFile: zipFile,
+		Mod:     mod,
+		Time:    time.Now(),
+	})
+}
+
+// PublishModule stores the files of a module version. Versions are immutable,
+// the go checksum database would reject a version whose content changed.
+func (r *LocalRegistry) PublishModule(ctx context.Context, info pkg.GoModuleArtifactInfo, m Module) []error {
+	exists, err := r.VersionExists(ctx, info)
+	if err != nil {
+		return processError(err)
+	}
+	if exists {
+		return []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("module %s version %s already exists", info.Image, info.Version))}
+	}
+
+	modName := utils.FileName(info.Version, utils.ModExtension)
+	modInfo, err := r.uploadFile(ctx, info, modName, bytes.NewReader(m.Mod))
+	if err != nil {
+		return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	zipFile, err := os.Open(m.ZipFile)
+	if err != nil {
+		return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	defer zipFile.Close()
+	zipName := utils.FileName(info.Version, utils.ZipExtension)
+	zipInfo, err := r.uploadFile(ctx, info, zipName, zipFile)
+	if err != nil {
+		return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+
+	now := time.Now().UnixMilli()
+	goMetadata := &database.GoMetadata{
+		Files: []database.File{
+			{Size: modInfo.Size, Filename: modInfo.Filename, CreatedAt: now},
+			{Size: zipInfo.Size, Filename: zipInfo.Filename, CreatedAt: now},
+		},
+		FileCount: 2,
+		Time:      m.Time.UTC().Format(time.RFC3339),
+		GoVersion: utils.GoVersion(m.Mod),
+		Origin:    m.Origin,
+	}
+
+	err = r.tx.WithTx(
+		ctx, func(ctx context.Context) error {
+			dbImage := &types.Image{
+				Name:       info.Image,
+				RegistryID: info.RegistryID,
+				Enabled:    true,
+			}
+			if err := r.DBStore.ImageDao.CreateOrUpdate(ctx, dbImage); err != nil {
+				return err
+			}
+			metadataJSON, err := json.Marshal(goMetadata)
+			if err != nil {
+				return err
+			}
+			return r.DBStore.ArtifactDao.CreateOrUpdate(ctx, &types.Artifact{
+				ImageID:  dbImage.ID,
+				Version:  info.Version,
+				Metadata: metadataJSON,
+			})
+		})
+	if err != nil {
+		return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return nil
+}
+
+// VersionExists tells whether the version of the module was published.
+func (r *LocalRegistry) VersionExists(ctx context.Context, info pkg.GoModuleArtifactInfo) (bool, error) {
+	_, err := r.metadata(ctx, info)
+	if errors.Is(err, store2.ErrResourceNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *LocalRegistry) uploadFile(
+	ctx context.Context, info pkg.GoModuleArtifactInfo, fileName string, file io.Reader,
+) (pkg.FileInfo, error) {
+	filePath := utils.GetFilePath(info.Image, info.Version, fileName)
+	return r.fileManager.UploadFile(ctx, filePath, info.RegIdentifier,
+		info.RegistryID, info.RootParentID, info.RootIdentifier, nil, file, fileName)
+}
+
+func (r *LocalRegistry) versions(ctx context.Context, info pkg.GoModuleArtifactInfo) ([]string, error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := r.DBStore.ArtifactDao.GetAllArtifactsByImageID(ctx, dbImage.ID)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(*artifacts))
+	for _, artifact := range *artifacts {
+		versions = append(versions, artifact.Version)
+	}
+	return versions, nil
+}
+
+func (r *LocalRegistry) metadata(ctx context.Context, info pkg.GoModuleArtifactInfo) (*database.GoMetadata, error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, err
+	}
+	dbArtifact, err := r.DBStore.ArtifactDao.GetByName(ctx, dbImage.ID, info.Version)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &database.GoMetadata{}
+	if err = json.Unmarshal(dbArtifact.Metadata, metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of %s %s: %w", info.Image, info.Version, err)
+	}
+	return metadata, nil
+}
+
+func processError(err error) []error {
+	if errors.Is(err, store2.ErrResourceNotFound) ||
+		strings.Contains(err.Error(), sql.ErrNoRows.Error()) ||
+		strings.Contains(err.Error(), "resource not found") {
+		return []error{commons.NotFoundError(err.Error(), err)}
+	}
+	return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+}
@@ -0,0 +1,192 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	modzip "golang.org/x/mod/zip"
+)
+
+const goModFile = "go.mod"
+
+var (
+	ErrInvalidZip     = errors.New("invalid module zip")
+	ErrModuleMismatch = errors.New("module path does not match go.mod")
+	ErrNoGoMod        = errors.New("no go.mod found")
+)
+
+// Info is the JSON document served for the .info and @latest requests.
+type Info struct {
+	Version string
+	Time    string
+	Origin  *Origin `json:",omitempty"`
+}
+
+// Origin describes where a version was built from, which lets the go
+// command verify a cached version is still current.
+type Origin struct {
+	VCS  string `json:",omitempty"`
+	URL  string `json:",omitempty"`
+	Ref  string `json:",omitempty"`
+	Hash string `json:",omitempty"`
+}
+
+// ReadZip validates the module zip at zipFile, as the go command does before
+// extracting it, and returns the go.mod of the module. Modules without a
+// go.mod file get the one the go command synthesizes for them.
+func ReadZip(m module.Version, zipFile string) ([]byte, error) {
+	cf, err := modzip.CheckZip(m, zipFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZip, err)
+	}
+	if err := cf.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZip, err)
+	}
+
+	r, err := zip.OpenReader(zipFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZip, err)
+	}
+	defer r.Close()
+
+	mod, err := readFile(&r.Reader, m.String()+"/"+goModFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return SynthesizeModFile(m.Path), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkModulePath(m.Path, mod); err != nil {
+		return nil, err
+	}
+	return mod, nil
+}
+
+// SynthesizeModFile returns the go.mod the go command uses for a module that
+// has none.
+func SynthesizeModFile(modulePath string) []byte {
+	return []byte("module " + modfile.AutoQuote(modulePath) + "\n")
+}
+
+// GoVersion returns the go directive of a go.mod file, if any.
+func GoVersion(mod []byte) string {
+	f, err := modfile.ParseLax(goModFile, mod, nil)
+	if err != nil || f.Go == nil {
+		return ""
+	}
+	return f.Go.Version
+}
+
+// ReadArchiveModFile returns the go.mod found in the dir directory of a
+// repository archive, which has no prefix in front of the repository paths.
+func ReadArchiveModFile(r *zip.Reader, dir string) ([]byte, error) {
+	mod, err := readFile(r, path.Join(dir, goModFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoGoMod
+	}
+	return mod, err
+}
+
+// CreateZipFromArchive writes the zip of module m built from the files in the
+// dir directory of a repository archive. Files of nested modules, vendored
+// packages and anything else the go command ignores are left out.
+func CreateZipFromArchive(w io.Writer, m module.Version, r *zip.Reader, dir string) error {
+	prefix := ""
+	if dir != "" && dir != "." {
+		prefix = strings.Trim(dir, "/") + "/"
+	}
+
+	var files []modzip.File
+	for _, f := range r.File {
+		if !strings.HasPrefix(f.Name, prefix) || strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		files = append(files, archiveFile{f: f, path: strings.TrimPrefix(f.Name, prefix)})
+	}
+
+	if err := modzip.Create(w, m, files); err != nil {
+		return fmt.Errorf("failed to create module zip: %w", err)
+	}
+	return nil
+}
+
+// archiveFile exposes an archive entry as a file of the module zip.
+type archiveFile struct {
+	f    *zip.File
+	path string
+}
+
+func (a archiveFile) Path() string {
+	return a.path
+}
+
+func (a archiveFile) Lstat() (fs.FileInfo, error) {
+	return a.f.FileInfo(), nil
+}
+
+func (a archiveFile) Open() (io.ReadCloser, error) {
+	return a.f.Open()
+}
+
+func readFile(r *zip.Reader, name string) ([]byte, error) {
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		return data, nil
+	}
+	return nil, fs.ErrNotExist
+}
+
+func checkModulePath(modulePath string, mod []byte) error {
+	declared := modfile.ModulePath(mod)
+	if declared == "" {
+		return fmt.Errorf("%w: go.mod has no module directive", ErrInvalidZip)
+	}
+	if declared != modulePath {
+		return fmt.Errorf("%w: go.mod declares %q, not %q", ErrModuleMismatch, declared, modulePath)
+	}
+	return nil
+}
+
+// ModulePath returns the module path declared by a go.mod file.
+func ModulePath(mod []byte) (string, error) {
+	modulePath := modfile.ModulePath(mod)
+	if modulePath == "" {
+		return "", fmt.Errorf("%w: go.mod has no module directive", ErrInvalidModule)
+	}
+	if err := module.CheckPath(modulePath); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidModule, err)
+	}
+	return modulePath, nil
+}
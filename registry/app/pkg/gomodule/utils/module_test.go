@@ -0,0 +1,141 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+)
+
+// createZip writes a zip with the given files, in the given order.
+func createZip(t *testing.T, files [][2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(f[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, data []byte) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "module.zip")
+	require.NoError(t, os.WriteFile(name, data, 0o600))
+	return name
+}
+
+func zipFileNames(t *testing.T, data []byte) []string {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	names := make([]string, 0, len(r.File))
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReadZip(t *testing.T) {
+	m := module.Version{Path: "example.com/mod", Version: "v1.0.0"}
+	zipFile := writeFile(t, createZip(t, [][2]string{
+		{"example.com/mod@v1.0.0/go.mod", "module example.com/mod\n\ngo 1.22\n"},
+		{"example.com/mod@v1.0.0/mod.go", "package mod\n"},
+	}))
+
+	mod, err := ReadZip(m, zipFile)
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/mod\n\ngo 1.22\n", string(mod))
+	assert.Equal(t, "1.22", GoVersion(mod))
+}
+
+func TestReadZip_SynthesizesModFile(t *testing.T) {
+	m := module.Version{Path: "example.com/legacy", Version: "v1.0.0"}
+	zipFile := writeFile(t, createZip(t, [][2]string{
+		{"example.com/legacy@v1.0.0/legacy.go", "package legacy\n"},
+	}))
+
+	mod, err := ReadZip(m, zipFile)
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/legacy\n", string(mod))
+	assert.Equal(t, "", GoVersion(mod))
+}
+
+func TestReadZip_Invalid(t *testing.T) {
+	m := module.Version{Path: "example.com/mod", Version: "v1.0.0"}
+
+	_, err := ReadZip(m, writeFile(t, createZip(t, [][2]string{
+		{"example.com/other@v1.0.0/go.mod", "module example.com/other\n"},
+	})))
+	assert.ErrorIs(t, err, ErrInvalidZip)
+
+	_, err = ReadZip(m, writeFile(t, createZip(t, [][2]string{
+		{"example.com/mod@v1.0.0/go.mod", "module example.com/other\n"},
+	})))
+	assert.ErrorIs(t, err, ErrModuleMismatch)
+
+	_, err = ReadZip(m, writeFile(t, []byte("not a zip")))
+	assert.ErrorIs(t, err, ErrInvalidZip)
+}
+
+func TestCreateZipFromArchive(t *testing.T) {
+	archive := createZip(t, [][2]string{
+		{"go.mod", "module example.com/repo\n"},
+		{"api/", ""},
+		{"api/go.mod", "module example.com/repo/api\n"},
+		{"api/api.go", "package api\n"},
+		{"api/internal/go.mod", "module example.com/repo/api/internal\n"},
+		{"api/internal/internal.go", "package internal\n"},
+		{"api/vendor/example.com/dep/dep.go", "package dep\n"},
+		{"main.go", "package main\n"},
+	})
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	mod, err := ReadArchiveModFile(r, "api")
+	require.NoError(t, err)
+	modulePath, err := ModulePath(mod)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/repo/api", modulePath)
+
+	m := module.Version{Path: modulePath, Version: "v1.2.0"}
+	var buf bytes.Buffer
+	require.NoError(t, CreateZipFromArchive(&buf, m, r, "api"))
+	assert.Equal(t, []string{
+		"example.com/repo/api@v1.2.0/api.go",
+		"example.com/repo/api@v1.2.0/go.mod",
+	}, zipFileNames(t, buf.Bytes()))
+
+	// The created zip is valid for the go command.
+	mod, err = ReadZip(m, writeFile(t, buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/repo/api\n", string(mod))
+
+	_, err = ReadArchiveModFile(r, "cmd")
+	assert.ErrorIs(t, err, ErrNoGoMod)
+}
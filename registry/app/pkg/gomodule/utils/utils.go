@@ -0,0 +1,212 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	ListPath      = "list"
+	LatestPath    = "@latest"
+	VersionsPath  = "@v"
+	InfoExtension = ".info"
+	ModExtension  = ".mod"
+	ZipExtension  = ".zip"
+
+	ContentTypeText = "text/plain; charset=utf-8"
+	ContentTypeJSON = "application/json"
+	ContentTypeZip  = "application/zip"
+)
+
+// Action is the kind of GOPROXY request made for a module.
+type Action string
+
+const (
+	ActionList   Action = "list"
+	ActionLatest Action = "latest"
+	ActionInfo   Action = "info"
+	ActionMod    Action = "mod"
+	ActionZip    Action = "zip"
+)
+
+var (
+	ErrInvalidPath    = errors.New("invalid module request path")
+	ErrInvalidModule  = errors.New("invalid module path")
+	ErrInvalidVersion = errors.New("invalid module version")
+)
+
+// Request is a parsed GOPROXY request.
+type Request struct {
+	ModulePath string
+	Version    string
+	Action     Action
+}
+
+// ParseRequestPath parses the part of a GOPROXY URL following the registry,
+// e.g. "github.com/!azure/sdk/@v/v1.2.0.zip", into the unescaped module
+// path, the version and the requested action.
+func ParseRequestPath(p string) (Request, error) {
+	p = strings.Trim(p, "/")
+
+	if escaped, ok := strings.CutSuffix(p, "/"+LatestPath); ok {
+		modulePath, err := unescapePath(escaped)
+		if err != nil {
+			return Request{}, err
+		}
+		return Request{ModulePath: modulePath, Action: ActionLatest}, nil
+	}
+
+	i := strings.LastIndex(p, "/"+VersionsPath+"/")
+	if i <= 0 {
+		return Request{}, fmt.Errorf("%w: %q", ErrInvalidPath, p)
+	}
+	modulePath, err := unescapePath(p[:i])
+	if err != nil {
+		return Request{}, err
+	}
+
+	file := p[i+len(VersionsPath)+2:]
+	if file == ListPath {
+		return Request{ModulePath: modulePath, Action: ActionList}, nil
+	}
+
+	var escapedVersion string
+	var action Action
+	switch {
+	case strings.HasSuffix(file, InfoExtension):
+		escapedVersion, action = strings.TrimSuffix(file, InfoExtension), ActionInfo
+	case strings.HasSuffix(file, ModExtension):
+		escapedVersion, action = strings.TrimSuffix(file, ModExtension), ActionMod
+	case strings.HasSuffix(file, ZipExtension):
+		escapedVersion, action = strings.TrimSuffix(file, ZipExtension), ActionZip
+	default:
+		return Request{}, fmt.Errorf("%w: %q", ErrInvalidPath, p)
+	}
+
+	version, err := module.UnescapeVersion(escapedVersion)
+	if err != nil {
+		return Request{}, fmt.Errorf("%w: %q", ErrInvalidVersion, escapedVersion)
+	}
+	if err := ValidateVersion(modulePath, version); err != nil {
+		return Request{}, err
+	}
+	return Request{ModulePath: modulePath, Version: version, Action: action}, nil
+}
+
+func unescapePath(escaped string) (string, error) {
+	modulePath, err := module.UnescapePath(escaped)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidModule, escaped)
+	}
+	if err := module.CheckPath(modulePath); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidModule, err)
+	}
+	return modulePath, nil
+}
+
+// ValidateVersion checks the version is a canonical semantic version that
+// matches the major version suffix of the module path.
+func ValidateVersion(modulePath string, version string) error {
+	if version == "" || module.CanonicalVersion(version) != version {
+		return fmt.Errorf("%w: %q is not a canonical semantic version", ErrInvalidVersion, version)
+	}
+	if err := module.Check(modulePath, version); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVersion, err)
+	}
+	return nil
+}
+
+// FileName returns the name of the given module file of a version.
+func FileName(version string, extension string) string {
+	return version + extension
+}
+
+// GetFilePath returns the path a module file is stored under.
+func GetFilePath(modulePath string, version string, fileName string) string {
+	return "/" + modulePath + "/" + version + "/" + fileName
+}
+
+// ModuleURL returns the URL a module is served under in the given registry,
+// which is the module path in its escaped form.
+func ModuleURL(registryURL string, modulePath string) string {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		escaped = modulePath
+	}
+	return strings.TrimRight(registryURL, "/") + "/" + escaped
+}
+
+// FileURL returns the download URL of a module file in the given registry.
+func FileURL(registryURL string, modulePath string, fileName string) string {
+	return ModuleURL(registryURL, modulePath) + "/" + VersionsPath + "/" + fileName
+}
+
+// SortVersions sorts versions in increasing semantic version order.
+func SortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return semver.Compare(versions[i], versions[j]) < 0
+	})
+}
+
+// ListVersions returns the versions "go list -m -versions" knows about: the
+// released and prereleased versions, without pseudo-versions, sorted.
+func ListVersions(versions []string) []string {
+	list := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if !module.IsPseudoVersion(v) {
+			list = append(list, v)
+		}
+	}
+	SortVersions(list)
+	return list
+}
+
+// LatestVersion picks the version the @latest query resolves to: the highest
+// release, else the highest prerelease, else the highest pseudo-version.
+func LatestVersion(versions []string) string {
+	var release, prerelease, pseudo string
+	for _, v := range versions {
+		switch {
+		case module.IsPseudoVersion(v):
+			pseudo = maxVersion(pseudo, v)
+		case semver.Prerelease(v) != "":
+			prerelease = maxVersion(prerelease, v)
+		default:
+			release = maxVersion(release, v)
+		}
+	}
+	switch {
+	case release != "":
+		return release
+	case prerelease != "":
+		return prerelease
+	default:
+		return pseudo
+	}
+}
+
+func maxVersion(current string, v string) string {
+	if current == "" || semver.Compare(v, current) > 0 {
+		return v
+	}
+	return current
+}
@@ -0,0 +1,94 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequestPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected Request
+	}{
+		{"example.com/mod/@v/list", Request{ModulePath: "example.com/mod", Action: ActionList}},
+		{"example.com/mod/@latest", Request{ModulePath: "example.com/mod", Action: ActionLatest}},
+		{"example.com/mod/@v/v1.2.3.info", Request{"example.com/mod", "v1.2.3", ActionInfo}},
+		{"example.com/mod/@v/v1.2.3.mod", Request{"example.com/mod", "v1.2.3", ActionMod}},
+		{"/example.com/mod/@v/v1.2.3.zip", Request{"example.com/mod", "v1.2.3", ActionZip}},
+		{"github.com/!azure/sdk/v2/@v/v2.0.0-!r!c1.zip", Request{"github.com/Azure/sdk/v2", "v2.0.0-RC1", ActionZip}},
+		{
+			"example.com/mod/@v/v0.0.0-20240101000000-abcdefabcdef.info",
+			Request{"example.com/mod", "v0.0.0-20240101000000-abcdefabcdef", ActionInfo},
+		},
+	}
+	for _, test := range tests {
+		request, err := ParseRequestPath(test.path)
+		require.NoError(t, err, test.path)
+		assert.Equal(t, test.expected, request, test.path)
+	}
+}
+
+func TestParseRequestPath_Invalid(t *testing.T) {
+	tests := map[string]error{
+		"example.com/mod":                 ErrInvalidPath,
+		"example.com/mod/@v/":             ErrInvalidPath,
+		"example.com/mod/@v/v1.0.0.tar":   ErrInvalidPath,
+		"@v/list":                         ErrInvalidPath,
+		"example.com/Mod/@v/list":         ErrInvalidModule,
+		"example.com/mod/@v/master.info":  ErrInvalidVersion,
+		"example.com/mod/@v/v1.0.info":    ErrInvalidVersion,
+		"example.com/mod/@v/v2.0.0.mod":   ErrInvalidVersion,
+		"example.com/mod/v2/@v/v1.0.0.go": ErrInvalidPath,
+	}
+	for path, expected := range tests {
+		_, err := ParseRequestPath(path)
+		assert.ErrorIs(t, err, expected, path)
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	assert.NoError(t, ValidateVersion("example.com/mod", "v1.0.0"))
+	assert.NoError(t, ValidateVersion("example.com/mod", "v2.0.0+incompatible"))
+	assert.NoError(t, ValidateVersion("example.com/mod/v2", "v2.1.0"))
+	assert.ErrorIs(t, ValidateVersion("example.com/mod/v2", "v1.0.0"), ErrInvalidVersion)
+	assert.ErrorIs(t, ValidateVersion("example.com/mod", "1.0.0"), ErrInvalidVersion)
+	assert.ErrorIs(t, ValidateVersion("example.com/mod", "v1.0"), ErrInvalidVersion)
+}
+
+func TestFileURL(t *testing.T) {
+	assert.Equal(t,
+		"https://reg.example.com/go/root/reg/github.com/!azure/sdk/@v/v1.0.0.zip",
+		FileURL("https://reg.example.com/go/root/reg/", "github.com/Azure/sdk", FileName("v1.0.0", ZipExtension)))
+	assert.Equal(t, "/example.com/mod/v1.0.0/v1.0.0.mod",
+		GetFilePath("example.com/mod", "v1.0.0", FileName("v1.0.0", ModExtension)))
+}
+
+func TestListVersions(t *testing.T) {
+	versions := []string{"v1.10.0", "v0.0.0-20240101000000-abcdefabcdef", "v1.2.0", "v1.2.0-rc.1", "v1.9.1"}
+	assert.Equal(t, []string{"v1.2.0-rc.1", "v1.2.0", "v1.9.1", "v1.10.0"}, ListVersions(versions))
+	assert.Empty(t, ListVersions(nil))
+}
+
+func TestLatestVersion(t *testing.T) {
+	pseudo := "v0.0.0-20240101000000-abcdefabcdef"
+	assert.Equal(t, "v1.10.0", LatestVersion([]string{"v1.9.0", "v1.10.0", "v2.0.0-beta.1", pseudo}))
+	assert.Equal(t, "v2.0.0-beta.2", LatestVersion([]string{"v2.0.0-beta.1", "v2.0.0-beta.2", pseudo}))
+	assert.Equal(t, pseudo, LatestVersion([]string{pseudo}))
+	assert.Equal(t, "", LatestVersion(nil))
+}
@@ -0,0 +1,56 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomodule
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	gitnessstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+func LocalRegistryProvider(
+	dBStore *DBStore,
+	tx dbtx.Transactor,
+	fileManager filemanager.FileManager,
+) *LocalRegistry {
+	return NewLocalRegistry(dBStore, tx, fileManager)
+}
+
+func DBStoreProvider(
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	spaceStore gitnessstore.SpaceStore,
+) *DBStore {
+	return NewDBStore(registryDao, imageDao, artifactDao, spaceStore)
+}
+
+func ControllerProvider(
+	localRegistry *LocalRegistry,
+	authorizer authz.Authorizer,
+	dBStore *DBStore,
+) *Controller {
+	return NewController(localRegistry, authorizer, dBStore)
+}
+
+var DBStoreSet = wire.NewSet(DBStoreProvider)
+var RegistrySet = wire.NewSet(LocalRegistryProvider)
+var ControllerSet = wire.NewSet(ControllerProvider)
+
+var WireSet = wire.NewSet(ControllerSet, DBStoreSet, RegistrySet)
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	chartFileName = "Chart.yaml"
+	// maxChartFileSize bounds the size of the Chart.yaml we are willing to read.
+	maxChartFileSize = 1 << 20
+)
+
+// chartNameRegex matches chart names which are also valid OCI repository path components.
+var chartNameRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*$`)
+
+var (
+	ErrChartFileNotFound = errors.New("Chart.yaml not found in chart archive")
+	ErrInvalidChart      = errors.New("invalid chart")
+)
+
+// Maintainer describes a chart maintainer.
+type Maintainer struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	URL   string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// Dependency describes a chart dependency.
+type Dependency struct {
+	Name         string   `json:"name" yaml:"name"`
+	Version      string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Repository   string   `json:"repository" yaml:"repository"`
+	Condition    string   `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Tags         []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Enabled      bool     `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	ImportValues []any    `json:"import-values,omitempty" yaml:"import-values,omitempty"`
+	Alias        string   `json:"alias,omitempty" yaml:"alias,omitempty"`
+}
+
+// Metadata is the content of a Chart.yaml file. The JSON form is the one
+// helm stores as the configuration of a chart pushed to an OCI registry.
+type Metadata struct {
+	Name         string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Home         string            `json:"home,omitempty" yaml:"home,omitempty"`
+	Sources      []string          `json:"sources,omitempty" yaml:"sources,omitempty"`
+	Version      string            `json:"version,omitempty" yaml:"version,omitempty"`
+	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Keywords     []string          `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+	Maintainers  []*Maintainer     `json:"maintainers,omitempty" yaml:"maintainers,omitempty"`
+	Icon         string            `json:"icon,omitempty" yaml:"icon,omitempty"`
+	APIVersion   string            `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	Condition    string            `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Tags         string            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	AppVersion   string            `json:"appVersion,omitempty" yaml:"appVersion,omitempty"`
+	Deprecated   bool              `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	KubeVersion  string            `json:"kubeVersion,omitempty" yaml:"kubeVersion,omitempty"`
+	Dependencies []*Dependency     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Type         string            `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// Validate checks the fields helm itself requires, and that the chart name can
+// be used as an image name.
+func (m *Metadata) Validate() error {
+	if m.APIVersion == "" {
+		return fmt.Errorf("%w: chart.metadata.apiVersion is required", ErrInvalidChart)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("%w: chart.metadata.name is required", ErrInvalidChart)
+	}
+	if !chartNameRegex.MatchString(m.Name) {
+		return fmt.Errorf("%w: chart name %q is not supported", ErrInvalidChart, m.Name)
+	}
+	if m.Version == "" {
+		return fmt.Errorf("%w: chart.metadata.version is required", ErrInvalidChart)
+	}
+	if _, err := semver.StrictNewVersion(m.Version); err != nil {
+		return fmt.Errorf("%w: chart.metadata.version %q is not a valid SemVerV2", ErrInvalidChart, m.Version)
+	}
+	return nil
+}
+
+// Tag returns the OCI tag of the chart version. OCI tags don't allow "+",
+// so like helm we replace it with "_".
+func (m *Metadata) Tag() string {
+	return VersionToTag(m.Version)
+}
+
+// VersionToTag converts a chart version to the tag it is stored under.
+func VersionToTag(version string) string {
+	return strings.ReplaceAll(version, "+", "_")
+}
+
+// ParseChart reads the Chart.yaml of a packaged (.tgz) chart.
+func ParseChart(content []byte) (*Metadata, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidChart, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrChartFileNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidChart, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Chart.yaml is at the root of the chart directory: <chart>/Chart.yaml.
+		parts := strings.Split(strings.TrimPrefix(hdr.Name, "./"), "/")
+		if len(parts) != 2 || parts[1] != chartFileName {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tr, maxChartFileSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidChart, err)
+		}
+		if len(data) > maxChartFileSize {
+			return nil, fmt.Errorf("%w: %s is too large", ErrInvalidChart, chartFileName)
+		}
+
+		metadata := &Metadata{}
+		if err = yaml.Unmarshal(data, metadata); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse %s: %w", ErrInvalidChart, chartFileName, err)
+		}
+		if err = metadata.Validate(); err != nil {
+			return nil, err
+		}
+		return metadata, nil
+	}
+}
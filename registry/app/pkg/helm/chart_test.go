@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func packageChart(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestParseChart(t *testing.T) {
+	chart := packageChart(t, map[string]string{
+		"mychart/Chart.yaml": "apiVersion: v2\nname: mychart\nversion: 1.2.3+build.1\n" +
+			"appVersion: \"4.5\"\ndescription: A test chart\nkeywords:\n  - test\n",
+		"mychart/values.yaml":              "replicas: 1\n",
+		"mychart/charts/dep/Chart.yaml":    "apiVersion: v2\nname: dep\nversion: 0.1.0\n",
+		"mychart/templates/configmap.yaml": "kind: ConfigMap\n",
+	})
+
+	metadata, err := ParseChart(chart)
+	require.NoError(t, err)
+	assert.Equal(t, "mychart", metadata.Name)
+	assert.Equal(t, "1.2.3+build.1", metadata.Version)
+	assert.Equal(t, "1.2.3_build.1", metadata.Tag())
+	assert.Equal(t, "4.5", metadata.AppVersion)
+	assert.Equal(t, "A test chart", metadata.Description)
+	assert.Equal(t, []string{"test"}, metadata.Keywords)
+}
+
+func TestParseChart_Invalid(t *testing.T) {
+	_, err := ParseChart([]byte("not a chart"))
+	assert.ErrorIs(t, err, ErrInvalidChart)
+
+	_, err = ParseChart(packageChart(t, map[string]string{"mychart/values.yaml": "a: b\n"}))
+	assert.ErrorIs(t, err, ErrChartFileNotFound)
+
+	_, err = ParseChart(packageChart(t, map[string]string{
+		"mychart/Chart.yaml": "apiVersion: v2\nname: mychart\nversion: latest\n",
+	}))
+	assert.ErrorIs(t, err, ErrInvalidChart)
+
+	_, err = ParseChart(packageChart(t, map[string]string{
+		"MyChart/Chart.yaml": "apiVersion: v2\nname: MyChart\nversion: 1.0.0\n",
+	}))
+	assert.ErrorIs(t, err, ErrInvalidChart)
+}
+
+func TestParseChartFileName(t *testing.T) {
+	name, version, prov, err := ParseChartFileName("my-chart/my-chart-1.0.0-rc.1.tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "my-chart", name)
+	assert.Equal(t, "1.0.0-rc.1", version)
+	assert.False(t, prov)
+
+	_, version, prov, err = ParseChartFileName("my-chart/my-chart-1.0.0.tgz.prov")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+	assert.True(t, prov)
+
+	_, _, _, err = ParseChartFileName("my-chart/other-1.0.0.tgz")
+	assert.Error(t, err)
+	_, _, _, err = ParseChartFileName("my-chart-1.0.0.tgz")
+	assert.Error(t, err)
+}
+
+func TestIndexFile(t *testing.T) {
+	index := NewIndexFile()
+	created := time.Now()
+	index.Add(Metadata{APIVersion: "v2", Name: "mychart", Version: "1.9.0"}, "aa", created)
+	index.Add(Metadata{APIVersion: "v2", Name: "mychart", Version: "1.10.0"}, "bb", created)
+	index.SortEntries()
+
+	versions := index.Entries["mychart"]
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.10.0", versions[0].Version)
+	assert.Equal(t, []string{"charts/mychart/mychart-1.10.0.tgz"}, versions[0].URLs)
+	assert.Equal(t, "bb", versions[0].Digest)
+}
@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth/authz"
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/manifest"
+	"github.com/harness/gitness/registry/app/manifest/ocischema"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/docker"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store"
+	store2 "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rs/zerolog/log"
+)
+
+type Controller struct {
+	spaceStore    corestore.SpaceStore
+	authorizer    authz.Authorizer
+	localRegistry *docker.LocalRegistry
+	DBStore       *DBStore
+}
+
+type DBStore struct {
+	RegistryDao store.RegistryRepository
+	ManifestDao store.ManifestRepository
+	TagDao      store.TagRepository
+}
+
+func NewController(
+	spaceStore corestore.SpaceStore,
+	authorizer authz.Authorizer,
+	localRegistry *docker.LocalRegistry,
+	dBStore *DBStore,
+) *Controller {
+	return &Controller{
+		spaceStore:    spaceStore,
+		authorizer:    authorizer,
+		localRegistry: localRegistry,
+		DBStore:       dBStore,
+	}
+}
+
+func NewDBStore(
+	registryDao store.RegistryRepository,
+	manifestDao store.ManifestRepository,
+	tagDao store.TagRepository,
+) *DBStore {
+	return &DBStore{
+		RegistryDao: registryDao,
+		ManifestDao: manifestDao,
+		TagDao:      tagDao,
+	}
+}
+
+// UploadChart stores a packaged chart, and optionally its provenance file, as
+// an OCI artifact, the same way `helm push` would. This way charts uploaded
+// through the classic repository API can also be pulled with oci:// and show up
+// in the artifact APIs.
+func (c *Controller) UploadChart(
+	ctx context.Context,
+	info pkg.HelmArtifactInfo,
+	chart []byte,
+	prov []byte,
+) (*Metadata, errcode.Error) {
+	err := pkg.GetRegistryCheckAccess(
+		ctx, c.DBStore.RegistryDao, c.authorizer, c.spaceStore, info.RegIdentifier, info.ParentID,
+		enum.PermissionArtifactsUpload,
+	)
+	if err != nil {
+		return nil, errcode.ErrCodeDenied.WithDetail(err)
+	}
+
+	metadata, err := ParseChart(chart)
+	if err != nil {
+		return nil, errcode.ErrCodeInvalidRequest.WithDetail(err)
+	}
+
+	_, err = c.DBStore.ManifestDao.FindManifestByTagName(ctx, info.RegistryID, metadata.Name, metadata.Tag())
+	if err == nil {
+		return nil, errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("chart %s version %s already exists", metadata.Name, metadata.Version),
+		)
+	}
+	if !errors.Is(err, store2.ErrResourceNotFound) {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+
+	regInfo := c.registryInfo(info, metadata.Name)
+	regInfo.Tag = metadata.Tag()
+
+	configJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	config, err := c.localRegistry.PushBlobContent(ctx, regInfo, docker.HelmConfigMediaType, configJSON)
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	layers := make([]manifest.Descriptor, 0, 2)
+	chartLayer, err := c.localRegistry.PushBlobContent(ctx, regInfo, docker.HelmChartLayerMediaType, chart)
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	layers = append(layers, chartLayer)
+	if len(prov) > 0 {
+		provLayer, err := c.localRegistry.PushBlobContent(ctx, regInfo, docker.HelmProvenanceLayerMediaType, prov)
+		if err != nil {
+			return nil, errcode.ErrCodeUnknown.WithDetail(err)
+		}
+		layers = append(layers, provLayer)
+	}
+
+	m, err := ocischema.FromStruct(ocischema.Manifest{
+		Versioned: manifest.Versioned{
+			SchemaVersion: 2,
+			MediaType:     v1.MediaTypeImageManifest,
+		},
+		Config:      config,
+		Layers:      layers,
+		Annotations: chartAnnotations(metadata),
+	})
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+	mediaType, payload, err := m.Payload()
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+
+	_, errs := c.localRegistry.PutManifest(
+		ctx, regInfo, mediaType, io.NopCloser(bytes.NewReader(payload)), int64(len(payload)),
+	)
+	if len(errs) > 0 {
+		log.Ctx(ctx).Error().Msgf("failed to store manifest for chart %s:%s: %v", metadata.Name, metadata.Version, errs)
+		var e errcode.Error
+		if errors.As(errs[0], &e) {
+			return nil, e
+		}
+		return nil, errcode.ErrCodeUnknown.WithDetail(errs[0])
+	}
+	return metadata, errcode.Error{}
+}
+
+// GetIndex builds the repository index from every chart stored in the registry.
+func (c *Controller) GetIndex(ctx context.Context, info pkg.HelmArtifactInfo) (*IndexFile, errcode.Error) {
+	err := pkg.GetRegistryCheckAccess(
+		ctx, c.DBStore.RegistryDao, c.authorizer, c.spaceStore, info.RegIdentifier, info.ParentID,
+		enum.PermissionArtifactsDownload,
+	)
+	if err != nil {
+		return nil, errcode.ErrCodeDenied.WithDetail(err)
+	}
+
+	tags, err := c.DBStore.TagDao.GetAllTagsByConfigMediaType(ctx, info.RegistryID, docker.HelmConfigMediaType)
+	if err != nil {
+		return nil, errcode.ErrCodeUnknown.WithDetail(err)
+	}
+
+	index := NewIndexFile()
+	for _, tag := range *tags {
+		var metadata Metadata
+		if err := json.Unmarshal(tag.ConfigurationPayload, &metadata); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("skipping chart %s:%s with invalid configuration", tag.ImageName, tag.Name)
+			continue
+		}
+		if metadata.Name == "" || metadata.Version == "" {
+			continue
+		}
+		layer, err := findLayer(tag.ManifestPayload, docker.HelmChartLayerMediaType)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("skipping chart %s:%s", tag.ImageName, tag.Name)
+			continue
+		}
+		index.Add(metadata, layer.Digest.Encoded(), tag.CreatedAt)
+	}
+	index.SortEntries()
+	return index, errcode.Error{}
+}
+
+// DownloadChart returns the archive, or the provenance file, of a chart version.
+func (c *Controller) DownloadChart(
+	ctx context.Context,
+	info pkg.HelmArtifactInfo,
+	prov bool,
+) (*storage.FileReader, int64, string, errcode.Error) {
+	err := pkg.GetRegistryCheckAccess(
+		ctx, c.DBStore.RegistryDao, c.authorizer, c.spaceStore, info.RegIdentifier, info.ParentID,
+		enum.PermissionArtifactsDownload,
+	)
+	if err != nil {
+		return nil, 0, "", errcode.ErrCodeDenied.WithDetail(err)
+	}
+
+	m, err := c.DBStore.ManifestDao.FindManifestByTagName(ctx, info.RegistryID, info.Image, VersionToTag(info.Version))
+	if err != nil {
+		if errors.Is(err, store2.ErrResourceNotFound) {
+			return nil, 0, "", errcode.ErrCodeManifestUnknown.WithDetail(
+				fmt.Errorf("chart %s version %s not found", info.Image, info.Version),
+			)
+		}
+		return nil, 0, "", errcode.ErrCodeUnknown.WithDetail(err)
+	}
+
+	mediaType := docker.HelmChartLayerMediaType
+	if prov {
+		mediaType = docker.HelmProvenanceLayerMediaType
+	}
+	layer, err := findLayer(m.Payload, mediaType)
+	if err != nil {
+		return nil, 0, "", errcode.ErrCodeBlobUnknown.WithDetail(err)
+	}
+
+	regInfo := c.registryInfo(info, info.Image)
+	regInfo.Digest = layer.Digest.String()
+	_, fileReader, size, _, redirectURL, errs := c.localRegistry.GetBlob(ctx, regInfo)
+	if len(errs) > 0 {
+		var e errcode.Error
+		if errors.As(errs[0], &e) {
+			return nil, 0, "", e
+		}
+		return nil, 0, "", errcode.ErrCodeUnknown.WithDetail(errs[0])
+	}
+	return fileReader, size, redirectURL, errcode.Error{}
+}
+
+func (c *Controller) registryInfo(info pkg.HelmArtifactInfo, image string) pkg.RegistryInfo {
+	return pkg.RegistryInfo{
+		ArtifactInfo: &pkg.ArtifactInfo{
+			BaseInfo:      info.BaseInfo,
+			RegIdentifier: info.RegIdentifier,
+			Image:         image,
+		},
+		URLBuilder: info.URLBuilder,
+	}
+}
+
+func findLayer(payload []byte, mediaType string) (*manifest.Descriptor, error) {
+	var m ocischema.Manifest
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	for i := range m.Layers {
+		if m.Layers[i].MediaType == mediaType {
+			return &m.Layers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no layer with media type %s found", mediaType)
+}
+
+func chartAnnotations(metadata *Metadata) map[string]string {
+	annotations := map[string]string{
+		v1.AnnotationTitle:   metadata.Name,
+		v1.AnnotationVersion: metadata.Version,
+	}
+	if metadata.Description != "" {
+		annotations[v1.AnnotationDescription] = metadata.Description
+	}
+	if metadata.Home != "" {
+		annotations[v1.AnnotationURL] = metadata.Home
+	}
+	return annotations
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	indexAPIVersion = "v1"
+	chartsPath      = "charts"
+	chartExtension  = ".tgz"
+	provExtension   = ".prov"
+)
+
+// IndexFile is the index.yaml served to classic helm repository clients.
+type IndexFile struct {
+	APIVersion string                     `yaml:"apiVersion"`
+	Generated  time.Time                  `yaml:"generated"`
+	Entries    map[string][]*ChartVersion `yaml:"entries"`
+}
+
+// ChartVersion is a single chart version listed in the index.
+type ChartVersion struct {
+	Metadata `yaml:",inline"`
+	URLs     []string  `yaml:"urls"`
+	Created  time.Time `yaml:"created,omitempty"`
+	Digest   string    `yaml:"digest,omitempty"`
+}
+
+// NewIndexFile returns an empty index.
+func NewIndexFile() *IndexFile {
+	return &IndexFile{
+		APIVersion: indexAPIVersion,
+		Generated:  time.Now().UTC(),
+		Entries:    map[string][]*ChartVersion{},
+	}
+}
+
+// Add adds a chart version to the index.
+func (i *IndexFile) Add(metadata Metadata, digest string, created time.Time) {
+	i.Entries[metadata.Name] = append(i.Entries[metadata.Name], &ChartVersion{
+		Metadata: metadata,
+		URLs:     []string{ChartPath(metadata.Name, metadata.Version)},
+		Created:  created,
+		Digest:   digest,
+	})
+}
+
+// SortEntries sorts the versions of every chart, newest version first.
+func (i *IndexFile) SortEntries() {
+	for _, versions := range i.Entries {
+		sort.SliceStable(versions, func(a, b int) bool {
+			va, errA := semver.NewVersion(versions[a].Version)
+			vb, errB := semver.NewVersion(versions[b].Version)
+			if errA != nil || errB != nil {
+				return versions[a].Version > versions[b].Version
+			}
+			return va.GreaterThan(vb)
+		})
+	}
+}
+
+// ChartPath returns the path of a chart archive relative to the repository root.
+func ChartPath(name, version string) string {
+	return fmt.Sprintf("%s/%s/%s-%s%s", chartsPath, name, name, version, chartExtension)
+}
+
+// ParseChartFileName extracts the chart name and version from a download path
+// relative to the charts directory, i.e. <name>/<name>-<version>.tgz. It also
+// reports whether the provenance file of the chart was requested.
+func ParseChartFileName(path string) (name string, version string, prov bool, err error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != 2 {
+		return "", "", false, fmt.Errorf("invalid chart path: %s", path)
+	}
+	name = segments[0]
+	fileName := segments[1]
+
+	switch {
+	case strings.HasSuffix(fileName, chartExtension+provExtension):
+		prov = true
+		fileName = strings.TrimSuffix(fileName, chartExtension+provExtension)
+	case strings.HasSuffix(fileName, chartExtension):
+		fileName = strings.TrimSuffix(fileName, chartExtension)
+	default:
+		return "", "", false, fmt.Errorf("invalid chart file name: %s", segments[1])
+	}
+
+	if !strings.HasPrefix(fileName, name+"-") {
+		return "", "", false, fmt.Errorf("chart file name %s does not match chart %s", segments[1], name)
+	}
+	version = strings.TrimPrefix(fileName, name+"-")
+	if version == "" {
+		return "", "", false, fmt.Errorf("chart version missing in %s", segments[1])
+	}
+	return name, version, prov, nil
+}
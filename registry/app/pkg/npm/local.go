@@ -0,0 +1,601 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/npm/utils"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store/database"
+	"github.com/harness/gitness/registry/types"
+	store2 "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	ArtifactTypeLocalRegistry = "Local Registry"
+
+	timeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+func NewLocalRegistry(dBStore *DBStore, tx dbtx.Transactor,
+	fileManager filemanager.FileManager,
+) Registry {
+	return &LocalRegistry{
+		DBStore:     dBStore,
+		tx:          tx,
+		fileManager: fileManager,
+	}
+}
+
+type LocalRegistry struct {
+	DBStore     *DBStore
+	tx          dbtx.Transactor
+	fileManager filemanager.FileManager
+}
+
+func (r *LocalRegistry) GetNpmArtifactType() string {
+	return ArtifactTypeLocalRegistry
+}
+
+func (r *LocalRegistry) GetPackument(ctx context.Context, info pkg.NpmArtifactInfo) (
+	packument *utils.Packument, errs []error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, processError(err)
+	}
+	artifacts, err := r.DBStore.ArtifactDao.GetAllArtifactsByImageID(ctx, dbImage.ID)
+	if err != nil {
+		return nil, processError(err)
+	}
+	if len(*artifacts) == 0 {
+		return nil, []error{commons.NotFoundError(fmt.Sprintf("package %s not found", info.Image), nil)}
+	}
+	tags, err := r.DBStore.PackageTagDao.GetAllByImageID(ctx, dbImage.ID)
+	if err != nil {
+		return nil, processError(err)
+	}
+
+	packument, err = buildPackument(info, *artifacts, *tags)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return packument, nil
+}
+
+func (r *LocalRegistry) GetTarball(ctx context.Context, info pkg.NpmArtifactInfo) (
+	responseHeaders *commons.ResponseHeaders, body *storage.FileReader, readCloser io.ReadCloser,
+	redirectURL string, errs []error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, nil, nil, "", processError(err)
+	}
+	_, err = r.DBStore.ArtifactDao.GetByName(ctx, dbImage.ID, info.Version)
+	if err != nil {
+		return nil, nil, nil, "", processError(err)
+	}
+
+	filePath := utils.GetFilePath(info.Image, info.Version, info.FileName)
+	fileReader, size, redirectURL, err := r.fileManager.DownloadFile(ctx, filePath, types.Registry{
+		ID:   info.RegistryID,
+		Name: info.RootIdentifier,
+	}, info.RootIdentifier)
+	if err != nil {
+		return nil, nil, nil, "", processError(err)
+	}
+	responseHeaders = &commons.ResponseHeaders{
+		Headers: map[string]string{
+			commons.HeaderContentType: utils.ContentTypeTarball,
+		},
+		Code: http.StatusOK,
+	}
+	if redirectURL == "" {
+		responseHeaders.Headers[commons.HeaderContentLength] = strconv.FormatInt(size, 10)
+	}
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
+
+// PutPackage stores a version along with its tarball, replacing the version if
+// it already exists.
+func (r *LocalRegistry) PutPackage(
+	ctx context.Context, info pkg.NpmArtifactInfo, version json.RawMessage, tarball io.Reader,
+) (responseHeaders *commons.ResponseHeaders, errs []error) {
+	return r.putPackage(ctx, info, version, tarball, nil)
+}
+
+// PublishPackage handles the document sent by npm publish: the packument of
+// the package with the new version and its tarball attached.
+func (r *LocalRegistry) PublishPackage(
+	ctx context.Context, info pkg.NpmArtifactInfo, packument *utils.Packument,
+) (responseHeaders *commons.ResponseHeaders, errs []error) {
+	if packument.Name != info.Image {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("package name %q does not match the request path", packument.Name))}
+	}
+	if len(packument.Versions) != 1 {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			errors.New("exactly one version must be published at a time"))}
+	}
+
+	var versionName string
+	var raw json.RawMessage
+	for v, doc := range packument.Versions {
+		versionName, raw = v, doc
+	}
+	if err := utils.ValidateVersion(versionName); err != nil {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+	}
+	version, err := utils.ParseVersion(raw)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+	}
+	if version.Name != info.Image || version.Version != versionName {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("version metadata does not match %s@%s", info.Image, versionName))}
+	}
+
+	tarball, err := getAttachment(packument, versionName, version.Dist.Shasum)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+	}
+
+	var tags []string
+	for tag, v := range packument.DistTags {
+		if v != versionName {
+			continue
+		}
+		if err = utils.ValidateDistTag(tag); err != nil {
+			return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		tags = append(tags, utils.LatestTag)
+	}
+
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil && !errors.Is(err, store2.ErrResourceNotFound) {
+		return nil, processError(err)
+	}
+	if dbImage != nil {
+		_, err = r.DBStore.ArtifactDao.GetByName(ctx, dbImage.ID, versionName)
+		if err == nil {
+			return nil, []error{errcode.ErrCodeDenied.WithDetail(
+				fmt.Errorf("cannot publish over the previously published version %s", versionName))}
+		}
+		if !errors.Is(err, store2.ErrResourceNotFound) {
+			return nil, processError(err)
+		}
+	}
+
+	info.Version = versionName
+	info.FileName = utils.TarballFileName(info.Image, versionName)
+	return r.putPackage(ctx, info, raw, bytes.NewReader(tarball), tags)
+}
+
+// UpdatePackage handles the packument npm sends back after changing it: the
+// versions missing from it are unpublished, the deprecation messages and the
+// dist-tags are updated.
+func (r *LocalRegistry) UpdatePackage(
+	ctx context.Context, info pkg.NpmArtifactInfo, packument *utils.Packument,
+) (errs []error) {
+	if packument.Name != info.Image {
+		return []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("package name %q does not match the request path", packument.Name))}
+	}
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return processError(err)
+	}
+
+	err = r.tx.WithTx(
+		ctx, func(ctx context.Context) error {
+			artifacts, err := r.DBStore.ArtifactDao.GetAllArtifactsByImageID(ctx, dbImage.ID)
+			if err != nil {
+				return err
+			}
+
+			remaining := map[string]bool{}
+			for i := range *artifacts {
+				artifact := &(*artifacts)[i]
+				raw, ok := packument.Versions[artifact.Version]
+				if !ok {
+					if err = r.deleteVersion(ctx, info, dbImage.ID, artifact.Version); err != nil {
+						return err
+					}
+					continue
+				}
+				remaining[artifact.Version] = true
+				if err = r.updateDeprecation(ctx, artifact, raw); err != nil {
+					return err
+				}
+			}
+
+			if len(remaining) == 0 {
+				return r.DBStore.ImageDao.DeleteByID(ctx, dbImage.ID)
+			}
+			if packument.DistTags == nil {
+				return nil
+			}
+			return r.syncDistTags(ctx, dbImage.ID, packument.DistTags, remaining)
+		})
+	if err != nil {
+		return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return nil
+}
+
+// UnpublishPackage removes a package with all its versions.
+func (r *LocalRegistry) UnpublishPackage(ctx context.Context, info pkg.NpmArtifactInfo) (errs []error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return processError(err)
+	}
+
+	err = r.tx.WithTx(
+		ctx, func(ctx context.Context) error {
+			artifacts, err := r.DBStore.ArtifactDao.GetAllArtifactsByImageID(ctx, dbImage.ID)
+			if err != nil {
+				return err
+			}
+			for _, artifact := range *artifacts {
+				if err = r.deleteVersion(ctx, info, dbImage.ID, artifact.Version); err != nil {
+					return err
+				}
+			}
+			tags, err := r.DBStore.PackageTagDao.GetAllByImageID(ctx, dbImage.ID)
+			if err != nil {
+				return err
+			}
+			for _, tag := range *tags {
+				if err = r.DBStore.PackageTagDao.DeleteByNameAndImageID(ctx, tag.Name, dbImage.ID); err != nil {
+					return err
+				}
+			}
+			return r.DBStore.ImageDao.DeleteByID(ctx, dbImage.ID)
+		})
+	if err != nil {
+		return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return nil
+}
+
+// DeleteTarball removes the tarball of a version. npm deletes the tarball after
+// removing the version from the packument, so a missing version isn't an error.
+func (r *LocalRegistry) DeleteTarball(ctx context.Context, info pkg.NpmArtifactInfo) (errs []error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return processError(err)
+	}
+
+	err = r.tx.WithTx(
+		ctx, func(ctx context.Context) error {
+			return r.deleteVersion(ctx, info, dbImage.ID, info.Version)
+		})
+	if err != nil {
+		return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return nil
+}
+
+// PutDistTag points a dist-tag to an existing version.
+func (r *LocalRegistry) PutDistTag(
+	ctx context.Context, info pkg.NpmArtifactInfo, version string,
+) (distTags map[string]string, errs []error) {
+	if err := utils.ValidateDistTag(info.DistTag); err != nil {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+	}
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, processError(err)
+	}
+	if _, err = r.DBStore.ArtifactDao.GetByName(ctx, dbImage.ID, version); err != nil {
+		return nil, processError(err)
+	}
+
+	err = r.DBStore.PackageTagDao.CreateOrUpdate(ctx, &types.PackageTag{
+		Name:    info.DistTag,
+		ImageID: dbImage.ID,
+		Version: version,
+	})
+	if err != nil {
+		return nil, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return r.getDistTags(ctx, dbImage.ID)
+}
+
+// DeleteDistTag removes a dist-tag, the latest tag can only be moved.
+func (r *LocalRegistry) DeleteDistTag(
+	ctx context.Context, info pkg.NpmArtifactInfo,
+) (distTags map[string]string, errs []error) {
+	if info.DistTag == utils.LatestTag {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("the %s dist-tag cannot be removed", utils.LatestTag))}
+	}
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, processError(err)
+	}
+	if err = r.DBStore.PackageTagDao.DeleteByNameAndImageID(ctx, info.DistTag, dbImage.ID); err != nil {
+		return nil, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return r.getDistTags(ctx, dbImage.ID)
+}
+
+func (r *LocalRegistry) putPackage(
+	ctx context.Context, info pkg.NpmArtifactInfo, version json.RawMessage, tarball io.Reader, tags []string,
+) (responseHeaders *commons.ResponseHeaders, errs []error) {
+	filePath := utils.GetFilePath(info.Image, info.Version, info.FileName)
+	fileInfo, err := r.fileManager.UploadFile(ctx, filePath, info.RegIdentifier,
+		info.RegistryID, info.RootParentID, info.RootIdentifier, nil, tarball, info.FileName)
+	if err != nil {
+		return responseHeaders, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	err = r.tx.WithTx(
+		ctx, func(ctx context.Context) error {
+			dbImage := &types.Image{
+				Name:       info.Image,
+				RegistryID: info.RegistryID,
+				Enabled:    true,
+			}
+			if err := r.DBStore.ImageDao.CreateOrUpdate(ctx, dbImage); err != nil {
+				return err
+			}
+
+			metadata := &database.NpmMetadata{
+				Files: []database.File{{Size: fileInfo.Size, Filename: fileInfo.Filename,
+					CreatedAt: time.Now().UnixMilli()}},
+				FileCount: 1,
+				Package:   version,
+			}
+			metadataJSON, err := json.Marshal(metadata)
+			if err != nil {
+				return err
+			}
+			dbArtifact := &types.Artifact{
+				ImageID:  dbImage.ID,
+				Version:  info.Version,
+				Metadata: metadataJSON,
+			}
+			if err = r.DBStore.ArtifactDao.CreateOrUpdate(ctx, dbArtifact); err != nil {
+				return err
+			}
+
+			for _, tag := range tags {
+				err = r.DBStore.PackageTagDao.CreateOrUpdate(ctx, &types.PackageTag{
+					Name:    tag,
+					ImageID: dbImage.ID,
+					Version: info.Version,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return responseHeaders, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	responseHeaders = &commons.ResponseHeaders{
+		Headers: map[string]string{},
+		Code:    http.StatusCreated,
+	}
+	return responseHeaders, nil
+}
+
+func (r *LocalRegistry) deleteVersion(
+	ctx context.Context, info pkg.NpmArtifactInfo, imageID int64, version string,
+) error {
+	if err := r.DBStore.PackageTagDao.DeleteByVersionAndImageID(ctx, version, imageID); err != nil {
+		return err
+	}
+	if err := r.DBStore.ArtifactDao.DeleteByImageIDAndVersion(ctx, imageID, version); err != nil {
+		return err
+	}
+	filePath := utils.GetFilePath(info.Image, version, utils.TarballFileName(info.Image, version))
+	return r.fileManager.DeleteFile(ctx, filePath, int(info.RegistryID))
+}
+
+func (r *LocalRegistry) updateDeprecation(ctx context.Context, artifact *types.Artifact, raw json.RawMessage) error {
+	version, err := utils.ParseVersion(raw)
+	if err != nil {
+		return err
+	}
+	metadata := &database.NpmMetadata{}
+	if err = json.Unmarshal(artifact.Metadata, metadata); err != nil {
+		return err
+	}
+	current, err := utils.ParseVersion(metadata.Package)
+	if err != nil {
+		return err
+	}
+	if current.Deprecated == version.Deprecated {
+		return nil
+	}
+
+	metadata.Package, err = utils.UpdateVersion(metadata.Package, "", version.Deprecated)
+	if err != nil {
+		return err
+	}
+	artifact.Metadata, err = json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return r.DBStore.ArtifactDao.CreateOrUpdate(ctx, artifact)
+}
+
+func (r *LocalRegistry) syncDistTags(
+	ctx context.Context, imageID int64, distTags map[string]string, versions map[string]bool,
+) error {
+	tags, err := r.DBStore.PackageTagDao.GetAllByImageID(ctx, imageID)
+	if err != nil {
+		return err
+	}
+	for _, tag := range *tags {
+		if _, ok := distTags[tag.Name]; !ok {
+			if err = r.DBStore.PackageTagDao.DeleteByNameAndImageID(ctx, tag.Name, imageID); err != nil {
+				return err
+			}
+		}
+	}
+	for tag, version := range distTags {
+		if !versions[version] || utils.ValidateDistTag(tag) != nil {
+			continue
+		}
+		err = r.DBStore.PackageTagDao.CreateOrUpdate(ctx, &types.PackageTag{
+			Name:    tag,
+			ImageID: imageID,
+			Version: version,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *LocalRegistry) getDistTags(ctx context.Context, imageID int64) (map[string]string, []error) {
+	tags, err := r.DBStore.PackageTagDao.GetAllByImageID(ctx, imageID)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	distTags := make(map[string]string, len(*tags))
+	for _, tag := range *tags {
+		distTags[tag.Name] = tag.Version
+	}
+	return distTags, nil
+}
+
+// buildPackument assembles the packument of a package from its stored versions.
+// The tarball URLs point to the registry of the request.
+func buildPackument(
+	info pkg.NpmArtifactInfo, artifacts []types.Artifact, tags []types.PackageTag,
+) (*utils.Packument, error) {
+	packument := &utils.Packument{
+		ID:       info.Image,
+		Name:     info.Image,
+		DistTags: map[string]string{},
+		Versions: map[string]json.RawMessage{},
+		Time:     map[string]string{},
+	}
+
+	var created, modified time.Time
+	var latest *semver.Version
+	descriptions := map[string]string{}
+	for _, artifact := range artifacts {
+		metadata := &database.NpmMetadata{}
+		if err := json.Unmarshal(artifact.Metadata, metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse metadata of %s@%s: %w", info.Image, artifact.Version, err)
+		}
+		version, err := utils.ParseVersion(metadata.Package)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := utils.UpdateVersion(metadata.Package,
+			utils.TarballURL(info.RegistryURL, info.Image, utils.TarballFileName(info.Image, artifact.Version)),
+			version.Deprecated)
+		if err != nil {
+			return nil, err
+		}
+		packument.Versions[artifact.Version] = raw
+		packument.Time[artifact.Version] = artifact.CreatedAt.UTC().Format(timeFormat)
+		descriptions[artifact.Version] = version.Description
+
+		if created.IsZero() || artifact.CreatedAt.Before(created) {
+			created = artifact.CreatedAt
+		}
+		if artifact.UpdatedAt.After(modified) {
+			modified = artifact.UpdatedAt
+		}
+		if v, err := semver.NewVersion(artifact.Version); err == nil && (latest == nil || v.GreaterThan(latest)) {
+			latest = v
+		}
+	}
+
+	for _, tag := range tags {
+		if _, ok := packument.Versions[tag.Version]; ok {
+			packument.DistTags[tag.Name] = tag.Version
+		}
+	}
+	if _, ok := packument.DistTags[utils.LatestTag]; !ok && latest != nil {
+		packument.DistTags[utils.LatestTag] = latest.Original()
+	}
+
+	packument.Description = descriptions[packument.DistTags[utils.LatestTag]]
+	packument.Time["created"] = created.UTC().Format(timeFormat)
+	packument.Time["modified"] = modified.UTC().Format(timeFormat)
+	packument.Rev = fmt.Sprintf("%d-%x", len(artifacts), modified.UnixMilli())
+	return packument, nil
+}
+
+// getAttachment decodes the tarball attached to a publish request. npm names
+// the attachment after the package name and the version.
+func getAttachment(packument *utils.Packument, version string, shasum string) ([]byte, error) {
+	var attachment *utils.Attachment
+	for name, a := range packument.Attachments {
+		if strings.HasSuffix(name, "-"+version+utils.TarballExtension) {
+			attachment = a
+			break
+		}
+	}
+	if attachment == nil {
+		return nil, fmt.Errorf("tarball of version %s is missing", version)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(attachment.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tarball: %w", err)
+	}
+	if attachment.Length > 0 && int64(len(data)) != attachment.Length {
+		return nil, fmt.Errorf("tarball size %d does not match the declared length %d", len(data), attachment.Length)
+	}
+	if shasum != "" {
+		sum := sha1.Sum(data) //nolint:gosec
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), shasum) {
+			return nil, errors.New("tarball shasum does not match the version metadata")
+		}
+	}
+	return data, nil
+}
+
+func processError(err error) []error {
+	if errors.Is(err, store2.ErrResourceNotFound) ||
+		strings.Contains(err.Error(), sql.ErrNoRows.Error()) ||
+		strings.Contains(err.Error(), "resource not found") ||
+		strings.Contains(err.Error(), "http status code: 404") {
+		return []error{commons.NotFoundError(err.Error(), err)}
+	}
+	return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+}
@@ -0,0 +1,108 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm/utils"
+	"github.com/harness/gitness/registry/app/remote/controller/proxy/npm"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ArtifactTypeRemoteRegistry = "Remote Registry"
+)
+
+func NewRemoteRegistry(dBStore *DBStore, tx dbtx.Transactor, local *LocalRegistry,
+	proxyController npm.Controller,
+) Registry {
+	return &RemoteRegistry{
+		DBStore:         dBStore,
+		tx:              tx,
+		local:           local,
+		proxyController: proxyController,
+	}
+}
+
+type RemoteRegistry struct {
+	local           *LocalRegistry
+	proxyController npm.Controller
+	DBStore         *DBStore
+	tx              dbtx.Transactor
+}
+
+func (r *RemoteRegistry) GetNpmArtifactType() string {
+	return ArtifactTypeRemoteRegistry
+}
+
+// GetPackument always asks the upstream registry, so new versions show up
+// right away. The versions cached so far are served when it can't be reached.
+func (r *RemoteRegistry) GetPackument(ctx context.Context, info pkg.NpmArtifactInfo) (
+	packument *utils.Packument, errs []error) {
+	log.Ctx(ctx).Info().Msgf("Npm Proxy: %s", info.RegIdentifier)
+
+	upstreamProxy, err := r.DBStore.UpstreamProxyDao.GetByRegistryIdentifier(ctx, info.ParentID, info.RegIdentifier)
+	if err != nil {
+		return nil, processError(err)
+	}
+
+	packument, err = r.proxyController.ProxyPackument(ctx, info, *upstreamProxy)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to fetch packument of %s from upstream, using local cache",
+			info.Image)
+		cached, errs := r.local.GetPackument(ctx, info)
+		if len(errs) > 0 {
+			return nil, processError(err)
+		}
+		return cached, nil
+	}
+	return packument, nil
+}
+
+func (r *RemoteRegistry) GetTarball(ctx context.Context, info pkg.NpmArtifactInfo) (
+	responseHeaders *commons.ResponseHeaders, body *storage.FileReader, readCloser io.ReadCloser,
+	redirectURL string, errs []error) {
+	log.Ctx(ctx).Info().Msgf("Npm Proxy: %s", info.RegIdentifier)
+
+	responseHeaders, body, redirectURL, useLocal := r.proxyController.UseLocalFile(ctx, info)
+	if useLocal {
+		return responseHeaders, body, readCloser, redirectURL, errs
+	}
+
+	upstreamProxy, err := r.DBStore.UpstreamProxyDao.GetByRegistryIdentifier(ctx, info.ParentID, info.RegIdentifier)
+	if err != nil {
+		return nil, nil, nil, "", processError(err)
+	}
+
+	// This is start of proxy Code.
+	responseHeaders, readCloser, err = r.proxyController.ProxyFile(ctx, info, *upstreamProxy)
+	if err != nil {
+		return nil, nil, nil, "", processError(err)
+	}
+	return responseHeaders, nil, readCloser, "", errs
+}
+
+func (r *RemoteRegistry) PutPackage(_ context.Context, _ pkg.NpmArtifactInfo, _ json.RawMessage, _ io.Reader) (
+	responseHeaders *commons.ResponseHeaders, errs []error) {
+	return nil, nil
+}
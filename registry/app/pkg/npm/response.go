@@ -0,0 +1,101 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"io"
+
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm/utils"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+type Response interface {
+	GetErrors() []error
+	SetError(error)
+}
+
+var _ Response = (*GetPackumentResponse)(nil)
+var _ Response = (*GetTarballResponse)(nil)
+var _ Response = (*PutPackageResponse)(nil)
+var _ Response = (*DeletePackageResponse)(nil)
+var _ Response = (*DistTagsResponse)(nil)
+
+type GetPackumentResponse struct {
+	Errors    []error
+	Packument *utils.Packument
+}
+
+func (r *GetPackumentResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *GetPackumentResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type GetTarballResponse struct {
+	Errors          []error
+	ResponseHeaders *commons.ResponseHeaders
+	RedirectURL     string
+	Body            *storage.FileReader
+	ReadCloser      io.ReadCloser
+}
+
+func (r *GetTarballResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *GetTarballResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type PutPackageResponse struct {
+	Errors          []error
+	ResponseHeaders *commons.ResponseHeaders
+}
+
+func (r *PutPackageResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *PutPackageResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type DeletePackageResponse struct {
+	Errors []error
+}
+
+func (r *DeletePackageResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *DeletePackageResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type DistTagsResponse struct {
+	Errors   []error
+	DistTags map[string]string
+}
+
+func (r *DistTagsResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *DistTagsResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
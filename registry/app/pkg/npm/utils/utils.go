@@ -0,0 +1,184 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	// LatestTag is the dist-tag npm installs when no version is requested.
+	LatestTag = "latest"
+
+	TarballExtension   = ".tgz"
+	ContentTypeTarball = "application/octet-stream"
+	ContentTypeJSON    = "application/json"
+
+	maxPackageNameLength = 214
+)
+
+var (
+	packageNameRegex = regexp.MustCompile(`^(?:@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+	distTagRegex     = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-._~]*$`)
+
+	ErrInvalidPackageName = errors.New("invalid package name")
+	ErrInvalidVersion     = errors.New("invalid package version")
+	ErrInvalidDistTag     = errors.New("invalid dist-tag")
+)
+
+// Packument is the document describing a package and all its versions. The
+// versions are kept as sent by the client so no field gets lost.
+type Packument struct {
+	ID          string                     `json:"_id,omitempty"`
+	Rev         string                     `json:"_rev,omitempty"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description,omitempty"`
+	DistTags    map[string]string          `json:"dist-tags"`
+	Versions    map[string]json.RawMessage `json:"versions"`
+	Time        map[string]string          `json:"time,omitempty"`
+	Readme      string                     `json:"readme,omitempty"`
+	Attachments map[string]*Attachment     `json:"_attachments,omitempty"`
+}
+
+// Attachment is a tarball attached to a publish request, base64 encoded.
+type Attachment struct {
+	ContentType string `json:"content_type"`
+	Data        string `json:"data"`
+	Length      int64  `json:"length"`
+}
+
+// PackageVersion holds the fields of a version document the registry relies on.
+type PackageVersion struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	Deprecated  string `json:"deprecated,omitempty"`
+	Dist        Dist   `json:"dist"`
+}
+
+// Dist describes the tarball of a version.
+type Dist struct {
+	Tarball   string `json:"tarball"`
+	Shasum    string `json:"shasum,omitempty"`
+	Integrity string `json:"integrity,omitempty"`
+}
+
+// ValidatePackageName checks the name follows the rules for new npm packages,
+// scoped (@scope/name) or not.
+func ValidatePackageName(name string) error {
+	if name == "" || len(name) > maxPackageNameLength || !packageNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidPackageName, name)
+	}
+	return nil
+}
+
+// ValidateVersion checks the version is a valid semantic version.
+func ValidateVersion(version string) error {
+	if _, err := semver.StrictNewVersion(version); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	return nil
+}
+
+// ValidateDistTag checks the tag can't be mistaken for a version or a range.
+func ValidateDistTag(tag string) error {
+	if !distTagRegex.MatchString(tag) {
+		return fmt.Errorf("%w: %q", ErrInvalidDistTag, tag)
+	}
+	if _, err := semver.NewConstraint(tag); err == nil {
+		return fmt.Errorf("%w: %q is a valid semver range", ErrInvalidDistTag, tag)
+	}
+	return nil
+}
+
+// UnescapePackageName converts the escaped form of a scoped package name used
+// in urls (@scope%2fname) back to the package name.
+func UnescapePackageName(name string) string {
+	if strings.HasPrefix(name, "@") {
+		name = strings.Replace(name, "%2f", "/", 1)
+		name = strings.Replace(name, "%2F", "/", 1)
+	}
+	return name
+}
+
+// TarballFileName returns the file name of the tarball of a version, it never
+// contains the scope of the package.
+func TarballFileName(name string, version string) string {
+	return name[strings.LastIndex(name, "/")+1:] + "-" + version + TarballExtension
+}
+
+// ParseTarballFileName extracts the version from the file name of a tarball
+// of the given package.
+func ParseTarballFileName(name string, fileName string) (string, error) {
+	prefix := name[strings.LastIndex(name, "/")+1:] + "-"
+	if !strings.HasPrefix(fileName, prefix) || !strings.HasSuffix(fileName, TarballExtension) {
+		return "", fmt.Errorf("tarball %s does not belong to package %s", fileName, name)
+	}
+	version := strings.TrimSuffix(strings.TrimPrefix(fileName, prefix), TarballExtension)
+	if err := ValidateVersion(version); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// GetFilePath returns the path the tarball of a version is stored under.
+func GetFilePath(name string, version string, fileName string) string {
+	return "/" + name + "/" + version + "/" + fileName
+}
+
+// TarballURL returns the download URL of a tarball in the given registry.
+func TarballURL(registryURL string, name string, fileName string) string {
+	return strings.TrimRight(registryURL, "/") + "/" + name + "/-/" + fileName
+}
+
+// ParseVersion reads the fields of a version document the registry relies on.
+func ParseVersion(raw json.RawMessage) (*PackageVersion, error) {
+	version := &PackageVersion{}
+	if err := json.Unmarshal(raw, version); err != nil {
+		return nil, fmt.Errorf("failed to parse version metadata: %w", err)
+	}
+	return version, nil
+}
+
+// UpdateVersion sets the tarball URL, unless empty, and the deprecation message
+// of a version document, keeping every other field untouched.
+func UpdateVersion(raw json.RawMessage, tarballURL string, deprecated string) (json.RawMessage, error) {
+	doc := map[string]any{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse version metadata: %w", err)
+	}
+
+	if tarballURL != "" {
+		dist, ok := doc["dist"].(map[string]any)
+		if !ok {
+			dist = map[string]any{}
+		}
+		dist["tarball"] = tarballURL
+		doc["dist"] = dist
+	}
+
+	if deprecated != "" {
+		doc["deprecated"] = deprecated
+	} else {
+		delete(doc, "deprecated")
+	}
+	return json.Marshal(doc)
+}
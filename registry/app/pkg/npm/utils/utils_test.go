@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePackageName(t *testing.T) {
+	for _, name := range []string{"left-pad", "@scope/pkg", "a.b_c~d", "@my-org/my.pkg"} {
+		assert.NoError(t, ValidatePackageName(name), name)
+	}
+	for _, name := range []string{"", "Upper", "@scope", "@scope/", "a/b", ".hidden", "_private", "a b"} {
+		assert.ErrorIs(t, ValidatePackageName(name), ErrInvalidPackageName, name)
+	}
+}
+
+func TestValidateDistTag(t *testing.T) {
+	for _, tag := range []string{"latest", "beta", "next-major"} {
+		assert.NoError(t, ValidateDistTag(tag), tag)
+	}
+	for _, tag := range []string{"", "1.0.0", "v1", "^1", "-beta"} {
+		assert.ErrorIs(t, ValidateDistTag(tag), ErrInvalidDistTag, tag)
+	}
+}
+
+func TestTarballFileName(t *testing.T) {
+	assert.Equal(t, "pkg-1.0.0.tgz", TarballFileName("@scope/pkg", "1.0.0"))
+	assert.Equal(t, "left-pad-1.3.0-rc.1.tgz", TarballFileName("left-pad", "1.3.0-rc.1"))
+
+	version, err := ParseTarballFileName("@scope/pkg", "pkg-1.0.0.tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+
+	version, err = ParseTarballFileName("left-pad", "left-pad-1.3.0-rc.1.tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0-rc.1", version)
+
+	_, err = ParseTarballFileName("left-pad", "right-pad-1.0.0.tgz")
+	require.Error(t, err)
+	_, err = ParseTarballFileName("left-pad", "left-pad-latest.tgz")
+	require.ErrorIs(t, err, ErrInvalidVersion)
+}
+
+func TestUnescapePackageName(t *testing.T) {
+	assert.Equal(t, "@scope/pkg", UnescapePackageName("@scope%2fpkg"))
+	assert.Equal(t, "@scope/pkg", UnescapePackageName("@scope%2Fpkg"))
+	assert.Equal(t, "left-pad", UnescapePackageName("left-pad"))
+}
+
+func TestUpdateVersion(t *testing.T) {
+	raw := json.RawMessage(`{"name":"pkg","version":"1.0.0","custom":{"a":1},` +
+		`"dist":{"tarball":"https://registry.npmjs.org/pkg/-/pkg-1.0.0.tgz","shasum":"abc"}}`)
+
+	updated, err := UpdateVersion(raw, "https://example.com/npm/root/reg/pkg/-/pkg-1.0.0.tgz", "use 2.x")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"pkg","version":"1.0.0","custom":{"a":1},"deprecated":"use 2.x",`+
+		`"dist":{"tarball":"https://example.com/npm/root/reg/pkg/-/pkg-1.0.0.tgz","shasum":"abc"}}`, string(updated))
+
+	version, err := ParseVersion(updated)
+	require.NoError(t, err)
+	assert.Equal(t, "use 2.x", version.Deprecated)
+	assert.Equal(t, "abc", version.Dist.Shasum)
+
+	// An empty message un-deprecates the version, an empty URL keeps the tarball.
+	updated, err = UpdateVersion(updated, "", "")
+	require.NoError(t, err)
+	version, err = ParseVersion(updated)
+	require.NoError(t, err)
+	assert.Empty(t, version.Deprecated)
+	assert.Equal(t, "https://example.com/npm/root/reg/pkg/-/pkg-1.0.0.tgz", version.Dist.Tarball)
+}
@@ -0,0 +1,332 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/app/dist_temp/errcode"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/pkg/python/utils"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/app/store/database"
+	"github.com/harness/gitness/registry/types"
+	store2 "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ArtifactTypeLocalRegistry = "Local Registry"
+)
+
+func NewLocalRegistry(dBStore *DBStore, tx dbtx.Transactor,
+	fileManager filemanager.FileManager,
+) Registry {
+	return &LocalRegistry{
+		DBStore:     dBStore,
+		tx:          tx,
+		fileManager: fileManager,
+	}
+}
+
+type LocalRegistry struct {
+	DBStore     *DBStore
+	tx          dbtx.Transactor
+	fileManager filemanager.FileManager
+}
+
+func (r *LocalRegistry) GetPythonArtifactType() string {
+	return ArtifactTypeLocalRegistry
+}
+
+// ListProjects returns the names of the projects stored in the registry.
+func (r *LocalRegistry) ListProjects(ctx context.Context, info pkg.PythonArtifactInfo) (
+	projects *utils.ProjectList, errs []error) {
+	images, err := r.DBStore.ImageDao.GetAllByRegistryID(ctx, info.RegistryID)
+	if err != nil {
+		return nil, processError(err)
+	}
+	names := make([]string, 0, len(*images))
+	for _, image := range *images {
+		names = append(names, image.Name)
+	}
+	return utils.NewProjectList(names), nil
+}
+
+func (r *LocalRegistry) GetProject(ctx context.Context, info pkg.PythonArtifactInfo) (
+	project *utils.ProjectIndex, errs []error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return nil, processError(err)
+	}
+	artifacts, err := r.DBStore.ArtifactDao.GetAllArtifactsByImageID(ctx, dbImage.ID)
+	if err != nil {
+		return nil, processError(err)
+	}
+	if len(*artifacts) == 0 {
+		return nil, []error{commons.NotFoundError(fmt.Sprintf("project %s not found", info.Image), nil)}
+	}
+
+	project, err = buildProjectIndex(info, *artifacts)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	return project, nil
+}
+
+func (r *LocalRegistry) DownloadFile(ctx context.Context, info pkg.PythonArtifactInfo) (
+	responseHeaders *commons.ResponseHeaders, body *storage.FileReader, readCloser io.ReadCloser,
+	redirectURL string, errs []error) {
+	exists, err := r.fileExists(ctx, info)
+	if err != nil {
+		return nil, nil, nil, "", processError(err)
+	}
+	if !exists {
+		return nil, nil, nil, "", []error{commons.NotFoundError(
+			fmt.Sprintf("file %s not found", info.FileName), nil)}
+	}
+
+	filePath := utils.GetFilePath(info.Image, info.Version, info.FileName)
+	fileReader, size, redirectURL, err := r.fileManager.DownloadFile(ctx, filePath, types.Registry{
+		ID:   info.RegistryID,
+		Name: info.RootIdentifier,
+	}, info.RootIdentifier)
+	if err != nil {
+		return nil, nil, nil, "", processError(err)
+	}
+	responseHeaders = &commons.ResponseHeaders{
+		Headers: map[string]string{
+			commons.HeaderContentType: utils.ContentTypePackage,
+		},
+		Code: http.StatusOK,
+	}
+	if redirectURL == "" {
+		responseHeaders.Headers[commons.HeaderContentLength] = strconv.FormatInt(size, 10)
+	}
+	return responseHeaders, fileReader, nil, redirectURL, nil
+}
+
+// UploadPackage stores a distribution sent through the upload API twine uses.
+// The name and the version sent along with the file must match the ones in the
+// file name and in the core metadata of the file. Files can't be replaced.
+func (r *LocalRegistry) UploadPackage(
+	ctx context.Context, info pkg.PythonArtifactInfo, file io.ReaderAt, size int64, sha256 string,
+) (responseHeaders *commons.ResponseHeaders, errs []error) {
+	name, version, _, err := utils.ParseFileName(info.FileName)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+	}
+	if name != info.Image || !strings.EqualFold(version, info.Version) {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf(
+			"file name %s does not match %s %s", info.FileName, info.Image, info.Version))}
+	}
+	metadata, err := utils.ExtractMetadata(file, size, info.FileName)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(err)}
+	}
+	if utils.NormalizeName(metadata.Name) != info.Image || !strings.EqualFold(metadata.Version, version) {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(fmt.Errorf(
+			"metadata of %s does not match %s %s", info.FileName, info.Image, info.Version))}
+	}
+
+	// Files are stored under the version of their name, so the download URLs
+	// can be derived from the file name alone.
+	info.Version = version
+	exists, err := r.fileExists(ctx, info)
+	if err != nil && !errors.Is(err, store2.ErrResourceNotFound) {
+		return nil, processError(err)
+	}
+	if exists {
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("file %s already exists", info.FileName))}
+	}
+	return r.PutFile(ctx, info, metadata, io.NewSectionReader(file, 0, size), sha256)
+}
+
+// PutFile stores a file and adds it to the files of the release. The core
+// metadata of the release is updated with the non-empty fields of metadata.
+// When sha256 is set, the file is rejected if its digest doesn't match.
+func (r *LocalRegistry) PutFile(
+	ctx context.Context, info pkg.PythonArtifactInfo, metadata *utils.Metadata, file io.Reader, sha256 string,
+) (responseHeaders *commons.ResponseHeaders, errs []error) {
+	filePath := utils.GetFilePath(info.Image, info.Version, info.FileName)
+	fileInfo, err := r.fileManager.UploadFile(ctx, filePath, info.RegIdentifier,
+		info.RegistryID, info.RootParentID, info.RootIdentifier, nil, file, info.FileName)
+	if err != nil {
+		return nil, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	if sha256 != "" && !strings.EqualFold(fileInfo.Sha256, sha256) {
+		if err = r.fileManager.DeleteFile(ctx, filePath, int(info.RegistryID)); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msgf("failed to delete file %s", filePath)
+		}
+		return nil, []error{errcode.ErrCodeInvalidRequest.WithDetail(
+			fmt.Errorf("sha256 digest of %s does not match", info.FileName))}
+	}
+
+	err = r.tx.WithTx(
+		ctx, func(ctx context.Context) error {
+			dbImage := &types.Image{
+				Name:       info.Image,
+				RegistryID: info.RegistryID,
+				Enabled:    true,
+			}
+			if err := r.DBStore.ImageDao.CreateOrUpdate(ctx, dbImage); err != nil {
+				return err
+			}
+
+			pythonMetadata := &database.PythonMetadata{}
+			dbArtifact, err := r.DBStore.ArtifactDao.GetByName(ctx, dbImage.ID, info.Version)
+			switch {
+			case err == nil:
+				if err = json.Unmarshal(dbArtifact.Metadata, pythonMetadata); err != nil {
+					return err
+				}
+			case errors.Is(err, store2.ErrResourceNotFound):
+				dbArtifact = &types.Artifact{
+					ImageID: dbImage.ID,
+					Version: info.Version,
+				}
+			default:
+				return err
+			}
+
+			mergeMetadata(pythonMetadata, metadata, database.PythonFile{
+				File: database.File{
+					Size:      fileInfo.Size,
+					Filename:  fileInfo.Filename,
+					CreatedAt: time.Now().UnixMilli(),
+				},
+				Sha256:         fileInfo.Sha256,
+				RequiresPython: metadata.RequiresPython,
+			})
+			dbArtifact.Metadata, err = json.Marshal(pythonMetadata)
+			if err != nil {
+				return err
+			}
+			return r.DBStore.ArtifactDao.CreateOrUpdate(ctx, dbArtifact)
+		})
+	if err != nil {
+		return nil, []error{errcode.ErrCodeUnknown.WithDetail(err)}
+	}
+	responseHeaders = &commons.ResponseHeaders{
+		Headers: map[string]string{},
+		Code:    http.StatusOK,
+	}
+	return responseHeaders, nil
+}
+
+// fileExists tells whether the file is one of the files of its release.
+func (r *LocalRegistry) fileExists(ctx context.Context, info pkg.PythonArtifactInfo) (bool, error) {
+	dbImage, err := r.DBStore.ImageDao.GetByName(ctx, info.RegistryID, info.Image)
+	if err != nil {
+		return false, err
+	}
+	dbArtifact, err := r.DBStore.ArtifactDao.GetByName(ctx, dbImage.ID, info.Version)
+	if err != nil {
+		return false, err
+	}
+	metadata := &database.PythonMetadata{}
+	if err = json.Unmarshal(dbArtifact.Metadata, metadata); err != nil {
+		return false, err
+	}
+	for _, f := range metadata.Files {
+		if f.Filename == info.FileName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mergeMetadata adds a file to the metadata of a release, replacing the entry
+// of a file with the same name.
+func mergeMetadata(target *database.PythonMetadata, metadata *utils.Metadata, file database.PythonFile) {
+	files := make([]database.PythonFile, 0, len(target.Files)+1)
+	for _, f := range target.Files {
+		if f.Filename != file.Filename {
+			files = append(files, f)
+		}
+	}
+	target.Files = append(files, file)
+	target.FileCount = int64(len(target.Files))
+
+	setIfNotEmpty(&target.Name, metadata.Name)
+	setIfNotEmpty(&target.Summary, metadata.Summary)
+	setIfNotEmpty(&target.RequiresPython, metadata.RequiresPython)
+	setIfNotEmpty(&target.HomePage, metadata.HomePage)
+	setIfNotEmpty(&target.Author, metadata.Author)
+	setIfNotEmpty(&target.License, metadata.License)
+	if len(metadata.RequiresDist) > 0 {
+		target.RequiresDist = metadata.RequiresDist
+	}
+}
+
+func setIfNotEmpty(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
+// buildProjectIndex assembles the page of a project from its stored releases.
+// The file URLs point to the registry of the request.
+func buildProjectIndex(info pkg.PythonArtifactInfo, artifacts []types.Artifact) (*utils.ProjectIndex, error) {
+	project := utils.NewProjectIndex(info.Image)
+	for _, artifact := range artifacts {
+		metadata := &database.PythonMetadata{}
+		if err := json.Unmarshal(artifact.Metadata, metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse metadata of %s %s: %w", info.Image, artifact.Version, err)
+		}
+		project.Versions = append(project.Versions, artifact.Version)
+		for _, f := range metadata.Files {
+			size := f.Size
+			requiresPython := f.RequiresPython
+			if requiresPython == "" {
+				requiresPython = metadata.RequiresPython
+			}
+			project.Files = append(project.Files, utils.File{
+				Filename:       f.Filename,
+				URL:            utils.FileURL(info.RegistryURL, info.Image, artifact.Version, f.Filename),
+				Hashes:         map[string]string{"sha256": f.Sha256},
+				RequiresPython: requiresPython,
+				Size:           &size,
+				UploadTime:     utils.FormatUploadTime(f.CreatedAt),
+			})
+		}
+	}
+	project.SortFiles()
+	return project, nil
+}
+
+func processError(err error) []error {
+	if errors.Is(err, store2.ErrResourceNotFound) ||
+		strings.Contains(err.Error(), sql.ErrNoRows.Error()) ||
+		strings.Contains(err.Error(), "resource not found") ||
+		strings.Contains(err.Error(), "http status code: 404") {
+		return []error{commons.NotFoundError(err.Error(), err)}
+	}
+	return []error{errcode.ErrCodeUnknown.WithDetail(err)}
+}
@@ -0,0 +1,88 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"io"
+
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/python/utils"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+type Response interface {
+	GetErrors() []error
+	SetError(error)
+}
+
+var _ Response = (*ProjectListResponse)(nil)
+var _ Response = (*GetProjectResponse)(nil)
+var _ Response = (*GetFileResponse)(nil)
+var _ Response = (*UploadResponse)(nil)
+
+type ProjectListResponse struct {
+	Errors      []error
+	ProjectList *utils.ProjectList
+}
+
+func (r *ProjectListResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *ProjectListResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type GetProjectResponse struct {
+	Errors  []error
+	Project *utils.ProjectIndex
+}
+
+func (r *GetProjectResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *GetProjectResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type GetFileResponse struct {
+	Errors          []error
+	ResponseHeaders *commons.ResponseHeaders
+	RedirectURL     string
+	Body            *storage.FileReader
+	ReadCloser      io.ReadCloser
+}
+
+func (r *GetFileResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *GetFileResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
+
+type UploadResponse struct {
+	Errors          []error
+	ResponseHeaders *commons.ResponseHeaders
+}
+
+func (r *UploadResponse) GetErrors() []error {
+	return r.Errors
+}
+func (r *UploadResponse) SetError(err error) {
+	r.Errors = make([]error, 1)
+	r.Errors[0] = err
+}
@@ -0,0 +1,292 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	xhtml "golang.org/x/net/html"
+)
+
+const (
+	// APIVersion is the version of the simple repository API served.
+	APIVersion = "1.1"
+
+	ContentTypeSimpleJSON = "application/vnd.pypi.simple.v1+json"
+	ContentTypeSimpleHTML = "application/vnd.pypi.simple.v1+html"
+	ContentTypeHTML       = "text/html"
+
+	// FormatQueryParam lets clients pick the format of the index without an Accept header.
+	FormatQueryParam = "format"
+
+	uploadTimeFormat = "2006-01-02T15:04:05.000000Z"
+)
+
+// Meta is the meta key of the JSON responses of PEP 691.
+type Meta struct {
+	APIVersion string `json:"api-version"`
+}
+
+// ProjectList is the root page of the simple index, listing every project.
+type ProjectList struct {
+	Meta     Meta              `json:"meta"`
+	Projects []ProjectListItem `json:"projects"`
+}
+
+type ProjectListItem struct {
+	Name string `json:"name"`
+}
+
+// ProjectIndex is the page of a project in the simple index, listing its files.
+type ProjectIndex struct {
+	Meta     Meta     `json:"meta"`
+	Name     string   `json:"name"`
+	Files    []File   `json:"files"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// File is a distribution file listed in a project page. Yanked is kept as
+// received: false, true or the reason of the yank.
+type File struct {
+	Filename       string            `json:"filename"`
+	URL            string            `json:"url"`
+	Hashes         map[string]string `json:"hashes"`
+	RequiresPython string            `json:"requires-python,omitempty"`
+	Yanked         json.RawMessage   `json:"yanked,omitempty"`
+	Size           *int64            `json:"size,omitempty"`
+	UploadTime     string            `json:"upload-time,omitempty"`
+}
+
+// NewProjectList returns the project list for the given project names.
+func NewProjectList(names []string) *ProjectList {
+	projects := make([]ProjectListItem, 0, len(names))
+	for _, name := range names {
+		projects = append(projects, ProjectListItem{Name: name})
+	}
+	return &ProjectList{Meta: Meta{APIVersion: APIVersion}, Projects: projects}
+}
+
+// NewProjectIndex returns an empty project page.
+func NewProjectIndex(name string) *ProjectIndex {
+	return &ProjectIndex{
+		Meta:  Meta{APIVersion: APIVersion},
+		Name:  NormalizeName(name),
+		Files: []File{},
+	}
+}
+
+// SortFiles orders the files by name, so the pages are stable.
+func (p *ProjectIndex) SortFiles() {
+	sort.Slice(p.Files, func(i, j int) bool {
+		return p.Files[i].Filename < p.Files[j].Filename
+	})
+}
+
+// FormatUploadTime formats a timestamp in milliseconds the way PEP 700 requires.
+func FormatUploadTime(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(uploadTimeFormat)
+}
+
+// NegotiateContentType picks the format of the simple index from the format
+// query parameter, or else the Accept header, as described by PEP 691. HTML
+// is served when the client doesn't express a supported preference.
+func NegotiateContentType(accept string, format string) string {
+	if isSupportedContentType(format) {
+		return format
+	}
+
+	best, bestQ := ContentTypeHTML, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || !isSupportedContentType(mediaType) {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ && q > 0 {
+			best, bestQ = mediaType, q
+		}
+	}
+	return best
+}
+
+func isSupportedContentType(contentType string) bool {
+	return contentType == ContentTypeSimpleJSON || contentType == ContentTypeSimpleHTML ||
+		contentType == ContentTypeHTML
+}
+
+// RenderProjectListHTML renders the root page of the simple index as HTML (PEP 503).
+func RenderProjectListHTML(w io.Writer, list *ProjectList) error {
+	var b strings.Builder
+	b.WriteString(htmlHeader("Simple index"))
+	for _, p := range list.Projects {
+		fmt.Fprintf(&b, "    <a href=\"%s/\">%s</a><br/>\n", url.PathEscape(p.Name), html.EscapeString(p.Name))
+	}
+	b.WriteString(htmlFooter)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderProjectHTML renders the page of a project as HTML (PEP 503).
+func RenderProjectHTML(w io.Writer, project *ProjectIndex) error {
+	var b strings.Builder
+	b.WriteString(htmlHeader("Links for " + project.Name))
+	for _, f := range project.Files {
+		href := f.URL
+		if sha256, ok := f.Hashes["sha256"]; ok {
+			href += "#sha256=" + sha256
+		}
+		b.WriteString("    <a href=\"" + html.EscapeString(href) + "\"")
+		if f.RequiresPython != "" {
+			b.WriteString(" data-requires-python=\"" + html.EscapeString(f.RequiresPython) + "\"")
+		}
+		if reason, yanked := yankReason(f.Yanked); yanked {
+			b.WriteString(" data-yanked=\"" + html.EscapeString(reason) + "\"")
+		}
+		b.WriteString(">" + html.EscapeString(f.Filename) + "</a><br/>\n")
+	}
+	b.WriteString(htmlFooter)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func htmlHeader(title string) string {
+	return "<!DOCTYPE html>\n<html>\n  <head>\n" +
+		"    <meta name=\"pypi:repository-version\" content=\"" + APIVersion + "\">\n" +
+		"    <title>" + html.EscapeString(title) + "</title>\n  </head>\n  <body>\n" +
+		"    <h1>" + html.EscapeString(title) + "</h1>\n"
+}
+
+const htmlFooter = "  </body>\n</html>\n"
+
+func yankReason(raw json.RawMessage) (string, bool) {
+	if len(raw) == 0 {
+		return "", false
+	}
+	var yanked bool
+	if err := json.Unmarshal(raw, &yanked); err == nil {
+		return "", yanked
+	}
+	var reason string
+	if err := json.Unmarshal(raw, &reason); err == nil {
+		return reason, true
+	}
+	return "", false
+}
+
+// ParseProjectIndex reads the page of a project served by an upstream index,
+// in either format. Relative file URLs are resolved against the page URL.
+func ParseProjectIndex(r io.Reader, contentType string, pageURL string) (*ProjectIndex, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index url %q: %w", pageURL, err)
+	}
+
+	var project *ProjectIndex
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if strings.HasSuffix(mediaType, "json") {
+		project = &ProjectIndex{}
+		if err := json.NewDecoder(r).Decode(project); err != nil {
+			return nil, fmt.Errorf("failed to parse index: %w", err)
+		}
+	} else {
+		project, err = parseProjectHTML(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range project.Files {
+		ref, err := url.Parse(project.Files[i].URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file url %q: %w", project.Files[i].URL, err)
+		}
+		project.Files[i].URL = base.ResolveReference(ref).String()
+	}
+	return project, nil
+}
+
+func parseProjectHTML(r io.Reader) (*ProjectIndex, error) {
+	project := &ProjectIndex{Files: []File{}}
+	tokenizer := xhtml.NewTokenizer(r)
+	var current *File
+	for {
+		switch tokenizer.Next() {
+		case xhtml.ErrorToken:
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("failed to parse index: %w", err)
+			}
+			return project, nil
+		case xhtml.StartTagToken:
+			token := tokenizer.Token()
+			if token.Data != "a" {
+				continue
+			}
+			current = newFileFromAnchor(token)
+		case xhtml.TextToken:
+			if current != nil {
+				current.Filename += strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case xhtml.EndTagToken:
+			if current != nil && tokenizer.Token().Data == "a" {
+				if current.URL != "" && current.Filename != "" {
+					project.Files = append(project.Files, *current)
+				}
+				current = nil
+			}
+		case xhtml.SelfClosingTagToken, xhtml.CommentToken, xhtml.DoctypeToken:
+		}
+	}
+}
+
+func newFileFromAnchor(token xhtml.Token) *File {
+	f := &File{Hashes: map[string]string{}}
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "href":
+			href := attr.Val
+			if i := strings.Index(href, "#"); i >= 0 {
+				if algorithm, digest, ok := strings.Cut(href[i+1:], "="); ok {
+					f.Hashes[algorithm] = digest
+				}
+				href = href[:i]
+			}
+			f.URL = href
+		case "data-requires-python":
+			f.RequiresPython = attr.Val
+		case "data-yanked":
+			if attr.Val == "" {
+				f.Yanked = json.RawMessage("true")
+			} else if reason, err := json.Marshal(attr.Val); err == nil {
+				f.Yanked = reason
+			}
+		}
+	}
+	return f
+}
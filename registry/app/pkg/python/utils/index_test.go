@@ -0,0 +1,119 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	tests := []struct {
+		accept   string
+		format   string
+		expected string
+	}{
+		{"", "", ContentTypeHTML},
+		{"*/*", "", ContentTypeHTML},
+		{ContentTypeSimpleJSON, "", ContentTypeSimpleJSON},
+		{ContentTypeSimpleJSON + ", " + ContentTypeSimpleHTML + ";q=0.2, text/html;q=0.01", "", ContentTypeSimpleJSON},
+		{ContentTypeSimpleJSON + ";q=0.1, " + ContentTypeSimpleHTML, "", ContentTypeSimpleHTML},
+		{ContentTypeSimpleJSON + ";q=0", "", ContentTypeHTML},
+		{"text/html", ContentTypeSimpleJSON, ContentTypeSimpleJSON},
+		{ContentTypeSimpleJSON, "application/xml", ContentTypeSimpleJSON},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, NegotiateContentType(test.accept, test.format), test.accept)
+	}
+}
+
+func testProject() *ProjectIndex {
+	size := int64(42)
+	project := NewProjectIndex("My_Pkg")
+	project.Files = append(project.Files,
+		File{
+			Filename:       "my_pkg-1.0.tar.gz",
+			URL:            "https://host/files/my-pkg/1.0/my_pkg-1.0.tar.gz",
+			Hashes:         map[string]string{"sha256": "abc"},
+			RequiresPython: ">=3.8",
+			Size:           &size,
+		},
+		File{
+			Filename: "my_pkg-0.9-py3-none-any.whl",
+			URL:      "https://host/files/my-pkg/0.9/my_pkg-0.9-py3-none-any.whl",
+			Hashes:   map[string]string{"sha256": "def"},
+			Yanked:   json.RawMessage(`"broken"`),
+		},
+	)
+	project.SortFiles()
+	return project
+}
+
+func TestRenderProjectHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderProjectHTML(&buf, testProject()))
+	html := buf.String()
+	assert.Contains(t, html, `<meta name="pypi:repository-version" content="1.1">`)
+	assert.Contains(t, html, `<a href="https://host/files/my-pkg/1.0/my_pkg-1.0.tar.gz#sha256=abc" `+
+		`data-requires-python="&gt;=3.8">my_pkg-1.0.tar.gz</a>`)
+	assert.Contains(t, html, `data-yanked="broken"`)
+	assert.Less(t, strings.Index(html, "my_pkg-0.9"), strings.Index(html, "my_pkg-1.0"))
+
+	buf.Reset()
+	require.NoError(t, RenderProjectListHTML(&buf, NewProjectList([]string{"my-pkg"})))
+	assert.Contains(t, buf.String(), `<a href="my-pkg/">my-pkg</a>`)
+}
+
+func TestParseProjectIndexRoundTrip(t *testing.T) {
+	expected := testProject()
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderProjectHTML(&buf, expected))
+	project, err := ParseProjectIndex(&buf, "text/html; charset=utf-8", "https://host/simple/my-pkg/")
+	require.NoError(t, err)
+	require.Len(t, project.Files, 2)
+	for i, f := range project.Files {
+		assert.Equal(t, expected.Files[i].Filename, f.Filename)
+		assert.Equal(t, expected.Files[i].URL, f.URL)
+		assert.Equal(t, expected.Files[i].Hashes, f.Hashes)
+		assert.Equal(t, expected.Files[i].RequiresPython, f.RequiresPython)
+	}
+	assert.JSONEq(t, `"broken"`, string(project.Files[0].Yanked))
+
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+	project, err = ParseProjectIndex(bytes.NewReader(data), ContentTypeSimpleJSON, "https://host/simple/my-pkg/")
+	require.NoError(t, err)
+	assert.Equal(t, expected.Files, project.Files)
+}
+
+func TestParseProjectIndexRelativeURLs(t *testing.T) {
+	html := `<html><body>` +
+		`<a href="../../packages/ab/my_pkg-1.0.tar.gz#sha256=abc">my_pkg-1.0.tar.gz</a>` +
+		`<a href="/other/my_pkg-0.9.tar.gz" data-yanked="">my_pkg-0.9.tar.gz</a>` +
+		`</body></html>`
+	project, err := ParseProjectIndex(strings.NewReader(html), "text/html", "https://host/simple/my-pkg/")
+	require.NoError(t, err)
+	require.Len(t, project.Files, 2)
+	assert.Equal(t, "https://host/packages/ab/my_pkg-1.0.tar.gz", project.Files[0].URL)
+	assert.Equal(t, "abc", project.Files[0].Hashes["sha256"])
+	assert.Equal(t, "https://host/other/my_pkg-0.9.tar.gz", project.Files[1].URL)
+	assert.JSONEq(t, "true", string(project.Files[1].Yanked))
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"path"
+	"strings"
+)
+
+const (
+	wheelMetadataFile = "METADATA"
+	sdistMetadataFile = "PKG-INFO"
+	distInfoSuffix    = ".dist-info"
+	// maxMetadataSize bounds the size of the metadata file we are willing to read.
+	maxMetadataSize = 4 << 20
+)
+
+var ErrMetadataNotFound = errors.New("metadata not found in distribution")
+
+// Metadata holds the core metadata fields of a distribution the registry uses.
+type Metadata struct {
+	MetadataVersion string
+	Name            string
+	Version         string
+	Summary         string
+	RequiresPython  string
+	HomePage        string
+	Author          string
+	License         string
+	RequiresDist    []string
+}
+
+// ParseMetadata reads a core metadata file, the METADATA file of a wheel or the
+// PKG-INFO file of a source distribution. Both use the email header format.
+func ParseMetadata(r io.Reader) (*Metadata, error) {
+	header, err := textproto.NewReader(bufio.NewReader(r)).ReadMIMEHeader()
+	// The description may be in the body, or missing along with the blank line
+	// separating it from the headers.
+	if err != nil && (!errors.Is(err, io.EOF) || len(header) == 0) {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	metadata := &Metadata{
+		MetadataVersion: header.Get("Metadata-Version"),
+		Name:            header.Get("Name"),
+		Version:         header.Get("Version"),
+		Summary:         header.Get("Summary"),
+		RequiresPython:  header.Get("Requires-Python"),
+		HomePage:        header.Get("Home-Page"),
+		Author:          header.Get("Author"),
+		License:         header.Get("License"),
+		RequiresDist:    header.Values("Requires-Dist"),
+	}
+	if metadata.Name == "" || metadata.Version == "" {
+		return nil, fmt.Errorf("metadata is missing the name or the version")
+	}
+	if err := ValidateName(metadata.Name); err != nil {
+		return nil, err
+	}
+	if err := ValidateVersion(metadata.Version); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// ExtractMetadata reads the core metadata of a wheel or of a source
+// distribution.
+func ExtractMetadata(file io.ReaderAt, size int64, fileName string) (*Metadata, error) {
+	switch {
+	case strings.HasSuffix(fileName, WheelExtension):
+		return extractFromZip(file, size, isWheelMetadata)
+	case strings.HasSuffix(fileName, SdistZipExtension):
+		return extractFromZip(file, size, isSdistMetadata)
+	case strings.HasSuffix(fileName, SdistTarExtension):
+		return extractFromTarGz(io.NewSectionReader(file, 0, size))
+	default:
+		return nil, fmt.Errorf("%w: unsupported file type %q", ErrInvalidFileName, fileName)
+	}
+}
+
+// isWheelMetadata matches {name}-{version}.dist-info/METADATA.
+func isWheelMetadata(name string) bool {
+	dir, file := path.Split(name)
+	return file == wheelMetadataFile && strings.Count(dir, "/") == 1 &&
+		strings.HasSuffix(strings.TrimSuffix(dir, "/"), distInfoSuffix)
+}
+
+// isSdistMetadata matches {name}-{version}/PKG-INFO.
+func isSdistMetadata(name string) bool {
+	dir, file := path.Split(strings.TrimPrefix(name, "./"))
+	return file == sdistMetadataFile && strings.Count(dir, "/") == 1
+}
+
+func extractFromZip(file io.ReaderAt, size int64, match func(string) bool) (*Metadata, error) {
+	zr, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if !match(f.Name) {
+			continue
+		}
+		if f.UncompressedSize64 > maxMetadataSize {
+			return nil, fmt.Errorf("metadata file %s is too large", f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		defer rc.Close()
+		return readMetadata(rc)
+	}
+	return nil, ErrMetadataNotFound
+}
+
+func extractFromTarGz(r io.Reader) (*Metadata, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrMetadataNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !isSdistMetadata(hdr.Name) {
+			continue
+		}
+		return readMetadata(tr)
+	}
+}
+
+func readMetadata(r io.Reader) (*Metadata, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	if len(data) > maxMetadataSize {
+		return nil, fmt.Errorf("metadata file is too large")
+	}
+	return ParseMetadata(bytes.NewReader(data))
+}
@@ -0,0 +1,139 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = "Metadata-Version: 2.1\n" +
+	"Name: My_Pkg\n" +
+	"Version: 1.0\n" +
+	"Summary: A test package\n" +
+	"Home-page: https://example.com\n" +
+	"Author: Jane Doe\n" +
+	"License: MIT\n" +
+	"Requires-Python: >=3.8\n" +
+	"Requires-Dist: requests>=2.0\n" +
+	"Requires-Dist: click; extra == \"cli\"\n" +
+	"\n" +
+	"The long description.\n"
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func buildTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func assertTestMetadata(t *testing.T, metadata *Metadata) {
+	t.Helper()
+	assert.Equal(t, "2.1", metadata.MetadataVersion)
+	assert.Equal(t, "My_Pkg", metadata.Name)
+	assert.Equal(t, "1.0", metadata.Version)
+	assert.Equal(t, "A test package", metadata.Summary)
+	assert.Equal(t, "https://example.com", metadata.HomePage)
+	assert.Equal(t, "Jane Doe", metadata.Author)
+	assert.Equal(t, "MIT", metadata.License)
+	assert.Equal(t, ">=3.8", metadata.RequiresPython)
+	assert.Equal(t, []string{"requests>=2.0", `click; extra == "cli"`}, metadata.RequiresDist)
+}
+
+func TestParseMetadata(t *testing.T) {
+	metadata, err := ParseMetadata(strings.NewReader(testMetadata))
+	require.NoError(t, err)
+	assertTestMetadata(t, metadata)
+
+	// The headers don't have to be followed by a description.
+	metadata, err = ParseMetadata(strings.NewReader("Name: pkg\nVersion: 2.0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "pkg", metadata.Name)
+
+	_, err = ParseMetadata(strings.NewReader("Name: pkg\n\n"))
+	require.Error(t, err)
+	_, err = ParseMetadata(strings.NewReader("Name: pkg\nVersion: latest\n"))
+	require.ErrorIs(t, err, ErrInvalidVersion)
+}
+
+func TestExtractMetadataWheel(t *testing.T) {
+	data := buildZip(t, map[string]string{
+		"my_pkg/__init__.py":                 "",
+		"my_pkg/data/METADATA":               "Name: other\nVersion: 9.9\n",
+		"my_pkg-1.0.dist-info/METADATA":      testMetadata,
+		"my_pkg-1.0.dist-info/RECORD":        "",
+		"my_pkg-1.0.dist-info/entry_points":  "",
+		"nested/my_pkg-1.0.dist-info/README": "",
+	})
+	metadata, err := ExtractMetadata(bytes.NewReader(data), int64(len(data)), "my_pkg-1.0-py3-none-any.whl")
+	require.NoError(t, err)
+	assertTestMetadata(t, metadata)
+
+	data = buildZip(t, map[string]string{"my_pkg/__init__.py": ""})
+	_, err = ExtractMetadata(bytes.NewReader(data), int64(len(data)), "my_pkg-1.0-py3-none-any.whl")
+	require.ErrorIs(t, err, ErrMetadataNotFound)
+}
+
+func TestExtractMetadataSdist(t *testing.T) {
+	data := buildTarGz(t, map[string]string{
+		"my_pkg-1.0/setup.py":                 "",
+		"my_pkg-1.0/my_pkg.egg-info/PKG-INFO": "Name: other\nVersion: 9.9\n",
+		"my_pkg-1.0/PKG-INFO":                 testMetadata,
+	})
+	metadata, err := ExtractMetadata(bytes.NewReader(data), int64(len(data)), "my_pkg-1.0.tar.gz")
+	require.NoError(t, err)
+	assertTestMetadata(t, metadata)
+
+	data = buildZip(t, map[string]string{"my_pkg-1.0/PKG-INFO": testMetadata})
+	metadata, err = ExtractMetadata(bytes.NewReader(data), int64(len(data)), "my_pkg-1.0.zip")
+	require.NoError(t, err)
+	assertTestMetadata(t, metadata)
+
+	_, err = ExtractMetadata(bytes.NewReader([]byte("not an archive")), 14, "my_pkg-1.0.tar.gz")
+	require.Error(t, err)
+	_, err = ExtractMetadata(bytes.NewReader(data), int64(len(data)), "my_pkg-1.0.egg")
+	require.ErrorIs(t, err, ErrInvalidFileName)
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	WheelExtension     = ".whl"
+	SdistTarExtension  = ".tar.gz"
+	SdistZipExtension  = ".zip"
+	ContentTypePackage = "application/octet-stream"
+)
+
+// FileType is the kind of distribution a file holds, as sent by twine.
+type FileType string
+
+const (
+	FileTypeWheel FileType = "bdist_wheel"
+	FileTypeSdist FileType = "sdist"
+)
+
+var (
+	// nameRegex is the project name format defined by the core metadata specification.
+	nameRegex          = regexp.MustCompile(`(?i)^([a-z0-9]|[a-z0-9][a-z0-9._-]*[a-z0-9])$`)
+	nameSeparatorRegex = regexp.MustCompile(`[-_.]+`)
+	// versionRegex is the version format of PEP 440, including the forms
+	// normalization turns into the canonical one.
+	versionRegex = regexp.MustCompile(`(?i)^v?(?:[0-9]+!)?[0-9]+(?:\.[0-9]+)*` +
+		`(?:[-_.]?(?:alpha|a|beta|b|preview|pre|c|rc)[-_.]?[0-9]*)?` +
+		`(?:-[0-9]+|[-_.]?(?:post|rev|r)[-_.]?[0-9]*)?` +
+		`(?:[-_.]?dev[-_.]?[0-9]*)?` +
+		`(?:\+[a-z0-9]+(?:[-_.][a-z0-9]+)*)?$`)
+
+	ErrInvalidName     = errors.New("invalid project name")
+	ErrInvalidVersion  = errors.New("invalid version")
+	ErrInvalidFileName = errors.New("invalid distribution file name")
+)
+
+// NormalizeName returns the normalized form of a project name defined by
+// PEP 503, which is the name projects are stored and looked up under.
+func NormalizeName(name string) string {
+	return strings.ToLower(nameSeparatorRegex.ReplaceAllString(name, "-"))
+}
+
+// ValidateName checks the project name is valid.
+func ValidateName(name string) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// ValidateVersion checks the version is a valid PEP 440 version.
+func ValidateVersion(version string) error {
+	if !versionRegex.MatchString(version) {
+		return fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	return nil
+}
+
+// ParseFileName extracts the project name and the version from the name of a
+// wheel ({name}-{version}(-{build})?-{python}-{abi}-{platform}.whl) or of a
+// source distribution ({name}-{version}.tar.gz or .zip). The returned name is
+// normalized.
+func ParseFileName(fileName string) (name string, version string, fileType FileType, err error) {
+	if strings.ContainsAny(fileName, `/\`) {
+		return "", "", "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+	}
+
+	switch {
+	case strings.HasSuffix(fileName, WheelExtension):
+		parts := strings.Split(strings.TrimSuffix(fileName, WheelExtension), "-")
+		if len(parts) != 5 && len(parts) != 6 {
+			return "", "", "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+		}
+		name, version, fileType = parts[0], parts[1], FileTypeWheel
+	case strings.HasSuffix(fileName, SdistTarExtension), strings.HasSuffix(fileName, SdistZipExtension):
+		base := strings.TrimSuffix(strings.TrimSuffix(fileName, SdistTarExtension), SdistZipExtension)
+		// Older source distributions don't escape the dashes of the name, the
+		// version never contains one once normalized.
+		i := strings.LastIndex(base, "-")
+		if i <= 0 {
+			return "", "", "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+		}
+		name, version, fileType = base[:i], base[i+1:], FileTypeSdist
+	default:
+		return "", "", "", fmt.Errorf("%w: unsupported file type %q", ErrInvalidFileName, fileName)
+	}
+
+	if err := ValidateName(name); err != nil {
+		return "", "", "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+	}
+	if err := ValidateVersion(version); err != nil {
+		return "", "", "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+	}
+	return NormalizeName(name), version, fileType, nil
+}
+
+// GetFilePath returns the path a distribution file is stored under.
+func GetFilePath(name string, version string, fileName string) string {
+	return "/" + name + "/" + version + "/" + fileName
+}
+
+// FileURL returns the download URL of a distribution file in the given registry.
+func FileURL(registryURL string, name string, version string, fileName string) string {
+	return strings.TrimRight(registryURL, "/") + "/files/" + name + "/" + version + "/" + fileName
+}
@@ -0,0 +1,89 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"requests":          "requests",
+		"Django":            "django",
+		"zope.interface":    "zope-interface",
+		"My__Package-.Foo":  "my-package-foo",
+		"typing_extensions": "typing-extensions",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, NormalizeName(name), name)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"a", "requests", "zope.interface", "my_pkg-2"} {
+		assert.NoError(t, ValidateName(name), name)
+	}
+	for _, name := range []string{"", "-pkg", "pkg.", "my pkg", "pkg/../x"} {
+		assert.ErrorIs(t, ValidateName(name), ErrInvalidName, name)
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	for _, version := range []string{"1", "1.0", "2.0.0rc1", "1.0.post2", "1.0.dev3", "1!2.0", "1.0+local.7", "v1.2"} {
+		assert.NoError(t, ValidateVersion(version), version)
+	}
+	for _, version := range []string{"", "latest", "1.0-", "1..0", "1.0/../x"} {
+		assert.ErrorIs(t, ValidateVersion(version), ErrInvalidVersion, version)
+	}
+}
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		fileName string
+		name     string
+		version  string
+		fileType FileType
+	}{
+		{"requests-2.31.0-py3-none-any.whl", "requests", "2.31.0", FileTypeWheel},
+		{"my_pkg-1.0-1-cp311-cp311-manylinux_2_17_x86_64.whl", "my-pkg", "1.0", FileTypeWheel},
+		{"my_pkg-1.0.tar.gz", "my-pkg", "1.0", FileTypeSdist},
+		{"my-pkg-1.0rc1.tar.gz", "my-pkg", "1.0rc1", FileTypeSdist},
+		{"Zope.Interface-6.0.zip", "zope-interface", "6.0", FileTypeSdist},
+	}
+	for _, test := range tests {
+		name, version, fileType, err := ParseFileName(test.fileName)
+		require.NoError(t, err, test.fileName)
+		assert.Equal(t, test.name, name, test.fileName)
+		assert.Equal(t, test.version, version, test.fileName)
+		assert.Equal(t, test.fileType, fileType, test.fileName)
+	}
+
+	for _, fileName := range []string{
+		"requests-2.31.0.egg", "requests.whl", "requests-2.31.0-py3.whl", "requests.tar.gz",
+		"../requests-2.31.0.tar.gz", "requests-latest.tar.gz",
+	} {
+		_, _, _, err := ParseFileName(fileName)
+		assert.ErrorIs(t, err, ErrInvalidFileName, fileName)
+	}
+}
+
+func TestFileURL(t *testing.T) {
+	assert.Equal(t, "https://host/python/root/reg/files/my-pkg/1.0/my_pkg-1.0.tar.gz",
+		FileURL("https://host/python/root/reg/", "my-pkg", "1.0", "my_pkg-1.0.tar.gz"))
+	assert.Equal(t, "/my-pkg/1.0/my_pkg-1.0.tar.gz", GetFilePath("my-pkg", "1.0", "my_pkg-1.0.tar.gz"))
+}
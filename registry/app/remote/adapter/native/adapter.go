@@ -53,7 +53,7 @@ func NewAdapter(
 		proxy: reg,
 	}
 	// Get the password: lookup secrets.secret_data using secret_identifier & secret_space_id.
-	password := getPwd(ctx, spacePathStore, service, reg)
+	password := GetPwd(ctx, spacePathStore, service, reg)
 	username, password, url := reg.UserName, password, reg.RepoURL
 	adapter.Client = registry.NewClient(url, username, password, false)
 	return adapter
@@ -67,8 +67,8 @@ func NewAdapterWithAuthorizer(reg types.UpstreamProxy, authorizer lib.Authorizer
 	}
 }
 
-// getPwd: lookup secrets.secret_data using secret_identifier & secret_space_id.
-func getPwd(
+// GetPwd: lookup secrets.secret_data using secret_identifier & secret_space_id.
+func GetPwd(
 	ctx context.Context, spacePathStore store.SpacePathStore, secretService secret.Service, reg types.UpstreamProxy,
 ) string {
 	if api.AuthType(reg.RepoAuthType) == api.AuthTypeUserPassword {
@@ -0,0 +1,108 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	store2 "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	adp "github.com/harness/gitness/registry/app/remote/adapter"
+	"github.com/harness/gitness/registry/app/remote/adapter/native"
+	"github.com/harness/gitness/registry/app/remote/clients/registry/auth/basic"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+// NpmjsURL is the URL of the public npm registry.
+const NpmjsURL = "https://registry.npmjs.org"
+
+func init() {
+	adapterType := string(artifact.UpstreamConfigSourceNpmjs)
+	if err := adp.RegisterFactory(adapterType, new(factory)); err != nil {
+		log.Error().Stack().Err(err).Msgf("Register adapter factory for %s", adapterType)
+		return
+	}
+}
+
+// Registry defines the operations of an upstream npm registry.
+type Registry interface {
+	// GetPackument downloads the document describing all the versions of a package.
+	GetPackument(name string) (*commons.ResponseHeaders, io.ReadCloser, error)
+
+	// GetTarball downloads a package tarball.
+	GetTarball(name string, fileName string) (*commons.ResponseHeaders, io.ReadCloser, error)
+}
+
+var (
+	_ adp.Adapter          = (*adapter)(nil)
+	_ adp.ArtifactRegistry = (*adapter)(nil)
+	_ Registry             = (*adapter)(nil)
+)
+
+type adapter struct {
+	*native.Adapter
+}
+
+type factory struct {
+}
+
+// Create ...
+func (f *factory) Create(
+	ctx context.Context, spacePathStore store2.SpacePathStore, record types.UpstreamProxy, service secret.Service,
+) (adp.Adapter, error) {
+	return newAdapter(ctx, spacePathStore, service, record), nil
+}
+
+func newAdapter(
+	ctx context.Context, spacePathStore store2.SpacePathStore, service secret.Service, registry types.UpstreamProxy,
+) *adapter {
+	password := native.GetPwd(ctx, spacePathStore, service, registry)
+	return newAdapterWithPassword(registry, password)
+}
+
+// newAdapterWithPassword creates the adapter of an upstream registry with
+// resolved credentials. Any registry implementing the npm registry API can be
+// used, by default the public registry is used.
+func newAdapterWithPassword(registry types.UpstreamProxy, password string) *adapter {
+	if registry.Source == string(artifact.UpstreamConfigSourceNpmjs) || registry.RepoURL == "" {
+		registry.RepoURL = NpmjsURL
+	}
+	registry.RepoURL = strings.TrimRight(registry.RepoURL, "/")
+	return &adapter{
+		Adapter: native.NewAdapterWithAuthorizer(registry, basic.NewAuthorizer(registry.UserName, password)),
+	}
+}
+
+// GetPackument downloads the packument of a package. The slash of scoped
+// package names is escaped, the same way the npm client does.
+func (a *adapter) GetPackument(name string) (*commons.ResponseHeaders, io.ReadCloser, error) {
+	return a.GetFile(escapePackageName(name))
+}
+
+// GetTarball downloads a tarball from the conventional location, which all the
+// registries compatible with registry.npmjs.org use.
+func (a *adapter) GetTarball(name string, fileName string) (*commons.ResponseHeaders, io.ReadCloser, error) {
+	return a.GetFile(name + "/-/" + fileName)
+}
+
+func escapePackageName(name string) string {
+	return strings.Replace(name, "/", "%2f", 1)
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubRegistry serves a scoped and an unscoped package the way
+// registry.npmjs.org does.
+func newStubRegistry(t *testing.T, user, password string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user != "" {
+			u, p, ok := r.BasicAuth()
+			if !ok || u != user || p != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		switch r.URL.EscapedPath() {
+		case "/left-pad":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"left-pad"}`))
+		case "/@scope%2fpkg":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"@scope/pkg"}`))
+		case "/@scope/pkg/-/pkg-1.0.0.tgz":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("tarball"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func readAll(t *testing.T, body io.ReadCloser) string {
+	t.Helper()
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestAdapterGetPackument(t *testing.T) {
+	server := newStubRegistry(t, "", "")
+	a := newAdapterWithPassword(types.UpstreamProxy{RepoURL: server.URL + "/"}, "")
+
+	_, body, err := a.GetPackument("left-pad")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"left-pad"}`, readAll(t, body))
+
+	_, body, err = a.GetPackument("@scope/pkg")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"@scope/pkg"}`, readAll(t, body))
+
+	_, _, err = a.GetPackument("missing")
+	require.Error(t, err)
+}
+
+func TestAdapterGetTarball(t *testing.T) {
+	server := newStubRegistry(t, "", "")
+	a := newAdapterWithPassword(types.UpstreamProxy{RepoURL: server.URL}, "")
+
+	_, body, err := a.GetTarball("@scope/pkg", "pkg-1.0.0.tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "tarball", readAll(t, body))
+
+	_, _, err = a.GetTarball("@scope/pkg", "pkg-2.0.0.tgz")
+	require.Error(t, err)
+}
+
+func TestAdapterBasicAuth(t *testing.T) {
+	server := newStubRegistry(t, "user", "secret")
+
+	a := newAdapterWithPassword(types.UpstreamProxy{RepoURL: server.URL, UserName: "user"}, "secret")
+	_, body, err := a.GetPackument("left-pad")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"left-pad"}`, readAll(t, body))
+
+	a = newAdapterWithPassword(types.UpstreamProxy{RepoURL: server.URL, UserName: "user"}, "wrong")
+	_, _, err = a.GetPackument("left-pad")
+	require.Error(t, err)
+}
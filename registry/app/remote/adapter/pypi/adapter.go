@@ -0,0 +1,169 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	store2 "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	mavenutils "github.com/harness/gitness/registry/app/pkg/maven/utils"
+	pythonutils "github.com/harness/gitness/registry/app/pkg/python/utils"
+	adp "github.com/harness/gitness/registry/app/remote/adapter"
+	"github.com/harness/gitness/registry/app/remote/adapter/native"
+	"github.com/harness/gitness/registry/app/remote/clients/registry"
+	"github.com/harness/gitness/registry/app/remote/clients/registry/auth/basic"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// PyPIURL is the URL of the simple index of the public Python package index.
+	PyPIURL = "https://pypi.org/simple"
+
+	// maxIndexSize bounds the size of the project pages read from upstream indexes.
+	maxIndexSize = 64 << 20
+)
+
+// acceptHeader prefers the JSON form of the simple API, falling back to the HTML forms.
+var acceptHeader = pythonutils.ContentTypeSimpleJSON + ", " +
+	pythonutils.ContentTypeSimpleHTML + ";q=0.2, " + pythonutils.ContentTypeHTML + ";q=0.1"
+
+func init() {
+	adapterType := string(artifact.UpstreamConfigSourcePyPi)
+	if err := adp.RegisterFactory(adapterType, new(factory)); err != nil {
+		log.Error().Stack().Err(err).Msgf("Register adapter factory for %s", adapterType)
+		return
+	}
+}
+
+// Registry defines the operations of an upstream Python package index.
+type Registry interface {
+	// GetProject downloads the page of a project from the simple index.
+	GetProject(ctx context.Context, name string) (*pythonutils.ProjectIndex, error)
+
+	// DownloadFile downloads a distribution file from the URL the index lists it at.
+	DownloadFile(ctx context.Context, fileURL string) (*commons.ResponseHeaders, io.ReadCloser, error)
+}
+
+var (
+	_ adp.Adapter          = (*adapter)(nil)
+	_ adp.ArtifactRegistry = (*adapter)(nil)
+	_ Registry             = (*adapter)(nil)
+)
+
+type adapter struct {
+	*native.Adapter
+	indexURL *url.URL
+	// anonymous downloads the files hosted outside of the index, like the ones
+	// pypi.org serves from files.pythonhosted.org, so the credentials of the
+	// index aren't sent to other hosts.
+	anonymous registry.Client
+}
+
+type factory struct {
+}
+
+// Create ...
+func (f *factory) Create(
+	ctx context.Context, spacePathStore store2.SpacePathStore, record types.UpstreamProxy, service secret.Service,
+) (adp.Adapter, error) {
+	return newAdapter(ctx, spacePathStore, service, record)
+}
+
+func newAdapter(
+	ctx context.Context, spacePathStore store2.SpacePathStore, service secret.Service, registry types.UpstreamProxy,
+) (*adapter, error) {
+	password := native.GetPwd(ctx, spacePathStore, service, registry)
+	return newAdapterWithPassword(registry, password)
+}
+
+// newAdapterWithPassword creates the adapter of an upstream index with resolved
+// credentials. The URL is the one of the simple index, like
+// https://pypi.org/simple, which is used by default.
+func newAdapterWithPassword(reg types.UpstreamProxy, password string) (*adapter, error) {
+	if reg.Source == string(artifact.UpstreamConfigSourcePyPi) || reg.RepoURL == "" {
+		reg.RepoURL = PyPIURL
+	}
+	reg.RepoURL = strings.TrimRight(reg.RepoURL, "/")
+	indexURL, err := url.Parse(reg.RepoURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index url %q: %w", reg.RepoURL, err)
+	}
+	return &adapter{
+		Adapter:   native.NewAdapterWithAuthorizer(reg, basic.NewAuthorizer(reg.UserName, password)),
+		indexURL:  indexURL,
+		anonymous: registry.NewClientWithAuthorizer(reg.RepoURL, nil, false),
+	}, nil
+}
+
+// GetProject downloads the page of a project, in whichever format the index
+// supports. The URLs of the files are made absolute.
+func (a *adapter) GetProject(ctx context.Context, name string) (*pythonutils.ProjectIndex, error) {
+	pageURL := a.indexURL.String() + "/" + url.PathEscape(pythonutils.NormalizeName(name)) + "/"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", acceptHeader)
+
+	resp, err := a.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Indexes redirect to the page under the normalized name, the relative
+	// URLs are resolved against the page actually served.
+	if resp.Request != nil && resp.Request.URL != nil {
+		pageURL = resp.Request.URL.String()
+	}
+	return pythonutils.ParseProjectIndex(io.LimitReader(resp.Body, maxIndexSize),
+		resp.Header.Get("Content-Type"), pageURL)
+}
+
+// DownloadFile downloads a file, with the credentials of the index only when
+// the file is hosted by the index itself.
+func (a *adapter) DownloadFile(
+	ctx context.Context,
+	fileURL string,
+) (*commons.ResponseHeaders, io.ReadCloser, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid file url %q: %w", fileURL, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client := a.anonymous
+	if u.Scheme == a.indexURL.Scheme && u.Host == a.indexURL.Host {
+		client = a.Client
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mavenutils.ParseResponseHeaders(resp), resp.Body, nil
+}
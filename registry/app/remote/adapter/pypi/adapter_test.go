@@ -0,0 +1,138 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/registry/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubIndex serves a project page in JSON and another one in HTML only, the
+// files of the latter being hosted on files.
+func newStubIndex(t *testing.T, files *httptest.Server, user, password string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user != "" {
+			u, p, ok := r.BasicAuth()
+			if !ok || u != user || p != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		switch r.URL.Path {
+		case "/simple/my-pkg/":
+			w.Header().Set("Content-Type", "application/vnd.pypi.simple.v1+json")
+			_, _ = w.Write([]byte(`{"meta":{"api-version":"1.1"},"name":"my-pkg","files":[` +
+				`{"filename":"my_pkg-1.0.tar.gz","url":"../../packages/my_pkg-1.0.tar.gz",` +
+				`"hashes":{"sha256":"abc"},"requires-python":">=3.8"}]}`))
+		case "/simple/html-only/":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><body><a href="` + files.URL + `/html_only-2.0-py3-none-any.whl#sha256=def"` +
+				` data-requires-python="&gt;=3.9">html_only-2.0-py3-none-any.whl</a></body></html>`))
+		case "/packages/my_pkg-1.0.tar.gz":
+			_, _ = w.Write([]byte("sdist"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newStubFiles serves files and fails the requests carrying credentials.
+func newStubFiles(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("wheel"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func readAll(t *testing.T, body io.ReadCloser) string {
+	t.Helper()
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestAdapterGetProjectJSON(t *testing.T) {
+	server := newStubIndex(t, newStubFiles(t), "", "")
+	a, err := newAdapterWithPassword(types.UpstreamProxy{RepoURL: server.URL + "/simple/"}, "")
+	require.NoError(t, err)
+
+	project, err := a.GetProject(context.Background(), "My_Pkg")
+	require.NoError(t, err)
+	assert.Equal(t, "my-pkg", project.Name)
+	require.Len(t, project.Files, 1)
+	assert.Equal(t, server.URL+"/packages/my_pkg-1.0.tar.gz", project.Files[0].URL)
+	assert.Equal(t, "abc", project.Files[0].Hashes["sha256"])
+	assert.Equal(t, ">=3.8", project.Files[0].RequiresPython)
+
+	_, body, err := a.DownloadFile(context.Background(), project.Files[0].URL)
+	require.NoError(t, err)
+	assert.Equal(t, "sdist", readAll(t, body))
+
+	_, err = a.GetProject(context.Background(), "missing")
+	require.Error(t, err)
+}
+
+func TestAdapterGetProjectHTML(t *testing.T) {
+	files := newStubFiles(t)
+	server := newStubIndex(t, files, "user", "secret")
+	a, err := newAdapterWithPassword(types.UpstreamProxy{RepoURL: server.URL + "/simple", UserName: "user"}, "secret")
+	require.NoError(t, err)
+
+	project, err := a.GetProject(context.Background(), "html-only")
+	require.NoError(t, err)
+	require.Len(t, project.Files, 1)
+	assert.Equal(t, "html_only-2.0-py3-none-any.whl", project.Files[0].Filename)
+	assert.Equal(t, files.URL+"/html_only-2.0-py3-none-any.whl", project.Files[0].URL)
+	assert.Equal(t, "def", project.Files[0].Hashes["sha256"])
+	assert.Equal(t, ">=3.9", project.Files[0].RequiresPython)
+
+	// The credentials of the index are not sent to the host of the files.
+	_, body, err := a.DownloadFile(context.Background(), project.Files[0].URL)
+	require.NoError(t, err)
+	assert.Equal(t, "wheel", readAll(t, body))
+}
+
+func TestAdapterBasicAuth(t *testing.T) {
+	server := newStubIndex(t, newStubFiles(t), "user", "secret")
+	a, err := newAdapterWithPassword(types.UpstreamProxy{RepoURL: server.URL + "/simple", UserName: "user"}, "wrong")
+	require.NoError(t, err)
+
+	_, err = a.GetProject(context.Background(), "my-pkg")
+	require.Error(t, err)
+}
+
+func TestAdapterDefaultURL(t *testing.T) {
+	a, err := newAdapterWithPassword(types.UpstreamProxy{Source: "PyPi", RepoURL: "https://example.com"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, PyPIURL, a.indexURL.String())
+}
@@ -0,0 +1,187 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/npm/utils"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxPackumentSize bounds the size of the packuments read from upstream registries.
+const maxPackumentSize = 64 << 20
+
+type controller struct {
+	localRegistry  registryInterface
+	secretService  secret.Service
+	spacePathStore store.SpacePathStore
+}
+
+type Controller interface {
+	UseLocalFile(ctx context.Context, info pkg.NpmArtifactInfo) (
+		responseHeaders *commons.ResponseHeaders, fileReader *storage.FileReader, redirectURL string, useLocal bool)
+
+	ProxyPackument(
+		ctx context.Context, info pkg.NpmArtifactInfo, proxy types.UpstreamProxy,
+	) (*utils.Packument, error)
+
+	ProxyFile(
+		ctx context.Context, info pkg.NpmArtifactInfo, proxy types.UpstreamProxy,
+	) (*commons.ResponseHeaders, io.ReadCloser, error)
+}
+
+// NewProxyController -- get the proxy controller instance.
+func NewProxyController(
+	l registryInterface, secretService secret.Service,
+	spacePathStore store.SpacePathStore,
+) Controller {
+	return &controller{
+		localRegistry:  l,
+		secretService:  secretService,
+		spacePathStore: spacePathStore,
+	}
+}
+
+func (c *controller) UseLocalFile(ctx context.Context, info pkg.NpmArtifactInfo) (
+	responseHeaders *commons.ResponseHeaders, fileReader *storage.FileReader, redirectURL string, useLocal bool) {
+	responseHeaders, body, _, redirectURL, e := c.localRegistry.GetTarball(ctx, info)
+	return responseHeaders, body, redirectURL, len(e) == 0
+}
+
+// ProxyPackument fetches the packument from the upstream registry. The tarball
+// URLs are pointed to the registry the request came in through, so the
+// tarballs get cached once they are downloaded.
+func (c *controller) ProxyPackument(
+	ctx context.Context, info pkg.NpmArtifactInfo, proxy types.UpstreamProxy,
+) (*utils.Packument, error) {
+	rHelper, err := NewRemoteHelper(ctx, c.spacePathStore, c.secretService, proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	packument, err := getPackument(rHelper, info.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	for v, raw := range packument.Versions {
+		version, err := utils.ParseVersion(raw)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("skipping version %s of upstream package %s", v, info.Image)
+			delete(packument.Versions, v)
+			continue
+		}
+		raw, err = utils.UpdateVersion(raw,
+			utils.TarballURL(info.RegistryURL, info.Image, utils.TarballFileName(info.Image, v)), version.Deprecated)
+		if err != nil {
+			return nil, err
+		}
+		packument.Versions[v] = raw
+	}
+	packument.Rev = ""
+	packument.Attachments = nil
+	return packument, nil
+}
+
+func (c *controller) ProxyFile(
+	ctx context.Context, info pkg.NpmArtifactInfo, proxy types.UpstreamProxy,
+) (responseHeaders *commons.ResponseHeaders, body io.ReadCloser, errs error) {
+	rHelper, err := NewRemoteHelper(ctx, c.spacePathStore, c.secretService, proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseHeaders, body, err = rHelper.GetTarball(info.Image, info.FileName)
+	if err != nil {
+		return responseHeaders, nil, err
+	}
+
+	go func(info pkg.NpmArtifactInfo) {
+		// Cloning Context.
+		session, ok := request.AuthSessionFrom(ctx)
+		if !ok {
+			log.Error().Stack().Msg("failed to get auth session from context")
+			return
+		}
+		ctx2 := request.WithAuthSession(context.Background(), session)
+
+		err := c.putFileToLocal(ctx2, info, rHelper)
+		if err != nil {
+			log.Ctx(ctx2).Error().Str("goRoutine",
+				"AddNpmPackage").Stack().Err(err).Msgf("error while putting file to localRegistry, %v", err)
+			return
+		}
+		log.Ctx(ctx2).Info().Str("goRoutine", "AddNpmPackage").Msgf("Successfully cached package "+
+			"%s@%s to registry: %s", info.Image, info.Version, info.RegIdentifier)
+	}(info)
+	return responseHeaders, body, nil
+}
+
+// putFileToLocal caches a package version: the version document from the
+// upstream packument along with the tarball.
+func (c *controller) putFileToLocal(
+	ctx context.Context,
+	info pkg.NpmArtifactInfo,
+	r RemoteInterface,
+) error {
+	packument, err := getPackument(r, info.Image)
+	if err != nil {
+		return err
+	}
+	version, ok := packument.Versions[info.Version]
+	if !ok {
+		return fmt.Errorf("version %s not found in upstream packument of %s", info.Version, info.Image)
+	}
+
+	_, fileReader, err := r.GetTarball(info.Image, info.FileName)
+	if err != nil {
+		return err
+	}
+	defer fileReader.Close()
+	_, errs := c.localRegistry.PutPackage(ctx, info, version, fileReader)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func getPackument(r RemoteInterface, name string) (*utils.Packument, error) {
+	_, body, err := r.GetPackument(name)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	packument := &utils.Packument{}
+	if err := json.NewDecoder(io.LimitReader(body, maxPackumentSize)).Decode(packument); err != nil {
+		return nil, fmt.Errorf("failed to parse upstream packument of %s: %w", name, err)
+	}
+	if packument.Versions == nil {
+		packument.Versions = map[string]json.RawMessage{}
+	}
+	return packument, nil
+}
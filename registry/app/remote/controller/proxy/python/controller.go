@@ -0,0 +1,182 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/python/utils"
+	"github.com/harness/gitness/registry/app/storage"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+	store2 "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+type controller struct {
+	localRegistry  registryInterface
+	secretService  secret.Service
+	spacePathStore store.SpacePathStore
+}
+
+type Controller interface {
+	UseLocalFile(ctx context.Context, info pkg.PythonArtifactInfo) (
+		responseHeaders *commons.ResponseHeaders, fileReader *storage.FileReader, redirectURL string, useLocal bool)
+
+	ProxyProject(
+		ctx context.Context, info pkg.PythonArtifactInfo, proxy types.UpstreamProxy,
+	) (*utils.ProjectIndex, error)
+
+	ProxyFile(
+		ctx context.Context, info pkg.PythonArtifactInfo, proxy types.UpstreamProxy,
+	) (*commons.ResponseHeaders, io.ReadCloser, error)
+}
+
+// NewProxyController -- get the proxy controller instance.
+func NewProxyController(
+	l registryInterface, secretService secret.Service,
+	spacePathStore store.SpacePathStore,
+) Controller {
+	return &controller{
+		localRegistry:  l,
+		secretService:  secretService,
+		spacePathStore: spacePathStore,
+	}
+}
+
+func (c *controller) UseLocalFile(ctx context.Context, info pkg.PythonArtifactInfo) (
+	responseHeaders *commons.ResponseHeaders, fileReader *storage.FileReader, redirectURL string, useLocal bool) {
+	responseHeaders, body, _, redirectURL, e := c.localRegistry.DownloadFile(ctx, info)
+	return responseHeaders, body, redirectURL, len(e) == 0
+}
+
+// ProxyProject fetches the page of a project from the upstream index. The file
+// URLs are pointed to the registry the request came in through, so the files
+// get cached once they are downloaded. Files the registry can't store, like
+// eggs, are left out.
+func (c *controller) ProxyProject(
+	ctx context.Context, info pkg.PythonArtifactInfo, proxy types.UpstreamProxy,
+) (*utils.ProjectIndex, error) {
+	rHelper, err := NewRemoteHelper(ctx, c.spacePathStore, c.secretService, proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, err := rHelper.GetProject(ctx, info.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	project := utils.NewProjectIndex(info.Image)
+	project.Versions = upstream.Versions
+	for _, f := range upstream.Files {
+		name, version, _, err := utils.ParseFileName(f.Filename)
+		if err != nil || name != info.Image {
+			log.Ctx(ctx).Debug().Msgf("skipping file %s of upstream project %s", f.Filename, info.Image)
+			continue
+		}
+		f.URL = utils.FileURL(info.RegistryURL, info.Image, version, f.Filename)
+		project.Files = append(project.Files, f)
+	}
+	project.SortFiles()
+	return project, nil
+}
+
+func (c *controller) ProxyFile(
+	ctx context.Context, info pkg.PythonArtifactInfo, proxy types.UpstreamProxy,
+) (responseHeaders *commons.ResponseHeaders, body io.ReadCloser, errs error) {
+	rHelper, err := NewRemoteHelper(ctx, c.spacePathStore, c.secretService, proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := findFile(ctx, rHelper, info)
+	if err != nil {
+		return nil, nil, err
+	}
+	responseHeaders, body, err = rHelper.DownloadFile(ctx, file.URL)
+	if err != nil {
+		return responseHeaders, nil, err
+	}
+
+	go func(info pkg.PythonArtifactInfo, file utils.File) {
+		// Cloning Context.
+		session, ok := request.AuthSessionFrom(ctx)
+		if !ok {
+			log.Error().Stack().Msg("failed to get auth session from context")
+			return
+		}
+		ctx2 := request.WithAuthSession(context.Background(), session)
+
+		err := c.putFileToLocal(ctx2, info, file, rHelper)
+		if err != nil {
+			log.Ctx(ctx2).Error().Str("goRoutine",
+				"AddPythonPackage").Stack().Err(err).Msgf("error while putting file to localRegistry, %v", err)
+			return
+		}
+		log.Ctx(ctx2).Info().Str("goRoutine", "AddPythonPackage").Msgf("Successfully cached file "+
+			"%s to registry: %s", info.FileName, info.RegIdentifier)
+	}(info, file)
+	return responseHeaders, body, nil
+}
+
+// putFileToLocal caches a file. The core metadata isn't read from the file,
+// the release is described with what the index tells about the file.
+func (c *controller) putFileToLocal(
+	ctx context.Context,
+	info pkg.PythonArtifactInfo,
+	file utils.File,
+	r RemoteInterface,
+) error {
+	_, fileReader, err := r.DownloadFile(ctx, file.URL)
+	if err != nil {
+		return err
+	}
+	defer fileReader.Close()
+
+	metadata := &utils.Metadata{
+		Name:           info.Image,
+		Version:        info.Version,
+		RequiresPython: file.RequiresPython,
+	}
+	_, errs := c.localRegistry.PutFile(ctx, info, metadata, fileReader, file.Hashes["sha256"])
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// findFile looks the requested file up in the page of its project, which tells
+// where the upstream index hosts it.
+func findFile(ctx context.Context, r RemoteInterface, info pkg.PythonArtifactInfo) (utils.File, error) {
+	project, err := r.GetProject(ctx, info.Image)
+	if err != nil {
+		return utils.File{}, err
+	}
+	for _, f := range project.Files {
+		if f.Filename == info.FileName {
+			return f, nil
+		}
+	}
+	return utils.File{}, fmt.Errorf("file %s not found in upstream project %s: %w",
+		info.FileName, info.Image, store2.ErrResourceNotFound)
+}
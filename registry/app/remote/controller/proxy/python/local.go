@@ -0,0 +1,37 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/registry/app/pkg"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/python/utils"
+	"github.com/harness/gitness/registry/app/storage"
+)
+
+type registryInterface interface {
+	GetProject(ctx context.Context, info pkg.PythonArtifactInfo) (project *utils.ProjectIndex, errs []error)
+
+	DownloadFile(ctx context.Context, info pkg.PythonArtifactInfo) (
+		responseHeaders *commons.ResponseHeaders, body *storage.FileReader, readCloser io.ReadCloser,
+		redirectURL string, errs []error)
+
+	PutFile(
+		ctx context.Context, info pkg.PythonArtifactInfo, metadata *utils.Metadata, file io.Reader, sha256 string,
+	) (responseHeaders *commons.ResponseHeaders, errs []error)
+}
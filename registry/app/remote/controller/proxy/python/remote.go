@@ -0,0 +1,95 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/store"
+	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/pkg/commons"
+	"github.com/harness/gitness/registry/app/pkg/python/utils"
+	"github.com/harness/gitness/registry/app/remote/adapter"
+	pypiadapter "github.com/harness/gitness/registry/app/remote/adapter/pypi"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RemoteInterface defines operations related to remote repository under proxy.
+type RemoteInterface interface {
+	// Download the page of a project from the simple index
+	GetProject(ctx context.Context, name string) (*utils.ProjectIndex, error)
+
+	// Download a distribution file
+	DownloadFile(ctx context.Context, fileURL string) (*commons.ResponseHeaders, io.ReadCloser, error)
+}
+
+type remoteHelper struct {
+	registry      pypiadapter.Registry
+	upstreamProxy types.UpstreamProxy
+	secretService secret.Service
+}
+
+// NewRemoteHelper create a remote interface.
+func NewRemoteHelper(
+	ctx context.Context, spacePathStore store.SpacePathStore, secretService secret.Service,
+	proxy types.UpstreamProxy,
+) (RemoteInterface, error) {
+	r := &remoteHelper{
+		upstreamProxy: proxy,
+		secretService: secretService,
+	}
+	if err := r.init(ctx, spacePathStore, string(api.UpstreamConfigSourcePyPi)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *remoteHelper) init(ctx context.Context, spacePathStore store.SpacePathStore, proxyType string) error {
+	if r.registry != nil {
+		return nil
+	}
+
+	factory, err := adapter.GetFactory(proxyType)
+	if err != nil {
+		return err
+	}
+	adp, err := factory.Create(ctx, spacePathStore, r.upstreamProxy, r.secretService)
+	if err != nil {
+		return err
+	}
+	reg, ok := adp.(pypiadapter.Registry)
+	if !ok {
+		log.Warn().Msgf("Error: adp is not of type pypi.Registry")
+		return fmt.Errorf("adapter for %s is not a python package index", proxyType)
+	}
+	r.registry = reg
+	return nil
+}
+
+func (r *remoteHelper) GetProject(ctx context.Context, name string) (*utils.ProjectIndex, error) {
+	return r.registry.GetProject(ctx, name)
+}
+
+func (r *remoteHelper) DownloadFile(
+	ctx context.Context,
+	fileURL string,
+) (*commons.ResponseHeaders, io.ReadCloser, error) {
+	return r.registry.DownloadFile(ctx, fileURL)
+}
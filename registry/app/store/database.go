@@ -127,10 +127,18 @@ type ManifestRepository interface {
 		ctx context.Context, repoID int64,
 		id int64,
 	) (types.Manifests, error)
+	// ListManifestsBySubjectDigest lists the manifests of an image that refer
+	// to the manifest with the given digest through their subject field.
 	ListManifestsBySubjectDigest(
-		ctx context.Context, repoID int64,
+		ctx context.Context, repoID int64, imageName string,
 		digest types.Digest,
 	) (types.Manifests, error)
+	// AssignSubject links the manifests referring to a subject that was pushed
+	// after them to the subject.
+	AssignSubject(
+		ctx context.Context, repoID int64, imageName string,
+		subjectDigest types.Digest, subjectID int64,
+	) error
 	DeleteManifestsByImageName(ctx context.Context, registryID int64, imageName string) (err error)
 }
 
@@ -257,6 +265,13 @@ type TagRepository interface {
 		ctx context.Context, repoID int64, imageName string,
 		name string,
 	) (*types.Tag, error)
+
+	// GetAllTagsByConfigMediaType lists every tag of a registry which points to a
+	// manifest whose configuration has the given media type.
+	GetAllTagsByConfigMediaType(
+		ctx context.Context, repoID int64,
+		configMediaType string,
+	) (*[]types.TagConfiguration, error)
 }
 
 // UpstreamProxyConfig holds the record of a config of upstream proxy in DB.
@@ -407,6 +422,8 @@ type ImageRepository interface {
 		ctx context.Context, registryID int64,
 		name string,
 	) (*types.Image, error)
+	// Get all the Images of a registry, ordered by name
+	GetAllByRegistryID(ctx context.Context, registryID int64) (*[]types.Image, error)
 	// Get the Labels specified by Parent ID and Repo
 	GetLabelsByParentIDAndRepo(
 		ctx context.Context, parentID int64,
@@ -433,6 +450,8 @@ type ImageRepository interface {
 	DeleteByRegistryID(ctx context.Context, registryID int64) (err error)
 	DeleteBandwidthStatByRegistryID(ctx context.Context, registryID int64) (err error)
 	DeleteDownloadStatByRegistryID(ctx context.Context, registryID int64) (err error)
+	// Delete an Image together with its bandwidth stats
+	DeleteByID(ctx context.Context, id int64) (err error)
 }
 
 type ArtifactRepository interface {
@@ -469,6 +488,12 @@ type ArtifactRepository interface {
 	) (int64, error)
 	GetArtifactMetadata(ctx context.Context, id int64, identifier string,
 		image string, version string) (*types.ArtifactMetadata, error)
+	// Get all the Artifacts of an Image
+	GetAllArtifactsByImageID(ctx context.Context, imageID int64) (*[]types.Artifact, error)
+	// Delete an Artifact together with its download stats
+	DeleteByImageIDAndVersion(ctx context.Context, imageID int64, version string) error
+	// Delete all the Artifacts of an Image together with their download stats
+	DeleteByImageID(ctx context.Context, imageID int64) error
 }
 
 type DownloadStatRepository interface {
@@ -558,6 +583,17 @@ type GenericBlobRepository interface {
 	DeleteByID(ctx context.Context, id string) error
 }
 
+type PackageTagRepository interface {
+	// Get all the tags of an Image
+	GetAllByImageID(ctx context.Context, imageID int64) (*[]types.PackageTag, error)
+	// Create a tag, or move an existing tag to another version
+	CreateOrUpdate(ctx context.Context, tag *types.PackageTag) error
+	// Delete a tag of an Image
+	DeleteByNameAndImageID(ctx context.Context, name string, imageID int64) error
+	// Delete all the tags pointing to a version of an Image
+	DeleteByVersionAndImageID(ctx context.Context, version string, imageID int64) error
+}
+
 type WebhooksRepository interface {
 	Create(ctx context.Context, webhook *types.Webhook) error
 	GetByRegistryAndIdentifier(ctx context.Context, registryID int64, webhookIdentifier string) (*types.Webhook, error)
@@ -130,6 +130,91 @@ func (a ArtifactDao) Count(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+func (a ArtifactDao) GetAllArtifactsByImageID(ctx context.Context, imageID int64) (*[]types.Artifact, error) {
+	q := databaseg.Builder.Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(artifactDB{}), ",")).
+		From("artifacts").
+		Where("artifact_image_id = ?", imageID).
+		OrderBy("artifact_created_at ASC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	dst := []*artifactDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to get artifacts")
+	}
+
+	artifacts := make([]types.Artifact, 0, len(dst))
+	for _, d := range dst {
+		artifact, err := a.mapToArtifact(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, *artifact)
+	}
+	return &artifacts, nil
+}
+
+func (a ArtifactDao) DeleteByImageIDAndVersion(ctx context.Context, imageID int64, version string) error {
+	return a.delete(ctx, sq.Eq{"artifact_image_id": imageID, "artifact_version": version})
+}
+
+func (a ArtifactDao) DeleteByImageID(ctx context.Context, imageID int64) error {
+	return a.delete(ctx, sq.Eq{"artifact_image_id": imageID})
+}
+
+// delete removes the artifacts matching the condition, the download stats
+// reference artifacts so they are purged first.
+func (a ArtifactDao) delete(ctx context.Context, cond sq.Eq) error {
+	var ids []int64
+	stmt := databaseg.Builder.Select("artifact_id").
+		From("artifacts").
+		Where(cond)
+
+	db := dbtx.GetAccessor(ctx, a.db)
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if err = db.SelectContext(ctx, &ids, query, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to find artifacts")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	delStmt := databaseg.Builder.Delete("download_stats").
+		Where(sq.Eq{"download_stat_artifact_id": ids})
+
+	delQuery, delArgs, err := delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, delQuery, delArgs...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	delStmt = databaseg.Builder.Delete("artifacts").
+		Where(sq.Eq{"artifact_id": ids})
+
+	delQuery, delArgs, err = delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, delQuery, delArgs...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+	return nil
+}
+
 func (a ArtifactDao) mapToInternalArtifact(ctx context.Context, in *types.Artifact) *artifactDB {
 	session, _ := request.AuthSessionFrom(ctx)
 
@@ -762,6 +847,50 @@ type MavenMetadata struct {
 	FileCount int64  `json:"file_count"`
 }
 
+type NpmMetadata struct {
+	Files     []File          `json:"files"`
+	FileCount int64           `json:"file_count"`
+	Package   json.RawMessage `json:"package"`
+}
+
+// PythonMetadata holds the core metadata of a release and the files, wheels
+// and source distributions, uploaded for it.
+type PythonMetadata struct {
+	Files          []PythonFile `json:"files"`
+	FileCount      int64        `json:"file_count"`
+	Name           string       `json:"name"`
+	Summary        string       `json:"summary,omitempty"`
+	RequiresPython string       `json:"requires_python,omitempty"`
+	HomePage       string       `json:"home_page,omitempty"`
+	Author         string       `json:"author,omitempty"`
+	License        string       `json:"license,omitempty"`
+	RequiresDist   []string     `json:"requires_dist,omitempty"`
+}
+
+type PythonFile struct {
+	File
+	Sha256         string `json:"sha256"`
+	RequiresPython string `json:"requires_python,omitempty"`
+}
+
+// GoMetadata holds the metadata of a module version, its go.mod file and zip
+// are the files of the version. Origin is set for the versions generated from
+// a tag of a repository.
+type GoMetadata struct {
+	Files     []File    `json:"files"`
+	FileCount int64     `json:"file_count"`
+	Time      string    `json:"time"`
+	GoVersion string    `json:"go_version,omitempty"`
+	Origin    *GoOrigin `json:"origin,omitempty"`
+}
+
+type GoOrigin struct {
+	VCS  string `json:"vcs"`
+	URL  string `json:"url,omitempty"`
+	Ref  string `json:"ref,omitempty"`
+	Hash string `json:"hash"`
+}
+
 type File struct {
 	Size      int64  `json:"size"`
 	Filename  string `json:"file_name"`
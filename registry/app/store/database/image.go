@@ -162,6 +162,35 @@ func (i ImageDao) DeleteByRegistryID(ctx context.Context, registryID int64) erro
 	return nil
 }
 
+func (i ImageDao) DeleteByID(ctx context.Context, id int64) error {
+	db := dbtx.GetAccessor(ctx, i.db)
+
+	delStmt := databaseg.Builder.Delete("bandwidth_stats").
+		Where("bandwidth_stat_image_id = ?", id)
+
+	delQuery, delArgs, err := delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, delQuery, delArgs...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+
+	delStmt = databaseg.Builder.Delete("images").
+		Where("image_id = ?", id)
+
+	delQuery, delArgs, err = delStmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert purge query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, delQuery, delArgs...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+	return nil
+}
+
 func (i ImageDao) DeleteDownloadStatByRegistryID(ctx context.Context, registryID int64) (err error) {
 	var ids []int64
 	stmt := databaseg.Builder.Select("download_stat_id").
@@ -217,6 +246,34 @@ func (i ImageDao) GetByName(ctx context.Context, registryID int64, name string)
 	return i.mapToImage(ctx, dst)
 }
 
+func (i ImageDao) GetAllByRegistryID(ctx context.Context, registryID int64) (*[]types.Image, error) {
+	q := databaseg.Builder.Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(imageDB{}), ",")).
+		From("images").
+		Where("image_registry_id = ?", registryID).
+		OrderBy("image_name")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, i.db)
+
+	dst := []*imageDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to get images")
+	}
+	images := make([]types.Image, 0, len(dst))
+	for _, d := range dst {
+		image, err := i.mapToImage(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, *image)
+	}
+	return &images, nil
+}
+
 func (i ImageDao) CreateOrUpdate(ctx context.Context, image *types.Image) error {
 	const sqlQuery = `
 		INSERT INTO images ( 
@@ -466,7 +466,7 @@ func (dao manifestDao) FindManifestByDigest(
 }
 
 func (dao manifestDao) ListManifestsBySubjectDigest(
-	ctx context.Context, repoID int64,
+	ctx context.Context, repoID int64, imageName string,
 	digest types.Digest,
 ) (types.Manifests, error) {
 	digestBytes, err := util.GetHexDecodedBytes(string(digest))
@@ -477,9 +477,10 @@ func (dao manifestDao) ListManifestsBySubjectDigest(
 	stmt := ReadQuery.
 		LeftJoin("blobs ON manifest_configuration_blob_id = blob_id").
 		Where(
-			"manifest_registry_id = ? AND manifest_subject_digest = ?",
-			repoID, digestBytes,
-		)
+			"manifest_registry_id = ? AND manifest_image_name = ? AND manifest_subject_digest = ?",
+			repoID, imageName, digestBytes,
+		).
+		OrderBy("manifest_created_at", "manifest_id")
 
 	toSQL, args, err := stmt.ToSql()
 	if err != nil {
@@ -641,7 +642,7 @@ func (dao manifestDao) ListManifestsBySubject(
 	dst := []*manifestMetadataDB{}
 	db := dbtx.GetAccessor(ctx, dao.sqlDB)
 
-	if err = db.SelectContext(ctx, dst, toSQL, args...); err != nil {
+	if err = db.SelectContext(ctx, &dst, toSQL, args...); err != nil {
 		err := database.ProcessSQLErrorf(ctx, err, "Failed to find manifest")
 		return nil, err
 	}
@@ -654,6 +655,36 @@ func (dao manifestDao) ListManifestsBySubject(
 	return *result, nil
 }
 
+func (dao manifestDao) AssignSubject(
+	ctx context.Context, repoID int64, imageName string,
+	subjectDigest types.Digest, subjectID int64,
+) error {
+	digestBytes, err := util.GetHexDecodedBytes(string(subjectDigest))
+	if err != nil {
+		return err
+	}
+
+	stmt := database.Builder.Update("manifests").
+		Set("manifest_subject_id", subjectID).
+		Where(
+			"manifest_registry_id = ? AND manifest_image_name = ? AND manifest_subject_digest = ?",
+			repoID, imageName, digestBytes,
+		).
+		Where("manifest_subject_id IS NULL")
+
+	toSQL, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert manifest query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, dao.sqlDB)
+
+	if _, err = db.ExecContext(ctx, toSQL, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to assign subject to manifests")
+	}
+	return nil
+}
+
 func mapToInternalManifest(ctx context.Context, in *types.Manifest) (*manifestDB, error) {
 	if in.CreatedAt.IsZero() {
 		in.CreatedAt = time.Now()
@@ -0,0 +1,185 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/app/store/database/util"
+	"github.com/harness/gitness/registry/types"
+	databaseg "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type PackageTagDao struct {
+	db *sqlx.DB
+}
+
+func NewPackageTagDao(db *sqlx.DB) store.PackageTagRepository {
+	return &PackageTagDao{
+		db: db,
+	}
+}
+
+type packageTagDB struct {
+	ID        int64  `db:"package_tag_id"`
+	Name      string `db:"package_tag_name"`
+	ImageID   int64  `db:"package_tag_image_id"`
+	Version   string `db:"package_tag_version"`
+	CreatedAt int64  `db:"package_tag_created_at"`
+	UpdatedAt int64  `db:"package_tag_updated_at"`
+	CreatedBy int64  `db:"package_tag_created_by"`
+	UpdatedBy int64  `db:"package_tag_updated_by"`
+}
+
+func (p PackageTagDao) GetAllByImageID(ctx context.Context, imageID int64) (*[]types.PackageTag, error) {
+	q := databaseg.Builder.Select(util.ArrToStringByDelimiter(util.GetDBTagsFromStruct(packageTagDB{}), ",")).
+		From("package_tags").
+		Where("package_tag_image_id = ?", imageID).
+		OrderBy("package_tag_name ASC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, p.db)
+
+	dst := []*packageTagDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to get package tags")
+	}
+
+	tags := make([]types.PackageTag, 0, len(dst))
+	for _, d := range dst {
+		tags = append(tags, *p.mapToPackageTag(d))
+	}
+	return &tags, nil
+}
+
+func (p PackageTagDao) CreateOrUpdate(ctx context.Context, tag *types.PackageTag) error {
+	const sqlQuery = `
+		INSERT INTO package_tags (
+		         package_tag_name
+				,package_tag_image_id
+				,package_tag_version
+				,package_tag_created_at
+				,package_tag_updated_at
+				,package_tag_created_by
+				,package_tag_updated_by
+		    ) VALUES (
+						 :package_tag_name
+						,:package_tag_image_id
+						,:package_tag_version
+						,:package_tag_created_at
+						,:package_tag_updated_at
+						,:package_tag_created_by
+						,:package_tag_updated_by
+		    )
+            ON CONFLICT (package_tag_image_id, package_tag_name)
+		    DO UPDATE SET package_tag_version = :package_tag_version,
+		                  package_tag_updated_at = :package_tag_updated_at,
+		                  package_tag_updated_by = :package_tag_updated_by
+            RETURNING package_tag_id`
+
+	db := dbtx.GetAccessor(ctx, p.db)
+	query, arg, err := db.BindNamed(sqlQuery, p.mapToInternalPackageTag(ctx, tag))
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to bind package tag object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&tag.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+	return nil
+}
+
+func (p PackageTagDao) DeleteByNameAndImageID(ctx context.Context, name string, imageID int64) error {
+	stmt := databaseg.Builder.Delete("package_tags").
+		Where("package_tag_image_id = ? AND package_tag_name = ?", imageID, name)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, p.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+	return nil
+}
+
+func (p PackageTagDao) DeleteByVersionAndImageID(ctx context.Context, version string, imageID int64) error {
+	stmt := databaseg.Builder.Delete("package_tags").
+		Where("package_tag_image_id = ? AND package_tag_version = ?", imageID, version)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, p.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete query failed")
+	}
+	return nil
+}
+
+func (p PackageTagDao) mapToInternalPackageTag(ctx context.Context, in *types.PackageTag) *packageTagDB {
+	session, _ := request.AuthSessionFrom(ctx)
+
+	if in.CreatedAt.IsZero() {
+		in.CreatedAt = time.Now()
+	}
+	if in.CreatedBy == 0 {
+		in.CreatedBy = session.Principal.ID
+	}
+	in.UpdatedAt = time.Now()
+	in.UpdatedBy = session.Principal.ID
+
+	return &packageTagDB{
+		ID:        in.ID,
+		Name:      in.Name,
+		ImageID:   in.ImageID,
+		Version:   in.Version,
+		CreatedAt: in.CreatedAt.UnixMilli(),
+		UpdatedAt: in.UpdatedAt.UnixMilli(),
+		CreatedBy: in.CreatedBy,
+		UpdatedBy: in.UpdatedBy,
+	}
+}
+
+func (p PackageTagDao) mapToPackageTag(dst *packageTagDB) *types.PackageTag {
+	return &types.PackageTag{
+		ID:        dst.ID,
+		Name:      dst.Name,
+		ImageID:   dst.ImageID,
+		Version:   dst.Version,
+		CreatedAt: time.UnixMilli(dst.CreatedAt),
+		UpdatedAt: time.UnixMilli(dst.UpdatedAt),
+		CreatedBy: dst.CreatedBy,
+		UpdatedBy: dst.UpdatedBy,
+	}
+}
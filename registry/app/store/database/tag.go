@@ -99,6 +99,17 @@ type tagMetadataDB struct {
 	DownloadCount   int64                `db:"download_count"`
 }
 
+type tagConfigurationDB struct {
+	Name                 string `db:"name"`
+	ImageName            string `db:"image_name"`
+	ManifestDigest       []byte `db:"manifest_digest"`
+	ManifestPayload      []byte `db:"manifest_payload"`
+	ConfigurationPayload []byte `db:"manifest_configuration_payload"`
+	Size                 int64  `db:"size"`
+	CreatedAt            int64  `db:"created_at"`
+	UpdatedAt            int64  `db:"updated_at"`
+}
+
 type tagDetailDB struct {
 	ID            int64  `db:"id"`
 	Name          string `db:"name"`
@@ -1083,6 +1094,62 @@ func (t tagDao) FindTag(
 	return t.mapToTag(ctx, dst)
 }
 
+func (t tagDao) GetAllTagsByConfigMediaType(
+	ctx context.Context, repoID int64,
+	configMediaType string,
+) (*[]types.TagConfiguration, error) {
+	q := databaseg.Builder.
+		Select(`
+            t.tag_name AS name, 
+            t.tag_image_name AS image_name, 
+            m.manifest_digest, 
+            m.manifest_payload, 
+            m.manifest_configuration_payload, 
+            m.manifest_total_size AS size, 
+            t.tag_created_at AS created_at, 
+            t.tag_updated_at AS updated_at
+        `).
+		From("tags t").
+		Join("manifests m ON t.tag_manifest_id = m.manifest_id").
+		Where(
+			"t.tag_registry_id = ? AND m.manifest_configuration_media_type = ?",
+			repoID, configMediaType,
+		).
+		OrderBy("t.tag_image_name ASC", "t.tag_created_at DESC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, t.db)
+
+	dst := []*tagConfigurationDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed executing tag configuration list query")
+	}
+
+	tags := make([]types.TagConfiguration, 0, len(dst))
+	for _, d := range dst {
+		dgst := types.Digest(util.GetHexEncodedString(d.ManifestDigest))
+		parsedDigest, err := dgst.Parse()
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, types.TagConfiguration{
+			Name:                 d.Name,
+			ImageName:            d.ImageName,
+			ManifestDigest:       parsedDigest.String(),
+			ManifestPayload:      d.ManifestPayload,
+			ConfigurationPayload: d.ConfigurationPayload,
+			Size:                 d.Size,
+			CreatedAt:            time.UnixMilli(d.CreatedAt),
+			UpdatedAt:            time.UnixMilli(d.UpdatedAt),
+		})
+	}
+	return &tags, nil
+}
+
 func (t tagDao) mapToInternalTag(ctx context.Context, in *types.Tag) *tagDB {
 	if in.CreatedAt.IsZero() {
 		in.CreatedAt = time.Now()
@@ -95,6 +95,10 @@ func ProvideNodeDao(db *sqlx.DB) store.NodesRepository {
 	return NewNodeDao(db)
 }
 
+func ProvidePackageTagDao(db *sqlx.DB) store.PackageTagRepository {
+	return NewPackageTagDao(db)
+}
+
 func ProvideGenericBlobDao(db *sqlx.DB) store.GenericBlobRepository {
 	return NewGenericBlobDao(db)
 }
@@ -117,5 +121,6 @@ var WireSet = wire.NewSet(
 	ProvideBandwidthStatDao,
 	ProvideNodeDao,
 	ProvideGenericBlobDao,
+	ProvidePackageTagDao,
 	ProvideWebhookDao,
 )
@@ -59,6 +59,19 @@ type TagMetadata struct {
 	DownloadCount   int64
 }
 
+// TagConfiguration is a tag together with the manifest and
+// configuration payloads it points to.
+type TagConfiguration struct {
+	Name                 string
+	ImageName            string
+	ManifestDigest       string
+	ManifestPayload      Payload
+	ConfigurationPayload Payload
+	Size                 int64
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
 type TagDetail struct {
 	ID            int64
 	Name          string
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// StageTypeApproval is the type of the stages that pause an execution
+// until a decision is made by one of the approvers.
+const StageTypeApproval = "approval"
+
+// Approval is the manual approval gate of a pipeline stage.
+type Approval struct {
+	ID          int64  `json:"-"`
+	ExecutionID int64  `json:"-"`
+	StageID     int64  `json:"-"`
+	Message     string `json:"message,omitempty"`
+
+	// Users and UserGroups restrict who can make the decision,
+	// anyone allowed to execute the pipeline can decide if both are empty.
+	Users      []string `json:"users"`
+	UserGroups []string `json:"user_groups"`
+
+	// Timeout in milliseconds after which the DefaultDecision is applied, zero disables the timeout.
+	Timeout         int64                 `json:"timeout,omitempty"`
+	DefaultDecision enum.ApprovalDecision `json:"default_decision,omitempty"`
+
+	Decision  enum.ApprovalDecision `json:"decision,omitempty"`
+	DecidedBy *int64                `json:"decided_by,omitempty"`
+	Comment   string                `json:"comment,omitempty"`
+	Decided   int64                 `json:"decided,omitempty"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+	Version int64 `json:"-"`
+}
+
+// IsDecided returns true if a decision was already made.
+func (a *Approval) IsDecided() bool {
+	return a.Decision != ""
+}
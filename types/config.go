@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
 	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 
@@ -174,6 +175,19 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// RunnerHeartbeatTimeout is the duration after which the stages of a remote runner
+		// that stopped calling the server are reclaimed and scheduled again.
+		RunnerHeartbeatTimeout time.Duration `envconfig:"GITNESS_CI_RUNNER_HEARTBEAT_TIMEOUT" default:"5m"`
+
+		// ArtifactRetention is the maximum time the artifacts uploaded by an execution are kept.
+		ArtifactRetention time.Duration `envconfig:"GITNESS_CI_ARTIFACT_RETENTION" default:"168h"`
+
+		// CacheRetention is the maximum time a pipeline cache is kept since it was last used.
+		CacheRetention time.Duration `envconfig:"GITNESS_CI_CACHE_RETENTION" default:"168h"`
+
+		// ArtifactMaxSize is the maximum size in bytes of a single artifact or cache upload.
+		ArtifactMaxSize int64 `envconfig:"GITNESS_CI_ARTIFACT_MAX_SIZE" default:"1073741824"`
 	}
 
 	// Database defines the database configuration parameters.
@@ -318,6 +332,18 @@ type Config struct {
 		ChannelSize      int           `envconfig:"GITNESS_PUBSUB_CHANNEL_SIZE"      default:"100"`
 	}
 
+	LogStream struct {
+		// Provider is the implementation used to share the live logs, redis is required
+		// when running multiple instances.
+		Provider livelog.Provider `envconfig:"GITNESS_LOGSTREAM_PROVIDER"    default:"inmemory"`
+		// Namespace is the prefix of the redis keys and channels.
+		Namespace string `envconfig:"GITNESS_LOGSTREAM_NAMESPACE"   default:"gitness"`
+		// BufferSize is the number of lines kept per stream for new subscribers.
+		BufferSize int `envconfig:"GITNESS_LOGSTREAM_BUFFER_SIZE" default:"5000"`
+		// Expiry is the duration after which an abandoned stream is removed from redis.
+		Expiry time.Duration `envconfig:"GITNESS_LOGSTREAM_EXPIRY"      default:"24h"`
+	}
+
 	BackgroundJobs struct {
 		// MaxRunning is maximum number of jobs that can be running at once.
 		MaxRunning int `envconfig:"GITNESS_JOBS_MAX_RUNNING" default:"10"`
@@ -466,11 +492,25 @@ type Config struct {
 
 		BusyActionInMins int `envconfig:"GITNESS_BUSY_ACTION_IN_MINS" default:"15"`
 
+		// IdleTimeoutInMins is the default number of minutes after which idle gitspaces are stopped.
+		// It can be overridden per space, a value of 0 disables the auto-stop.
+		IdleTimeoutInMins int `envconfig:"GITNESS_GITSPACE_IDLE_TIMEOUT_IN_MINS" default:"60"`
+
 		Events struct {
 			Concurrency   int `envconfig:"GITNESS_GITSPACE_EVENTS_CONCURRENCY" default:"4"`
 			MaxRetries    int `envconfig:"GITNESS_GITSPACE_EVENTS_MAX_RETRIES" default:"3"`
 			TimeoutInMins int `envconfig:"GITNESS_GITSPACE_EVENTS_TIMEOUT_IN_MINS" default:"45"`
 		}
+
+		// Prebuilds configures building the gitspace images of the repository branches on branch updates.
+		Prebuilds struct {
+			Enable       bool          `envconfig:"GITNESS_GITSPACE_PREBUILDS_ENABLE" default:"true"`
+			Concurrency  int           `envconfig:"GITNESS_GITSPACE_PREBUILDS_CONCURRENCY" default:"2"`
+			MaxRetries   int           `envconfig:"GITNESS_GITSPACE_PREBUILDS_MAX_RETRIES" default:"3"`
+			BuildTimeout time.Duration `envconfig:"GITNESS_GITSPACE_PREBUILDS_BUILD_TIMEOUT" default:"1h"`
+			// Retention is the duration after which the image of a prebuild that hasn't been rebuilt is removed.
+			Retention time.Duration `envconfig:"GITNESS_GITSPACE_PREBUILDS_RETENTION" default:"720h"`
+		}
 	}
 
 	UI struct {
@@ -532,6 +572,16 @@ type Config struct {
 			TransactionTimeoutDuration  time.Duration `envconfig:"GITNESS_REGISTRY_GARBAGE_COLLECTION_TRANSACTION_TIMEOUT_DURATION" default:"10s"` //nolint:lll
 			BlobsStorageTimeoutDuration time.Duration `envconfig:"GITNESS_REGISTRY_GARBAGE_COLLECTION_BLOB_STORAGE_TIMEOUT_DURATION" default:"5s"` //nolint:lll
 		}
+
+		// GoModules configures the publishing of Go modules from the tags pushed to the repositories.
+		GoModules struct {
+			// PublishTags enables publishing a module version for every semver tag pushed to a repository.
+			PublishTags bool `envconfig:"GITNESS_REGISTRY_GO_MODULES_PUBLISH_TAGS" default:"false"`
+			// Registry is the identifier of the Go registry of the root space the versions are published to.
+			Registry    string `envconfig:"GITNESS_REGISTRY_GO_MODULES_REGISTRY" default:"go"`
+			Concurrency int    `envconfig:"GITNESS_REGISTRY_GO_MODULES_CONCURRENCY" default:"4"`
+			MaxRetries  int    `envconfig:"GITNESS_REGISTRY_GO_MODULES_MAX_RETRIES" default:"3"`
+		}
 	}
 
 	Instrumentation struct {
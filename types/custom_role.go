@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// CustomRole is a named set of permissions defined by a space, which can be assigned to the members
+// of the space and of all its subspaces instead of one of the predefined membership roles.
+type CustomRole struct {
+	ID          int64             `json:"-"`
+	SpaceID     int64             `json:"space_id"`
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+	CreatedBy   int64             `json:"created_by"`
+	Created     int64             `json:"created"`
+	Updated     int64             `json:"updated"`
+	Version     int64             `json:"-"`
+}
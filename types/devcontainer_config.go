@@ -32,8 +32,15 @@ const FeatureDefaultTag = "latest"
 //nolint:tagliatelle
 type DevcontainerConfig struct {
 	Image                       string                           `json:"image,omitempty"`
+	Build                       *DevcontainerBuildConfig         `json:"build,omitempty"`
+	DockerComposeFile           StringOrArray                    `json:"dockerComposeFile,omitempty"`
+	Service                     string                           `json:"service,omitempty"`
+	RunServices                 []string                         `json:"runServices,omitempty"`
+	OnCreateCommand             LifecycleCommand                 `json:"onCreateCommand,omitempty"`
+	UpdateContentCommand        LifecycleCommand                 `json:"updateContentCommand,omitempty"`
 	PostCreateCommand           LifecycleCommand                 `json:"postCreateCommand,omitempty"`
 	PostStartCommand            LifecycleCommand                 `json:"postStartCommand,omitempty"`
+	PostAttachCommand           LifecycleCommand                 `json:"postAttachCommand,omitempty"`
 	ForwardPorts                []json.Number                    `json:"forwardPorts,omitempty"`
 	ContainerEnv                map[string]string                `json:"containerEnv,omitempty"`
 	Customizations              DevContainerConfigCustomizations `json:"customizations,omitempty"`
@@ -49,6 +56,50 @@ type DevcontainerConfig struct {
 	Mounts                      []*Mount                         `json:"mounts,omitempty"`
 }
 
+// DevcontainerBuildConfig describes how to build the gitspace image from a Dockerfile in the repo.
+// Paths are relative to the directory containing devcontainer.json.
+//
+//nolint:tagliatelle
+type DevcontainerBuildConfig struct {
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Context    string            `json:"context,omitempty"`
+	Args       map[string]string `json:"args,omitempty"`
+	Target     string            `json:"target,omitempty"`
+	CacheFrom  StringOrArray     `json:"cacheFrom,omitempty"`
+}
+
+// IsComposeBased returns true if the devcontainer is backed by a docker compose project.
+func (c *DevcontainerConfig) IsComposeBased() bool {
+	return len(c.DockerComposeFile) > 0
+}
+
+// IsDockerfileBased returns true if the devcontainer image has to be built from a Dockerfile.
+func (c *DevcontainerConfig) IsDockerfileBased() bool {
+	return c.Build != nil && c.Build.Dockerfile != ""
+}
+
+// StringOrArray is a list of strings which can be specified either as a single string or an array in JSON.
+type StringOrArray []string
+
+func (s *StringOrArray) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		if str == "" {
+			*s = nil
+			return nil
+		}
+		*s = StringOrArray{str}
+		return nil
+	}
+
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return fmt.Errorf("invalid format: must be string or []string")
+	}
+	*s = arr
+	return nil
+}
+
 // Constants for discriminator values.
 const (
 	TypeString     = "string"
@@ -24,21 +24,24 @@ import (
 
 //nolint:tagliatelle
 type DevcontainerFeatureConfig struct {
-	ID                string            `json:"id,omitempty"`
-	Version           string            `json:"version,omitempty"`
-	Name              string            `json:"name,omitempty"`
-	Options           *Options          `json:"options,omitempty"`
-	DependsOn         *Features         `json:"dependsOn,omitempty"`
-	ContainerEnv      map[string]string `json:"containerEnv,omitempty"`
-	Privileged        bool              `json:"privileged,omitempty"`
-	Init              bool              `json:"init,omitempty"`
-	CapAdd            []string          `json:"capAdd,omitempty"`
-	SecurityOpt       []string          `json:"securityOpt,omitempty"`
-	Entrypoint        string            `json:"entrypoint,omitempty"`
-	InstallsAfter     []string          `json:"installsAfter,omitempty"`
-	Mounts            []*Mount          `json:"mounts,omitempty"`
-	PostCreateCommand LifecycleCommand  `json:"postCreateCommand,omitempty"`
-	PostStartCommand  LifecycleCommand  `json:"postStartCommand,omitempty"`
+	ID                   string            `json:"id,omitempty"`
+	Version              string            `json:"version,omitempty"`
+	Name                 string            `json:"name,omitempty"`
+	Options              *Options          `json:"options,omitempty"`
+	DependsOn            *Features         `json:"dependsOn,omitempty"`
+	ContainerEnv         map[string]string `json:"containerEnv,omitempty"`
+	Privileged           bool              `json:"privileged,omitempty"`
+	Init                 bool              `json:"init,omitempty"`
+	CapAdd               []string          `json:"capAdd,omitempty"`
+	SecurityOpt          []string          `json:"securityOpt,omitempty"`
+	Entrypoint           string            `json:"entrypoint,omitempty"`
+	InstallsAfter        []string          `json:"installsAfter,omitempty"`
+	Mounts               []*Mount          `json:"mounts,omitempty"`
+	OnCreateCommand      LifecycleCommand  `json:"onCreateCommand,omitempty"`
+	UpdateContentCommand LifecycleCommand  `json:"updateContentCommand,omitempty"`
+	PostCreateCommand    LifecycleCommand  `json:"postCreateCommand,omitempty"`
+	PostStartCommand     LifecycleCommand  `json:"postStartCommand,omitempty"`
+	PostAttachCommand    LifecycleCommand  `json:"postAttachCommand,omitempty"`
 }
 
 type Options map[string]*OptionDefinition
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ApprovalDecision defines the decision made on a manual approval stage.
+type ApprovalDecision string
+
+func (ApprovalDecision) Enum() []interface{} { return toInterfaceSlice(approvalDecisions) }
+func (d ApprovalDecision) Sanitize() (ApprovalDecision, bool) {
+	return Sanitize(d, GetAllApprovalDecisions)
+}
+func GetAllApprovalDecisions() ([]ApprovalDecision, ApprovalDecision) {
+	return approvalDecisions, "" // No default value
+}
+
+// ApprovalDecision enumeration.
+const (
+	ApprovalDecisionApproved ApprovalDecision = "approved"
+	ApprovalDecisionRejected ApprovalDecision = "rejected"
+)
+
+var approvalDecisions = sortEnum([]ApprovalDecision{
+	ApprovalDecisionApproved,
+	ApprovalDecisionRejected,
+})
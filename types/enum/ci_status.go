@@ -24,16 +24,17 @@ import (
 type CIStatus string
 
 const (
-	CIStatusSkipped       CIStatus = "skipped"
-	CIStatusBlocked       CIStatus = "blocked"
-	CIStatusDeclined      CIStatus = "declined"
-	CIStatusWaitingOnDeps CIStatus = "waiting_on_dependencies"
-	CIStatusPending       CIStatus = "pending"
-	CIStatusRunning       CIStatus = "running"
-	CIStatusSuccess       CIStatus = "success"
-	CIStatusFailure       CIStatus = "failure"
-	CIStatusKilled        CIStatus = "killed"
-	CIStatusError         CIStatus = "error"
+	CIStatusSkipped           CIStatus = "skipped"
+	CIStatusBlocked           CIStatus = "blocked"
+	CIStatusDeclined          CIStatus = "declined"
+	CIStatusWaitingOnDeps     CIStatus = "waiting_on_dependencies"
+	CIStatusWaitingOnApproval CIStatus = "waiting_on_approval"
+	CIStatusPending           CIStatus = "pending"
+	CIStatusRunning           CIStatus = "running"
+	CIStatusSuccess           CIStatus = "success"
+	CIStatusFailure           CIStatus = "failure"
+	CIStatusKilled            CIStatus = "killed"
+	CIStatusError             CIStatus = "error"
 )
 
 // Enum returns all possible CIStatus values.
@@ -52,7 +53,7 @@ func GetAllCIStatuses() ([]CIStatus, CIStatus) {
 }
 
 func (status CIStatus) ConvertToCheckStatus() CheckStatus {
-	if status == CIStatusPending || status == CIStatusWaitingOnDeps {
+	if status == CIStatusPending || status == CIStatusWaitingOnDeps || status == CIStatusWaitingOnApproval {
 		return CheckStatusPending
 	}
 	if status == CIStatusSuccess || status == CIStatusSkipped {
@@ -73,7 +74,7 @@ func (status CIStatus) ConvertToCheckStatus() CheckStatus {
 func ParseCIStatus(status string) CIStatus {
 	switch strings.ToLower(status) {
 	case "skipped", "blocked", "declined", "waiting_on_dependencies",
-		"pending", "running", "success", "failure", "killed", "error":
+		"waiting_on_approval", "pending", "running", "success", "failure", "killed", "error":
 		return CIStatus(strings.ToLower(status))
 	case "": // just in case status is not passed through
 		return CIStatusPending
@@ -87,6 +88,7 @@ func (status CIStatus) IsDone() bool {
 	//nolint:exhaustive
 	switch status {
 	case CIStatusWaitingOnDeps,
+		CIStatusWaitingOnApproval,
 		CIStatusPending,
 		CIStatusRunning,
 		CIStatusBlocked:
@@ -109,6 +111,7 @@ var ciStatuses = sortEnum([]CIStatus{
 	CIStatusBlocked,
 	CIStatusDeclined,
 	CIStatusWaitingOnDeps,
+	CIStatusWaitingOnApproval,
 	CIStatusPending,
 	CIStatusRunning,
 	CIStatusSuccess,
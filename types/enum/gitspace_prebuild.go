@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// GitspacePrebuildState is the state of the latest build of a gitspace prebuild.
+type GitspacePrebuildState string
+
+func (GitspacePrebuildState) Enum() []interface{} {
+	return toInterfaceSlice(gitspacePrebuildStates)
+}
+
+var gitspacePrebuildStates = []GitspacePrebuildState{
+	GitspacePrebuildStatePending,
+	GitspacePrebuildStateBuilding,
+	GitspacePrebuildStateReady,
+	GitspacePrebuildStateFailed,
+	GitspacePrebuildStateStale,
+}
+
+const (
+	// GitspacePrebuildStatePending means nothing was built yet.
+	GitspacePrebuildStatePending GitspacePrebuildState = "pending"
+	// GitspacePrebuildStateBuilding means a build is in progress.
+	GitspacePrebuildStateBuilding GitspacePrebuildState = "building"
+	// GitspacePrebuildStateReady means the prebuilt image can be used by new gitspaces.
+	GitspacePrebuildStateReady GitspacePrebuildState = "ready"
+	// GitspacePrebuildStateFailed means the latest build failed.
+	GitspacePrebuildStateFailed GitspacePrebuildState = "failed"
+	// GitspacePrebuildStateStale means the prebuilt image was removed because the branch wasn't updated for too long.
+	GitspacePrebuildStateStale GitspacePrebuildState = "stale"
+)
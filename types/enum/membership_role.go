@@ -28,6 +28,7 @@ var MembershipRoles = sortEnum([]MembershipRole{
 	MembershipRoleExecutor,
 	MembershipRoleContributor,
 	MembershipRoleSpaceOwner,
+	MembershipRoleCustom,
 })
 
 var membershipRoleReaderPermissions = slices.Clip(slices.Insert([]Permission{}, 0,
@@ -136,4 +137,15 @@ const (
 	MembershipRoleExecutor    MembershipRole = "executor"
 	MembershipRoleContributor MembershipRole = "contributor"
 	MembershipRoleSpaceOwner  MembershipRole = "space_owner"
+
+	// MembershipRoleCustom is the role of the memberships with a custom role of the space assigned,
+	// the permissions of such memberships are the permissions of the custom role.
+	MembershipRoleCustom MembershipRole = "custom"
 )
+
+// IsAssignable returns true if the permission can be granted by a space membership,
+// which makes it a valid permission of a custom role.
+func (p Permission) IsAssignable() bool {
+	_, ok := slices.BinarySearch(membershipRoleSpaceOwnerPermissions, p)
+	return ok
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationChannelType defines the type of a chat notification channel.
+type NotificationChannelType string
+
+func (NotificationChannelType) Enum() []interface{} {
+	return toInterfaceSlice(notificationChannelTypes)
+}
+
+func (t NotificationChannelType) Sanitize() (NotificationChannelType, bool) {
+	return Sanitize(t, GetAllNotificationChannelTypes)
+}
+
+func GetAllNotificationChannelTypes() ([]NotificationChannelType, NotificationChannelType) {
+	return notificationChannelTypes, "" // No default value
+}
+
+// NotificationChannelType enumeration.
+const (
+	// NotificationChannelTypeSlack posts messages to a Slack incoming webhook.
+	NotificationChannelTypeSlack NotificationChannelType = "slack"
+	// NotificationChannelTypeMSTeams posts messages to a Microsoft Teams incoming webhook.
+	NotificationChannelTypeMSTeams NotificationChannelType = "msteams"
+	// NotificationChannelTypeWebhook posts generic JSON messages to any incoming webhook.
+	NotificationChannelTypeWebhook NotificationChannelType = "webhook"
+)
+
+var notificationChannelTypes = sortEnum([]NotificationChannelType{
+	NotificationChannelTypeSlack,
+	NotificationChannelTypeMSTeams,
+	NotificationChannelTypeWebhook,
+})
+
+// NotificationTrigger defines the notifications sent by the notification service.
+// Notification channels subscribe to them and users choose how each of them is delivered.
+type NotificationTrigger string
+
+func (NotificationTrigger) Enum() []interface{} { return toInterfaceSlice(notificationTriggers) }
+
+func (t NotificationTrigger) Sanitize() (NotificationTrigger, bool) {
+	return Sanitize(t, GetAllNotificationTriggers)
+}
+
+func GetAllNotificationTriggers() ([]NotificationTrigger, NotificationTrigger) {
+	return notificationTriggers, "" // No default value
+}
+
+// NotificationTrigger enumeration.
+const (
+	NotificationTriggerReviewerAdded        NotificationTrigger = "reviewer_added"
+	NotificationTriggerCommentPRAuthor      NotificationTrigger = "comment_pr_author"
+	NotificationTriggerCommentMentions      NotificationTrigger = "comment_mentions"
+	NotificationTriggerCommentParticipants  NotificationTrigger = "comment_participants"
+	NotificationTriggerPullReqBranchUpdated NotificationTrigger = "pullreq_branch_updated"
+	NotificationTriggerReviewSubmitted      NotificationTrigger = "review_submitted"
+	NotificationTriggerPullReqStateChanged  NotificationTrigger = "pullreq_state_changed"
+	NotificationTriggerReviewReminder       NotificationTrigger = "review_reminder"
+	NotificationTriggerReviewEscalation     NotificationTrigger = "review_escalation"
+	NotificationTriggerReviewDismissed      NotificationTrigger = "review_dismissed"
+	NotificationTriggerReviewRequested      NotificationTrigger = "review_requested"
+)
+
+var notificationTriggers = sortEnum([]NotificationTrigger{
+	NotificationTriggerReviewerAdded,
+	NotificationTriggerCommentPRAuthor,
+	NotificationTriggerCommentMentions,
+	NotificationTriggerCommentParticipants,
+	NotificationTriggerPullReqBranchUpdated,
+	NotificationTriggerReviewSubmitted,
+	NotificationTriggerPullReqStateChanged,
+	NotificationTriggerReviewReminder,
+	NotificationTriggerReviewEscalation,
+	NotificationTriggerReviewDismissed,
+	NotificationTriggerReviewRequested,
+})
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationDelivery defines how a user receives a notification.
+type NotificationDelivery string
+
+func (NotificationDelivery) Enum() []interface{} { return toInterfaceSlice(notificationDeliveries) }
+
+func (d NotificationDelivery) Sanitize() (NotificationDelivery, bool) {
+	return Sanitize(d, GetAllNotificationDeliveries)
+}
+
+func GetAllNotificationDeliveries() ([]NotificationDelivery, NotificationDelivery) {
+	return notificationDeliveries, NotificationDeliveryInstant
+}
+
+// NotificationDelivery enumeration.
+const (
+	// NotificationDeliveryInstant sends the notification as soon as the event happens.
+	NotificationDeliveryInstant NotificationDelivery = "instant"
+	// NotificationDeliveryDigest queues the notification for the daily digest email.
+	NotificationDeliveryDigest NotificationDelivery = "digest"
+	// NotificationDeliveryOff doesn't send the notification.
+	NotificationDeliveryOff NotificationDelivery = "off"
+)
+
+var notificationDeliveries = sortEnum([]NotificationDelivery{
+	NotificationDeliveryInstant,
+	NotificationDeliveryDigest,
+	NotificationDeliveryOff,
+})
+
+// RepoNotificationMode defines whether a user receives notifications about a repository.
+type RepoNotificationMode string
+
+func (RepoNotificationMode) Enum() []interface{} { return toInterfaceSlice(repoNotificationModes) }
+
+func (m RepoNotificationMode) Sanitize() (RepoNotificationMode, bool) {
+	return Sanitize(m, GetAllRepoNotificationModes)
+}
+
+func GetAllRepoNotificationModes() ([]RepoNotificationMode, RepoNotificationMode) {
+	return repoNotificationModes, RepoNotificationModeWatch
+}
+
+// RepoNotificationMode enumeration.
+const (
+	// RepoNotificationModeWatch delivers the notifications about the repository according to the user's settings.
+	RepoNotificationModeWatch RepoNotificationMode = "watch"
+	// RepoNotificationModeIgnore mutes all notifications about the repository.
+	RepoNotificationModeIgnore RepoNotificationMode = "ignore"
+)
+
+var repoNotificationModes = sortEnum([]RepoNotificationMode{
+	RepoNotificationModeWatch,
+	RepoNotificationModeIgnore,
+})
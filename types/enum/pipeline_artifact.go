@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PipelineArtifactKind defines the kind of file a pipeline stores in the artifact storage.
+type PipelineArtifactKind string
+
+func (PipelineArtifactKind) Enum() []interface{} { return toInterfaceSlice(pipelineArtifactKinds) }
+func (k PipelineArtifactKind) Sanitize() (PipelineArtifactKind, bool) {
+	return Sanitize(k, GetAllPipelineArtifactKinds)
+}
+func GetAllPipelineArtifactKinds() ([]PipelineArtifactKind, PipelineArtifactKind) {
+	return pipelineArtifactKinds, PipelineArtifactKindArtifact
+}
+
+// PipelineArtifactKind enumeration.
+const (
+	// PipelineArtifactKindArtifact is a file produced by an execution.
+	PipelineArtifactKindArtifact PipelineArtifactKind = "artifact"
+	// PipelineArtifactKindCache is a file shared between the executions of a pipeline.
+	PipelineArtifactKindCache PipelineArtifactKind = "cache"
+)
+
+var pipelineArtifactKinds = sortEnum([]PipelineArtifactKind{
+	PipelineArtifactKindArtifact,
+	PipelineArtifactKindCache,
+})
@@ -85,11 +85,15 @@ const (
 	PullReqActivityTypeReviewSubmit   PullReqActivityType = "review-submit"
 	PullReqActivityTypeReviewerAdd    PullReqActivityType = "reviewer-add"
 	PullReqActivityTypeReviewerDelete PullReqActivityType = "reviewer-delete"
+	PullReqActivityTypeReviewDismiss  PullReqActivityType = "review-dismiss"
+	PullReqActivityTypeReviewRequest  PullReqActivityType = "review-request"
 	PullReqActivityTypeBranchUpdate   PullReqActivityType = "branch-update"
 	PullReqActivityTypeBranchDelete   PullReqActivityType = "branch-delete"
 	PullReqActivityTypeBranchRestore  PullReqActivityType = "branch-restore"
 	PullReqActivityTypeMerge          PullReqActivityType = "merge"
 	PullReqActivityTypeLabelModify    PullReqActivityType = "label-modify"
+
+	PullReqActivityTypeTargetBranchChange PullReqActivityType = "target-branch-change"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -100,11 +104,14 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeReviewSubmit,
 	PullReqActivityTypeReviewerAdd,
 	PullReqActivityTypeReviewerDelete,
+	PullReqActivityTypeReviewDismiss,
+	PullReqActivityTypeReviewRequest,
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
 	PullReqActivityTypeBranchRestore,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeTargetBranchChange,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -215,6 +222,33 @@ var pullReqReviewerTypes = sortEnum([]PullReqReviewerType{
 	PullReqReviewerTypeSelfAssigned,
 })
 
+// PullReqReviewReminderType defines type of a notification sent for a pending pull request review.
+type PullReqReviewReminderType string
+
+func (PullReqReviewReminderType) Enum() []interface{} {
+	return toInterfaceSlice(pullReqReviewReminderTypes)
+}
+
+// PullReqReviewReminderType enumeration.
+const (
+	// PullReqReviewReminderTypeReminder is a reminder sent to a reviewer that hasn't submitted a review.
+	PullReqReviewReminderTypeReminder PullReqReviewReminderType = "reminder"
+	// PullReqReviewReminderTypeEscalation is a notification sent to the code owners (or a configured user group)
+	// about a review that is still pending after the escalation threshold.
+	PullReqReviewReminderTypeEscalation PullReqReviewReminderType = "escalation"
+	// PullReqReviewReminderTypeChatReminder is a review reminder posted to the chat channels of the repository.
+	PullReqReviewReminderTypeChatReminder PullReqReviewReminderType = "chat_reminder"
+	// PullReqReviewReminderTypeChatEscalation is a review escalation posted to the chat channels of the repository.
+	PullReqReviewReminderTypeChatEscalation PullReqReviewReminderType = "chat_escalation"
+)
+
+var pullReqReviewReminderTypes = sortEnum([]PullReqReviewReminderType{
+	PullReqReviewReminderTypeReminder,
+	PullReqReviewReminderTypeEscalation,
+	PullReqReviewReminderTypeChatReminder,
+	PullReqReviewReminderTypeChatEscalation,
+})
+
 type MergeMethod gitenum.MergeMethod
 
 // MergeMethod enumeration.
@@ -27,6 +27,9 @@ const (
 	SSETypeExecutionCompleted SSEType = "execution_completed"
 	SSETypeExecutionCanceled  SSEType = "execution_canceled"
 
+	SSETypeExecutionWaitingOnApproval SSEType = "execution_waiting_on_approval"
+	SSETypeExecutionApprovalDecided   SSEType = "execution_approval_decided"
+
 	// Repo import/export.
 
 	SSETypeRepositoryImportCompleted SSEType = "repository_import_completed"
@@ -40,6 +40,10 @@ const (
 	TriggerActionPullReqClosed TriggerAction = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged TriggerAction = "pullreq_merged"
+
+	// TriggerActionPromote is used by executions promoting a build to an environment.
+	// It can't be used by triggers, hence it's not part of the list of trigger actions.
+	TriggerActionPromote TriggerAction = "promote"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -55,6 +59,9 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
 		return TriggerEventTag
 	}
+	if t == TriggerActionPromote {
+		return TriggerEventPromote
+	}
 	if t == "" {
 		return TriggerEventManual
 	}
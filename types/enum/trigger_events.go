@@ -24,6 +24,7 @@ const (
 	TriggerEventPush        TriggerEvent = "push"
 	TriggerEventPullRequest TriggerEvent = "pull_request"
 	TriggerEventTag         TriggerEvent = "tag"
+	TriggerEventPromote     TriggerEvent = "promote"
 )
 
 // Enum returns all possible TriggerEvent values.
@@ -48,4 +49,5 @@ var triggerEvents = sortEnum([]TriggerEvent{
 	TriggerEventPush,
 	TriggerEventPullRequest,
 	TriggerEventTag,
+	TriggerEventPromote,
 })
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Environment is a deployment target of a repository, builds are deployed to it by promoting them.
+type Environment struct {
+	ID          int64                 `json:"-"`
+	RepoID      int64                 `json:"-"`
+	Identifier  string                `json:"identifier"`
+	Description string                `json:"description"`
+	Protection  EnvironmentProtection `json:"protection"`
+	CreatedBy   int64                 `json:"created_by"`
+	Created     int64                 `json:"created"`
+	Updated     int64                 `json:"updated"`
+	Version     int64                 `json:"-"`
+}
+
+// EnvironmentProtection restricts the promotions to an environment.
+type EnvironmentProtection struct {
+	// Approvers and ApproverGroups are the users (by UID or email) and user groups allowed
+	// to promote to the environment, anyone allowed to execute the pipeline can promote if both are empty.
+	Approvers      []string `json:"approvers"`
+	ApproverGroups []string `json:"approver_groups"`
+
+	// Branches are the glob patterns of the branches whose builds can be promoted,
+	// builds of any branch can be promoted if empty.
+	Branches []string `json:"branches"`
+}
+
+// IsEmpty returns true if the environment isn't protected.
+func (p EnvironmentProtection) IsEmpty() bool {
+	return len(p.Approvers) == 0 && len(p.ApproverGroups) == 0 && len(p.Branches) == 0
+}
+
+// Deployment is the latest successful promotion to an environment.
+// Execution is nil if nothing was deployed to the environment yet.
+type Deployment struct {
+	Environment *Environment `json:"environment"`
+	Execution   *Execution   `json:"execution"`
+}
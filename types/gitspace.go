@@ -40,6 +40,8 @@ type GitspaceConfig struct {
 	CodeRepo
 	GitspaceUser
 	Connectors []PlatformConnector `json:"-"`
+	// PrebuiltImage is the image of a ready prebuild of the branch, the gitspace is started from it if set.
+	PrebuiltImage string `json:"-"`
 }
 
 type CodeRepo struct {
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// GitspacePrebuild is the prebuild configuration of a repository branch. Every time the branch is updated,
+// a gitspace image with the repository cloned and the onCreateCommand and updateContentCommand
+// already executed is built, and new gitspaces for the branch are started from it.
+type GitspacePrebuild struct {
+	ID         int64                      `json:"-"`
+	RepoID     int64                      `json:"-"`
+	Identifier string                     `json:"identifier"`
+	Branch     string                     `json:"branch"`
+	Disabled   bool                       `json:"disabled"`
+	State      enum.GitspacePrebuildState `json:"state"`
+	// CommitSHA is the commit the latest build was started for.
+	CommitSHA string `json:"commit_sha"`
+	// Image is the prebuilt image of the latest successful build.
+	Image         string  `json:"image"`
+	ErrorMessage  *string `json:"error_message"`
+	BuildStarted  int64   `json:"build_started"`
+	BuildFinished int64   `json:"build_finished"`
+	CreatedBy     int64   `json:"created_by"`
+	Created       int64   `json:"created"`
+	Updated       int64   `json:"updated"`
+	Version       int64   `json:"-"`
+}
+
+// GitspacePrebuildLogs contains the output of the latest build of a gitspace prebuild.
+type GitspacePrebuildLogs struct {
+	CommitSHA string `json:"commit_sha"`
+	Logs      string `json:"logs"`
+}
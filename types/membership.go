@@ -33,6 +33,11 @@ type Membership struct {
 	Updated   int64 `json:"updated"`
 
 	Role enum.MembershipRole `json:"role"`
+
+	// CustomRoleID is the ID of the custom role of the membership, it's set if Role is MembershipRoleCustom.
+	CustomRoleID *int64 `json:"-"`
+	// CustomRole is the identifier of the custom role of the membership.
+	CustomRole string `json:"custom_role,omitempty"`
 }
 
 // MembershipUser adds user info to the Membership data.
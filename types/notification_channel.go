@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationChannel is a chat channel (incoming webhook) of a space or a repository
+// that receives the selected pull request notifications.
+type NotificationChannel struct {
+	ID         int64                   `json:"-"`
+	Version    int64                   `json:"-"`
+	ParentID   int64                   `json:"parent_id"`
+	ParentType enum.ParentResourceType `json:"parent_type"`
+	CreatedBy  int64                   `json:"created_by"`
+	Created    int64                   `json:"created"`
+	Updated    int64                   `json:"updated"`
+
+	Identifier  string                       `json:"identifier"`
+	Description string                       `json:"description"`
+	Type        enum.NotificationChannelType `json:"type"`
+	// URL is the encrypted incoming webhook URL. It's never exposed, as it usually contains a token.
+	URL      string                     `json:"-"`
+	Enabled  bool                       `json:"enabled"`
+	Triggers []enum.NotificationTrigger `json:"triggers"`
+}
+
+// MarshalJSON overrides the default json marshaling for `NotificationChannel` allowing us to inject
+// the `HasURL` field, as the URL itself isn't exposed.
+func (c *NotificationChannel) MarshalJSON() ([]byte, error) {
+	type NotificationChannelAlias NotificationChannel
+	return json.Marshal(&struct {
+		*NotificationChannelAlias
+		HasURL bool `json:"has_url"`
+	}{
+		NotificationChannelAlias: (*NotificationChannelAlias)(c),
+		HasURL:                   c.URL != "",
+	})
+}
+
+// HasTrigger returns true if the channel is subscribed to the provided trigger.
+func (c *NotificationChannel) HasTrigger(trigger enum.NotificationTrigger) bool {
+	return slices.Contains(c.Triggers, trigger)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// NotificationPreference is the delivery a user has chosen for a notification trigger.
+type NotificationPreference struct {
+	PrincipalID int64                     `json:"-"`
+	Trigger     enum.NotificationTrigger  `json:"trigger"`
+	Delivery    enum.NotificationDelivery `json:"delivery"`
+	Updated     int64                     `json:"updated"`
+}
+
+// RepoNotificationPreference defines whether a user watches or ignores the notifications about a repository.
+type RepoNotificationPreference struct {
+	PrincipalID int64                     `json:"-"`
+	RepoID      int64                     `json:"repo_id"`
+	RepoPath    string                    `json:"repo_path"`
+	Mode        enum.RepoNotificationMode `json:"mode"`
+	Updated     int64                     `json:"updated"`
+}
+
+// NotificationSettings are the notification settings of a user.
+type NotificationSettings struct {
+	// Events holds the delivery of every notification trigger.
+	Events map[enum.NotificationTrigger]enum.NotificationDelivery `json:"events"`
+	// Repos holds the repositories the user explicitly watches or ignores.
+	Repos []*RepoNotificationPreference `json:"repos"`
+}
+
+// NotificationDigestItem is a notification queued for the daily digest email of a user.
+type NotificationDigestItem struct {
+	ID          int64                    `json:"id"`
+	PrincipalID int64                    `json:"principal_id"`
+	RepoID      int64                    `json:"repo_id"`
+	EventID     string                   `json:"-"`
+	Trigger     enum.NotificationTrigger `json:"trigger"`
+	Title       string                   `json:"title"`
+	URL         string                   `json:"url"`
+	Created     int64                    `json:"created"`
+}
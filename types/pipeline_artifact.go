@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PipelineArtifact is a file uploaded to the artifact storage by a pipeline step.
+// Artifacts belong to a single execution, caches are shared by all executions of a pipeline.
+type PipelineArtifact struct {
+	ID         int64 `json:"-"`
+	RepoID     int64 `json:"-"`
+	PipelineID int64 `json:"-"`
+
+	// ExecutionID is zero for caches.
+	ExecutionID int64                     `json:"-"`
+	Kind        enum.PipelineArtifactKind `json:"kind"`
+	Key         string                    `json:"key"`
+	Size        int64                     `json:"size"`
+	CreatedBy   int64                     `json:"created_by"`
+	Created     int64                     `json:"created"`
+	Updated     int64                     `json:"updated"`
+
+	// Expires is the time after which the artifact is removed by the retention sweep.
+	Expires int64 `json:"expires"`
+}
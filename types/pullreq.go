@@ -69,6 +69,25 @@ type PullReq struct {
 	Labels       []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
 	CheckSummary *CheckCountSummary            `json:"check_summary,omitempty"`
 	Rules        []RuleInfo                    `json:"rules,omitempty"`
+	Stack        *PullReqStack                 `json:"stack,omitempty"`
+}
+
+// PullReqStack describes the dependency chain of a stacked pull request.
+type PullReqStack struct {
+	// Base are the open pull requests the pull request depends on, starting with the closest one.
+	Base []PullReqStackEntry `json:"base"`
+	// Dependents are the open pull requests that directly depend on the pull request.
+	Dependents []PullReqStackEntry `json:"dependents"`
+}
+
+// PullReqStackEntry is a pull request that is part of a pull request stack.
+type PullReqStackEntry struct {
+	Number       int64             `json:"number"`
+	Title        string            `json:"title"`
+	State        enum.PullReqState `json:"state"`
+	IsDraft      bool              `json:"is_draft"`
+	SourceBranch string            `json:"source_branch"`
+	TargetBranch string            `json:"target_branch"`
 }
 
 func (pr *PullReq) UpdateMergeOutcome(method enum.MergeMethod, conflictFiles []string) {
@@ -178,6 +197,8 @@ type PullReqMetadataOptions struct {
 	IncludeGitStats bool `json:"include_git_stats"`
 	IncludeChecks   bool `json:"include_checks"`
 	IncludeRules    bool `json:"include_rules"`
+	// IncludeStack is supported only when fetching a single pull request.
+	IncludeStack bool `json:"include_stack"`
 }
 
 // PullReqReview holds pull request review.
@@ -251,6 +272,19 @@ type PullReqFileView struct {
 	Updated int64 `json:"-"`
 }
 
+// PullReqReviewReminder records the latest notification sent to a principal about a pending pull request review.
+type PullReqReviewReminder struct {
+	PullReqID   int64                          `json:"-"`
+	PrincipalID int64                          `json:"-"`
+	Type        enum.PullReqReviewReminderType `json:"type"`
+
+	// Count is the number of notifications sent so far.
+	Count int64 `json:"count"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
 type MergeResponse struct {
 	SHA            string           `json:"sha,omitempty"`
 	BranchDeleted  bool             `json:"branch_deleted,omitempty"`
@@ -280,3 +314,11 @@ type PullReqRepo struct {
 	PullRequest *PullReq        `json:"pull_request"`
 	Repository  *RepositoryCore `json:"repository"`
 }
+
+// PullReqTemplate is a pull request description template stored in a repository.
+type PullReqTemplate struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Default bool   `json:"default"`
+	Content string `json:"content"`
+}
@@ -58,9 +58,12 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadStateChange{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadTitleChange{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewDismiss{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewRequest{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadTargetBranchChange{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -150,6 +153,28 @@ func (a *PullRequestActivityPayloadReviewerDelete) ActivityType() enum.PullReqAc
 	return enum.PullReqActivityTypeReviewerDelete
 }
 
+// PullRequestActivityPayloadReviewDismiss is the payload of a dismissed review.
+// Automatic is set if the review got dismissed because the files the reviewer viewed have changed.
+type PullRequestActivityPayloadReviewDismiss struct {
+	CommitSHA   string                     `json:"commit_sha"`
+	Decision    enum.PullReqReviewDecision `json:"decision"`
+	PrincipalID int64                      `json:"principal_id"`
+	Reason      string                     `json:"reason,omitempty"`
+	Automatic   bool                       `json:"automatic,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadReviewDismiss) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeReviewDismiss
+}
+
+type PullRequestActivityPayloadReviewRequest struct {
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (a *PullRequestActivityPayloadReviewRequest) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeReviewRequest
+}
+
 type PullRequestActivityPayloadBranchUpdate struct {
 	Old    string `json:"old"`
 	New    string `json:"new"`
@@ -178,6 +203,17 @@ func (a *PullRequestActivityPayloadBranchRestore) ActivityType() enum.PullReqAct
 	return enum.PullReqActivityTypeBranchRestore
 }
 
+type PullRequestActivityPayloadTargetBranchChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+	// MergedPullReqNumber is the number of the merged pull request that caused the change, if any.
+	MergedPullReqNumber int64 `json:"merged_pullreq_number,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadTargetBranchChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeTargetBranchChange
+}
+
 type PullRequestActivityLabel struct {
 	Label         string                        `json:"label"`
 	LabelColor    enum.LabelColor               `json:"label_color"`
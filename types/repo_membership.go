@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoMembership represents a membership of a principal or a user group in a single repository.
+// Exactly one of PrincipalID and UserGroupID is set.
+type RepoMembership struct {
+	ID          int64  `json:"id"`
+	RepoID      int64  `json:"-"`
+	PrincipalID *int64 `json:"-"`
+	UserGroupID *int64 `json:"-"`
+
+	Role enum.MembershipRole `json:"role"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// RepoMembershipInfo adds principal and user group info to the RepoMembership data.
+type RepoMembershipInfo struct {
+	RepoMembership
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+	UserGroup *UserGroupInfo `json:"usergroup,omitempty"`
+	AddedBy   PrincipalInfo  `json:"added_by"`
+}
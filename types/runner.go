@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "strings"
+
+// RunnerMachinePrefix prefixes the machine of the stages accepted by remote runners,
+// to tell them apart from the stages executed by the embedded runners.
+const RunnerMachinePrefix = "runner:"
+
+// Runner is a remote machine registered to execute pipeline stages.
+type Runner struct {
+	ID          int64             `json:"-"`
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels"`
+	Platform    RunnerPlatform    `json:"platform"`
+	TokenHash   string            `json:"-"`
+	LastSeen    int64             `json:"last_seen,omitempty"`
+	CreatedBy   int64             `json:"created_by"`
+	Created     int64             `json:"created"`
+	Updated     int64             `json:"updated"`
+}
+
+// RunnerPlatform is the platform the runner last requested stages for,
+// used to verify the stages accepted by the runner.
+type RunnerPlatform struct {
+	Kind    string            `json:"kind,omitempty"`
+	Type    string            `json:"type,omitempty"`
+	OS      string            `json:"os,omitempty"`
+	Arch    string            `json:"arch,omitempty"`
+	Kernel  string            `json:"kernel,omitempty"`
+	Variant string            `json:"variant,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// RunnerWithToken is returned once on registration of a runner, the token
+// can't be retrieved afterwards.
+type RunnerWithToken struct {
+	Runner
+	Token string `json:"token"`
+}
+
+// Machine returns the machine assigned to the stages accepted by the runner.
+func (r *Runner) Machine() string {
+	return RunnerMachinePrefix + r.Identifier
+}
+
+// RunnerIdentifierFromMachine returns the identifier of the remote runner
+// executing a stage, or false if the stage isn't executed by a remote runner.
+func RunnerIdentifierFromMachine(machine string) (string, bool) {
+	if !strings.HasPrefix(machine, RunnerMachinePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(machine, RunnerMachinePrefix), true
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnerIdentifierFromMachine(t *testing.T) {
+	runner := &Runner{Identifier: "build-01"}
+
+	tests := []struct {
+		name       string
+		machine    string
+		identifier string
+		ok         bool
+	}{
+		{name: "remote runner", machine: runner.Machine(), identifier: "build-01", ok: true},
+		{name: "embedded runner", machine: "gitness", identifier: "", ok: false},
+		{name: "not accepted", machine: "", identifier: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identifier, ok := RunnerIdentifierFromMachine(test.machine)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.identifier, identifier)
+		})
+	}
+}
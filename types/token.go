@@ -32,6 +32,26 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+	// Scope is an optional restriction of the permissions a PAT grants on top of the permissions of its principal.
+	Scope *TokenScope `db:"-"                        json:"scope,omitempty"`
+}
+
+// TokenScope restricts the permissions of a token to a subset of permissions and
+// to a set of spaces (including their subspaces and repositories) and repositories.
+// An empty list of permissions or an empty set of resources doesn't restrict the token.
+type TokenScope struct {
+	Permissions []enum.Permission `json:"permissions,omitempty"`
+	SpaceIDs    []int64           `json:"-"`
+	RepoIDs     []int64           `json:"-"`
+
+	// Spaces and Repos are the paths of the spaces and repositories the token is restricted to.
+	Spaces []string `json:"spaces,omitempty"`
+	Repos  []string `json:"repos,omitempty"`
+}
+
+// HasResources returns true if the scope restricts the token to a set of spaces or repositories.
+func (s *TokenScope) HasResources() bool {
+	return len(s.SpaceIDs) > 0 || len(s.RepoIDs) > 0
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
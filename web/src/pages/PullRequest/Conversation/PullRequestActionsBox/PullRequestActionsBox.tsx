@@ -269,7 +269,7 @@ export const PullRequestActionsBox: React.FC<PullRequestActionsBoxProps> = ({
         messageTitle =
           mergeOption.method === MergeStrategy.SQUASH
             ? `${pullReqMetadata?.title} (#${pullReqMetadata?.number})`
-            : `Merge branch ${pullReqMetadata?.source_branch} of ${repoMetadata?.path} (#${pullReqMetadata?.number})`
+            : `Merge branch '${pullReqMetadata?.source_branch}' of ${repoMetadata?.path} (#${pullReqMetadata?.number})`
       })
     }
     return {